@@ -3,13 +3,19 @@ package main
 import (
 	_ "api-gateway/docs"
 	api "api-gateway/internal/http"
+	"api-gateway/internal/http/middleware"
 	athleteClient "api-gateway/internal/pkg/athlete-service"
 	countryClient "api-gateway/internal/pkg/country-service"
+	"api-gateway/internal/pkg/downstream"
 	eventClient "api-gateway/internal/pkg/event-service"
+	"api-gateway/internal/pkg/jwks"
 	liveClient "api-gateway/internal/pkg/live-service"
 	config "api-gateway/internal/pkg/load"
+	"api-gateway/internal/pkg/tracing"
 	medalClient "api-gateway/internal/pkg/medal-service"
+	redisClient "api-gateway/internal/pkg/redis"
 	userClient "api-gateway/internal/pkg/user-service"
+	"api-gateway/internal/ratelimit"
 	service "api-gateway/internal/service"
 	"api-gateway/logger"
 	"context"
@@ -31,45 +37,75 @@ func main() {
 	}
 	logger.Info("Configuration loaded successfully")
 
-	connUserService, err := userClient.DialWithUserService(*cfg)
+	shutdownTracing, err := tracing.Init(context.Background(), "api-gateway", *cfg)
+	if err != nil {
+		logger.Fatal("Failed to set up tracing: ", err)
+	}
+
+	monitor := downstream.NewMonitor()
+	defer monitor.Close()
+
+	connUserService, err := userClient.DialWithUserService(*cfg, monitor)
 	if err != nil {
 		logger.Fatal("Failed to connect to user service: ", err)
 	}
 	logger.Info("Connected to user service successfully")
 
-	connMedalService, err := medalClient.DialWithMedalService(*cfg)
+	connMedalService, err := medalClient.DialWithMedalService(*cfg, monitor)
 	if err != nil {
 		logger.Fatal("Failed to connect to medal service: ", err)
 	}
 	logger.Info("Connected to medal service successfully")
 
-	connCountryService, err := countryClient.DialWithCountryService(*cfg)
+	connCountryService, err := countryClient.DialWithCountryService(*cfg, monitor)
 	if err != nil {
 		logger.Fatal("Failed to connect to country service: ", err)
 	}
 	logger.Info("Connected to country service successfully")
 
-	connEventService, err := eventClient.DialWithEventService(*cfg)
+	connEventService, err := eventClient.DialWithEventService(*cfg, monitor)
 	if err != nil {
 		logger.Fatal("Failed to connect to event service: ", err)
 	}
 	logger.Info("Connected to event service successfully")
 
-	connAthleteService, err := athleteClient.DialWithAthleteService(*cfg)
+	connAthleteService, err := athleteClient.DialWithAthleteService(*cfg, monitor)
 	if err != nil {
 		logger.Fatal("Failed to connect to athlete service: ", err)
 	}
 	logger.Info("Connected to athlete service successfully")
 
-	connLiveService, err := liveClient.DialWithLiveService(*cfg)
+	connLiveService, err := liveClient.DialWithLiveService(*cfg, monitor)
 	if err != nil {
 		logger.Fatal("Failed to connect to liveStream service: ", err)
 	}
 	logger.Info("Connected to liveStream service successfully")
 
-	s := service.NewServiceRepositoryClient(connUserService, connMedalService, connCountryService, connEventService, connAthleteService, connLiveService)
+	s := service.NewServiceRepositoryClient(connUserService, connMedalService, connCountryService, connEventService, connAthleteService, connLiveService, *cfg)
+
+	// Without a shared Redis every replica enforces the limits on its own,
+	// and logged out tokens stay valid until they expire.
+	var limits ratelimit.Store = ratelimit.NewMemoryStore()
+	var denylist middleware.Denylist
+	if cfg.Redis.Host != "" {
+		rdb, err := redisClient.ConnectRedis(*cfg)
+		if err != nil {
+			logger.Fatal("Failed to connect to redis: ", err)
+		}
+		defer rdb.Close()
+		limits = ratelimit.NewFallbackStore(ratelimit.NewRedisStore(rdb, "ratelimit:"), limits)
+		denylist = redisClient.NewDenylist(rdb)
+		logger.Info("Sharing rate limits and revoked tokens through redis")
+	} else {
+		logger.Warn("No redis configured: revoked tokens are not checked")
+	}
 
-	r := api.NewGin(s)
+	var keys middleware.Keys
+	if cfg.JWT.JWKSURL != "" {
+		keys = jwks.NewKeyset(cfg.JWT.JWKSURL, cfg.JWT.JWKSRefresh)
+	}
+
+	r := api.NewGin(s, monitor, limits, keys, denylist, *cfg)
 	addr := fmt.Sprintf(":%d", cfg.ServerPort)
 
 	sigChan := make(chan os.Signal, 1)
@@ -97,5 +133,8 @@ func main() {
 	if err := server.Shutdown(shutdownCtx); err != nil {
 		logger.Fatal("Server shutdown error: ", err)
 	}
+	if err := shutdownTracing(shutdownCtx); err != nil {
+		logger.Error("Failed to flush traces: ", err)
+	}
 	logger.Info("Graceful shutdown complete.")
 }
@@ -365,7 +365,7 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ],
-                "description": "This method registers a new user",
+                "description": "This method registers a new user with the user role; only an admin may grant another role",
                 "consumes": [
                     "application/json"
                 ],
@@ -1377,7 +1377,7 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ],
-                "description": "This method updates a user",
+                "description": "This method updates a user; the role is only changed when an admin sends one",
                 "consumes": [
                     "application/json"
                 ],
@@ -1863,6 +1863,9 @@ const docTemplate = `{
                 "event_id": {
                     "type": "string"
                 },
+                "id": {
+                    "type": "string"
+                },
                 "left_side": {
                     "type": "string"
                 },
@@ -2112,7 +2115,7 @@ const docTemplate = `{
     "securityDefinitions": {
         "BearerAuth": {
             "type": "apiKey",
-            "name": "Authourization",
+            "name": "Authorization",
             "in": "header"
         }
     }
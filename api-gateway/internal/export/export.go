@@ -0,0 +1,128 @@
+package export
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+)
+
+type Format string
+
+const (
+	FormatCSV    Format = "csv"
+	FormatNDJSON Format = "ndjson"
+	FormatXLSX   Format = "xlsx"
+)
+
+// ParseFormat validates the format query parameter; an empty value means CSV.
+func ParseFormat(value string) (Format, error) {
+	switch Format(strings.ToLower(value)) {
+	case "", FormatCSV:
+		return FormatCSV, nil
+	case FormatNDJSON, "jsonl":
+		return FormatNDJSON, nil
+	case FormatXLSX:
+		return FormatXLSX, nil
+	}
+	return "", fmt.Errorf("unsupported export format %q: use csv, ndjson or xlsx", value)
+}
+
+// ContentType is the MIME type of a format.
+func (f Format) ContentType() string {
+	switch f {
+	case FormatNDJSON:
+		return "application/x-ndjson"
+	case FormatXLSX:
+		return "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+	}
+	return "text/csv; charset=utf-8"
+}
+
+// Writer encodes a table one row at a time. Rows are written to the
+// underlying writer as they come; Close must be called to finish the file.
+type Writer interface {
+	WriteRow(values []string) error
+	// Flush pushes buffered rows to the underlying writer.
+	Flush() error
+	Close() error
+}
+
+// NewWriter starts a table with the given columns. The CSV header row and the
+// XLSX header row are written immediately.
+func NewWriter(w io.Writer, format Format, sheet string, columns []string) (Writer, error) {
+	switch format {
+	case FormatCSV:
+		return newCSVWriter(w, columns)
+	case FormatNDJSON:
+		return newNDJSONWriter(w, columns), nil
+	case FormatXLSX:
+		return newXLSXWriter(w, sheet, columns)
+	}
+	return nil, fmt.Errorf("unsupported export format %q", format)
+}
+
+type csvWriter struct {
+	w *csv.Writer
+}
+
+func newCSVWriter(w io.Writer, columns []string) (*csvWriter, error) {
+	c := &csvWriter{w: csv.NewWriter(w)}
+	if err := c.w.Write(columns); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+func (c *csvWriter) WriteRow(values []string) error {
+	return c.w.Write(values)
+}
+
+func (c *csvWriter) Flush() error {
+	c.w.Flush()
+	return c.w.Error()
+}
+
+func (c *csvWriter) Close() error {
+	return c.Flush()
+}
+
+type ndjsonWriter struct {
+	w       *bufio.Writer
+	columns []string
+}
+
+func newNDJSONWriter(w io.Writer, columns []string) *ndjsonWriter {
+	return &ndjsonWriter{w: bufio.NewWriter(w), columns: columns}
+}
+
+// WriteRow writes one JSON object whose keys keep the column order.
+func (n *ndjsonWriter) WriteRow(values []string) error {
+	n.w.WriteByte('{')
+	for i, column := range n.columns {
+		if i > 0 {
+			n.w.WriteByte(',')
+		}
+		key, _ := json.Marshal(column)
+		n.w.Write(key)
+		n.w.WriteByte(':')
+		value := ""
+		if i < len(values) {
+			value = values[i]
+		}
+		encoded, _ := json.Marshal(value)
+		n.w.Write(encoded)
+	}
+	n.w.WriteByte('}')
+	return n.w.WriteByte('\n')
+}
+
+func (n *ndjsonWriter) Flush() error {
+	return n.w.Flush()
+}
+
+func (n *ndjsonWriter) Close() error {
+	return n.Flush()
+}
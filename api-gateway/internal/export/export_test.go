@@ -0,0 +1,97 @@
+package export
+
+import (
+	"archive/zip"
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+)
+
+func write(t *testing.T, format Format, rows [][]string) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+	w, err := NewWriter(&buf, format, "medals", []string{"id", "name"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, row := range rows {
+		if err := w.WriteRow(row); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func TestCSV(t *testing.T) {
+	out := write(t, FormatCSV, [][]string{{"1", "Léon, Marchand"}})
+
+	want := "id,name\n1,\"Léon, Marchand\"\n"
+	if string(out) != want {
+		t.Fatalf("got %q, want %q", out, want)
+	}
+}
+
+func TestNDJSON(t *testing.T) {
+	out := write(t, FormatNDJSON, [][]string{{"1", "Teddy \"Riner\""}, {"2", "Pauline"}})
+
+	want := "{\"id\":\"1\",\"name\":\"Teddy \\\"Riner\\\"\"}\n{\"id\":\"2\",\"name\":\"Pauline\"}\n"
+	if string(out) != want {
+		t.Fatalf("got %q, want %q", out, want)
+	}
+}
+
+func TestXLSX(t *testing.T) {
+	out := write(t, FormatXLSX, [][]string{{"1", "A & B <C>"}})
+
+	r, err := zip.NewReader(bytes.NewReader(out), int64(len(out)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	files := map[string]string{}
+	for _, f := range r.File {
+		rc, err := f.Open()
+		if err != nil {
+			t.Fatal(err)
+		}
+		b, _ := io.ReadAll(rc)
+		rc.Close()
+		files[f.Name] = string(b)
+	}
+
+	for _, name := range []string{"[Content_Types].xml", "_rels/.rels", "xl/workbook.xml", "xl/_rels/workbook.xml.rels", "xl/worksheets/sheet1.xml"} {
+		if _, ok := files[name]; !ok {
+			t.Fatalf("missing part %s", name)
+		}
+	}
+	if !strings.Contains(files["xl/workbook.xml"], `name="medals"`) {
+		t.Fatalf("sheet name not set: %s", files["xl/workbook.xml"])
+	}
+	sheet := files["xl/worksheets/sheet1.xml"]
+	for _, want := range []string{`<c r="A1" t="inlineStr"><is><t xml:space="preserve">id</t>`, `<c r="B2"`, `A &amp; B &lt;C&gt;`, `</sheetData></worksheet>`} {
+		if !strings.Contains(sheet, want) {
+			t.Fatalf("sheet is missing %q: %s", want, sheet)
+		}
+	}
+}
+
+func TestColumnName(t *testing.T) {
+	for i, want := range map[int]string{0: "A", 25: "Z", 26: "AA", 27: "AB", 701: "ZZ", 702: "AAA"} {
+		if got := columnName(i); got != want {
+			t.Fatalf("columnName(%d) = %q, want %q", i, got, want)
+		}
+	}
+}
+
+func TestParseFormat(t *testing.T) {
+	if f, err := ParseFormat(""); err != nil || f != FormatCSV {
+		t.Fatalf("empty format should default to csv, got %q, %v", f, err)
+	}
+	if _, err := ParseFormat("parquet"); err == nil {
+		t.Fatal("expected an error for an unsupported format")
+	}
+}
@@ -3,11 +3,17 @@ package http
 import (
 	"api-gateway/internal/http/handler"
 	"api-gateway/internal/http/middleware"
+	"api-gateway/internal/hub"
+	"api-gateway/internal/pkg/downstream"
+	config "api-gateway/internal/pkg/load"
+	"api-gateway/internal/ratelimit"
 	service "api-gateway/internal/service"
 
 	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 	swaggerFiles "github.com/swaggo/files"
 	ginSwagger "github.com/swaggo/gin-swagger"
+	"go.opentelemetry.io/contrib/instrumentation/github.com/gin-gonic/gin/otelgin"
 )
 
 // @tite Api-gateway service
@@ -17,62 +23,148 @@ import (
 // @BasePath /
 // @securityDefinitions.apikey BearerAuth
 // @in header
-// @name Authourization
-func NewGin(service *service.ServiceRepositoryClient) *gin.Engine {
+// @name Authorization
+func NewGin(service *service.ServiceRepositoryClient, monitor *downstream.Monitor, limits ratelimit.Store, keys middleware.Keys, denylist middleware.Denylist, cfg config.Config) *gin.Engine {
 
 	r := gin.Default()
+	// Let handlers pass the gin context wherever a context.Context is
+	// expected; values such as the active span come from the request.
+	r.ContextWithFallback = true
 
 	r.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
+	// Registered ahead of the middleware so scrapes are neither traced,
+	// counted nor rate limited.
+	if cfg.Metrics.Enabled {
+		r.GET(cfg.Metrics.Path, gin.WrapH(promhttp.Handler()))
+	}
+
+	// Subscribers are fed from live-service, whichever replica stored the entry.
+	handler := handler.NewHandler(service, hub.NewHub(hub.DefaultBufferSize, hub.StreamFeed(service)), monitor)
+
+	// Probes, like the metrics, bypass the middleware.
+	r.GET("/healthz", handler.Healthz)
+	r.GET("/readyz", handler.Readyz)
+
+	r.Use(otelgin.Middleware("api-gateway"))
+	r.Use(middleware.RequestID())
+	r.Use(middleware.Metrics())
+
+	rateLimiter := middleware.NewRateLimiter(limits, ratelimit.PoliciesFromConfig(cfg.RateLimit))
+	authLimit := rateLimiter.Limit("auth")
+	importLimit := rateLimiter.Limit("import")
+	exportLimit := rateLimiter.Limit("export")
+
+	auth := middleware.NewAuthenticator(middleware.AuthConfig{
+		Issuer:   cfg.JWT.Issuer,
+		Secret:   cfg.JWT.Secret,
+		Keys:     keys,
+		Denylist: denylist,
+	})
+	adminOnly := middleware.RequireRole(middleware.RoleAdmin)
 
-	handler := handler.NewHandler(service)
+	// Authentication routes
+	// Registration is public; an admin's token lets it grant a role.
+	r.POST("/auth/register", authLimit, auth.Identify(), handler.RegisterUser)
+	r.POST("/auth/login", authLimit, handler.LoginUser)
+	r.POST("/auth/refresh", authLimit, handler.RefreshToken)
 
-	rateLimiter := middleware.NewRateLimiter(1, 5)
-	r.Use(rateLimiter.RateLimitMiddleware())
+	// Calendar apps subscribe without a token, so the feed is public and
+	// limited per IP.
+	r.GET("/events/calendar.ics", rateLimiter.Limit(ratelimit.DefaultGroup), handler.EventsCalendar)
 
-	// Authentication routes
-	r.POST("/auth/register", handler.RegisterUser)
-	r.POST("/auth/login", handler.LoginUser)
-	r.POST("/auth/refresh", handler.RefreshToken)
+	api := r.Group("/")
+	api.Use(auth.Authenticate())
+
+	// Logging out needs the token being revoked.
+	api.POST("/auth/logout", authLimit, handler.Logout)
+	api.POST("/auth/logout-all", authLimit, handler.LogoutAll)
+
+	// Everything but the bulk routes and the live WebSockets shares the
+	// default quota.
+	limited := api.Group("/", rateLimiter.Limit(ratelimit.DefaultGroup))
 
 	// User routes
-	r.PUT("/users/:id", handler.UpdateUser)
-	r.GET("/users/:id", handler.GetUserById)
-	r.GET("/users", handler.GetUsers)
-	r.GET("/users/filter", handler.GetUserByFilter)
-	r.DELETE("/users/:id", handler.DeleteUser)
+	limited.PUT("/users/:id", middleware.SelfOrAdmin("id"), handler.UpdateUser)
+	limited.GET("/users/:id", middleware.SelfOrAdmin("id"), handler.GetUserById)
+	limited.GET("/users", adminOnly, handler.GetUsers)
+	limited.GET("/users/filter", adminOnly, handler.GetUserByFilter)
+	limited.DELETE("/users/:id", middleware.SelfOrAdmin("id"), handler.DeleteUser)
 
 	//Model routes
-	r.POST("/medals", handler.CreateMedal)
-	r.GET("/medals", handler.GetMedals)
-	r.GET("/medals/:id", handler.GetMedalById)
-	r.GET("/medals/filter", handler.GetMedalByFilter)
-	r.PUT("/medals/:id", handler.UpdateMedal)
-	r.DELETE("/medals/:id", handler.DeleteMedal)
+	limited.POST("/medals", adminOnly, handler.CreateMedal)
+	limited.GET("/medals", handler.GetMedals)
+	limited.GET("/medals/:id", handler.GetMedalById)
+	limited.GET("/medals/table", handler.GetMedalTable)
+	limited.GET("/medals/filter", handler.GetMedalByFilter)
+	limited.PUT("/medals/:id", adminOnly, handler.UpdateMedal)
+	limited.DELETE("/medals/:id", adminOnly, handler.DeleteMedal)
 
 	// Athlete routes
-	r.POST("/athletes", handler.CreateAthlete)
-	r.GET("/athletes/:id", handler.GetAthlete)
-	r.GET("/athletes", handler.ListOfAthlete)
-	r.PUT("/athletes/:id", handler.UpdateAthlete)
-	r.DELETE("/athletes/:id", handler.DeleteAthlete)
+	limited.POST("/athletes", adminOnly, handler.CreateAthlete)
+	limited.GET("/athletes/:id", handler.GetAthlete)
+	limited.GET("/athletes", handler.ListOfAthlete)
+	limited.PUT("/athletes/:id", adminOnly, handler.UpdateAthlete)
+	limited.DELETE("/athletes/:id", adminOnly, handler.DeleteAthlete)
+
+	// Team routes
+	limited.POST("/teams", adminOnly, handler.CreateTeam)
+	limited.GET("/teams/:id", handler.GetTeam)
+	limited.GET("/teams", handler.ListTeams)
+	limited.PUT("/teams/:id", adminOnly, handler.UpdateTeam)
+	limited.DELETE("/teams/:id", adminOnly, handler.DeleteTeam)
 
 	// Event routes
-	r.POST("/events", handler.CreateEvent)
-	r.GET("/events/:id", handler.GetEvent)
-	r.GET("/events", handler.ListOfEvent)
-	r.PUT("/events/:id", handler.UpdateEvent)
-	r.DELETE("/events/:id", handler.DeleteEvent)
+	limited.POST("/events", adminOnly, handler.CreateEvent)
+	limited.GET("/events/:id", handler.GetEvent)
+	limited.GET("/events", handler.ListOfEvent)
+	limited.PUT("/events/:id", adminOnly, handler.UpdateEvent)
+	limited.DELETE("/events/:id", adminOnly, handler.DeleteEvent)
+	limited.POST("/events/:id/status", middleware.RequireRole(middleware.RoleAdmin, middleware.RoleCommentator), handler.TransitionEvent)
+	limited.GET("/schedule", handler.GetSchedule)
+
+	// Venue routes
+	limited.POST("/venues", adminOnly, handler.CreateVenue)
+	limited.GET("/venues/:id", handler.GetVenue)
+	limited.GET("/venues", handler.ListVenues)
+	limited.PUT("/venues/:id", adminOnly, handler.UpdateVenue)
+	limited.DELETE("/venues/:id", adminOnly, handler.DeleteVenue)
+
+	// Competition structure routes. Commentators may record results, as
+	// they may move events along their status.
+	limited.POST("/events/:id/phases", adminOnly, handler.CreatePhase)
+	limited.GET("/events/:id/phases", handler.ListPhases)
+	limited.POST("/events/:id/bracket", adminOnly, handler.GenerateBracket)
+	limited.GET("/phases/:id", handler.GetPhase)
+	limited.DELETE("/phases/:id", adminOnly, handler.DeletePhase)
+	limited.POST("/phases/:id/advance", adminOnly, handler.AdvancePhase)
+	limited.PUT("/units/:id/start-list", adminOnly, handler.SetStartList)
+	limited.PUT("/units/:id/results", middleware.RequireRole(middleware.RoleAdmin, middleware.RoleCommentator), handler.RecordResults)
+	limited.POST("/events/:id/finalize", adminOnly, handler.FinalizeEvent)
 
 	// Country routes
-	r.POST("/countries", handler.CreateCountry)
-	r.GET("/countries/:id", handler.GetCountry)
-	r.GET("/countries", handler.ListOfCountry)
-	r.PUT("/countries/:id", handler.UpdateCountry)
-	r.DELETE("/countries/:id", handler.DeleteCountry)
-
-	r.GET("/live/:eventId", handler.GetLiveStream)
-
-	r.GET("/live", handler.CreateLiveStream)
+	limited.POST("/countries", adminOnly, handler.CreateCountry)
+	limited.GET("/countries/:id", handler.GetCountry)
+	limited.GET("/countries", handler.ListOfCountry)
+	limited.PUT("/countries/:id", adminOnly, handler.UpdateCountry)
+	limited.DELETE("/countries/:id", adminOnly, handler.DeleteCountry)
+
+	// Bulk import routes
+	api.POST("/import/countries", adminOnly, importLimit, handler.ImportCountries)
+	api.POST("/import/athletes", adminOnly, importLimit, handler.ImportAthletes)
+	api.POST("/import/events", adminOnly, importLimit, handler.ImportEvents)
+	api.POST("/import/medals", adminOnly, importLimit, handler.ImportMedals)
+
+	// Export routes
+	api.GET("/export/countries", exportLimit, handler.ExportCountries)
+	api.GET("/export/athletes", exportLimit, handler.ExportAthletes)
+	api.GET("/export/events", exportLimit, handler.ExportEvents)
+	api.GET("/export/medals", exportLimit, handler.ExportMedals)
+
+	limited.GET("/live/:eventId", handler.GetLiveStream)
+	api.GET("/live/:eventId/subscribe", handler.SubscribeLiveStream)
+	limited.GET("/live/:eventId/timeline", handler.ListLiveStream)
+
+	api.GET("/live", middleware.RequireRole(middleware.RoleAdmin, middleware.RoleCommentator), handler.CreateLiveStream)
 
 	return r
 }
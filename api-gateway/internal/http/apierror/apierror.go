@@ -0,0 +1,163 @@
+package apierror
+
+import (
+	"api-gateway/logger"
+	"api-gateway/models"
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// HeaderRequestID carries the id that ties a response to the gateway's logs.
+const HeaderRequestID = "X-Request-ID"
+
+// HeaderRetryAfter tells the client when a refused request may be retried.
+const HeaderRetryAfter = "Retry-After"
+
+// StatusClientClosedRequest is the non-standard status for a request the
+// client gave up on before it was answered.
+const StatusClientClosedRequest = 499
+
+var httpStatuses = map[codes.Code]int{
+	codes.OK:                 http.StatusOK,
+	codes.Canceled:           StatusClientClosedRequest,
+	codes.Unknown:            http.StatusInternalServerError,
+	codes.InvalidArgument:    http.StatusBadRequest,
+	codes.DeadlineExceeded:   http.StatusGatewayTimeout,
+	codes.NotFound:           http.StatusNotFound,
+	codes.AlreadyExists:      http.StatusConflict,
+	codes.PermissionDenied:   http.StatusForbidden,
+	codes.ResourceExhausted:  http.StatusTooManyRequests,
+	codes.FailedPrecondition: http.StatusConflict,
+	codes.Aborted:            http.StatusConflict,
+	codes.OutOfRange:         http.StatusBadRequest,
+	codes.Unimplemented:      http.StatusNotImplemented,
+	codes.Internal:           http.StatusInternalServerError,
+	codes.Unavailable:        http.StatusServiceUnavailable,
+	codes.DataLoss:           http.StatusInternalServerError,
+	codes.Unauthenticated:    http.StatusUnauthorized,
+}
+
+// HTTPStatus maps a gRPC status code to the HTTP status the gateway answers
+// with. FailedPrecondition is a conflict with the stored data, e.g. a second
+// gold medal for one event, so it shares 409 with AlreadyExists.
+func HTTPStatus(code codes.Code) int {
+	if s, ok := httpStatuses[code]; ok {
+		return s
+	}
+	return http.StatusInternalServerError
+}
+
+// CodeName renders a gRPC code the way the Google API error model spells
+// it: NotFound becomes NOT_FOUND.
+func CodeName(code codes.Code) string {
+	if code == codes.OK {
+		return "OK"
+	}
+	var b strings.Builder
+	for i, r := range code.String() {
+		if i > 0 && unicode.IsUpper(r) {
+			b.WriteByte('_')
+		}
+		b.WriteRune(unicode.ToUpper(r))
+	}
+	return b.String()
+}
+
+// Write answers the request with err. gRPC errors keep their code; any other
+// error is reported as UNKNOWN. The text of server-side failures is logged
+// rather than returned, since it may hold SQL or connection details.
+func Write(c *gin.Context, err error) {
+	AbortWithStatus(c, 0, status.Convert(err))
+}
+
+// Abort answers the request with a new status built from code and message.
+func Abort(c *gin.Context, code codes.Code, format string, args ...interface{}) {
+	AbortWithStatus(c, 0, status.Newf(code, format, args...))
+}
+
+// BadRequest answers with INVALID_ARGUMENT, for input the gateway itself
+// could not parse.
+func BadRequest(c *gin.Context, err error) {
+	AbortWithStatus(c, 0, status.New(codes.InvalidArgument, err.Error()))
+}
+
+// AbortWithStatus renders st with the given HTTP status, or with the status
+// HTTPStatus picks when httpStatus is 0.
+func AbortWithStatus(c *gin.Context, httpStatus int, st *status.Status) {
+	if httpStatus == 0 {
+		httpStatus = HTTPStatus(st.Code())
+	}
+
+	requestID := c.Writer.Header().Get(HeaderRequestID)
+	message := st.Message()
+	if httpStatus >= http.StatusInternalServerError && st.Code() != codes.Unavailable && st.Code() != codes.DeadlineExceeded {
+		logger.ErrorContext(c, "Request failed: ", logrus.Fields{
+			"request_id": requestID,
+			"path":       c.FullPath(),
+			"code":       st.Code().String(),
+			"error":      message,
+		})
+		message = http.StatusText(httpStatus)
+	}
+
+	if delay, ok := retryDelay(st); ok {
+		c.Header(HeaderRetryAfter, strconv.FormatInt(int64(math.Ceil(delay.Seconds())), 10))
+	}
+
+	c.AbortWithStatusJSON(httpStatus, models.Message{
+		Err:       message,
+		Code:      CodeName(st.Code()),
+		Details:   details(st),
+		RequestID: requestID,
+	})
+}
+
+// details flattens the error details services attach to a status into
+// readable lines.
+func details(st *status.Status) []string {
+	var lines []string
+	for _, detail := range st.Details() {
+		switch d := detail.(type) {
+		case *errdetails.BadRequest:
+			for _, v := range d.GetFieldViolations() {
+				lines = append(lines, fmt.Sprintf("%s: %s", v.GetField(), v.GetDescription()))
+			}
+		case *errdetails.PreconditionFailure:
+			for _, v := range d.GetViolations() {
+				lines = append(lines, fmt.Sprintf("%s: %s", v.GetSubject(), v.GetDescription()))
+			}
+		case *errdetails.ResourceInfo:
+			lines = append(lines, fmt.Sprintf("%s %s: %s", d.GetResourceType(), d.GetResourceName(), d.GetDescription()))
+		case *errdetails.ErrorInfo:
+			lines = append(lines, d.GetReason())
+		case *errdetails.RetryInfo:
+			// Reported in the Retry-After header instead.
+		case error:
+			lines = append(lines, d.Error())
+		default:
+			lines = append(lines, fmt.Sprint(d))
+		}
+	}
+	return lines
+}
+
+// retryDelay returns the delay of a RetryInfo detail, if st carries one.
+func retryDelay(st *status.Status) (time.Duration, bool) {
+	for _, detail := range st.Details() {
+		if info, ok := detail.(*errdetails.RetryInfo); ok && info.GetRetryDelay() != nil {
+			return info.GetRetryDelay().AsDuration(), true
+		}
+	}
+	return 0, false
+}
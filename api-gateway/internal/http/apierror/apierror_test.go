@@ -0,0 +1,130 @@
+package apierror
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"api-gateway/models"
+
+	"github.com/gin-gonic/gin"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+func serve(t *testing.T, err error) (*httptest.ResponseRecorder, models.Message) {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodGet, "/events/1", nil)
+	c.Header(HeaderRequestID, "req-1")
+	Write(c, err)
+
+	var body models.Message
+	if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+		t.Fatalf("decode body: %v", err)
+	}
+	return w, body
+}
+
+func TestWriteNotFound(t *testing.T) {
+	w, body := serve(t, status.Error(codes.NotFound, "event 1 not found"))
+
+	if w.Code != http.StatusNotFound {
+		t.Fatalf("status = %d, want 404", w.Code)
+	}
+	if body.Err != "event 1 not found" || body.Code != "NOT_FOUND" || body.RequestID != "req-1" {
+		t.Fatalf("body = %+v", body)
+	}
+}
+
+func TestWriteDetails(t *testing.T) {
+	st, err := status.New(codes.InvalidArgument, "invalid event").WithDetails(&errdetails.BadRequest{
+		FieldViolations: []*errdetails.BadRequest_FieldViolation{
+			{Field: "date", Description: "must be YYYY-MM-DD"},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	w, body := serve(t, st.Err())
+
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("status = %d, want 400", w.Code)
+	}
+	if len(body.Details) != 1 || body.Details[0] != "date: must be YYYY-MM-DD" {
+		t.Fatalf("details = %v", body.Details)
+	}
+}
+
+func TestWriteRetryInfo(t *testing.T) {
+	st, err := status.New(codes.ResourceExhausted, "too many failed logins").WithDetails(&errdetails.RetryInfo{
+		RetryDelay: durationpb.New(90500 * time.Millisecond),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	w, body := serve(t, st.Err())
+
+	if w.Code != http.StatusTooManyRequests {
+		t.Fatalf("status = %d, want 429", w.Code)
+	}
+	if got := w.Header().Get(HeaderRetryAfter); got != "91" {
+		t.Fatalf("%s = %q, want 91", HeaderRetryAfter, got)
+	}
+	if len(body.Details) != 0 {
+		t.Fatalf("details = %v", body.Details)
+	}
+}
+
+func TestWriteHidesInternalErrors(t *testing.T) {
+	w, body := serve(t, errors.New("pq: connection refused"))
+
+	if w.Code != http.StatusInternalServerError {
+		t.Fatalf("status = %d, want 500", w.Code)
+	}
+	if body.Err != "Internal Server Error" || body.Code != "UNKNOWN" {
+		t.Fatalf("body = %+v", body)
+	}
+}
+
+func TestHTTPStatus(t *testing.T) {
+	tests := map[codes.Code]int{
+		codes.InvalidArgument:    http.StatusBadRequest,
+		codes.Unauthenticated:    http.StatusUnauthorized,
+		codes.PermissionDenied:   http.StatusForbidden,
+		codes.NotFound:           http.StatusNotFound,
+		codes.AlreadyExists:      http.StatusConflict,
+		codes.FailedPrecondition: http.StatusConflict,
+		codes.ResourceExhausted:  http.StatusTooManyRequests,
+		codes.Unavailable:        http.StatusServiceUnavailable,
+		codes.DeadlineExceeded:   http.StatusGatewayTimeout,
+		codes.Code(99):           http.StatusInternalServerError,
+	}
+	for code, want := range tests {
+		if got := HTTPStatus(code); got != want {
+			t.Errorf("HTTPStatus(%v) = %d, want %d", code, got, want)
+		}
+	}
+}
+
+func TestCodeName(t *testing.T) {
+	tests := map[codes.Code]string{
+		codes.OK:                 "OK",
+		codes.NotFound:           "NOT_FOUND",
+		codes.FailedPrecondition: "FAILED_PRECONDITION",
+		codes.Unauthenticated:    "UNAUTHENTICATED",
+	}
+	for code, want := range tests {
+		if got := CodeName(code); got != want {
+			t.Errorf("CodeName(%v) = %q, want %q", code, got, want)
+		}
+	}
+}
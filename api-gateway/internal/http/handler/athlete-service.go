@@ -3,11 +3,13 @@ package handler
 import (
 	pb "github.com/Bekzodbekk/paris2024_livestream_protos/genproto/athletepb"
 	pbCountry "github.com/Bekzodbekk/paris2024_livestream_protos/genproto/countrypb"
+	"api-gateway/internal/http/apierror"
 	"api-gateway/logger"
-	"api-gateway/models"
 
 	"github.com/gin-gonic/gin"
 	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 // @Router /athletes [post]
@@ -25,25 +27,29 @@ func (h *HandlerST) CreateAthlete(c *gin.Context) {
 
 	req := pb.CreateAthleteRequest{}
 	if err := c.BindJSON(&req); err != nil {
-		logger.Error("CreateAthlete: Failed to bind JSON: ", err)
-		c.JSON(400, models.Message{Err: err.Error()})
+		logger.ErrorContext(c, "CreateAthlete: Failed to bind JSON: ", err)
+		apierror.BadRequest(c, err)
 		return
 	}
 
 	//Check Country Id
-	if _, err := h.Service.GetCountry(&pbCountry.GetCountryRequest{Id: req.CountryId}); err != nil {
-		logger.Error("CreateAthlete: Failed to get country: ", err)
-		c.JSON(500, models.Message{Err: "Country with the provided ID does not exist or has been deleted"})
+	if _, err := h.Service.GetCountry(c.Request.Context(), &pbCountry.GetCountryRequest{Id: req.CountryId}); err != nil {
+		logger.ErrorContext(c, "CreateAthlete: Failed to get country: ", err)
+		if status.Code(err) == codes.NotFound {
+			apierror.Abort(c, codes.FailedPrecondition, "country %s does not exist or has been deleted", req.CountryId)
+			return
+		}
+		apierror.Write(c, err)
 		return
 	}
 
-	resp, err := h.Service.CreateAthlete(&req)
+	resp, err := h.Service.CreateAthlete(c.Request.Context(), &req)
 	if err != nil {
-		logger.Error("CreateAthlete: Failed to create athlete: ", err)
-		c.JSON(500, models.Message{Err: err.Error()})
+		logger.ErrorContext(c, "CreateAthlete: Failed to create athlete: ", err)
+		apierror.Write(c, err)
 		return
 	}
-	logger.Info("CreateAthlete: Athlete created successfully: ", logrus.Fields{
+	logger.InfoContext(c, "CreateAthlete: Athlete created successfully: ", logrus.Fields{
 		"id":resp.Id,
 		"name":resp.Name,
 	})
@@ -60,20 +66,21 @@ func (h *HandlerST) CreateAthlete(c *gin.Context) {
 // @Param id path string true "ID"
 // @Success 200 {object} models.GetAthleteResponse
 // @Failure 400 {object} models.Message
+// @Failure 404 {object} models.Message
 // @Failure 500 {object} models.Message
 func (h *HandlerST) GetAthlete(c *gin.Context) {
 
 	req := pb.GetAthleteRequest{}
 	req.Id = c.Param("id")
-	resp, err := h.Service.GetAthlete(&req)
+	resp, err := h.Service.GetAthlete(c.Request.Context(), &req)
 	if err != nil {
-		logger.Error("GetAthlete: Failed to get athlete with ID ", logrus.Fields{
+		logger.ErrorContext(c, "GetAthlete: Failed to get athlete with ID ", logrus.Fields{
 			"id":req.Id,
 		})
-		c.JSON(500, models.Message{Err: err.Error()})
+		apierror.Write(c, err)
 		return
 	}
-	logger.Info("GetAthlete: Athlete retrieved successfully: ", logrus.Fields{
+	logger.InfoContext(c, "GetAthlete: Athlete retrieved successfully: ", logrus.Fields{
 		"name":resp.Name,
 	})
 	c.JSON(200, resp)
@@ -86,19 +93,35 @@ func (h *HandlerST) GetAthlete(c *gin.Context) {
 // @Tags ATHLETE
 // @Accept json
 // @Produce json
+// @Param page_size query int false "Page size (default 50, max 1000)"
+// @Param page_token query string false "Token of the next page"
+// @Param order_by query string false "name, sport_type, country_id or created_at, optionally followed by desc"
+// @Param sport_type query string false "Sport type"
+// @Param country_id query string false "Country ID"
 // @Success 200 {object} models.ListOfAthleteResponse
 // @Failure 400 {object} models.Message
 // @Failure 500 {object} models.Message
 func (h *HandlerST) ListOfAthlete(c *gin.Context) {
 
-	resp, err := h.Service.ListOfAthlete(&pb.ListOfAthleteRequest{})
+	pageSize, pageToken, orderBy, err := pageQuery(c)
 	if err != nil {
-		logger.Error("ListOfAthlete: Failed to list athletes: ", err)
-		c.JSON(500, models.Message{Err: err.Error()})
+		apierror.BadRequest(c, err)
+		return
+	}
+	resp, err := h.Service.ListOfAthlete(c.Request.Context(), &pb.ListOfAthleteRequest{
+		PageSize:  pageSize,
+		PageToken: pageToken,
+		OrderBy:   orderBy,
+		SportType: c.Query("sport_type"),
+		CountryId: c.Query("country_id"),
+	})
+	if err != nil {
+		logger.ErrorContext(c, "ListOfAthlete: Failed to list athletes: ", err)
+		apierror.Write(c, err)
 		return
 	}
 
-	logger.Info("ListOfAthlete: Athletes retrieved successfully")
+	logger.InfoContext(c, "ListOfAthlete: Athletes retrieved successfully")
 	c.JSON(200, resp)
 }
 
@@ -113,27 +136,28 @@ func (h *HandlerST) ListOfAthlete(c *gin.Context) {
 // @Param athlete body models.UpdateAthleteRequest true "Athlete"
 // @Success 200 {object} models.Athlete
 // @Failure 400 {object} models.Message
+// @Failure 404 {object} models.Message
 // @Failure 500 {object} models.Message
 func (h *HandlerST) UpdateAthlete(c *gin.Context) {
 
 	req := pb.UpdateAthleteRequest{}
 	req.Id = c.Param("id")
 	if err := c.BindJSON(&req); err != nil {
-		logger.Error("UpdateAthlete: Failed to bind JSON for athlete ID ", logrus.Fields{
+		logger.ErrorContext(c, "UpdateAthlete: Failed to bind JSON for athlete ID ", logrus.Fields{
 			"id":req.Id,
 		})
-		c.JSON(400, models.Message{Err: err.Error()})
+		apierror.BadRequest(c, err)
 		return
 	}
-	resp, err := h.Service.UpdateAthlete(&req)
+	resp, err := h.Service.UpdateAthlete(c.Request.Context(), &req)
 	if err != nil {
-		logger.Error("UpdateAthlete: Failed to update athlete with ID ", logrus.Fields{
+		logger.ErrorContext(c, "UpdateAthlete: Failed to update athlete with ID ", logrus.Fields{
 			"id":req.Id,
 		})
-		c.JSON(500, models.Message{Err: err.Error()})
+		apierror.Write(c, err)
 		return
 	}
-	logger.Info("UpdateAthlete: Athlete updated successfully: ", logrus.Fields{
+	logger.InfoContext(c, "UpdateAthlete: Athlete updated successfully: ", logrus.Fields{
 		"time":resp.UpdatedAt,
 	})
 	c.JSON(200, resp)
@@ -149,20 +173,204 @@ func (h *HandlerST) UpdateAthlete(c *gin.Context) {
 // @Param id path string true "ID"
 // @Success 200 {object} models.DeleteAthleteResponse
 // @Failure 400 {object} models.Message
+// @Failure 404 {object} models.Message
 // @Failure 500 {object} models.Message
 func (h *HandlerST) DeleteAthlete(c *gin.Context) {
 
 	req := pb.DeleteAthleteRequest{}
 	req.Id = c.Param("id")
-	resp, err := h.Service.DeleteAthlete(&req)
+	resp, err := h.Service.DeleteAthlete(c.Request.Context(), &req)
 	if err != nil {
-		logger.Error("DeleteAthlete: Failed to delete athlete with ID ", logrus.Fields{
+		logger.ErrorContext(c, "DeleteAthlete: Failed to delete athlete with ID ", logrus.Fields{
 			"id":req.Id,
 		})
-		c.JSON(500, models.Message{Err: err.Error()})
+		apierror.Write(c, err)
+		return
+	}
+
+	logger.InfoContext(c, "DeleteAthlete: Athlete deleted successfully: ", resp.Status)
+	c.JSON(200, resp)
+}
+
+// @Router /teams [post]
+// @Summary CREATE TEAM
+// @Description This method creates a team of at least two athletes of one country, e.g. a relay squad
+// @Security BearerAuth
+// @Tags TEAM
+// @Accept json
+// @Produce json
+// @Param team body models.CreateTeamRequest true "Team"
+// @Success 200 {object} models.Team
+// @Failure 400 {object} models.Message
+// @Failure 500 {object} models.Message
+func (h *HandlerST) CreateTeam(c *gin.Context) {
+
+	req := pb.CreateTeamRequest{}
+	if err := c.BindJSON(&req); err != nil {
+		logger.ErrorContext(c, "CreateTeam: Failed to bind JSON: ", err)
+		apierror.BadRequest(c, err)
+		return
+	}
+
+	//Check Country Id
+	if _, err := h.Service.GetCountry(c.Request.Context(), &pbCountry.GetCountryRequest{Id: req.CountryId}); err != nil {
+		logger.ErrorContext(c, "CreateTeam: Failed to get country: ", err)
+		if status.Code(err) == codes.NotFound {
+			apierror.Abort(c, codes.FailedPrecondition, "country %s does not exist or has been deleted", req.CountryId)
+			return
+		}
+		apierror.Write(c, err)
+		return
+	}
+
+	resp, err := h.Service.CreateTeam(c.Request.Context(), &req)
+	if err != nil {
+		logger.ErrorContext(c, "CreateTeam: Failed to create team: ", err)
+		apierror.Write(c, err)
+		return
+	}
+	logger.InfoContext(c, "CreateTeam: Team created successfully: ", logrus.Fields{
+		"id":      resp.Id,
+		"name":    resp.Name,
+		"members": len(resp.AthleteIds),
+	})
+	c.JSON(200, resp)
+}
+
+// @Router /teams/{id} [get]
+// @Summary GET TEAM
+// @Description This method gets a team with its roster
+// @Security BearerAuth
+// @Tags TEAM
+// @Accept json
+// @Produce json
+// @Param id path string true "ID"
+// @Success 200 {object} models.Team
+// @Failure 400 {object} models.Message
+// @Failure 404 {object} models.Message
+// @Failure 500 {object} models.Message
+func (h *HandlerST) GetTeam(c *gin.Context) {
+
+	req := pb.GetTeamRequest{}
+	req.Id = c.Param("id")
+	resp, err := h.Service.GetTeam(c.Request.Context(), &req)
+	if err != nil {
+		logger.ErrorContext(c, "GetTeam: Failed to get team with ID ", logrus.Fields{
+			"id": req.Id,
+		})
+		apierror.Write(c, err)
+		return
+	}
+	logger.InfoContext(c, "GetTeam: Team retrieved successfully: ", logrus.Fields{
+		"name": resp.Name,
+	})
+	c.JSON(200, resp)
+}
+
+// @Router /teams [get]
+// @Summary GET TEAMS
+// @Description This method gets teams
+// @Security BearerAuth
+// @Tags TEAM
+// @Accept json
+// @Produce json
+// @Param page_size query int false "Page size (default 50, max 1000)"
+// @Param page_token query string false "Token of the next page"
+// @Param order_by query string false "name, sport_type, country_id or created_at, optionally followed by desc"
+// @Param sport_type query string false "Sport type"
+// @Param country_id query string false "Country ID"
+// @Param athlete_id query string false "Only teams this athlete is a member of"
+// @Success 200 {object} models.ListTeamsResponse
+// @Failure 400 {object} models.Message
+// @Failure 500 {object} models.Message
+func (h *HandlerST) ListTeams(c *gin.Context) {
+
+	pageSize, pageToken, orderBy, err := pageQuery(c)
+	if err != nil {
+		apierror.BadRequest(c, err)
+		return
+	}
+	resp, err := h.Service.ListTeams(c.Request.Context(), &pb.ListTeamsRequest{
+		PageSize:  pageSize,
+		PageToken: pageToken,
+		OrderBy:   orderBy,
+		SportType: c.Query("sport_type"),
+		CountryId: c.Query("country_id"),
+		AthleteId: c.Query("athlete_id"),
+	})
+	if err != nil {
+		logger.ErrorContext(c, "ListTeams: Failed to list teams: ", err)
+		apierror.Write(c, err)
+		return
+	}
+
+	logger.InfoContext(c, "ListTeams: Teams retrieved successfully")
+	c.JSON(200, resp)
+}
+
+// @Router /teams/{id} [put]
+// @Summary UPDATE TEAM
+// @Description This method updates a team and replaces its roster. Medals already awarded keep the roster they were awarded to
+// @Security BearerAuth
+// @Tags TEAM
+// @Accept json
+// @Produce json
+// @Param id path string true "ID"
+// @Param team body models.UpdateTeamRequest true "Team"
+// @Success 200 {object} models.Team
+// @Failure 400 {object} models.Message
+// @Failure 404 {object} models.Message
+// @Failure 500 {object} models.Message
+func (h *HandlerST) UpdateTeam(c *gin.Context) {
+
+	req := pb.UpdateTeamRequest{}
+	if err := c.BindJSON(&req); err != nil {
+		logger.ErrorContext(c, "UpdateTeam: Failed to bind JSON for team ID ", logrus.Fields{
+			"id": c.Param("id"),
+		})
+		apierror.BadRequest(c, err)
+		return
+	}
+	req.Id = c.Param("id")
+	resp, err := h.Service.UpdateTeam(c.Request.Context(), &req)
+	if err != nil {
+		logger.ErrorContext(c, "UpdateTeam: Failed to update team with ID ", logrus.Fields{
+			"id": req.Id,
+		})
+		apierror.Write(c, err)
+		return
+	}
+	logger.InfoContext(c, "UpdateTeam: Team updated successfully: ", logrus.Fields{
+		"time": resp.UpdatedAt,
+	})
+	c.JSON(200, resp)
+}
+
+// @Router /teams/{id} [delete]
+// @Summary DELETE TEAM
+// @Description This method deletes a team
+// @Security BearerAuth
+// @Tags TEAM
+// @Accept json
+// @Produce json
+// @Param id path string true "ID"
+// @Success 200 {object} models.DeleteTeamResponse
+// @Failure 400 {object} models.Message
+// @Failure 404 {object} models.Message
+// @Failure 500 {object} models.Message
+func (h *HandlerST) DeleteTeam(c *gin.Context) {
+
+	req := pb.DeleteTeamRequest{}
+	req.Id = c.Param("id")
+	resp, err := h.Service.DeleteTeam(c.Request.Context(), &req)
+	if err != nil {
+		logger.ErrorContext(c, "DeleteTeam: Failed to delete team with ID ", logrus.Fields{
+			"id": req.Id,
+		})
+		apierror.Write(c, err)
 		return
 	}
 
-	logger.Info("DeleteAthlete: Athlete deleted successfully: ", resp.Status)
+	logger.InfoContext(c, "DeleteTeam: Team deleted successfully: ", resp.Status)
 	c.JSON(200, resp)
 }
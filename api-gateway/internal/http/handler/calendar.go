@@ -0,0 +1,229 @@
+package handler
+
+import (
+	"api-gateway/internal/http/apierror"
+	"api-gateway/internal/ical"
+	"api-gateway/logger"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	pbAthlete "github.com/Bekzodbekk/paris2024_livestream_protos/genproto/athletepb"
+	pbEvent "github.com/Bekzodbekk/paris2024_livestream_protos/genproto/eventpb"
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+)
+
+// calendarUIDDomain qualifies event UIDs. Changing it would make subscribers
+// see every event twice.
+const calendarUIDDomain = "paris2024-livestream"
+
+// calendarFlushEvents is how many events are buffered before they are pushed
+// to the client.
+const calendarFlushEvents = 200
+
+// @Router /events/calendar.ics [get]
+// @Summary EVENT CALENDAR
+// @Description This method returns the schedule as an iCalendar feed in Europe/Paris time. It needs no token, so calendar apps can subscribe to it; deleted and cancelled events stay in the feed as cancellations
+// @Tags EVENT
+// @Produce text/calendar
+// @Param sport_type query []string false "Sport types" collectionFormat(csv)
+// @Param venue query string false "Venue ID or name"
+// @Param country query string false "Country ID; only sports its athletes compete in"
+// @Param id query []string false "Event IDs" collectionFormat(csv)
+// @Success 200 {string} string
+// @Failure 400 {object} models.Message
+// @Failure 500 {object} models.Message
+func (h *HandlerST) EventsCalendar(c *gin.Context) {
+
+	ctx := c.Request.Context()
+	req := pbEvent.ExportEventsRequest{
+		IncludeDeleted: true,
+		SportTypes:     queryList(c, "sport_type"),
+		Venue:          c.Query("venue"),
+		Ids:            queryList(c, "id"),
+	}
+
+	// A country whose athletes compete in none of the requested sports gets
+	// an empty calendar.
+	next := func() (*pbEvent.Event, error) { return nil, io.EOF }
+	empty := false
+	if country := c.Query("country"); country != "" {
+		sports, err := h.countrySports(ctx, country)
+		if err != nil {
+			logger.ErrorContext(c, "EventsCalendar: Failed to list the country's athletes: ", err)
+			apierror.Write(c, err)
+			return
+		}
+		req.SportTypes = intersectSports(req.SportTypes, sports)
+		empty = len(req.SportTypes) == 0
+	}
+	if !empty {
+		stream, err := h.Service.ExportEvents(ctx, &req)
+		if err != nil {
+			logger.ErrorContext(c, "EventsCalendar: Failed to export events: ", err)
+			apierror.Write(c, err)
+			return
+		}
+		next = stream.Recv
+	}
+
+	event, err := next()
+	if err != nil && err != io.EOF {
+		logger.ErrorContext(c, "EventsCalendar: Failed to read events: ", err)
+		apierror.Write(c, err)
+		return
+	}
+
+	c.Header("Content-Type", ical.ContentType)
+	c.Header("Content-Disposition", `inline; filename="events.ics"`)
+	c.Header("X-Accel-Buffering", "no")
+	c.Status(http.StatusOK)
+
+	w := ical.NewWriter(c.Writer, "Paris 2024")
+	count := 0
+	for err == nil {
+		entry, perr := calendarEvent(event)
+		if perr != nil {
+			logger.WarnContext(c, "EventsCalendar: Skipping event: ", logrus.Fields{
+				"event_id": event.Id,
+				"error":    perr,
+			})
+			event, err = next()
+			continue
+		}
+		if err = w.WriteEvent(entry); err != nil {
+			break
+		}
+		count++
+		if count%calendarFlushEvents == 0 {
+			if err = w.Flush(); err != nil {
+				break
+			}
+			c.Writer.Flush()
+		}
+		event, err = next()
+	}
+	if err != io.EOF {
+		logger.ErrorContext(c, "EventsCalendar: Calendar interrupted: ", logrus.Fields{
+			"error":  err,
+			"events": count,
+		})
+		c.Abort()
+		return
+	}
+
+	if err := w.Close(); err != nil {
+		logger.ErrorContext(c, "EventsCalendar: Failed to finish calendar: ", err)
+		return
+	}
+	c.Writer.Flush()
+
+	logger.InfoContext(c, "EventsCalendar: Calendar sent successfully: ", logrus.Fields{
+		"events": count,
+	})
+}
+
+// calendarEvent turns an event into a VEVENT. Its dates are Paris wall-clock
+// times; soft-deleted and cancelled events become cancellations.
+func calendarEvent(e *pbEvent.Event) (ical.Event, error) {
+	if len(e.Date) < len("2006-01-02") {
+		return ical.Event{}, fmt.Errorf("invalid date %q", e.Date)
+	}
+	date := e.Date[:len("2006-01-02")]
+	start, err := parseWallClock(date, e.StartTime)
+	if err != nil {
+		return ical.Event{}, err
+	}
+	end, err := parseWallClock(date, e.EndTime)
+	if err != nil {
+		return ical.Event{}, err
+	}
+	modified, err := time.Parse(time.RFC3339Nano, e.UpdatedAt)
+	if err != nil {
+		modified = time.Now()
+	}
+
+	description := "Sport: " + e.SportType
+	if e.Status != "" {
+		description += "\nStatus: " + e.Status
+	}
+	if e.StatusReason != "" {
+		description += "\n" + e.StatusReason
+	}
+
+	return ical.Event{
+		UID:         fmt.Sprintf("event-%s@%s", e.Id, calendarUIDDomain),
+		Sequence:    int(e.Sequence),
+		Modified:    modified,
+		Start:       start,
+		End:         end,
+		Summary:     e.Name,
+		Location:    e.Location,
+		Description: description,
+		Categories:  []string{e.SportType},
+		Cancelled:   e.DeletedAt != 0 || e.Status == "cancelled",
+	}, nil
+}
+
+// parseWallClock reads a date and an HH:MM[:SS] time as Paris local time.
+// A time sent as the RFC 3339 form lib/pq gives a scanned TIME column,
+// "0000-01-01T10:00:00Z", counts by its clock alone.
+func parseWallClock(date, clock string) (time.Time, error) {
+	if i := strings.IndexByte(clock, 'T'); i >= 0 {
+		clock = strings.TrimSuffix(clock[i+1:], "Z")
+	}
+	layout := "2006-01-02 15:04:05"
+	if strings.Count(clock, ":") == 1 {
+		layout = "2006-01-02 15:04"
+	}
+	t, err := time.ParseInLocation(layout, date+" "+clock, ical.Location)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid time %q on %s", clock, date)
+	}
+	return t, nil
+}
+
+// countrySports lists the sports the athletes of a country compete in.
+func (h *HandlerST) countrySports(ctx context.Context, countryID string) ([]string, error) {
+	var sports []string
+	seen := make(map[string]bool)
+	req := pbAthlete.ListOfAthleteRequest{PageSize: maxPageSize, CountryId: countryID}
+	for {
+		resp, err := h.Service.ListOfAthlete(ctx, &req)
+		if err != nil {
+			return nil, err
+		}
+		for _, athlete := range resp.Athletes {
+			if athlete.SportType != "" && !seen[athlete.SportType] {
+				seen[athlete.SportType] = true
+				sports = append(sports, athlete.SportType)
+			}
+		}
+		if resp.NextPageToken == "" {
+			return sports, nil
+		}
+		req.PageToken = resp.NextPageToken
+	}
+}
+
+// intersectSports keeps the requested sports the country competes in, or all
+// of the country's sports when none were requested.
+func intersectSports(requested, sports []string) []string {
+	if len(requested) == 0 {
+		return sports
+	}
+	var kept []string
+	for _, r := range requested {
+		for _, s := range sports {
+			if strings.EqualFold(r, s) {
+				kept = append(kept, s)
+				break
+			}
+		}
+	}
+	return kept
+}
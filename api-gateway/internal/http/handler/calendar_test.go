@@ -0,0 +1,39 @@
+package handler
+
+import (
+	"api-gateway/internal/ical"
+	"testing"
+	"time"
+
+	pbEvent "github.com/Bekzodbekk/paris2024_livestream_protos/genproto/eventpb"
+)
+
+func TestCalendarEvent(t *testing.T) {
+	start := time.Date(2024, 7, 27, 10, 0, 0, 0, ical.Location)
+	end := time.Date(2024, 7, 27, 12, 30, 0, 0, ical.Location)
+
+	for name, e := range map[string]*pbEvent.Event{
+		// What event-service selects with to_char.
+		"formatted": {Id: "1", Date: "2024-07-27", StartTime: "10:00:00", EndTime: "12:30:00"},
+		// What lib/pq produces for DATE and TIME columns scanned unformatted.
+		"driver":  {Id: "1", Date: "2024-07-27T00:00:00Z", StartTime: "0000-01-01T10:00:00Z", EndTime: "0000-01-01T12:30:00Z"},
+		"minutes": {Id: "1", Date: "2024-07-27", StartTime: "10:00", EndTime: "12:30"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			entry, err := calendarEvent(e)
+			if err != nil {
+				t.Fatalf("calendarEvent: %v", err)
+			}
+			if !entry.Start.Equal(start) || !entry.End.Equal(end) {
+				t.Fatalf("got %v - %v, want %v - %v", entry.Start, entry.End, start, end)
+			}
+		})
+	}
+}
+
+func TestCalendarEventInvalidTime(t *testing.T) {
+	_, err := calendarEvent(&pbEvent.Event{Id: "1", Date: "2024-07-27", StartTime: "ten", EndTime: "12:30"})
+	if err == nil {
+		t.Fatal("expected an invalid start time to be refused")
+	}
+}
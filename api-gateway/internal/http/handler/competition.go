@@ -0,0 +1,268 @@
+package handler
+
+import (
+	"api-gateway/internal/http/apierror"
+	"api-gateway/logger"
+
+	pb "github.com/Bekzodbekk/paris2024_livestream_protos/genproto/eventpb"
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+)
+
+// @Router /events/{id}/phases [post]
+// @Summary CREATE PHASE
+// @Description This method appends a phase (heats, a semifinal, a final or a knockout round) with its units to an event
+// @Security BearerAuth
+// @Tags COMPETITION
+// @Accept json
+// @Produce json
+// @Param id path string true "Event ID"
+// @Param phase body models.CreatePhaseRequest true "Phase"
+// @Success 200 {object} models.Phase
+// @Failure 400 {object} models.Message
+// @Failure 404 {object} models.Message
+// @Failure 500 {object} models.Message
+func (h *HandlerST) CreatePhase(c *gin.Context) {
+
+	req := pb.CreatePhaseRequest{}
+	if err := c.BindJSON(&req); err != nil {
+		logger.ErrorContext(c, "CreatePhase: Failed to bind JSON: ", err)
+		apierror.BadRequest(c, err)
+		return
+	}
+	req.EventId = c.Param("id")
+	resp, err := h.Service.CreatePhase(c.Request.Context(), &req)
+	if err != nil {
+		logger.ErrorContext(c, "CreatePhase: Failed to create phase: ", logrus.Fields{
+			"event_id": req.EventId,
+		})
+		apierror.Write(c, err)
+		return
+	}
+	logger.InfoContext(c, "CreatePhase: Phase created successfully: ", logrus.Fields{
+		"id":       resp.Id,
+		"event_id": resp.EventId,
+	})
+	c.JSON(200, resp)
+}
+
+// @Router /events/{id}/phases [get]
+// @Summary LIST PHASES
+// @Description This method returns the phases of an event in order, with their units, start lists and results
+// @Security BearerAuth
+// @Tags COMPETITION
+// @Produce json
+// @Param id path string true "Event ID"
+// @Success 200 {object} models.ListPhasesResponse
+// @Failure 400 {object} models.Message
+// @Failure 500 {object} models.Message
+func (h *HandlerST) ListPhases(c *gin.Context) {
+
+	req := pb.ListPhasesRequest{EventId: c.Param("id")}
+	resp, err := h.Service.ListPhases(c.Request.Context(), &req)
+	if err != nil {
+		logger.ErrorContext(c, "ListPhases: Failed to list phases: ", logrus.Fields{
+			"event_id": req.EventId,
+		})
+		apierror.Write(c, err)
+		return
+	}
+	logger.InfoContext(c, "ListPhases: Phases retrieved successfully")
+	c.JSON(200, resp)
+}
+
+// @Router /events/{id}/bracket [post]
+// @Summary GENERATE BRACKET
+// @Description This method appends the rounds of a knockout bracket to an event; competitors are listed best seed first
+// @Security BearerAuth
+// @Tags COMPETITION
+// @Accept json
+// @Produce json
+// @Param id path string true "Event ID"
+// @Param bracket body models.GenerateBracketRequest true "Bracket"
+// @Success 200 {object} models.GenerateBracketResponse
+// @Failure 400 {object} models.Message
+// @Failure 404 {object} models.Message
+// @Failure 500 {object} models.Message
+func (h *HandlerST) GenerateBracket(c *gin.Context) {
+
+	req := pb.GenerateBracketRequest{}
+	if err := c.BindJSON(&req); err != nil {
+		logger.ErrorContext(c, "GenerateBracket: Failed to bind JSON: ", err)
+		apierror.BadRequest(c, err)
+		return
+	}
+	req.EventId = c.Param("id")
+	resp, err := h.Service.GenerateBracket(c.Request.Context(), &req)
+	if err != nil {
+		logger.ErrorContext(c, "GenerateBracket: Failed to generate bracket: ", logrus.Fields{
+			"event_id": req.EventId,
+		})
+		apierror.Write(c, err)
+		return
+	}
+	logger.InfoContext(c, "GenerateBracket: Bracket generated successfully: ", logrus.Fields{
+		"event_id": req.EventId,
+		"rounds":   len(resp.Phases),
+	})
+	c.JSON(200, resp)
+}
+
+// @Router /phases/{id} [get]
+// @Summary GET PHASE
+// @Description This method gets a phase by ID with its units, start lists and results
+// @Security BearerAuth
+// @Tags COMPETITION
+// @Produce json
+// @Param id path string true "ID"
+// @Success 200 {object} models.Phase
+// @Failure 400 {object} models.Message
+// @Failure 404 {object} models.Message
+// @Failure 500 {object} models.Message
+func (h *HandlerST) GetPhase(c *gin.Context) {
+
+	req := pb.GetPhaseRequest{Id: c.Param("id")}
+	resp, err := h.Service.GetPhase(c.Request.Context(), &req)
+	if err != nil {
+		logger.ErrorContext(c, "GetPhase: Failed to get phase with ID ", logrus.Fields{
+			"id": req.Id,
+		})
+		apierror.Write(c, err)
+		return
+	}
+	logger.InfoContext(c, "GetPhase: Phase retrieved successfully")
+	c.JSON(200, resp)
+}
+
+// @Router /phases/{id} [delete]
+// @Summary DELETE PHASE
+// @Description This method deletes a phase
+// @Security BearerAuth
+// @Tags COMPETITION
+// @Produce json
+// @Param id path string true "ID"
+// @Success 200 {object} models.DeletePhaseResponse
+// @Failure 400 {object} models.Message
+// @Failure 404 {object} models.Message
+// @Failure 500 {object} models.Message
+func (h *HandlerST) DeletePhase(c *gin.Context) {
+
+	req := pb.DeletePhaseRequest{Id: c.Param("id")}
+	resp, err := h.Service.DeletePhase(c.Request.Context(), &req)
+	if err != nil {
+		logger.ErrorContext(c, "DeletePhase: Failed to delete phase with ID ", logrus.Fields{
+			"id": req.Id,
+		})
+		apierror.Write(c, err)
+		return
+	}
+	logger.InfoContext(c, "DeletePhase: Phase deleted successfully: ", logrus.Fields{
+		"id": req.Id,
+	})
+	c.JSON(200, resp)
+}
+
+// @Router /phases/{id}/advance [post]
+// @Summary ADVANCE PHASE
+// @Description This method fills the start lists of the next phase with the qualifiers of a completed phase
+// @Security BearerAuth
+// @Tags COMPETITION
+// @Produce json
+// @Param id path string true "ID"
+// @Success 200 {object} models.AdvancePhaseResponse
+// @Failure 400 {object} models.Message
+// @Failure 404 {object} models.Message
+// @Failure 409 {object} models.Message
+// @Failure 500 {object} models.Message
+func (h *HandlerST) AdvancePhase(c *gin.Context) {
+
+	req := pb.AdvancePhaseRequest{PhaseId: c.Param("id")}
+	resp, err := h.Service.AdvancePhase(c.Request.Context(), &req)
+	if err != nil {
+		logger.ErrorContext(c, "AdvancePhase: Failed to advance phase: ", logrus.Fields{
+			"id": req.PhaseId,
+		})
+		apierror.Write(c, err)
+		return
+	}
+	logger.InfoContext(c, "AdvancePhase: Phase advanced successfully: ", logrus.Fields{
+		"id":        req.PhaseId,
+		"qualified": len(resp.Qualified),
+	})
+	c.JSON(200, resp)
+}
+
+// @Router /units/{id}/start-list [put]
+// @Summary SET START LIST
+// @Description This method replaces the start list of a unit that has no results yet
+// @Security BearerAuth
+// @Tags COMPETITION
+// @Accept json
+// @Produce json
+// @Param id path string true "Unit ID"
+// @Param start_list body models.SetStartListRequest true "Start list"
+// @Success 200 {object} models.Unit
+// @Failure 400 {object} models.Message
+// @Failure 404 {object} models.Message
+// @Failure 409 {object} models.Message
+// @Failure 500 {object} models.Message
+func (h *HandlerST) SetStartList(c *gin.Context) {
+
+	req := pb.SetStartListRequest{}
+	if err := c.BindJSON(&req); err != nil {
+		logger.ErrorContext(c, "SetStartList: Failed to bind JSON: ", err)
+		apierror.BadRequest(c, err)
+		return
+	}
+	req.UnitId = c.Param("id")
+	resp, err := h.Service.SetStartList(c.Request.Context(), &req)
+	if err != nil {
+		logger.ErrorContext(c, "SetStartList: Failed to set start list: ", logrus.Fields{
+			"unit_id": req.UnitId,
+		})
+		apierror.Write(c, err)
+		return
+	}
+	logger.InfoContext(c, "SetStartList: Start list set successfully: ", logrus.Fields{
+		"unit_id": resp.Id,
+		"entries": len(resp.Entries),
+	})
+	c.JSON(200, resp)
+}
+
+// @Router /units/{id}/results [put]
+// @Summary RECORD RESULTS
+// @Description This method records the results of a unit, ranks it and marks it completed; recording again corrects them
+// @Security BearerAuth
+// @Tags COMPETITION
+// @Accept json
+// @Produce json
+// @Param id path string true "Unit ID"
+// @Param results body models.RecordResultsRequest true "Results"
+// @Success 200 {object} models.Unit
+// @Failure 400 {object} models.Message
+// @Failure 404 {object} models.Message
+// @Failure 500 {object} models.Message
+func (h *HandlerST) RecordResults(c *gin.Context) {
+
+	req := pb.RecordResultsRequest{}
+	if err := c.BindJSON(&req); err != nil {
+		logger.ErrorContext(c, "RecordResults: Failed to bind JSON: ", err)
+		apierror.BadRequest(c, err)
+		return
+	}
+	req.UnitId = c.Param("id")
+	resp, err := h.Service.RecordResults(c.Request.Context(), &req)
+	if err != nil {
+		logger.ErrorContext(c, "RecordResults: Failed to record results: ", logrus.Fields{
+			"unit_id": req.UnitId,
+		})
+		apierror.Write(c, err)
+		return
+	}
+	logger.InfoContext(c, "RecordResults: Results recorded successfully: ", logrus.Fields{
+		"unit_id": resp.Id,
+		"results": len(req.Results),
+	})
+	c.JSON(200, resp)
+}
@@ -2,8 +2,8 @@ package handler
 
 import (
 	pb "github.com/Bekzodbekk/paris2024_livestream_protos/genproto/countrypb"
+	"api-gateway/internal/http/apierror"
 	"api-gateway/logger"
-	"api-gateway/models"
 
 	"github.com/gin-gonic/gin"
 	"github.com/sirupsen/logrus"
@@ -24,17 +24,17 @@ func (h *HandlerST) CreateCountry(c *gin.Context) {
 
 	req := pb.CreateCountryRequest{}
 	if err := c.BindJSON(&req); err != nil {
-		logger.Error("CreateCountry: Failed to bind JSON: ", err)
-		c.JSON(400, models.Message{Err: err.Error()})
+		logger.ErrorContext(c, "CreateCountry: Failed to bind JSON: ", err)
+		apierror.BadRequest(c, err)
 		return
 	}
-	resp, err := h.Service.CreateCountry(&req)
+	resp, err := h.Service.CreateCountry(c.Request.Context(), &req)
 	if err != nil {
-		logger.Error("CreateCountry: Failed to create country: ", err)
-		c.JSON(500, models.Message{Err: err.Error()})
+		logger.ErrorContext(c, "CreateCountry: Failed to create country: ", err)
+		apierror.Write(c, err)
 		return
 	}
-	logger.Info("CreateCountry: Country created successfully: ", logrus.Fields{
+	logger.InfoContext(c, "CreateCountry: Country created successfully: ", logrus.Fields{
 		"id":   resp.Id,
 		"name": resp.Name,
 	})
@@ -51,20 +51,21 @@ func (h *HandlerST) CreateCountry(c *gin.Context) {
 // @Param id path string true "ID"
 // @Success 200 {object} models.Country
 // @Failure 400 {object} models.Message
+// @Failure 404 {object} models.Message
 // @Failure 500 {object} models.Message
 func (h *HandlerST) GetCountry(c *gin.Context) {
 
 	req := pb.GetCountryRequest{}
 	req.Id = c.Param("id")
-	resp, err := h.Service.GetCountry(&req)
+	resp, err := h.Service.GetCountry(c.Request.Context(), &req)
 	if err != nil {
-		logger.Error("GetCountry: Failed to get country with ID ", logrus.Fields{
+		logger.ErrorContext(c, "GetCountry: Failed to get country with ID ", logrus.Fields{
 			"id": req.Id,
 		})
-		c.JSON(500, models.Message{Err: err.Error()})
+		apierror.Write(c, err)
 		return
 	}
-	logger.Info("GetCountry: Country retrieved successfully: ", logrus.Fields{
+	logger.InfoContext(c, "GetCountry: Country retrieved successfully: ", logrus.Fields{
 		"name": resp.Name,
 	})
 	c.JSON(200, resp)
@@ -77,18 +78,32 @@ func (h *HandlerST) GetCountry(c *gin.Context) {
 // @Tags COUNTRY
 // @Accept json
 // @Produce json
+// @Param page_size query int false "Page size (default 50, max 1000)"
+// @Param page_token query string false "Token of the next page"
+// @Param order_by query string false "name, region or created_at, optionally followed by desc"
+// @Param region query string false "Region"
 // @Success 200 {object} models.ListOfCountryResponse
 // @Failure 400 {object} models.Message
 // @Failure 500 {object} models.Message
 func (h *HandlerST) ListOfCountry(c *gin.Context) {
 
-	resp, err := h.Service.ListOfCountry(&pb.ListOfCountryRequest{})
+	pageSize, pageToken, orderBy, err := pageQuery(c)
 	if err != nil {
-		logger.Error("ListOfCountry: Failed to list countries: ", err)
-		c.JSON(500, models.Message{Err: err.Error()})
+		apierror.BadRequest(c, err)
 		return
 	}
-	logger.Info("ListOfCountry: Countries retrieved successfully")
+	resp, err := h.Service.ListOfCountry(c.Request.Context(), &pb.ListOfCountryRequest{
+		PageSize:  pageSize,
+		PageToken: pageToken,
+		OrderBy:   orderBy,
+		Region:    c.Query("region"),
+	})
+	if err != nil {
+		logger.ErrorContext(c, "ListOfCountry: Failed to list countries: ", err)
+		apierror.Write(c, err)
+		return
+	}
+	logger.InfoContext(c, "ListOfCountry: Countries retrieved successfully")
 	c.JSON(200, resp)
 }
 
@@ -103,27 +118,28 @@ func (h *HandlerST) ListOfCountry(c *gin.Context) {
 // @Param country body models.UpdateCountryRequest true "Country"
 // @Success 200 {object} models.Country
 // @Failure 400 {object} models.Message
+// @Failure 404 {object} models.Message
 // @Failure 500 {object} models.Message
 func (h *HandlerST) UpdateCountry(c *gin.Context) {
 
 	req := pb.UpdateCountryRequest{}
 	req.Id = c.Param("id")
 	if err := c.BindJSON(&req); err != nil {
-		logger.Error("UpdateCountry: Failed to bind JSON for country ID ", logrus.Fields{
+		logger.ErrorContext(c, "UpdateCountry: Failed to bind JSON for country ID ", logrus.Fields{
 			"id": req.Id,
 		})
-		c.JSON(400, models.Message{Err: err.Error()})
+		apierror.BadRequest(c, err)
 		return
 	}
-	resp, err := h.Service.UpdateCountry(&req)
+	resp, err := h.Service.UpdateCountry(c.Request.Context(), &req)
 	if err != nil {
-		logger.Error("UpdateCountry: Failed to update country with ID ", logrus.Fields{
+		logger.ErrorContext(c, "UpdateCountry: Failed to update country with ID ", logrus.Fields{
 			"id": req.Id,
 		})
-		c.JSON(500, models.Message{Err: err.Error()})
+		apierror.Write(c, err)
 		return
 	}
-	logger.Info("UpdateCountry: Country updated successfully: ", logrus.Fields{
+	logger.InfoContext(c, "UpdateCountry: Country updated successfully: ", logrus.Fields{
 		"time": resp.UpdatedAt,
 	})
 	c.JSON(200, resp)
@@ -139,19 +155,20 @@ func (h *HandlerST) UpdateCountry(c *gin.Context) {
 // @Param id path string true "ID"
 // @Success 200 {object} models.DeleteCountryResponse
 // @Failure 400 {object} models.Message
+// @Failure 404 {object} models.Message
 // @Failure 500 {object} models.Message
 func (h *HandlerST) DeleteCountry(c *gin.Context) {
 
 	req := pb.DeleteCountryRequest{}
 	req.Id = c.Param("id")
-	resp, err := h.Service.DeleteCountry(&req)
+	resp, err := h.Service.DeleteCountry(c.Request.Context(), &req)
 	if err != nil {
-		logger.Error("DeleteCountry: Failed to delete country with ID ", logrus.Fields{
+		logger.ErrorContext(c, "DeleteCountry: Failed to delete country with ID ", logrus.Fields{
 			"id": req.Id,
 		})
-		c.JSON(500, models.Message{Err: err.Error()})
+		apierror.Write(c, err)
 		return
 	}
-	logger.Info("DeleteCountry: Country deleted successfully: ", resp.Status)
+	logger.InfoContext(c, "DeleteCountry: Country deleted successfully: ", resp.Status)
 	c.JSON(200, resp)
 }
@@ -1,13 +1,14 @@
 package handler
 
 import (
+	"api-gateway/internal/http/apierror"
 	"api-gateway/logger"
-	"api-gateway/models"
 
 	pb "github.com/Bekzodbekk/paris2024_livestream_protos/genproto/eventpb"
 
 	"github.com/gin-gonic/gin"
 	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
 )
 
 // @Router /events [post]
@@ -25,17 +26,17 @@ func (h *HandlerST) CreateEvent(c *gin.Context) {
 
 	req := pb.CreateEventRequest{}
 	if err := c.BindJSON(&req); err != nil {
-		logger.Error("CreateEvent: Failed to bind JSON: ", err)
-		c.JSON(400, models.Message{Err: err.Error()})
+		logger.ErrorContext(c, "CreateEvent: Failed to bind JSON: ", err)
+		apierror.BadRequest(c, err)
 		return
 	}
-	resp, err := h.Service.CreateEvent(&req)
+	resp, err := h.Service.CreateEvent(c.Request.Context(), &req)
 	if err != nil {
-		logger.Error("CreateEvent: Failed to create event: ", err)
-		c.JSON(500, models.Message{Err: err.Error()})
+		logger.ErrorContext(c, "CreateEvent: Failed to create event: ", err)
+		apierror.Write(c, err)
 		return
 	}
-	logger.Info("CreateEvent: Event created successfully: ", logrus.Fields{
+	logger.InfoContext(c, "CreateEvent: Event created successfully: ", logrus.Fields{
 		"id":   resp.Id,
 		"name": resp.Name,
 	})
@@ -52,20 +53,21 @@ func (h *HandlerST) CreateEvent(c *gin.Context) {
 // @Param id path string true "ID"
 // @Success 200 {object} models.Event
 // @Failure 400 {object} models.Message
+// @Failure 404 {object} models.Message
 // @Failure 500 {object} models.Message
 func (h *HandlerST) GetEvent(c *gin.Context) {
 
 	req := pb.GetEventRequest{}
 	req.Id = c.Param("id")
-	resp, err := h.Service.GetEvent(&req)
+	resp, err := h.Service.GetEvent(c.Request.Context(), &req)
 	if err != nil {
-		logger.Error("GetEvent: Failed to get event with ID ", logrus.Fields{
+		logger.ErrorContext(c, "GetEvent: Failed to get event with ID ", logrus.Fields{
 			"id": req.Id,
 		})
-		c.JSON(500, models.Message{Err: err.Error()})
+		apierror.Write(c, err)
 		return
 	}
-	logger.Info("GetEvent: Event retrieved successfully: ", logrus.Fields{
+	logger.InfoContext(c, "GetEvent: Event retrieved successfully: ", logrus.Fields{
 		"name": resp.Name,
 	})
 	c.JSON(200, resp)
@@ -78,18 +80,42 @@ func (h *HandlerST) GetEvent(c *gin.Context) {
 // @Tags EVENT
 // @Accept json
 // @Produce json
+// @Param page_size query int false "Page size (default 50, max 1000)"
+// @Param page_token query string false "Token of the next page"
+// @Param order_by query string false "name, sport_type, date, start_time or created_at, optionally followed by desc"
+// @Param sport_type query string false "Sport type"
+// @Param location query string false "Location"
+// @Param date_from query string false "Only events on or after this date (YYYY-MM-DD)"
+// @Param date_to query string false "Only events on or before this date (YYYY-MM-DD)"
+// @Param venue_id query string false "Venue ID"
+// @Param status query string false "scheduled, live, delayed, completed or cancelled"
 // @Success 200 {object} models.ListOfEventResponse
 // @Failure 400 {object} models.Message
 // @Failure 500 {object} models.Message
 func (h *HandlerST) ListOfEvent(c *gin.Context) {
 
-	resp, err := h.Service.ListOfEvent(&pb.ListOfEventRequest{})
+	pageSize, pageToken, orderBy, err := pageQuery(c)
 	if err != nil {
-		logger.Error("ListOfEvent: Failed to list events: ", err)
-		c.JSON(500, models.Message{Err: err.Error()})
+		apierror.BadRequest(c, err)
 		return
 	}
-	logger.Info("ListOfEvent: Events retrieved successfully")
+	resp, err := h.Service.ListOfEvent(c.Request.Context(), &pb.ListOfEventRequest{
+		PageSize:  pageSize,
+		PageToken: pageToken,
+		OrderBy:   orderBy,
+		SportType: c.Query("sport_type"),
+		Location:  c.Query("location"),
+		DateFrom:  c.Query("date_from"),
+		DateTo:    c.Query("date_to"),
+		VenueId:   c.Query("venue_id"),
+		Status:    c.Query("status"),
+	})
+	if err != nil {
+		logger.ErrorContext(c, "ListOfEvent: Failed to list events: ", err)
+		apierror.Write(c, err)
+		return
+	}
+	logger.InfoContext(c, "ListOfEvent: Events retrieved successfully")
 	c.JSON(200, resp)
 }
 
@@ -104,27 +130,28 @@ func (h *HandlerST) ListOfEvent(c *gin.Context) {
 // @Param event body models.UpdateEventRequest true "Event"
 // @Success 200 {object} models.Event
 // @Failure 400 {object} models.Message
+// @Failure 404 {object} models.Message
 // @Failure 500 {object} models.Message
 func (h *HandlerST) UpdateEvent(c *gin.Context) {
 
 	req := pb.UpdateEventRequest{}
 	req.Id = c.Param("id")
 	if err := c.BindJSON(&req); err != nil {
-		logger.Error("UpdateEvent: Failed to bind JSON for event ID ", logrus.Fields{
+		logger.ErrorContext(c, "UpdateEvent: Failed to bind JSON for event ID ", logrus.Fields{
 			"id": req.Id,
 		})
-		c.JSON(400, models.Message{Err: err.Error()})
+		apierror.BadRequest(c, err)
 		return
 	}
-	resp, err := h.Service.UpdateEvent(&req)
+	resp, err := h.Service.UpdateEvent(c.Request.Context(), &req)
 	if err != nil {
-		logger.Error("UpdateEvent: Failed to update event with ID ", logrus.Fields{
+		logger.ErrorContext(c, "UpdateEvent: Failed to update event with ID ", logrus.Fields{
 			"id": req.Id,
 		})
-		c.JSON(500, models.Message{Err: err.Error()})
+		apierror.Write(c, err)
 		return
 	}
-	logger.Info("UpdateEvent: Event updated successfully: ", logrus.Fields{
+	logger.InfoContext(c, "UpdateEvent: Event updated successfully: ", logrus.Fields{
 		"time": resp.UpdatedAt,
 	})
 	c.JSON(200, resp)
@@ -140,19 +167,252 @@ func (h *HandlerST) UpdateEvent(c *gin.Context) {
 // @Param id path string true "ID"
 // @Success 200 {object} models.DeleteEventResponse
 // @Failure 400 {object} models.Message
+// @Failure 404 {object} models.Message
 // @Failure 500 {object} models.Message
 func (h *HandlerST) DeleteEvent(c *gin.Context) {
 
 	req := pb.DeleteEventRequest{}
 	req.Id = c.Param("id")
-	resp, err := h.Service.DeleteEvent(&req)
+	resp, err := h.Service.DeleteEvent(c.Request.Context(), &req)
+	if err != nil {
+		logger.ErrorContext(c, "DeleteEvent: Failed to delete event with ID ", logrus.Fields{
+			"id": req.Id,
+		})
+		apierror.Write(c, err)
+		return
+	}
+	logger.InfoContext(c, "DeleteEvent: Event deleted successfully: ", resp.Status)
+	c.JSON(200, resp)
+}
+
+// @Router /events/{id}/status [post]
+// @Summary CHANGE EVENT STATUS
+// @Description This method moves an event along its lifecycle: scheduled, live, delayed, completed, cancelled
+// @Security BearerAuth
+// @Tags EVENT
+// @Accept json
+// @Produce json
+// @Param id path string true "ID"
+// @Param status body models.TransitionEventRequest true "Status"
+// @Success 200 {object} models.Event
+// @Failure 400 {object} models.Message
+// @Failure 404 {object} models.Message
+// @Failure 409 {object} models.Message
+// @Failure 500 {object} models.Message
+func (h *HandlerST) TransitionEvent(c *gin.Context) {
+
+	req := pb.TransitionEventRequest{}
+	if err := c.BindJSON(&req); err != nil {
+		logger.ErrorContext(c, "TransitionEvent: Failed to bind JSON: ", err)
+		apierror.BadRequest(c, err)
+		return
+	}
+	req.Id = c.Param("id")
+	resp, err := h.Service.TransitionEvent(c.Request.Context(), &req)
+	if err != nil {
+		logger.ErrorContext(c, "TransitionEvent: Failed to change event status: ", logrus.Fields{
+			"id":     req.Id,
+			"status": req.Status,
+		})
+		apierror.Write(c, err)
+		return
+	}
+	logger.InfoContext(c, "TransitionEvent: Event status changed: ", logrus.Fields{
+		"id":     resp.Id,
+		"status": resp.Status,
+	})
+	c.JSON(200, resp)
+}
+
+// @Router /schedule [get]
+// @Summary GET SCHEDULE
+// @Description This method returns the events of one day grouped by venue, in start order
+// @Security BearerAuth
+// @Tags EVENT
+// @Produce json
+// @Param date query string true "Day (YYYY-MM-DD)"
+// @Param venue query string false "Venue ID or name"
+// @Param sport query string false "Sport type"
+// @Success 200 {object} models.ScheduleResponse
+// @Failure 400 {object} models.Message
+// @Failure 500 {object} models.Message
+func (h *HandlerST) GetSchedule(c *gin.Context) {
+
+	date := c.Query("date")
+	if date == "" {
+		apierror.Abort(c, codes.InvalidArgument, "date is required")
+		return
+	}
+	resp, err := h.Service.GetSchedule(c.Request.Context(), &pb.GetScheduleRequest{
+		Date:      date,
+		Venue:     c.Query("venue"),
+		SportType: c.Query("sport"),
+	})
+	if err != nil {
+		logger.ErrorContext(c, "GetSchedule: Failed to get schedule: ", err)
+		apierror.Write(c, err)
+		return
+	}
+	logger.InfoContext(c, "GetSchedule: Schedule retrieved successfully: ", logrus.Fields{
+		"date":   resp.Date,
+		"venues": len(resp.Venues),
+	})
+	c.JSON(200, resp)
+}
+
+// @Router /venues [post]
+// @Summary CREATE VENUE
+// @Description This method creates a venue
+// @Security BearerAuth
+// @Tags VENUE
+// @Accept json
+// @Produce json
+// @Param venue body models.CreateVenueRequest true "Venue"
+// @Success 200 {object} models.Venue
+// @Failure 400 {object} models.Message
+// @Failure 409 {object} models.Message
+// @Failure 500 {object} models.Message
+func (h *HandlerST) CreateVenue(c *gin.Context) {
+
+	req := pb.CreateVenueRequest{}
+	if err := c.BindJSON(&req); err != nil {
+		logger.ErrorContext(c, "CreateVenue: Failed to bind JSON: ", err)
+		apierror.BadRequest(c, err)
+		return
+	}
+	resp, err := h.Service.CreateVenue(c.Request.Context(), &req)
+	if err != nil {
+		logger.ErrorContext(c, "CreateVenue: Failed to create venue: ", err)
+		apierror.Write(c, err)
+		return
+	}
+	logger.InfoContext(c, "CreateVenue: Venue created successfully: ", logrus.Fields{
+		"id":   resp.Id,
+		"name": resp.Name,
+	})
+	c.JSON(200, resp)
+}
+
+// @Router /venues/{id} [get]
+// @Summary GET VENUE
+// @Description This method gets a venue by ID
+// @Security BearerAuth
+// @Tags VENUE
+// @Produce json
+// @Param id path string true "ID"
+// @Success 200 {object} models.Venue
+// @Failure 400 {object} models.Message
+// @Failure 404 {object} models.Message
+// @Failure 500 {object} models.Message
+func (h *HandlerST) GetVenue(c *gin.Context) {
+
+	req := pb.GetVenueRequest{Id: c.Param("id")}
+	resp, err := h.Service.GetVenue(c.Request.Context(), &req)
+	if err != nil {
+		logger.ErrorContext(c, "GetVenue: Failed to get venue with ID ", logrus.Fields{
+			"id": req.Id,
+		})
+		apierror.Write(c, err)
+		return
+	}
+	c.JSON(200, resp)
+}
+
+// @Router /venues [get]
+// @Summary GET VENUES
+// @Description This method gets a list of venues
+// @Security BearerAuth
+// @Tags VENUE
+// @Produce json
+// @Param page_size query int false "Page size (default 50, max 1000)"
+// @Param page_token query string false "Token of the next page"
+// @Param order_by query string false "name, city, capacity or created_at, optionally followed by desc"
+// @Param city query string false "City"
+// @Success 200 {object} models.ListVenuesResponse
+// @Failure 400 {object} models.Message
+// @Failure 500 {object} models.Message
+func (h *HandlerST) ListVenues(c *gin.Context) {
+
+	pageSize, pageToken, orderBy, err := pageQuery(c)
+	if err != nil {
+		apierror.BadRequest(c, err)
+		return
+	}
+	resp, err := h.Service.ListVenues(c.Request.Context(), &pb.ListVenuesRequest{
+		PageSize:  pageSize,
+		PageToken: pageToken,
+		OrderBy:   orderBy,
+		City:      c.Query("city"),
+	})
+	if err != nil {
+		logger.ErrorContext(c, "ListVenues: Failed to list venues: ", err)
+		apierror.Write(c, err)
+		return
+	}
+	c.JSON(200, resp)
+}
+
+// @Router /venues/{id} [put]
+// @Summary UPDATE VENUE
+// @Description This method updates a venue; its events take the new name as their location
+// @Security BearerAuth
+// @Tags VENUE
+// @Accept json
+// @Produce json
+// @Param id path string true "ID"
+// @Param venue body models.UpdateVenueRequest true "Venue"
+// @Success 200 {object} models.Venue
+// @Failure 400 {object} models.Message
+// @Failure 404 {object} models.Message
+// @Failure 409 {object} models.Message
+// @Failure 500 {object} models.Message
+func (h *HandlerST) UpdateVenue(c *gin.Context) {
+
+	req := pb.UpdateVenueRequest{}
+	if err := c.BindJSON(&req); err != nil {
+		logger.ErrorContext(c, "UpdateVenue: Failed to bind JSON: ", err)
+		apierror.BadRequest(c, err)
+		return
+	}
+	req.Id = c.Param("id")
+	resp, err := h.Service.UpdateVenue(c.Request.Context(), &req)
+	if err != nil {
+		logger.ErrorContext(c, "UpdateVenue: Failed to update venue with ID ", logrus.Fields{
+			"id": req.Id,
+		})
+		apierror.Write(c, err)
+		return
+	}
+	logger.InfoContext(c, "UpdateVenue: Venue updated successfully: ", logrus.Fields{
+		"id":   resp.Id,
+		"name": resp.Name,
+	})
+	c.JSON(200, resp)
+}
+
+// @Router /venues/{id} [delete]
+// @Summary DELETE VENUE
+// @Description This method deletes a venue that no unfinished event is booked into
+// @Security BearerAuth
+// @Tags VENUE
+// @Produce json
+// @Param id path string true "ID"
+// @Success 200 {object} models.DeleteVenueResponse
+// @Failure 400 {object} models.Message
+// @Failure 404 {object} models.Message
+// @Failure 409 {object} models.Message
+// @Failure 500 {object} models.Message
+func (h *HandlerST) DeleteVenue(c *gin.Context) {
+
+	req := pb.DeleteVenueRequest{Id: c.Param("id")}
+	resp, err := h.Service.DeleteVenue(c.Request.Context(), &req)
 	if err != nil {
-		logger.Error("DeleteEvent: Failed to delete event with ID ", logrus.Fields{
+		logger.ErrorContext(c, "DeleteVenue: Failed to delete venue with ID ", logrus.Fields{
 			"id": req.Id,
 		})
-		c.JSON(500, models.Message{Err: err.Error()})
+		apierror.Write(c, err)
 		return
 	}
-	logger.Info("DeleteEvent: Event deleted successfully: ", resp.Status)
+	logger.InfoContext(c, "DeleteVenue: Venue deleted successfully: ", resp.Status)
 	c.JSON(200, resp)
 }
@@ -0,0 +1,408 @@
+package handler
+
+import (
+	"api-gateway/internal/export"
+	"api-gateway/internal/http/apierror"
+	"api-gateway/logger"
+	"api-gateway/models"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	pbAthlete "github.com/Bekzodbekk/paris2024_livestream_protos/genproto/athletepb"
+	pbCountry "github.com/Bekzodbekk/paris2024_livestream_protos/genproto/countrypb"
+	pbEvent "github.com/Bekzodbekk/paris2024_livestream_protos/genproto/eventpb"
+	pbMedal "github.com/Bekzodbekk/paris2024_livestream_protos/genproto/medalspb"
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+)
+
+// exportFlushRows is how many rows are buffered before they are pushed to the
+// client.
+const exportFlushRows = 500
+
+// exportTable streams the rows returned by next to the client in the
+// requested format. next returns io.EOF after the last row. Errors before the
+// first row are answered with a JSON error; once streaming has started the
+// response can only be cut short.
+func exportTable(c *gin.Context, name string, format export.Format, columns []string, next func() ([]string, error)) {
+
+	row, err := next()
+	if err != nil && err != io.EOF {
+		logger.ErrorContext(c, "Export: Failed to read "+name+": ", err)
+		apierror.Write(c, err)
+		return
+	}
+
+	filename := fmt.Sprintf("%s-%s.%s", name, time.Now().UTC().Format("20060102"), format)
+	c.Header("Content-Type", format.ContentType())
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+	c.Header("X-Accel-Buffering", "no")
+	c.Status(http.StatusOK)
+
+	w, err := export.NewWriter(c.Writer, format, name, columns)
+	if err != nil {
+		logger.ErrorContext(c, "Export: Failed to start "+name+" export: ", err)
+		return
+	}
+
+	count := 0
+	for err == nil {
+		if err = w.WriteRow(row); err != nil {
+			break
+		}
+		count++
+		if count%exportFlushRows == 0 {
+			if err = w.Flush(); err != nil {
+				break
+			}
+			c.Writer.Flush()
+		}
+		row, err = next()
+	}
+	if err != io.EOF {
+		logger.ErrorContext(c, "Export: "+name+" export interrupted: ", logrus.Fields{
+			"error": err,
+			"rows":  count,
+		})
+		c.Abort()
+		return
+	}
+
+	if err := w.Close(); err != nil {
+		logger.ErrorContext(c, "Export: Failed to finish "+name+" export: ", err)
+		return
+	}
+	c.Writer.Flush()
+
+	logger.InfoContext(c, "Export: "+name+" exported successfully: ", logrus.Fields{
+		"format": format,
+		"rows":   count,
+	})
+}
+
+// exportJoins reads the join query parameter and rejects names the export
+// cannot resolve.
+func exportJoins(c *gin.Context, allowed ...string) (map[string]bool, error) {
+	joins := make(map[string]bool)
+	for _, join := range queryList(c, "join") {
+		ok := false
+		for _, a := range allowed {
+			if join == a {
+				ok = true
+			}
+		}
+		if !ok {
+			return nil, fmt.Errorf("cannot join %q: supported joins are %v", join, allowed)
+		}
+		joins[join] = true
+	}
+	return joins, nil
+}
+
+// nameCache resolves ids to display names, asking the owning service once per id.
+// An empty id, such as the athlete of a team medal, has an empty name.
+type nameCache struct {
+	lookup func(id string) (string, error)
+	names  map[string]string
+}
+
+func newNameCache(lookup func(id string) (string, error)) *nameCache {
+	return &nameCache{lookup: lookup, names: make(map[string]string)}
+}
+
+func (n *nameCache) name(id string) string {
+	if id == "" {
+		return ""
+	}
+	if name, ok := n.names[id]; ok {
+		return name
+	}
+	name, err := n.lookup(id)
+	if err != nil {
+		logger.Warn("Export: Failed to resolve name: ", logrus.Fields{
+			"id":    id,
+			"error": err,
+		})
+	}
+	n.names[id] = name
+	return name
+}
+
+// countryNames preloads every country name; there are few enough of them
+// that one listing beats a lookup per id.
+func (h *HandlerST) countryNames(ctx context.Context) (*nameCache, error) {
+	countries, err := h.allCountries(ctx)
+	if err != nil {
+		return nil, err
+	}
+	cache := newNameCache(func(id string) (string, error) {
+		country, err := h.Service.GetCountry(ctx, &pbCountry.GetCountryRequest{Id: id})
+		if err != nil {
+			return "", err
+		}
+		return country.Name, nil
+	})
+	for _, country := range countries {
+		cache.names[country.Id] = country.Name
+	}
+	return cache, nil
+}
+
+func medalTypeName(medalType int32) string {
+	switch medalType {
+	case int32(models.GOLD):
+		return "gold"
+	case int32(models.SILVER):
+		return "silver"
+	case int32(models.BRONZE):
+		return "bronze"
+	}
+	return fmt.Sprint(medalType)
+}
+
+// @Router /export/countries [get]
+// @Summary EXPORT COUNTRIES
+// @Description This method streams every country as CSV, NDJSON or XLSX
+// @Security BearerAuth
+// @Tags EXPORT
+// @Produce text/csv
+// @Produce application/x-ndjson
+// @Produce application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Param format query string false "csv (default), ndjson or xlsx"
+// @Success 200 {file} file
+// @Failure 400 {object} models.Message
+// @Failure 500 {object} models.Message
+func (h *HandlerST) ExportCountries(c *gin.Context) {
+
+	format, err := export.ParseFormat(c.Query("format"))
+	if err != nil {
+		apierror.BadRequest(c, err)
+		return
+	}
+
+	stream, err := h.Service.ExportCountries(c.Request.Context(), &pbCountry.ExportCountriesRequest{})
+	if err != nil {
+		logger.ErrorContext(c, "ExportCountries: Failed to export countries: ", err)
+		apierror.Write(c, err)
+		return
+	}
+
+	columns := []string{"id", "name", "flag", "region", "created_at", "updated_at"}
+	exportTable(c, "countries", format, columns, func() ([]string, error) {
+		country, err := stream.Recv()
+		if err != nil {
+			return nil, err
+		}
+		return []string{country.Id, country.Name, country.Flag, country.Region, country.CreatedAt, country.UpdatedAt}, nil
+	})
+}
+
+// @Router /export/athletes [get]
+// @Summary EXPORT ATHLETES
+// @Description This method streams every athlete as CSV, NDJSON or XLSX; join=country adds the country name
+// @Security BearerAuth
+// @Tags EXPORT
+// @Produce text/csv
+// @Produce application/x-ndjson
+// @Produce application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Param format query string false "csv (default), ndjson or xlsx"
+// @Param join query []string false "country" collectionFormat(csv)
+// @Success 200 {file} file
+// @Failure 400 {object} models.Message
+// @Failure 500 {object} models.Message
+func (h *HandlerST) ExportAthletes(c *gin.Context) {
+
+	format, err := export.ParseFormat(c.Query("format"))
+	if err != nil {
+		apierror.BadRequest(c, err)
+		return
+	}
+	joins, err := exportJoins(c, "country")
+	if err != nil {
+		apierror.BadRequest(c, err)
+		return
+	}
+
+	columns := []string{"id", "name", "country_id"}
+	var countries *nameCache
+	if joins["country"] {
+		if countries, err = h.countryNames(c.Request.Context()); err != nil {
+			logger.ErrorContext(c, "ExportAthletes: Failed to list countries: ", err)
+			apierror.Write(c, err)
+			return
+		}
+		columns = append(columns, "country_name")
+	}
+	columns = append(columns, "sport_type", "created_at", "updated_at")
+
+	stream, err := h.Service.ExportAthletes(c.Request.Context(), &pbAthlete.ExportAthletesRequest{})
+	if err != nil {
+		logger.ErrorContext(c, "ExportAthletes: Failed to export athletes: ", err)
+		apierror.Write(c, err)
+		return
+	}
+
+	exportTable(c, "athletes", format, columns, func() ([]string, error) {
+		athlete, err := stream.Recv()
+		if err != nil {
+			return nil, err
+		}
+		row := []string{athlete.Id, athlete.Name, athlete.CountryId}
+		if countries != nil {
+			row = append(row, countries.name(athlete.CountryId))
+		}
+		return append(row, athlete.SportType, athlete.CreatedAt, athlete.UpdatedAt), nil
+	})
+}
+
+// @Router /export/events [get]
+// @Summary EXPORT EVENTS
+// @Description This method streams every event as CSV, NDJSON or XLSX
+// @Security BearerAuth
+// @Tags EXPORT
+// @Produce text/csv
+// @Produce application/x-ndjson
+// @Produce application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Param format query string false "csv (default), ndjson or xlsx"
+// @Success 200 {file} file
+// @Failure 400 {object} models.Message
+// @Failure 500 {object} models.Message
+func (h *HandlerST) ExportEvents(c *gin.Context) {
+
+	format, err := export.ParseFormat(c.Query("format"))
+	if err != nil {
+		apierror.BadRequest(c, err)
+		return
+	}
+
+	stream, err := h.Service.ExportEvents(c.Request.Context(), &pbEvent.ExportEventsRequest{})
+	if err != nil {
+		logger.ErrorContext(c, "ExportEvents: Failed to export events: ", err)
+		apierror.Write(c, err)
+		return
+	}
+
+	columns := []string{"id", "name", "sport_type", "location", "date", "start_time", "end_time", "venue_id", "status", "created_at", "updated_at"}
+	exportTable(c, "events", format, columns, func() ([]string, error) {
+		event, err := stream.Recv()
+		if err != nil {
+			return nil, err
+		}
+		return []string{event.Id, event.Name, event.SportType, event.Location, event.Date, event.StartTime, event.EndTime, event.VenueId, event.Status, event.CreatedAt, event.UpdatedAt}, nil
+	})
+}
+
+// @Router /export/medals [get]
+// @Summary EXPORT MEDALS
+// @Description This method streams every medal as CSV, NDJSON or XLSX; join=country,event,athlete,team adds the matching names
+// @Security BearerAuth
+// @Tags EXPORT
+// @Produce text/csv
+// @Produce application/x-ndjson
+// @Produce application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Param format query string false "csv (default), ndjson or xlsx"
+// @Param join query []string false "country, event, athlete and/or team" collectionFormat(csv)
+// @Success 200 {file} file
+// @Failure 400 {object} models.Message
+// @Failure 500 {object} models.Message
+func (h *HandlerST) ExportMedals(c *gin.Context) {
+
+	format, err := export.ParseFormat(c.Query("format"))
+	if err != nil {
+		apierror.BadRequest(c, err)
+		return
+	}
+	joins, err := exportJoins(c, "country", "event", "athlete", "team")
+	if err != nil {
+		apierror.BadRequest(c, err)
+		return
+	}
+
+	var countries, events, athletes, teams *nameCache
+	if joins["country"] {
+		if countries, err = h.countryNames(c.Request.Context()); err != nil {
+			logger.ErrorContext(c, "ExportMedals: Failed to list countries: ", err)
+			apierror.Write(c, err)
+			return
+		}
+	}
+	if joins["event"] {
+		events = newNameCache(func(id string) (string, error) {
+			event, err := h.Service.GetEvent(c.Request.Context(), &pbEvent.GetEventRequest{Id: id})
+			if err != nil {
+				return "", err
+			}
+			return event.Name, nil
+		})
+	}
+	if joins["athlete"] {
+		athletes = newNameCache(func(id string) (string, error) {
+			athlete, err := h.Service.GetAthlete(c.Request.Context(), &pbAthlete.GetAthleteRequest{Id: id})
+			if err != nil {
+				return "", err
+			}
+			return athlete.Name, nil
+		})
+	}
+	if joins["team"] {
+		teams = newNameCache(func(id string) (string, error) {
+			team, err := h.Service.GetTeam(c.Request.Context(), &pbAthlete.GetTeamRequest{Id: id})
+			if err != nil {
+				return "", err
+			}
+			return team.Name, nil
+		})
+	}
+
+	columns := []string{"id", "type", "country_id"}
+	if countries != nil {
+		columns = append(columns, "country_name")
+	}
+	columns = append(columns, "event_id")
+	if events != nil {
+		columns = append(columns, "event_name")
+	}
+	columns = append(columns, "athlete_id")
+	if athletes != nil {
+		columns = append(columns, "athlete_name")
+	}
+	columns = append(columns, "team_id")
+	if teams != nil {
+		columns = append(columns, "team_name")
+	}
+	columns = append(columns, "created_at", "updated_at")
+
+	stream, err := h.Service.ExportMedals(c.Request.Context(), &pbMedal.ExportMedalsRequest{})
+	if err != nil {
+		logger.ErrorContext(c, "ExportMedals: Failed to export medals: ", err)
+		apierror.Write(c, err)
+		return
+	}
+
+	exportTable(c, "medals", format, columns, func() ([]string, error) {
+		medal, err := stream.Recv()
+		if err != nil {
+			return nil, err
+		}
+		row := []string{medal.Id, medalTypeName(int32(medal.Type)), medal.CountryId}
+		if countries != nil {
+			row = append(row, countries.name(medal.CountryId))
+		}
+		row = append(row, medal.EventId)
+		if events != nil {
+			row = append(row, events.name(medal.EventId))
+		}
+		row = append(row, medal.AthleteId)
+		if athletes != nil {
+			row = append(row, athletes.name(medal.AthleteId))
+		}
+		row = append(row, medal.TeamId)
+		if teams != nil {
+			row = append(row, teams.name(medal.TeamId))
+		}
+		return append(row, medal.CreatedAt, medal.UpdatedAt), nil
+	})
+}
@@ -1,16 +1,22 @@
 package handler 
 
 import (
+	"api-gateway/internal/hub"
+	"api-gateway/internal/pkg/downstream"
 	service "api-gateway/internal/service"
 )
 
 type HandlerST struct {
 	Service *service.ServiceRepositoryClient
+	Hub     *hub.Hub
+	Health  *downstream.Monitor
 }
 
-func NewHandler(service *service.ServiceRepositoryClient) *HandlerST {
+func NewHandler(service *service.ServiceRepositoryClient, hub *hub.Hub, health *downstream.Monitor) *HandlerST {
 	return &HandlerST{
 		Service: service,
+		Hub:     hub,
+		Health:  health,
 	}
 }
 
@@ -0,0 +1,44 @@
+package handler
+
+import (
+	"api-gateway/internal/pkg/downstream"
+	"api-gateway/models"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// @Router /healthz [get]
+// @Summary LIVENESS PROBE
+// @Description Reports that the gateway is up, along with the health of every downstream service. A failing service does not fail this probe.
+// @Tags HEALTH
+// @Produce json
+// @Success 200 {object} models.Health
+func (h *HandlerST) Healthz(c *gin.Context) {
+	c.JSON(http.StatusOK, models.Health{
+		Status:   "ok",
+		Services: h.Health.Statuses(),
+	})
+}
+
+// @Router /readyz [get]
+// @Summary READINESS PROBE
+// @Description Reports whether every downstream service is serving.
+// @Tags HEALTH
+// @Produce json
+// @Success 200 {object} models.Health
+// @Failure 503 {object} models.Health
+func (h *HandlerST) Readyz(c *gin.Context) {
+	statuses := h.Health.Statuses()
+	if !downstream.Ready(statuses) {
+		c.JSON(http.StatusServiceUnavailable, models.Health{
+			Status:   "unavailable",
+			Services: statuses,
+		})
+		return
+	}
+	c.JSON(http.StatusOK, models.Health{
+		Status:   "ok",
+		Services: statuses,
+	})
+}
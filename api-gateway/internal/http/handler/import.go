@@ -0,0 +1,445 @@
+package handler
+
+import (
+	"api-gateway/internal/http/apierror"
+	"api-gateway/internal/importer"
+	"api-gateway/logger"
+	"api-gateway/models"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+
+	pbAthlete "github.com/Bekzodbekk/paris2024_livestream_protos/genproto/athletepb"
+	pbCountry "github.com/Bekzodbekk/paris2024_livestream_protos/genproto/countrypb"
+	pbEvent "github.com/Bekzodbekk/paris2024_livestream_protos/genproto/eventpb"
+	pbMedal "github.com/Bekzodbekk/paris2024_livestream_protos/genproto/medalspb"
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// maxImportRows is the largest batch a single upload may carry; every
+	// batch is written in one transaction.
+	maxImportRows = 10000
+	// maxImportBytes caps the size of an uploaded file.
+	maxImportBytes = 32 << 20
+)
+
+// importBatch is an uploaded file after the gateway-side checks: the rows to
+// send to the owning service, the file line of each of them, and the errors
+// found so far.
+type importBatch struct {
+	lines  []int
+	report models.ImportResponse
+}
+
+func (b *importBatch) fail(line int, format string, args ...interface{}) {
+	b.report.Errors = append(b.report.Errors, models.ImportRowError{
+		Row:     line,
+		Message: fmt.Sprintf(format, args...),
+	})
+}
+
+// dryRun reports whether the service should only validate the rows. Once any
+// row failed at the gateway the batch is never committed.
+func (b *importBatch) dryRun() bool {
+	return b.report.DryRun || len(b.report.Errors) > 0
+}
+
+// finish merges the outcome of the service call into the report and writes it.
+func (b *importBatch) finish(c *gin.Context, name string, accepted int32, committed bool, rowErrors []rowError) {
+	for _, rowErr := range rowErrors {
+		line := 0
+		if int(rowErr.index) < len(b.lines) {
+			line = b.lines[rowErr.index]
+		}
+		b.fail(line, "%s", rowErr.message)
+	}
+	sort.SliceStable(b.report.Errors, func(i, j int) bool {
+		return b.report.Errors[i].Row < b.report.Errors[j].Row
+	})
+	b.report.Accepted = int(accepted)
+	b.report.Committed = committed
+
+	logger.InfoContext(c, "Import"+name+": Import finished: ", logrus.Fields{
+		"rows":      b.report.Rows,
+		"accepted":  b.report.Accepted,
+		"errors":    len(b.report.Errors),
+		"committed": b.report.Committed,
+		"dry_run":   b.report.DryRun,
+	})
+
+	if len(b.report.Errors) > 0 {
+		c.JSON(http.StatusUnprocessableEntity, b.report)
+		return
+	}
+	c.JSON(http.StatusOK, b.report)
+}
+
+type rowError struct {
+	index   int32
+	message string
+}
+
+// readImport parses the multipart "file" field of the request. It writes the
+// error response itself and returns false when the upload is unusable.
+func readImport(c *gin.Context, name string) ([]importer.Record, *importBatch, bool) {
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxImportBytes)
+
+	header, err := c.FormFile("file")
+	if err != nil {
+		logger.ErrorContext(c, "Import"+name+": Failed to read upload: ", err)
+		apierror.Abort(c, codes.InvalidArgument, "multipart field \"file\" is required")
+		return nil, nil, false
+	}
+	format, err := importer.DetectFormat(c.PostForm("format"), header.Filename)
+	if err != nil {
+		apierror.BadRequest(c, err)
+		return nil, nil, false
+	}
+
+	dryRun := false
+	if value := c.DefaultQuery("dry_run", c.PostForm("dry_run")); value != "" {
+		dryRun, err = strconv.ParseBool(value)
+		if err != nil {
+			apierror.Abort(c, codes.InvalidArgument, "invalid dry_run: %q", value)
+			return nil, nil, false
+		}
+	}
+
+	file, err := header.Open()
+	if err != nil {
+		logger.ErrorContext(c, "Import"+name+": Failed to open upload: ", err)
+		apierror.BadRequest(c, err)
+		return nil, nil, false
+	}
+	defer file.Close()
+
+	records, err := importer.Read(file, format, maxImportRows)
+	if errors.Is(err, importer.ErrTooManyRows) {
+		apierror.AbortWithStatus(c, http.StatusRequestEntityTooLarge, status.Newf(codes.InvalidArgument, "an import may hold at most %d rows", maxImportRows))
+		return nil, nil, false
+	}
+	if err != nil {
+		logger.ErrorContext(c, "Import"+name+": Failed to parse upload: ", err)
+		apierror.BadRequest(c, err)
+		return nil, nil, false
+	}
+
+	batch := &importBatch{
+		report: models.ImportResponse{
+			Rows:   len(records),
+			DryRun: dryRun,
+			Errors: []models.ImportRowError{},
+		},
+	}
+	for _, record := range records {
+		if record.Err != nil {
+			batch.fail(record.Line, "%v", record.Err)
+		}
+	}
+	return records, batch, true
+}
+
+// existsCache remembers reference lookups so a file naming the same country a
+// thousand times asks country-service once.
+type existsCache struct {
+	lookup func(id string) error
+	seen   map[string]bool
+}
+
+func newExistsCache(lookup func(id string) error) *existsCache {
+	return &existsCache{lookup: lookup, seen: make(map[string]bool)}
+}
+
+// check reports whether the record exists. Only NotFound means it does not;
+// any other error, such as the owning service being down, is returned so the
+// import fails as a whole instead of blaming every row.
+func (e *existsCache) check(id string) (bool, error) {
+	if exists, ok := e.seen[id]; ok {
+		return exists, nil
+	}
+	err := e.lookup(id)
+	if err != nil && status.Code(err) != codes.NotFound {
+		return false, err
+	}
+	e.seen[id] = err == nil
+	return err == nil, nil
+}
+
+// @Router /import/countries [post]
+// @Summary IMPORT COUNTRIES
+// @Description This method imports countries from a CSV (name,flag,region header) or NDJSON upload. The whole file is written in one transaction; if any row fails nothing is written and every failing row is reported
+// @Security BearerAuth
+// @Tags IMPORT
+// @Accept multipart/form-data
+// @Produce json
+// @Param file formData file true "CSV or NDJSON file"
+// @Param format formData string false "csv or ndjson; detected from the file extension when omitted"
+// @Param dry_run query bool false "Validate without writing"
+// @Success 200 {object} models.ImportResponse
+// @Failure 400 {object} models.Message
+// @Failure 422 {object} models.ImportResponse
+// @Failure 500 {object} models.Message
+func (h *HandlerST) ImportCountries(c *gin.Context) {
+
+	records, batch, ok := readImport(c, "Countries")
+	if !ok {
+		return
+	}
+
+	req := pbCountry.ImportCountriesRequest{}
+	for _, record := range records {
+		if record.Err != nil {
+			continue
+		}
+		req.Countries = append(req.Countries, &pbCountry.CreateCountryRequest{
+			Name:   record.Fields["name"],
+			Flag:   record.Fields["flag"],
+			Region: record.Fields["region"],
+		})
+		batch.lines = append(batch.lines, record.Line)
+	}
+	req.DryRun = batch.dryRun()
+
+	resp, err := h.Service.ImportCountries(c.Request.Context(), &req)
+	if err != nil {
+		logger.ErrorContext(c, "ImportCountries: Failed to import countries: ", err)
+		apierror.Write(c, err)
+		return
+	}
+
+	var rowErrors []rowError
+	for _, e := range resp.Errors {
+		rowErrors = append(rowErrors, rowError{index: e.Index, message: e.Message})
+	}
+	batch.finish(c, "Countries", resp.Accepted, resp.Committed, rowErrors)
+}
+
+// @Router /import/athletes [post]
+// @Summary IMPORT ATHLETES
+// @Description This method imports athletes from a CSV (name,country_id,sport_type header) or NDJSON upload. Every country_id must exist. The whole file is written in one transaction; if any row fails nothing is written and every failing row is reported
+// @Security BearerAuth
+// @Tags IMPORT
+// @Accept multipart/form-data
+// @Produce json
+// @Param file formData file true "CSV or NDJSON file"
+// @Param format formData string false "csv or ndjson; detected from the file extension when omitted"
+// @Param dry_run query bool false "Validate without writing"
+// @Success 200 {object} models.ImportResponse
+// @Failure 400 {object} models.Message
+// @Failure 422 {object} models.ImportResponse
+// @Failure 500 {object} models.Message
+func (h *HandlerST) ImportAthletes(c *gin.Context) {
+
+	records, batch, ok := readImport(c, "Athletes")
+	if !ok {
+		return
+	}
+
+	countries := newExistsCache(func(id string) error {
+		_, err := h.Service.GetCountry(c.Request.Context(), &pbCountry.GetCountryRequest{Id: id})
+		return err
+	})
+
+	req := pbAthlete.ImportAthletesRequest{}
+	for _, record := range records {
+		if record.Err != nil {
+			continue
+		}
+		athlete := &pbAthlete.CreateAthleteRequest{
+			Name:      record.Fields["name"],
+			CountryId: record.Fields["country_id"],
+			SportType: record.Fields["sport_type"],
+		}
+		if athlete.CountryId != "" {
+			exists, err := countries.check(athlete.CountryId)
+			if err != nil {
+				logger.ErrorContext(c, "ImportAthletes: Failed to look up country: ", err)
+				apierror.Write(c, err)
+				return
+			}
+			if !exists {
+				batch.fail(record.Line, "country %s does not exist", athlete.CountryId)
+				continue
+			}
+		}
+		req.Athletes = append(req.Athletes, athlete)
+		batch.lines = append(batch.lines, record.Line)
+	}
+	req.DryRun = batch.dryRun()
+
+	resp, err := h.Service.ImportAthletes(c.Request.Context(), &req)
+	if err != nil {
+		logger.ErrorContext(c, "ImportAthletes: Failed to import athletes: ", err)
+		apierror.Write(c, err)
+		return
+	}
+
+	var rowErrors []rowError
+	for _, e := range resp.Errors {
+		rowErrors = append(rowErrors, rowError{index: e.Index, message: e.Message})
+	}
+	batch.finish(c, "Athletes", resp.Accepted, resp.Committed, rowErrors)
+}
+
+// @Router /import/events [post]
+// @Summary IMPORT EVENTS
+// @Description This method imports events from a CSV (name,sport_type,location,date,start_time,end_time header, optionally venue_id) or NDJSON upload. Events overlapping another session at their venue are rejected. The whole file is written in one transaction; if any row fails nothing is written and every failing row is reported
+// @Security BearerAuth
+// @Tags IMPORT
+// @Accept multipart/form-data
+// @Produce json
+// @Param file formData file true "CSV or NDJSON file"
+// @Param format formData string false "csv or ndjson; detected from the file extension when omitted"
+// @Param dry_run query bool false "Validate without writing"
+// @Success 200 {object} models.ImportResponse
+// @Failure 400 {object} models.Message
+// @Failure 422 {object} models.ImportResponse
+// @Failure 500 {object} models.Message
+func (h *HandlerST) ImportEvents(c *gin.Context) {
+
+	records, batch, ok := readImport(c, "Events")
+	if !ok {
+		return
+	}
+
+	req := pbEvent.ImportEventsRequest{}
+	for _, record := range records {
+		if record.Err != nil {
+			continue
+		}
+		req.Events = append(req.Events, &pbEvent.CreateEventRequest{
+			Name:      record.Fields["name"],
+			SportType: record.Fields["sport_type"],
+			Location:  record.Fields["location"],
+			Date:      record.Fields["date"],
+			StartTime: record.Fields["start_time"],
+			EndTime:   record.Fields["end_time"],
+			VenueId:   record.Fields["venue_id"],
+		})
+		batch.lines = append(batch.lines, record.Line)
+	}
+	req.DryRun = batch.dryRun()
+
+	resp, err := h.Service.ImportEvents(c.Request.Context(), &req)
+	if err != nil {
+		logger.ErrorContext(c, "ImportEvents: Failed to import events: ", err)
+		apierror.Write(c, err)
+		return
+	}
+
+	var rowErrors []rowError
+	for _, e := range resp.Errors {
+		rowErrors = append(rowErrors, rowError{index: e.Index, message: e.Message})
+	}
+	batch.finish(c, "Events", resp.Accepted, resp.Committed, rowErrors)
+}
+
+// @Router /import/medals [post]
+// @Summary IMPORT MEDALS
+// @Description This method imports medals from a CSV (country_id,type,event_id,athlete_id header, plus an optional team_id column) or NDJSON upload. type is gold, silver, bronze or 0-2, each row names an athlete or a team, and the referenced country, event, athlete and team must exist. The whole file is written in one transaction; if any row fails nothing is written and every failing row is reported
+// @Security BearerAuth
+// @Tags IMPORT
+// @Accept multipart/form-data
+// @Produce json
+// @Param file formData file true "CSV or NDJSON file"
+// @Param format formData string false "csv or ndjson; detected from the file extension when omitted"
+// @Param dry_run query bool false "Validate without writing"
+// @Success 200 {object} models.ImportResponse
+// @Failure 400 {object} models.Message
+// @Failure 422 {object} models.ImportResponse
+// @Failure 500 {object} models.Message
+func (h *HandlerST) ImportMedals(c *gin.Context) {
+
+	records, batch, ok := readImport(c, "Medals")
+	if !ok {
+		return
+	}
+
+	countries := newExistsCache(func(id string) error {
+		_, err := h.Service.GetCountry(c.Request.Context(), &pbCountry.GetCountryRequest{Id: id})
+		return err
+	})
+	events := newExistsCache(func(id string) error {
+		_, err := h.Service.GetEvent(c.Request.Context(), &pbEvent.GetEventRequest{Id: id})
+		return err
+	})
+	athletes := newExistsCache(func(id string) error {
+		_, err := h.Service.GetAthlete(c.Request.Context(), &pbAthlete.GetAthleteRequest{Id: id})
+		return err
+	})
+	teams := newExistsCache(func(id string) error {
+		_, err := h.Service.GetTeam(c.Request.Context(), &pbAthlete.GetTeamRequest{Id: id})
+		return err
+	})
+
+	req := pbMedal.ImportMedalsRequest{}
+	for _, record := range records {
+		if record.Err != nil {
+			continue
+		}
+		medalType, ok := parseMedalType(record.Fields["type"])
+		if !ok {
+			batch.fail(record.Line, "invalid type: %q", record.Fields["type"])
+			continue
+		}
+		medal := &pbMedal.CreateMedalRequest{
+			CountryId: record.Fields["country_id"],
+			Type:      medalType,
+			EventId:   record.Fields["event_id"],
+			AthleteId: record.Fields["athlete_id"],
+			TeamId:    record.Fields["team_id"],
+		}
+
+		valid := true
+		for _, ref := range []struct {
+			name  string
+			id    string
+			cache *existsCache
+		}{
+			{"country", medal.CountryId, countries},
+			{"event", medal.EventId, events},
+			{"athlete", medal.AthleteId, athletes},
+			{"team", medal.TeamId, teams},
+		} {
+			if ref.id == "" {
+				continue
+			}
+			exists, err := ref.cache.check(ref.id)
+			if err != nil {
+				logger.ErrorContext(c, "ImportMedals: Failed to look up "+ref.name+": ", err)
+				apierror.Write(c, err)
+				return
+			}
+			if !exists {
+				batch.fail(record.Line, "%s %s does not exist", ref.name, ref.id)
+				valid = false
+			}
+		}
+		if !valid {
+			continue
+		}
+
+		req.Medals = append(req.Medals, medal)
+		batch.lines = append(batch.lines, record.Line)
+	}
+	req.DryRun = batch.dryRun()
+
+	resp, err := h.Service.ImportMedals(c.Request.Context(), &req)
+	if err != nil {
+		logger.ErrorContext(c, "ImportMedals: Failed to import medals: ", err)
+		apierror.Write(c, err)
+		return
+	}
+
+	var rowErrors []rowError
+	for _, e := range resp.Errors {
+		rowErrors = append(rowErrors, rowError{index: e.Index, message: e.Message})
+	}
+	batch.finish(c, "Medals", resp.Accepted, resp.Committed, rowErrors)
+}
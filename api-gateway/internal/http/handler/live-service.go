@@ -1,11 +1,30 @@
 package handler
 
 import (
+	"api-gateway/internal/http/apierror"
+	"api-gateway/internal/pkg/metrics"
 	"api-gateway/logger"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
 
 	pb "github.com/Bekzodbekk/paris2024_livestream_protos/genproto/livepb"
 	"github.com/gin-gonic/gin"
 	"github.com/gorilla/websocket"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+)
+
+const (
+	// Time allowed to write a message to the peer.
+	writeWait = 10 * time.Second
+	// Time allowed to read the next pong message from the peer.
+	pongWait = 60 * time.Second
+	// Send pings to peer with this period. Must be less than pongWait.
+	pingPeriod = (pongWait * 9) / 10
+	// Interval of SSE heartbeat comments that keep proxies from closing idle streams.
+	heartbeatPeriod = 15 * time.Second
 )
 
 var upgrader = websocket.Upgrader{
@@ -17,7 +36,7 @@ func (h *HandlerST) CreateLiveStream(ctx *gin.Context) {
 
 	conn, err := upgrader.Upgrade(ctx.Writer, ctx.Request, nil)
 	if err != nil {
-		logger.Error("failed to upgrade connection: ", err)
+		logger.ErrorContext(ctx, "failed to upgrade connection: ", err)
 		return
 	}
 
@@ -28,15 +47,141 @@ func (h *HandlerST) CreateLiveStream(ctx *gin.Context) {
 		var msg pb.LiveStream
 		err := conn.ReadJSON(&msg)
 		if err != nil {
-			logger.Error("failed to read json:", err)
+			logger.ErrorContext(ctx, "failed to read json:", err)
 			break
 		}
 
-		logger.Info("Received message: from IP: ", c.RemoteAddr(), &msg)
-		h.Service.CreateLive(&msg)
+		logger.InfoContext(ctx, "Received message: from IP: ", c.RemoteAddr(), &msg)
+		// Subscribers get the entry back through the hub's live-service feed.
+		if _, err := h.Service.CreateLive(ctx.Request.Context(), &msg); err != nil {
+			logger.ErrorContext(ctx, "Failed to persist message: ", err)
+			continue
+		}
 
 		if err := conn.WriteJSON(&msg); err != nil {
-			logger.Error("Failed to write message: ", err)
+			logger.ErrorContext(ctx, "Failed to write message: ", err)
+			return
+		}
+	}
+}
+
+// @Router /live/{eventId}/subscribe [get]
+// @Summary Subscribe to Live Stream
+// @Description This method streams live updates of an event over WebSocket, or Server-Sent Events when the request is not a WebSocket upgrade
+// @Security BearerAuth
+// @Tags Live Stream
+// @Produce text/event-stream
+// @Param eventId path string true "Event ID"
+// @Success 200 {object} models.LiveStream
+// @Failure 401 {object} models.Message
+func (h *HandlerST) SubscribeLiveStream(ctx *gin.Context) {
+	eventId := ctx.Param("eventId")
+
+	if websocket.IsWebSocketUpgrade(ctx.Request) {
+		h.subscribeWebSocket(ctx, eventId)
+		return
+	}
+	h.subscribeSSE(ctx, eventId)
+}
+
+func (h *HandlerST) subscribeWebSocket(ctx *gin.Context, eventId string) {
+
+	conn, err := upgrader.Upgrade(ctx.Writer, ctx.Request, nil)
+	if err != nil {
+		logger.ErrorContext(ctx, "SubscribeLiveStream: failed to upgrade connection: ", err)
+		return
+	}
+	defer conn.Close()
+
+	sub := h.Hub.Subscribe(eventId)
+	defer h.Hub.Unsubscribe(sub)
+	metrics.LiveSubscribers.WithLabelValues("websocket").Inc()
+	defer metrics.LiveSubscribers.WithLabelValues("websocket").Dec()
+
+	logger.InfoContext(ctx, "SubscribeLiveStream: websocket subscriber joined: ", logrus.Fields{
+		"event_id": eventId,
+		"ip":       ctx.ClientIP(),
+	})
+
+	// Spectators only listen; the read loop exists to process pongs and
+	// notice when the peer goes away.
+	closed := make(chan struct{})
+	go func() {
+		defer close(closed)
+		conn.SetReadLimit(512)
+		conn.SetReadDeadline(time.Now().Add(pongWait))
+		conn.SetPongHandler(func(string) error {
+			return conn.SetReadDeadline(time.Now().Add(pongWait))
+		})
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}()
+
+	ticker := time.NewTicker(pingPeriod)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case msg := <-sub.Messages():
+			conn.SetWriteDeadline(time.Now().Add(writeWait))
+			if err := conn.WriteJSON(msg); err != nil {
+				logger.ErrorContext(ctx, "SubscribeLiveStream: failed to write message: ", err)
+				return
+			}
+		case <-ticker.C:
+			conn.SetWriteDeadline(time.Now().Add(writeWait))
+			if err := conn.WriteMessage(websocket.PingMessage, nil); err != nil {
+				return
+			}
+		case <-sub.Done():
+			conn.WriteControl(websocket.CloseMessage,
+				websocket.FormatCloseMessage(websocket.CloseTryAgainLater, "slow consumer"),
+				time.Now().Add(writeWait))
+			return
+		case <-closed:
+			return
+		}
+	}
+}
+
+func (h *HandlerST) subscribeSSE(ctx *gin.Context, eventId string) {
+
+	sub := h.Hub.Subscribe(eventId)
+	defer h.Hub.Unsubscribe(sub)
+	metrics.LiveSubscribers.WithLabelValues("sse").Inc()
+	defer metrics.LiveSubscribers.WithLabelValues("sse").Dec()
+
+	ctx.Writer.Header().Set("Content-Type", "text/event-stream")
+	ctx.Writer.Header().Set("Cache-Control", "no-cache")
+	ctx.Writer.Header().Set("Connection", "keep-alive")
+	ctx.Writer.Header().Set("X-Accel-Buffering", "no")
+	ctx.Status(http.StatusOK)
+	ctx.Writer.Flush()
+
+	logger.InfoContext(ctx, "SubscribeLiveStream: sse subscriber joined: ", logrus.Fields{
+		"event_id": eventId,
+		"ip":       ctx.ClientIP(),
+	})
+
+	ticker := time.NewTicker(heartbeatPeriod)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case msg := <-sub.Messages():
+			ctx.SSEvent("live", msg)
+			ctx.Writer.Flush()
+		case <-ticker.C:
+			fmt.Fprint(ctx.Writer, ": heartbeat\n\n")
+			ctx.Writer.Flush()
+		case <-sub.Done():
+			ctx.SSEvent("evicted", "slow consumer")
+			ctx.Writer.Flush()
+			return
+		case <-ctx.Request.Context().Done():
 			return
 		}
 	}
@@ -52,15 +197,68 @@ func (h *HandlerST) CreateLiveStream(ctx *gin.Context) {
 // @Param eventId path string true "Event ID"
 // @Success 200 {object} models.LiveStream
 // @Failure 400 {object} models.Message
+// @Failure 404 {object} models.Message
 // @Failure 500 {object} models.Message
 func (h *HandlerST) GetLiveStream(ctx *gin.Context) {
 	eventId := ctx.Param("eventId")
-	resp, err := h.Service.GetLive(&pb.GetStreamRequest{
+	resp, err := h.Service.GetLive(ctx.Request.Context(), &pb.GetStreamRequest{
 		Id: eventId,
 	})
 	if err != nil {
-		ctx.JSON(400, err.Error())
+		logger.ErrorContext(ctx, "GetLiveStream: Failed to get live stream: ", err)
+		apierror.Write(ctx, err)
 		return
 	}
 	ctx.JSON(200, resp)
 }
+
+// @Router /live/{eventId}/timeline [get]
+// @Summary List Live Stream Timeline
+// @Description This method lists every live stream entry of an event ordered by timestamp
+// @Security BearerAuth
+// @Tags Live Stream
+// @Accept json
+// @Produce json
+// @Param eventId path string true "Event ID"
+// @Param page_size query int false "Page size (default 50, max 500)"
+// @Param page_token query string false "Token of the next page"
+// @Param since query string false "Only entries at or after this timestamp"
+// @Param until query string false "Only entries before this timestamp"
+// @Param latest query int false "Return only the latest N entries"
+// @Success 200 {object} models.ListLiveStreamResponse
+// @Failure 400 {object} models.Message
+// @Failure 404 {object} models.Message
+// @Failure 500 {object} models.Message
+func (h *HandlerST) ListLiveStream(ctx *gin.Context) {
+
+	req := pb.ListLiveStreamRequest{
+		EventId:   ctx.Param("eventId"),
+		PageToken: ctx.Query("page_token"),
+		Since:     ctx.Query("since"),
+		Until:     ctx.Query("until"),
+	}
+	for name, field := range map[string]*int32{"page_size": &req.PageSize, "latest": &req.Latest} {
+		value := ctx.Query(name)
+		if value == "" {
+			continue
+		}
+		n, err := strconv.ParseInt(value, 10, 32)
+		if err != nil || n < 0 {
+			apierror.Abort(ctx, codes.InvalidArgument, "invalid %s: %q", name, value)
+			return
+		}
+		*field = int32(n)
+	}
+
+	resp, err := h.Service.ListLive(ctx.Request.Context(), &req)
+	if err != nil {
+		logger.ErrorContext(ctx, "ListLiveStream: Failed to list live stream: ", err)
+		apierror.Write(ctx, err)
+		return
+	}
+	logger.InfoContext(ctx, "ListLiveStream: Live stream timeline retrieved successfully: ", logrus.Fields{
+		"event_id": req.EventId,
+		"count":    len(resp.LiveStreams),
+	})
+	ctx.JSON(200, resp)
+}
@@ -1,21 +1,22 @@
 package handler
 
 import (
-	"context"
+	"strconv"
+	"strings"
 	pb "github.com/Bekzodbekk/paris2024_livestream_protos/genproto/medalspb"
 	pbCountry "github.com/Bekzodbekk/paris2024_livestream_protos/genproto/countrypb"
-	pbEvent "github.com/Bekzodbekk/paris2024_livestream_protos/genproto/eventpb"
-	pbAthlete "github.com/Bekzodbekk/paris2024_livestream_protos/genproto/athletepb"
+	"api-gateway/internal/http/apierror"
 	"api-gateway/logger"
 	"api-gateway/models"
 
 	"github.com/gin-gonic/gin"
 	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
 )
 
 // @Router /medals [post]
 // @Summary CREATE MEDAL
-// @Description This method creates a medal
+// @Description This method creates a medal for an athlete (athlete_id) or a team (team_id). A team medal counts once in the medal table and is credited to every member of the team
 // @Security BearerAuth
 // @Tags MEDAL
 // @Accept json
@@ -23,42 +24,24 @@ import (
 // @Param medal body models.CreateMedalRequest true "Medal"
 // @Success 200 {object} models.Medal
 // @Failure 400 {object} models.Message
+// @Failure 409 {object} models.Message
 // @Failure 500 {object} models.Message
 func (h *HandlerST) CreateMedal(c *gin.Context) {
 
 	req := pb.CreateMedalRequest{}
 	if err := c.BindJSON(&req); err != nil {
-		logger.Error("CreateMedal: Failed to bind JSON: ", err)
-		c.JSON(400, models.Message{Err: err.Error()})
-		return
-	}
-	
-	//Check Country Id
-	if _, err := h.Service.GetCountry(&pbCountry.GetCountryRequest{Id: req.CountryId}); err != nil {
-		logger.Error("CreateMedal: Failed to get country: ", err)
-		c.JSON(500, models.Message{Err: "Country with the provided ID does not exist or has been deleted"})
-		return
-	}
-	//Check Event Id
-	if _, err := h.Service.GetEvent(&pbEvent.GetEventRequest{Id: req.EventId}); err != nil {
-		logger.Error("CreateMedal: Failed to get event: ", err)
-		c.JSON(500, models.Message{Err: "Event with the provided ID does not exist or has been deleted"})
-		return
-	}
-	//Check Athelete Id
-	if _, err := h.Service.GetAthlete(&pbAthlete.GetAthleteRequest{Id: req.AthleteId}); err != nil {
-		logger.Error("CreateMedal: Failed to get athlete: ", err)
-		c.JSON(500, models.Message{Err: "Athlete with the provided ID does not exist or has been deleted"})
+		logger.ErrorContext(c, "CreateMedal: Failed to bind JSON: ", err)
+		apierror.BadRequest(c, err)
 		return
 	}
 
-	resp, err := h.Service.CreateMedal(context.Background(), &req)
+	resp, err := h.Service.CreateMedal(c.Request.Context(), &req)
 	if err != nil {
-		logger.Error("CreateMedal: Failed to create medal: ", err)
-		c.JSON(500, models.Message{Err: err.Error()})
+		logger.ErrorContext(c, "CreateMedal: Failed to create medal: ", err)
+		apierror.Write(c, err)
 		return
 	}
-	logger.Info("CreateMedal: Medal created successfully: ", logrus.Fields{
+	logger.InfoContext(c, "CreateMedal: Medal created successfully: ", logrus.Fields{
 		"id":   resp.Id,
 		"type": resp.Type,
 	})
@@ -76,27 +59,29 @@ func (h *HandlerST) CreateMedal(c *gin.Context) {
 // @Param medal body models.UpdateMedalRequest true "Medal"
 // @Success 200 {object} models.Medal
 // @Failure 400 {object} models.Message
+// @Failure 404 {object} models.Message
+// @Failure 409 {object} models.Message
 // @Failure 500 {object} models.Message
 func (h *HandlerST) UpdateMedal(c *gin.Context) {
 
 	req := pb.UpdateMedalRequest{}
 	req.Id = c.Param("id")
 	if err := c.BindJSON(&req); err != nil {
-		logger.Error("UpdateMedal: Failed to bind JSON for medal ID ", logrus.Fields{
+		logger.ErrorContext(c, "UpdateMedal: Failed to bind JSON for medal ID ", logrus.Fields{
 			"id": req.Id,
 		})
-		c.JSON(400, models.Message{Err: err.Error()})
+		apierror.BadRequest(c, err)
 		return
 	}
-	resp, err := h.Service.UpdateMedal(context.Background(), &req)
+	resp, err := h.Service.UpdateMedal(c.Request.Context(), &req)
 	if err != nil {
-		logger.Error("UpdateMedal: Failed to update medal with ID ", logrus.Fields{
+		logger.ErrorContext(c, "UpdateMedal: Failed to update medal with ID ", logrus.Fields{
 			"id": req.Id,
 		})
-		c.JSON(500, models.Message{Err: err.Error()})
+		apierror.Write(c, err)
 		return
 	}
-	logger.Info("UpdateMedal: Medal updated successfully: ", logrus.Fields{
+	logger.InfoContext(c, "UpdateMedal: Medal updated successfully: ", logrus.Fields{
 		"id":   resp.Id,
 		"name": resp.Type,
 	})
@@ -113,21 +98,22 @@ func (h *HandlerST) UpdateMedal(c *gin.Context) {
 // @Param id path string true "ID"
 // @Success 200 {object} models.DeleteMedalResponse
 // @Failure 400 {object} models.Message
+// @Failure 404 {object} models.Message
 // @Failure 500 {object} models.Message
 func (h *HandlerST) DeleteMedal(c *gin.Context) {
 
 	req := pb.DeleteMedalRequest{}
 	req.Id = c.Param("id")
-	resp, err := h.Service.DeleteMedal(context.Background(), &req)
+	resp, err := h.Service.DeleteMedal(c.Request.Context(), &req)
 	if err != nil {
-		logger.Error("DeleteMedal: Failed to delete medal with ID ", logrus.Fields{
+		logger.ErrorContext(c, "DeleteMedal: Failed to delete medal with ID ", logrus.Fields{
 			"id": req.Id,
 		})
-		c.JSON(500, models.Message{Err: err.Error()})
+		apierror.Write(c, err)
 		return
 	}
 
-	logger.Info("DeleteMedal: Medal deleted successfully")
+	logger.InfoContext(c, "DeleteMedal: Medal deleted successfully")
 	c.JSON(200, resp)
 }
 
@@ -141,20 +127,21 @@ func (h *HandlerST) DeleteMedal(c *gin.Context) {
 // @Param id path string true "ID"
 // @Success 200 {object} models.GetMedalByIdResponse
 // @Failure 400 {object} models.Message
+// @Failure 404 {object} models.Message
 // @Failure 500 {object} models.Message
 func (h *HandlerST) GetMedalById(c *gin.Context) {
 
 	req := pb.GetMedalByIdRequest{}
 	req.Id = c.Param("id")
-	resp, err := h.Service.GetMedalById(context.Background(), &req)
+	resp, err := h.Service.GetMedalById(c.Request.Context(), &req)
 	if err != nil {
-		logger.Error("GetMedalById: Failed to get medal with ID ", logrus.Fields{
+		logger.ErrorContext(c, "GetMedalById: Failed to get medal with ID ", logrus.Fields{
 			"id": req.Id,
 		})
-		c.JSON(500, models.Message{Err: err.Error()})
+		apierror.Write(c, err)
 		return
 	}
-	logger.Info("GetMedalById: Medal retrieved successfully: ", logrus.Fields{
+	logger.InfoContext(c, "GetMedalById: Medal retrieved successfully: ", logrus.Fields{
 		"id":   resp.Id,
 		"name": resp.Type,
 	})
@@ -168,19 +155,39 @@ func (h *HandlerST) GetMedalById(c *gin.Context) {
 // @Tags MEDAL
 // @Accept json
 // @Produce json
+// @Param page_size query int false "Page size (default 50, max 1000)"
+// @Param page_token query string false "Token of the next page"
+// @Param order_by query string false "created_at, type or country_id, optionally followed by desc"
+// @Param country_id query string false "Country ID"
+// @Param event_id query string false "Event ID"
+// @Param athlete_id query string false "Athlete ID; includes the medals of the athlete's teams"
+// @Param team_id query string false "Team ID"
 // @Success 200 {object} models.GetMedalsResponse
 // @Failure 400 {object} models.Message
 // @Failure 500 {object} models.Message
 func (h *HandlerST) GetMedals(c *gin.Context) {
 
-	resp, err := h.Service.GetMedals(context.Background(), &pb.VoidMedal{})
+	pageSize, pageToken, orderBy, err := pageQuery(c)
+	if err != nil {
+		apierror.BadRequest(c, err)
+		return
+	}
+	resp, err := h.Service.GetMedals(c.Request.Context(), &pb.GetMedalsRequest{
+		PageSize:  pageSize,
+		PageToken: pageToken,
+		OrderBy:   orderBy,
+		CountryId: c.Query("country_id"),
+		EventId:   c.Query("event_id"),
+		AthleteId: c.Query("athlete_id"),
+		TeamId:    c.Query("team_id"),
+	})
 	if err != nil {
-		logger.Error("GetMedals: Failed to get medals: ", err)
-		c.JSON(500, models.Message{Err: err.Error()})
+		logger.ErrorContext(c, "GetMedals: Failed to get medals: ", err)
+		apierror.Write(c, err)
 		return
 	}
 
-	logger.Info("GetMedals: Medals retrieved successfully")
+	logger.InfoContext(c, "GetMedals: Medals retrieved successfully")
 	c.JSON(200, resp)
 }
 
@@ -191,24 +198,158 @@ func (h *HandlerST) GetMedals(c *gin.Context) {
 // @Tags MEDAL
 // @Accept json
 // @Produce json
-// @Param filter body models.GetMedalByFilterRequest true "Filter"
+// @Param country_id query []string false "Country IDs, repeated or comma separated" collectionFormat(csv)
+// @Param type query []string false "Medal types: gold, silver, bronze or 0, 1, 2" collectionFormat(csv)
+// @Param event_id query []string false "Event IDs, repeated or comma separated" collectionFormat(csv)
+// @Param athlete_id query []string false "Athlete IDs, repeated or comma separated; includes the medals of their teams" collectionFormat(csv)
+// @Param team_id query []string false "Team IDs, repeated or comma separated" collectionFormat(csv)
+// @Param created_from query string false "Only medals created at or after this time (RFC 3339 or YYYY-MM-DD)"
+// @Param created_to query string false "Only medals created before this time; a YYYY-MM-DD date includes the whole day"
 // @Success 200 {object} models.GetMedalByFilterResponse
 // @Failure 400 {object} models.Message
 // @Failure 500 {object} models.Message
 func (h *HandlerST) GetMedalByFilter(c *gin.Context) {
-	
-	req := pb.GetMedalByFilterRequest{}
-	if err := c.BindJSON(&req); err != nil {
-		logger.Error("GetMedalByFilter: Failed to bind JSON: ", err)
-		c.JSON(400, models.Message{Err: err.Error()})
+
+	req := pb.GetMedalByFilterRequest{
+		CountryIds:  queryList(c, "country_id"),
+		EventIds:    queryList(c, "event_id"),
+		AthleteIds:  queryList(c, "athlete_id"),
+		TeamIds:     queryList(c, "team_id"),
+		CreatedFrom: c.Query("created_from"),
+		CreatedTo:   c.Query("created_to"),
+	}
+	for _, value := range queryList(c, "type") {
+		medalType, ok := parseMedalType(value)
+		if !ok {
+			apierror.Abort(c, codes.InvalidArgument, "invalid type: %q", value)
+			return
+		}
+		req.Types = append(req.Types, medalType)
+	}
+
+	resp, err := h.Service.GetMedalByFilter(c.Request.Context(), &req)
+	if err != nil {
+		logger.ErrorContext(c, "GetMedalByFilter: Failed to get medals by filter: ", err)
+		apierror.Write(c, err)
 		return
 	}
-	resp, err := h.Service.GetMedalByFilter(context.Background(), &req)
+	logger.InfoContext(c, "GetMedalByFilter: Medals retrieved successfully by filter")
+	c.JSON(200, resp)
+}
+
+// @Router /medals/table [get]
+// @Summary GET MEDAL TABLE
+// @Description This method returns the medal standings per country. Countries are ranked by gold, silver and bronze (rank_by=gold, default) or by total medals (rank_by=total); tied countries share a rank
+// @Security BearerAuth
+// @Tags MEDAL
+// @Accept json
+// @Produce json
+// @Param rank_by query string false "gold or total"
+// @Success 200 {object} models.GetMedalTableResponse
+// @Failure 400 {object} models.Message
+// @Failure 500 {object} models.Message
+func (h *HandlerST) GetMedalTable(c *gin.Context) {
+
+	req := pb.GetMedalTableRequest{RankBy: c.DefaultQuery("rank_by", "gold")}
+	table, err := h.Service.GetMedalTable(c.Request.Context(), &req)
 	if err != nil {
-		logger.Error("GetMedalByFilter: Failed to get medals by filter: ", err)
-		c.JSON(500, models.Message{Err: err.Error()})
+		logger.ErrorContext(c, "GetMedalTable: Failed to get medal table: ", err)
+		apierror.Write(c, err)
 		return
 	}
-	logger.Info("GetMedalByFilter: Medals retrieved successfully by filter")
+
+	countries, err := h.allCountries(c.Request.Context())
+	if err != nil {
+		logger.ErrorContext(c, "GetMedalTable: Failed to list countries: ", err)
+		apierror.Write(c, err)
+		return
+	}
+	byId := make(map[string]*pbCountry.Country, len(countries))
+	for _, country := range countries {
+		byId[country.Id] = country
+	}
+
+	resp := models.GetMedalTableResponse{
+		RankBy: req.RankBy,
+		Rows:   make([]models.MedalTableRow, 0, len(table.Rows)),
+	}
+	for _, row := range table.Rows {
+		item := models.MedalTableRow{
+			Rank:      row.Rank,
+			CountryID: row.CountryId,
+			Gold:      row.Gold,
+			Silver:    row.Silver,
+			Bronze:    row.Bronze,
+			Total:     row.Total,
+		}
+		if country, ok := byId[row.CountryId]; ok {
+			item.CountryName = country.Name
+			item.Flag = country.Flag
+		}
+		resp.Rows = append(resp.Rows, item)
+	}
+
+	logger.InfoContext(c, "GetMedalTable: Medal table retrieved successfully: ", logrus.Fields{
+		"rank_by":   req.RankBy,
+		"countries": len(resp.Rows),
+	})
 	c.JSON(200, resp)
 }
+
+// @Router /events/{id}/finalize [post]
+// @Summary FINALIZE EVENT
+// @Description This method awards the medals of an event from the results of its final phase: tied places share a medal, knockout events in sports that share bronze give it to the winners of their bronze medal matches or to both losing semifinalists, and a winning country's team gets one medal credited to its members. The awards pass the same checks as a medal created by hand, so results that break the event's award rules are refused. Medals the results no longer back are deleted, so finalizing again after a correction reconciles them. With dry_run it only reports what would change
+// @Security BearerAuth
+// @Tags MEDAL
+// @Produce json
+// @Param id path string true "Event ID"
+// @Param dry_run query bool false "Report without writing"
+// @Success 200 {object} models.FinalizeEventResponse
+// @Failure 400 {object} models.Message
+// @Failure 409 {object} models.Message
+// @Failure 500 {object} models.Message
+func (h *HandlerST) FinalizeEvent(c *gin.Context) {
+
+	req := pb.FinalizeEventRequest{EventId: c.Param("id")}
+	if value := c.Query("dry_run"); value != "" {
+		dryRun, err := strconv.ParseBool(value)
+		if err != nil {
+			apierror.Abort(c, codes.InvalidArgument, "invalid dry_run: %q", value)
+			return
+		}
+		req.DryRun = dryRun
+	}
+	resp, err := h.Service.FinalizeEvent(c.Request.Context(), &req)
+	if err != nil {
+		logger.ErrorContext(c, "FinalizeEvent: Failed to finalize event: ", logrus.Fields{
+			"event_id": req.EventId,
+		})
+		apierror.Write(c, err)
+		return
+	}
+	logger.InfoContext(c, "FinalizeEvent: Event finalized successfully: ", logrus.Fields{
+		"event_id":  req.EventId,
+		"created":   resp.Created,
+		"kept":      resp.Kept,
+		"removed":   resp.Removed,
+		"committed": resp.Committed,
+	})
+	c.JSON(200, resp)
+}
+
+// parseMedalType accepts a medal type by name or by its numeric value.
+func parseMedalType(value string) (int32, bool) {
+	switch strings.ToLower(value) {
+	case "gold":
+		return int32(models.GOLD), true
+	case "silver":
+		return int32(models.SILVER), true
+	case "bronze":
+		return int32(models.BRONZE), true
+	}
+	n, err := strconv.ParseInt(value, 10, 32)
+	if err != nil || n < int64(models.GOLD) || n > int64(models.BRONZE) {
+		return 0, false
+	}
+	return int32(n), true
+}
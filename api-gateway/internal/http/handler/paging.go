@@ -0,0 +1,58 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	pbCountry "github.com/Bekzodbekk/paris2024_livestream_protos/genproto/countrypb"
+	"github.com/gin-gonic/gin"
+)
+
+// maxPageSize mirrors the largest page the services hand out.
+const maxPageSize = 1000
+
+// pageQuery reads the page_size, page_token and order_by query parameters
+// shared by every list endpoint.
+func pageQuery(c *gin.Context) (pageSize int32, pageToken, orderBy string, err error) {
+	if value := c.Query("page_size"); value != "" {
+		n, err := strconv.ParseInt(value, 10, 32)
+		if err != nil || n < 0 {
+			return 0, "", "", fmt.Errorf("invalid page_size: %q", value)
+		}
+		pageSize = int32(n)
+	}
+	return pageSize, c.Query("page_token"), c.Query("order_by"), nil
+}
+
+// queryList collects a multi-valued query parameter given either repeatedly
+// (?id=a&id=b) or comma separated (?id=a,b).
+func queryList(c *gin.Context, name string) []string {
+	var values []string
+	for _, raw := range c.QueryArray(name) {
+		for _, value := range strings.Split(raw, ",") {
+			if value = strings.TrimSpace(value); value != "" {
+				values = append(values, value)
+			}
+		}
+	}
+	return values
+}
+
+// allCountries walks every page of ListOfCountry.
+func (h *HandlerST) allCountries(ctx context.Context) ([]*pbCountry.Country, error) {
+	var countries []*pbCountry.Country
+	req := pbCountry.ListOfCountryRequest{PageSize: maxPageSize}
+	for {
+		resp, err := h.Service.ListOfCountry(ctx, &req)
+		if err != nil {
+			return nil, err
+		}
+		countries = append(countries, resp.Countries...)
+		if resp.NextPageToken == "" {
+			return countries, nil
+		}
+		req.PageToken = resp.NextPageToken
+	}
+}
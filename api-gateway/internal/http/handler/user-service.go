@@ -1,18 +1,19 @@
 package handler 
 
 import (
-	"context"
 	pb "github.com/Bekzodbekk/paris2024_livestream_protos/genproto/userpb"
+	"api-gateway/internal/http/apierror"
+	"api-gateway/internal/http/middleware"
 	"api-gateway/logger"
-	"api-gateway/models"
 
 	"github.com/gin-gonic/gin"
 	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
 )
 
 // @Router /auth/register [post]
 // @Summary REGISTER USER
-// @Description This method registers a new user
+// @Description This method registers a new user with the user role; only an admin may grant another role
 // @Security BearerAuth
 // @Tags AUTH
 // @Accept json
@@ -20,22 +21,30 @@ import (
 // @Param user body models.CreateUserRequest true "User"
 // @Success 200 {object} models.User
 // @Failure 400 {object} models.Message
+// @Failure 403 {object} models.Message
 // @Failure 500 {object} models.Message
 func (h *HandlerST) RegisterUser(c *gin.Context) {
 
 	req := pb.CreateUserRequest{}
 	if err := c.BindJSON(&req); err != nil {
-		logger.Error("RegisterUser: Failed to bind JSON: ", err)
-		c.JSON(400, models.Message{Err: err.Error()})
+		logger.ErrorContext(c, "RegisterUser: Failed to bind JSON: ", err)
+		apierror.BadRequest(c, err)
 		return
 	}
-	resp, err := h.Service.Register(context.Background(), &req)
+	if req.Role == "" {
+		req.Role = middleware.RoleUser
+	}
+	if req.Role != middleware.RoleUser && !middleware.IsAdmin(c) {
+		apierror.Abort(c, codes.PermissionDenied, "only an admin may grant the %s role", req.Role)
+		return
+	}
+	resp, err := h.Service.Register(c.Request.Context(), &req)
 	if err != nil {
-		logger.Error("RegisterUser: Failed to register user: ", err)
-		c.JSON(500, models.Message{Err: err.Error()})
+		logger.ErrorContext(c, "RegisterUser: Failed to register user: ", err)
+		apierror.Write(c, err)
 		return
 	}
-	logger.Info("RegisterUser: User registered successfully: ", logrus.Fields{
+	logger.InfoContext(c, "RegisterUser: User registered successfully: ", logrus.Fields{
 		"id":   resp.User.Id,
 		"role": resp.User.Role,
 	})
@@ -57,19 +66,19 @@ func (h *HandlerST) LoginUser(c *gin.Context) {
 
 	req := pb.LoginRequest{}
 	if err := c.BindJSON(&req); err != nil {
-		logger.Error("LoginUser: Failed to bind JSON: ", err)
-		c.JSON(400, models.Message{Err: err.Error()})
+		logger.ErrorContext(c, "LoginUser: Failed to bind JSON: ", err)
+		apierror.BadRequest(c, err)
 		return
 	}
-	resp, err := h.Service.Login(context.Background(), &req)
+	resp, err := h.Service.Login(c.Request.Context(), &req)
 	if err != nil {
-		logger.Error("LoginUser: Failed to login user: ", err)
-		c.JSON(500, models.Message{Err: err.Error()})
+		logger.ErrorContext(c, "LoginUser: Failed to login user: ", err)
+		apierror.Write(c, err)
 		return
 	}
-	logger.Info("LoginUser: User logged in successfully: ", logrus.Fields{
-		"id":    resp.User.Id,
-		"token": resp.AccessToken,
+	logger.InfoContext(c, "LoginUser: User logged in successfully: ", logrus.Fields{
+		"id":   resp.User.Id,
+		"role": resp.User.Role,
 	})
 	c.JSON(200, resp)
 }
@@ -89,23 +98,76 @@ func (h *HandlerST) RefreshToken(c *gin.Context) {
 
 	req := pb.RefreshTokenRequest{}
 	if err := c.BindJSON(&req); err != nil {
-		logger.Error("RefreshToken: Failed to bind JSON: ", err)
-		c.JSON(400, models.Message{Err: err.Error()})
+		logger.ErrorContext(c, "RefreshToken: Failed to bind JSON: ", err)
+		apierror.BadRequest(c, err)
 		return
 	}
-	resp, err := h.Service.RefreshToken(context.Background(), &req)
+	resp, err := h.Service.RefreshToken(c.Request.Context(), &req)
+	if err != nil {
+		logger.ErrorContext(c, "RefreshToken: Failed to refresh token: ", err)
+		apierror.Write(c, err)
+		return
+	}
+	logger.InfoContext(c, "RefreshToken: Token refreshed successfully")
+	c.JSON(200, resp)
+}
+
+// @Router /auth/logout [post]
+// @Summary LOGOUT USER
+// @Description This method ends the current session and revokes its tokens
+// @Security BearerAuth
+// @Tags AUTH
+// @Produce json
+// @Success 200 {object} models.LogoutResponse
+// @Failure 401 {object} models.Message
+// @Failure 500 {object} models.Message
+func (h *HandlerST) Logout(c *gin.Context) {
+
+	req := pb.LogoutRequest{
+		AccessToken: c.GetString(middleware.ContextAccessToken),
+	}
+	resp, err := h.Service.Logout(c.Request.Context(), &req)
 	if err != nil {
-		logger.Error("RefreshToken: Failed to refresh token: ", err)
-		c.JSON(500, models.Message{Err: err.Error()})
+		logger.ErrorContext(c, "Logout: Failed to logout user: ", err)
+		apierror.Write(c, err)
 		return
 	}
-	logger.Info("RefreshToken: Token refreshed successfully")
+	logger.InfoContext(c, "Logout: User logged out successfully: ", logrus.Fields{
+		"id": c.GetString(middleware.ContextUserID),
+	})
+	c.JSON(200, resp)
+}
+
+// @Router /auth/logout-all [post]
+// @Summary LOGOUT USER EVERYWHERE
+// @Description This method ends every session of the current user and revokes their tokens
+// @Security BearerAuth
+// @Tags AUTH
+// @Produce json
+// @Success 200 {object} models.LogoutAllResponse
+// @Failure 401 {object} models.Message
+// @Failure 500 {object} models.Message
+func (h *HandlerST) LogoutAll(c *gin.Context) {
+
+	req := pb.LogoutAllRequest{
+		AccessToken: c.GetString(middleware.ContextAccessToken),
+	}
+	resp, err := h.Service.LogoutAll(c.Request.Context(), &req)
+	if err != nil {
+		logger.ErrorContext(c, "LogoutAll: Failed to logout user: ", err)
+		apierror.Write(c, err)
+		return
+	}
+	logger.InfoContext(c, "LogoutAll: User logged out of all sessions: ", logrus.Fields{
+		"id":       c.GetString(middleware.ContextUserID),
+		"sessions": resp.Sessions,
+	})
 	c.JSON(200, resp)
 }
 
 // @Router /users/{id} [put]
 // @Summary UPDATE USER
-// @Description This method updates a user
+// @Description This method updates a user; the role is only changed when an admin sends one
 // @Security BearerAuth
 // @Tags USER
 // @Accept json
@@ -114,30 +176,36 @@ func (h *HandlerST) RefreshToken(c *gin.Context) {
 // @Param user body models.UpdateUserRequest true "User"
 // @Success 200 {object} models.User
 // @Failure 400 {object} models.Message
+// @Failure 404 {object} models.Message
 // @Failure 500 {object} models.Message
 func (h *HandlerST) UpdateUser(c *gin.Context) {
 
-	req := pb.UpdateUserRequest{
-        User: &pb.User{},
-    }
-    req.User.Id = c.Param("id")
-
+	req := pb.UpdateUserRequest{}
 	if err := c.BindJSON(&req); err != nil {
-		logger.Error("UpdateUser: Failed to bind JSON for user ID ", logrus.Fields{
-			"id": req.User.Id,
+		logger.ErrorContext(c, "UpdateUser: Failed to bind JSON for user ID ", logrus.Fields{
+			"id": c.Param("id"),
 		})
-		c.JSON(400, models.Message{Err: err.Error()})
+		apierror.BadRequest(c, err)
 		return
 	}
-	resp, err := h.Service.UpdateUser(context.Background(), &req)
+	if req.User == nil {
+		req.User = &pb.User{}
+	}
+	// The path names the user, whatever the body says, and only admins
+	// change roles; an empty role keeps the stored one.
+	req.User.Id = c.Param("id")
+	if !middleware.IsAdmin(c) {
+		req.User.Role = ""
+	}
+	resp, err := h.Service.UpdateUser(c.Request.Context(), &req)
 	if err != nil {
-		logger.Error("UpdateUser: Failed to update user with ID ", logrus.Fields{
+		logger.ErrorContext(c, "UpdateUser: Failed to update user with ID ", logrus.Fields{
 			"id": req.User.Id,
 		})
-		c.JSON(500, models.Message{Err: err.Error()})
+		apierror.Write(c, err)
 		return
 	}
-	logger.Info("UpdateUser: User updated successfully: ", logrus.Fields{
+	logger.InfoContext(c, "UpdateUser: User updated successfully: ", logrus.Fields{
 		"id":   resp.User.Id,
 		"name": resp.User.Username,
 	})
@@ -154,21 +222,22 @@ func (h *HandlerST) UpdateUser(c *gin.Context) {
 // @Param id path string true "ID"
 // @Success 200 {object} models.DeleteUserResponse
 // @Failure 400 {object} models.Message
+// @Failure 404 {object} models.Message
 // @Failure 500 {object} models.Message
 func (h *HandlerST) DeleteUser(c *gin.Context) {
 
 	req := pb.DeleteUserRequest{}
 	req.Id = c.Param("id")
-	resp, err := h.Service.DeleteUser(context.Background(), &req)
+	resp, err := h.Service.DeleteUser(c.Request.Context(), &req)
 	if err != nil {
-		logger.Error("DeleteUser: Failed to delete user with ID ", logrus.Fields{
+		logger.ErrorContext(c, "DeleteUser: Failed to delete user with ID ", logrus.Fields{
 			"id": req.Id,
 		})
-		c.JSON(500, models.Message{Err: err.Error()})
+		apierror.Write(c, err)
 		return
 	}
 
-	logger.Info("DeleteUser: User deleted successfully")
+	logger.InfoContext(c, "DeleteUser: User deleted successfully")
 	c.JSON(200, resp)
 }
 
@@ -182,20 +251,21 @@ func (h *HandlerST) DeleteUser(c *gin.Context) {
 // @Param id path string true "ID"
 // @Success 200 {object} models.GetUserResponse
 // @Failure 400 {object} models.Message
+// @Failure 404 {object} models.Message
 // @Failure 500 {object} models.Message
 func (h *HandlerST) GetUserById(c *gin.Context) {
 
 	req := pb.GetUserRequest{}
 	req.Id = c.Param("id")
-	resp, err := h.Service.GetUserById(context.Background(), &req)
+	resp, err := h.Service.GetUserById(c.Request.Context(), &req)
 	if err != nil {
-		logger.Error("GetUserById: Failed to get user with ID ", logrus.Fields{
+		logger.ErrorContext(c, "GetUserById: Failed to get user with ID ", logrus.Fields{
 			"id": req.Id,
 		})
-		c.JSON(500, models.Message{Err: err.Error()})
+		apierror.Write(c, err)
 		return
 	}
-	logger.Info("GetUserById: User retrieved successfully: ", logrus.Fields{
+	logger.InfoContext(c, "GetUserById: User retrieved successfully: ", logrus.Fields{
 		"id":   resp.User.Id,
 		"name": resp.User.Username,
 	})
@@ -209,19 +279,33 @@ func (h *HandlerST) GetUserById(c *gin.Context) {
 // @Tags USER
 // @Accept json
 // @Produce json
+// @Param page_size query int false "Page size (default 50, max 1000)"
+// @Param page_token query string false "Token of the next page"
+// @Param order_by query string false "username, role or created_at, optionally followed by desc"
+// @Param role query string false "Role"
 // @Success 200 {object} models.GetUsersResponse
 // @Failure 400 {object} models.Message
 // @Failure 500 {object} models.Message
 func (h *HandlerST) GetUsers(c *gin.Context) {
 
-	resp, err := h.Service.GetUsers(context.Background(), &pb.Void{})
+	pageSize, pageToken, orderBy, err := pageQuery(c)
+	if err != nil {
+		apierror.BadRequest(c, err)
+		return
+	}
+	resp, err := h.Service.GetUsers(c.Request.Context(), &pb.GetUsersRequest{
+		PageSize:  pageSize,
+		PageToken: pageToken,
+		OrderBy:   orderBy,
+		Role:      c.Query("role"),
+	})
 	if err != nil {
-		logger.Error("GetUsers: Failed to get users: ", err)
-		c.JSON(500, models.Message{Err: err.Error()})
+		logger.ErrorContext(c, "GetUsers: Failed to get users: ", err)
+		apierror.Write(c, err)
 		return
 	}
 
-	logger.Info("GetUsers: Users retrieved successfully")
+	logger.InfoContext(c, "GetUsers: Users retrieved successfully")
 	c.JSON(200, resp)
 }
 
@@ -240,16 +324,16 @@ func (h *HandlerST) GetUserByFilter(c *gin.Context) {
 	
 	req := pb.UserFilter{}
 	if err := c.BindJSON(&req); err != nil {
-		logger.Error("GetUserByFilter: Failed to bind JSON: ", err)
-		c.JSON(400, models.Message{Err: err.Error()})
+		logger.ErrorContext(c, "GetUserByFilter: Failed to bind JSON: ", err)
+		apierror.BadRequest(c, err)
 		return
 	}
-	resp, err := h.Service.GetUserByFilter(context.Background(), &req)
+	resp, err := h.Service.GetUserByFilter(c.Request.Context(), &req)
 	if err != nil {
-		logger.Error("GetUserByFilter: Failed to get users by filter: ", err)
-		c.JSON(500, models.Message{Err: err.Error()})
+		logger.ErrorContext(c, "GetUserByFilter: Failed to get users by filter: ", err)
+		apierror.Write(c, err)
 		return
 	}
-	logger.Info("GetUserByFilter: Users retrieved successfully by filter")
+	logger.InfoContext(c, "GetUserByFilter: Users retrieved successfully by filter")
 	c.JSON(200, resp)
 }
@@ -0,0 +1,235 @@
+package middleware
+
+import (
+	"api-gateway/internal/http/apierror"
+	"api-gateway/logger"
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+	"google.golang.org/grpc/codes"
+)
+
+const (
+	RoleAdmin       = "admin"
+	RoleCommentator = "commentator"
+	// RoleUser is the role every self-registered account starts with.
+	RoleUser = "user"
+
+	ContextUserID   = "id"
+	ContextUsername = "username"
+	ContextRole     = "role"
+	// ContextAccessToken holds the raw bearer token, for the routes that
+	// hand it on to user-service.
+	ContextAccessToken = "access_token"
+)
+
+// tokenTypeAccess is the typ claim of access tokens; refresh tokens are
+// only good for /auth/refresh.
+const tokenTypeAccess = "access"
+
+// Keys resolves the public key named by a token's kid header.
+type Keys interface {
+	Key(ctx context.Context, kid, alg string) (interface{}, error)
+}
+
+// Denylist reports access tokens that were revoked before they expired, by
+// their jti claim.
+type Denylist interface {
+	Revoked(ctx context.Context, jti string) (bool, error)
+}
+
+// AuthConfig says which tokens an Authenticator accepts. RS256 and EdDSA
+// tokens are verified with Keys and HS256 tokens with Secret; leaving either
+// empty rejects those algorithms. A nil Denylist skips the revocation check,
+// so logged out tokens stay valid until they expire.
+type AuthConfig struct {
+	Issuer   string
+	Secret   string
+	Keys     Keys
+	Denylist Denylist
+}
+
+type Authenticator struct {
+	issuer   string
+	secret   []byte
+	keys     Keys
+	methods  []string
+	denylist Denylist
+}
+
+func NewAuthenticator(cfg AuthConfig) *Authenticator {
+	a := &Authenticator{
+		issuer:   cfg.Issuer,
+		secret:   []byte(cfg.Secret),
+		keys:     cfg.Keys,
+		denylist: cfg.Denylist,
+	}
+	if cfg.Keys != nil {
+		a.methods = append(a.methods, jwt.SigningMethodRS256.Alg(), jwt.SigningMethodEdDSA.Alg())
+	}
+	if cfg.Secret != "" {
+		a.methods = append(a.methods, jwt.SigningMethodHS256.Alg())
+	}
+	return a
+}
+
+// Authenticate validates the bearer token issued by user-service and stores
+// its id, username and role claims in the gin context.
+func (a *Authenticator) Authenticate() gin.HandlerFunc {
+
+	return func(c *gin.Context) {
+		tokenString, ok := bearerToken(c)
+		if !ok {
+			apierror.Abort(c, codes.Unauthenticated, "missing bearer token")
+			return
+		}
+		if a.identify(c, tokenString) {
+			c.Next()
+		}
+	}
+}
+
+// Identify is Authenticate for public routes: requests without a token pass
+// through anonymously, but a token that is sent must be valid.
+func (a *Authenticator) Identify() gin.HandlerFunc {
+
+	return func(c *gin.Context) {
+		tokenString, ok := bearerToken(c)
+		if !ok || a.identify(c, tokenString) {
+			c.Next()
+		}
+	}
+}
+
+// identify stores the claims of a valid token in the gin context, or aborts
+// the request and reports false.
+func (a *Authenticator) identify(c *gin.Context, tokenString string) bool {
+	claims, err := a.parse(c, tokenString)
+	if err != nil {
+		logger.WarnContext(c, "Authenticate: invalid token: ", err)
+		apierror.Abort(c, codes.Unauthenticated, "invalid or expired token")
+		return false
+	}
+	if a.revoked(c, claimString(claims, "jti")) {
+		apierror.Abort(c, codes.Unauthenticated, "token has been revoked")
+		return false
+	}
+
+	c.Set(ContextAccessToken, tokenString)
+	c.Set(ContextUserID, claimString(claims, "id"))
+	c.Set(ContextUsername, claimString(claims, "username"))
+	c.Set(ContextRole, claimString(claims, "role"))
+	return true
+}
+
+// RequireRole lets the request through only when the authenticated user has
+// one of the given roles.
+func RequireRole(roles ...string) gin.HandlerFunc {
+
+	return func(c *gin.Context) {
+		role := c.GetString(ContextRole)
+		for _, r := range roles {
+			if role == r {
+				c.Next()
+				return
+			}
+		}
+		apierror.Abort(c, codes.PermissionDenied, "insufficient permissions")
+	}
+}
+
+// IsAdmin reports whether the authenticated user is an admin.
+func IsAdmin(c *gin.Context) bool {
+	return c.GetString(ContextRole) == RoleAdmin
+}
+
+// SelfOrAdmin allows admins, or users whose id matches the given path parameter.
+func SelfOrAdmin(param string) gin.HandlerFunc {
+
+	return func(c *gin.Context) {
+		if IsAdmin(c) || c.GetString(ContextUserID) == c.Param(param) {
+			c.Next()
+			return
+		}
+		apierror.Abort(c, codes.PermissionDenied, "insufficient permissions")
+	}
+}
+
+// revoked checks the denylist. The check fails open: while the denylist is
+// unreachable a revoked token is accepted until it expires, which is at most
+// the access token lifetime, rather than every request being refused.
+func (a *Authenticator) revoked(ctx context.Context, jti string) bool {
+	if a.denylist == nil || jti == "" {
+		return false
+	}
+	revoked, err := a.denylist.Revoked(ctx, jti)
+	if err != nil {
+		logger.WarnContext(ctx, "Authenticate: denylist unavailable: ", err)
+		return false
+	}
+	return revoked
+}
+
+func (a *Authenticator) parse(ctx context.Context, tokenString string) (jwt.MapClaims, error) {
+	options := []jwt.ParserOption{
+		jwt.WithValidMethods(a.methods),
+		jwt.WithExpirationRequired(),
+	}
+	if a.issuer != "" {
+		options = append(options, jwt.WithIssuer(a.issuer))
+	}
+
+	claims := jwt.MapClaims{}
+	_, err := jwt.ParseWithClaims(tokenString, claims, func(t *jwt.Token) (interface{}, error) {
+		if _, ok := t.Method.(*jwt.SigningMethodHMAC); ok {
+			if len(a.secret) == 0 {
+				return nil, errors.New("HS256 tokens are not accepted")
+			}
+			return a.secret, nil
+		}
+		if a.keys == nil {
+			return nil, fmt.Errorf("%s tokens are not accepted", t.Method.Alg())
+		}
+		kid, _ := t.Header["kid"].(string)
+		return a.keys.Key(ctx, kid, t.Method.Alg())
+	}, options...)
+	if err != nil {
+		return nil, err
+	}
+
+	if claimString(claims, "typ") != tokenTypeAccess {
+		return nil, errors.New("not an access token")
+	}
+	if claimString(claims, "id") == "" {
+		return nil, fmt.Errorf("token has no subject")
+	}
+	return claims, nil
+}
+
+// bearerToken reads the token from the Authorization header. Browsers cannot
+// set headers on WebSocket and EventSource requests, so those may pass it in
+// the access_token query parameter instead.
+func bearerToken(c *gin.Context) (string, bool) {
+	header := c.GetHeader("Authorization")
+	if header == "" {
+		header = c.Query("access_token")
+	}
+	if header == "" {
+		return "", false
+	}
+	parts := strings.SplitN(header, " ", 2)
+	if len(parts) == 2 && strings.EqualFold(parts[0], "Bearer") {
+		header = parts[1]
+	}
+	header = strings.TrimSpace(header)
+	return header, header != ""
+}
+
+func claimString(claims jwt.MapClaims, key string) string {
+	value, _ := claims[key].(string)
+	return value
+}
@@ -0,0 +1,230 @@
+package middleware
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const (
+	testSecret = "secret"
+	testIssuer = "user-service"
+)
+
+type fakeDenylist struct {
+	revoked map[string]bool
+	err     error
+}
+
+func (d *fakeDenylist) Revoked(_ context.Context, jti string) (bool, error) {
+	return d.revoked[jti], d.err
+}
+
+// staticKeys holds one Ed25519 key.
+type staticKeys struct {
+	kid    string
+	public ed25519.PublicKey
+}
+
+func (k staticKeys) Key(_ context.Context, kid, alg string) (interface{}, error) {
+	if kid != k.kid || alg != "EdDSA" {
+		return nil, errors.New("unknown key")
+	}
+	return k.public, nil
+}
+
+func testClaims(typ, jti string) jwt.MapClaims {
+	claims := jwt.MapClaims{
+		"id":       "1",
+		"username": "mongosh",
+		"role":     RoleAdmin,
+		"typ":      typ,
+		"iss":      testIssuer,
+		"exp":      time.Now().Add(time.Hour).Unix(),
+	}
+	if jti != "" {
+		claims["jti"] = jti
+	}
+	return claims
+}
+
+func signToken(t *testing.T, jti string) string {
+	t.Helper()
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, testClaims(tokenTypeAccess, jti)).SignedString([]byte(testSecret))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return token
+}
+
+func authRouter(denylist Denylist) *gin.Engine {
+	return router(AuthConfig{Issuer: testIssuer, Secret: testSecret, Denylist: denylist})
+}
+
+func router(cfg AuthConfig) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.GET("/", NewAuthenticator(cfg).Authenticate(), func(c *gin.Context) {
+		c.String(http.StatusOK, c.GetString(ContextAccessToken))
+	})
+	return r
+}
+
+func authenticate(r *gin.Engine, token string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	r.ServeHTTP(w, req)
+	return w
+}
+
+func TestAuthenticateDenylist(t *testing.T) {
+	r := authRouter(&fakeDenylist{revoked: map[string]bool{"revoked": true}})
+
+	token := signToken(t, "active")
+	w := authenticate(r, token)
+	if w.Code != http.StatusOK {
+		t.Fatalf("active token: status %d, want %d", w.Code, http.StatusOK)
+	}
+	if w.Body.String() != token {
+		t.Errorf("access token in context = %q, want the bearer token", w.Body.String())
+	}
+
+	if w := authenticate(r, signToken(t, "revoked")); w.Code != http.StatusUnauthorized {
+		t.Errorf("revoked token: status %d, want %d", w.Code, http.StatusUnauthorized)
+	}
+
+	// Tokens issued before sessions existed carry no jti.
+	if w := authenticate(r, signToken(t, "")); w.Code != http.StatusOK {
+		t.Errorf("token without jti: status %d, want %d", w.Code, http.StatusOK)
+	}
+}
+
+func TestAuthenticateDenylistFailsOpen(t *testing.T) {
+	r := authRouter(&fakeDenylist{err: errors.New("connection refused")})
+
+	if w := authenticate(r, signToken(t, "revoked")); w.Code != http.StatusOK {
+		t.Errorf("status %d, want %d while the denylist is down", w.Code, http.StatusOK)
+	}
+}
+
+func TestAuthenticateWithoutDenylist(t *testing.T) {
+	r := authRouter(nil)
+
+	if w := authenticate(r, signToken(t, "revoked")); w.Code != http.StatusOK {
+		t.Errorf("status %d, want %d", w.Code, http.StatusOK)
+	}
+}
+
+func TestAuthenticateKeys(t *testing.T) {
+	public, private, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	r := router(AuthConfig{Issuer: testIssuer, Keys: staticKeys{kid: "k1", public: public}})
+
+	sign := func(kid string, claims jwt.MapClaims) string {
+		token := jwt.NewWithClaims(jwt.SigningMethodEdDSA, claims)
+		token.Header["kid"] = kid
+		s, err := token.SignedString(private)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return s
+	}
+
+	if w := authenticate(r, sign("k1", testClaims(tokenTypeAccess, "a"))); w.Code != http.StatusOK {
+		t.Errorf("access token: status %d, want %d", w.Code, http.StatusOK)
+	}
+
+	otherIssuer := testClaims(tokenTypeAccess, "a")
+	otherIssuer["iss"] = "someone-else"
+	for name, token := range map[string]string{
+		"refresh token":  sign("k1", testClaims("refresh", "r")),
+		"no type":        sign("k1", testClaims("", "a")),
+		"unknown kid":    sign("k2", testClaims(tokenTypeAccess, "a")),
+		"other issuer":   sign("k1", otherIssuer),
+		"HS256 disabled": signToken(t, "a"),
+	} {
+		if w := authenticate(r, token); w.Code != http.StatusUnauthorized {
+			t.Errorf("%s: status %d, want %d", name, w.Code, http.StatusUnauthorized)
+		}
+	}
+}
+
+func TestIdentify(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.GET("/", NewAuthenticator(AuthConfig{Issuer: testIssuer, Secret: testSecret}).Identify(), func(c *gin.Context) {
+		c.String(http.StatusOK, c.GetString(ContextRole))
+	})
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+	if w.Code != http.StatusOK || w.Body.String() != "" {
+		t.Errorf("anonymous: status %d role %q, want %d and no role", w.Code, w.Body.String(), http.StatusOK)
+	}
+
+	if w := authenticate(r, signToken(t, "a")); w.Code != http.StatusOK || w.Body.String() != RoleAdmin {
+		t.Errorf("admin token: status %d role %q, want %d and %q", w.Code, w.Body.String(), http.StatusOK, RoleAdmin)
+	}
+
+	if w := authenticate(r, "garbage"); w.Code != http.StatusUnauthorized {
+		t.Errorf("invalid token: status %d, want %d", w.Code, http.StatusUnauthorized)
+	}
+}
+
+func TestRequireRole(t *testing.T) {
+	for role, want := range map[string]int{
+		RoleAdmin:       http.StatusOK,
+		RoleCommentator: http.StatusOK,
+		RoleUser:        http.StatusForbidden,
+		"":              http.StatusForbidden,
+	} {
+		gin.SetMode(gin.TestMode)
+		r := gin.New()
+		r.GET("/", func(c *gin.Context) { c.Set(ContextRole, role) }, RequireRole(RoleAdmin, RoleCommentator), func(c *gin.Context) {
+			c.Status(http.StatusOK)
+		})
+
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+		if w.Code != want {
+			t.Errorf("role %q: status %d, want %d", role, w.Code, want)
+		}
+	}
+}
+
+func TestSelfOrAdmin(t *testing.T) {
+	for _, tc := range []struct {
+		id, role, path string
+		want           int
+	}{
+		{"1", RoleUser, "/users/1", http.StatusOK},
+		{"1", RoleUser, "/users/2", http.StatusForbidden},
+		{"1", RoleAdmin, "/users/2", http.StatusOK},
+	} {
+		gin.SetMode(gin.TestMode)
+		r := gin.New()
+		r.GET("/users/:id", func(c *gin.Context) {
+			c.Set(ContextUserID, tc.id)
+			c.Set(ContextRole, tc.role)
+		}, SelfOrAdmin("id"), func(c *gin.Context) {
+			c.Status(http.StatusOK)
+		})
+
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, tc.path, nil))
+		if w.Code != tc.want {
+			t.Errorf("%s %s on %s: status %d, want %d", tc.role, tc.id, tc.path, w.Code, tc.want)
+		}
+	}
+}
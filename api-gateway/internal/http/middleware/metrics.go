@@ -0,0 +1,25 @@
+package middleware
+
+import (
+	"api-gateway/internal/pkg/metrics"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Metrics records the count, latency and in-flight requests of every route.
+func Metrics() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		route := metrics.Route(c.FullPath())
+		inFlight := metrics.InFlight.WithLabelValues(route)
+		inFlight.Inc()
+		defer inFlight.Dec()
+
+		start := time.Now()
+		c.Next()
+
+		metrics.RequestDuration.WithLabelValues(route, c.Request.Method).Observe(time.Since(start).Seconds())
+		metrics.Requests.WithLabelValues(route, c.Request.Method, strconv.Itoa(c.Writer.Status())).Inc()
+	}
+}
@@ -1,54 +1,80 @@
 package middleware
 
 import (
-	"net/http"
-	"sync"
+	"api-gateway/internal/http/apierror"
+	"api-gateway/internal/pkg/metrics"
+	"api-gateway/internal/ratelimit"
+	"api-gateway/logger"
+	"math"
+	"strconv"
+	"time"
 
 	"github.com/gin-gonic/gin"
-	"golang.org/x/time/rate"
+	"google.golang.org/grpc/codes"
+)
+
+const (
+	HeaderRateLimitLimit     = "RateLimit-Limit"
+	HeaderRateLimitRemaining = "RateLimit-Remaining"
+	HeaderRateLimitReset     = "RateLimit-Reset"
+	HeaderRateLimitPolicy    = "RateLimit-Policy"
+	HeaderRetryAfter         = "Retry-After"
 )
 
 type RateLimiter struct {
-	visitors map[string]*rate.Limiter
-	mu       sync.Mutex
-	rate     rate.Limit
-	burst    int
+	store    ratelimit.Store
+	policies ratelimit.Policies
 }
 
-func NewRateLimiter(r rate.Limit, b int) *RateLimiter {
+func NewRateLimiter(store ratelimit.Store, policies ratelimit.Policies) *RateLimiter {
 	return &RateLimiter{
-		visitors: make(map[string]*rate.Limiter),
-		rate:     r,
-		burst:    b,
+		store:    store,
+		policies: policies,
 	}
 }
 
-func (rl *RateLimiter) getVisitor(ip string) *rate.Limiter {
-	rl.mu.Lock()
-	defer rl.mu.Unlock()
-
-	limiter, exists := rl.visitors[ip]
-	if !exists {
-		limiter = rate.NewLimiter(rl.rate, rl.burst)
-		rl.visitors[ip] = limiter
-	}
+// Limit counts requests against the policy of the named route group.
+// Authenticated requests are counted per user, so it must run after
+// Authenticate on protected routes; anonymous ones per client IP. If the
+// store cannot be reached the request is let through.
+func (rl *RateLimiter) Limit(group string) gin.HandlerFunc {
 
-	return limiter
-}
+	return func(c *gin.Context) {
+		userID := c.GetString(ContextUserID)
+		limit, matched, ok := rl.policies.Lookup(group, userID, c.GetString(ContextRole))
+		if !ok {
+			c.Next()
+			return
+		}
 
-func (rl *RateLimiter) RateLimitMiddleware() gin.HandlerFunc {
+		key := matched + ":ip:" + c.ClientIP()
+		if userID != "" {
+			key = matched + ":user:" + userID
+		}
+		result, err := rl.store.Allow(c, key, limit)
+		if err != nil {
+			logger.ErrorContext(c, "Rate limit check failed: ", err)
+			c.Next()
+			return
+		}
 
-	return func(c *gin.Context) {
-		ip := c.ClientIP()
-		limiter := rl.getVisitor(ip)
+		header := c.Writer.Header()
+		header.Set(HeaderRateLimitLimit, strconv.Itoa(limit.Capacity()))
+		header.Set(HeaderRateLimitRemaining, strconv.Itoa(result.Remaining))
+		header.Set(HeaderRateLimitReset, strconv.Itoa(ceilSeconds(result.ResetAfter)))
+		header.Set(HeaderRateLimitPolicy, limit.String())
 
-		if !limiter.Allow() {
-			c.AbortWithStatusJSON(http.StatusTooManyRequests, gin.H{
-				"error": "too many requests",
-			})
+		if !result.Allowed {
+			metrics.RateLimited.WithLabelValues(metrics.Route(c.FullPath())).Inc()
+			header.Set(HeaderRetryAfter, strconv.Itoa(ceilSeconds(result.RetryAfter)))
+			apierror.Abort(c, codes.ResourceExhausted, "too many requests")
 			return
 		}
 
 		c.Next()
 	}
 }
+
+func ceilSeconds(d time.Duration) int {
+	return int(math.Ceil(d.Seconds()))
+}
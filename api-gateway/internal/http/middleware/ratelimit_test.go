@@ -0,0 +1,115 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"api-gateway/internal/ratelimit"
+
+	"github.com/gin-gonic/gin"
+)
+
+func newLimitedRouter(policies ratelimit.Policies) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+
+	rl := NewRateLimiter(ratelimit.NewMemoryStore(), policies)
+	r := gin.New()
+	r.GET("/medals", func(c *gin.Context) {
+		if user := c.GetHeader("X-Test-User"); user != "" {
+			c.Set(ContextUserID, user)
+			c.Set(ContextRole, c.GetHeader("X-Test-Role"))
+		}
+		c.Next()
+	}, rl.Limit(ratelimit.DefaultGroup), func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+	return r
+}
+
+func get(r *gin.Engine, user, role string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, "/medals", nil)
+	req.RemoteAddr = "203.0.113.7:1234"
+	if user != "" {
+		req.Header.Set("X-Test-User", user)
+		req.Header.Set("X-Test-Role", role)
+	}
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w
+}
+
+func TestLimitSetsHeadersAndRejects(t *testing.T) {
+	r := newLimitedRouter(ratelimit.Policies{
+		Groups: map[string]ratelimit.Limit{
+			ratelimit.DefaultGroup: {Rate: 1, Period: time.Minute, Burst: 2},
+		},
+	})
+
+	w := get(r, "", "")
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200", w.Code)
+	}
+	if got := w.Header().Get(HeaderRateLimitLimit); got != "2" {
+		t.Fatalf("%s = %q, want 2", HeaderRateLimitLimit, got)
+	}
+	if got := w.Header().Get(HeaderRateLimitRemaining); got != "1" {
+		t.Fatalf("%s = %q, want 1", HeaderRateLimitRemaining, got)
+	}
+	if got := w.Header().Get(HeaderRateLimitPolicy); got != "1;w=60" {
+		t.Fatalf("%s = %q, want 1;w=60", HeaderRateLimitPolicy, got)
+	}
+
+	get(r, "", "")
+	w = get(r, "", "")
+	if w.Code != http.StatusTooManyRequests {
+		t.Fatalf("status = %d, want 429", w.Code)
+	}
+	if got := w.Header().Get(HeaderRetryAfter); got != "60" {
+		t.Fatalf("%s = %q, want 60", HeaderRetryAfter, got)
+	}
+	if got := w.Header().Get(HeaderRateLimitRemaining); got != "0" {
+		t.Fatalf("%s = %q, want 0", HeaderRateLimitRemaining, got)
+	}
+}
+
+func TestLimitCountsPerUserAndRole(t *testing.T) {
+	r := newLimitedRouter(ratelimit.Policies{
+		Groups: map[string]ratelimit.Limit{
+			ratelimit.DefaultGroup: {Rate: 1, Period: time.Minute, Burst: 1},
+		},
+		Roles: map[string]map[string]ratelimit.Limit{
+			RoleAdmin: {ratelimit.DefaultGroup: {Rate: 100, Period: time.Minute, Burst: 100}},
+		},
+	})
+
+	// Users behind the same address have their own quotas.
+	if w := get(r, "u1", "user"); w.Code != http.StatusOK {
+		t.Fatalf("u1: status = %d, want 200", w.Code)
+	}
+	if w := get(r, "u2", "user"); w.Code != http.StatusOK {
+		t.Fatalf("u2: status = %d, want 200", w.Code)
+	}
+	if w := get(r, "u1", "user"); w.Code != http.StatusTooManyRequests {
+		t.Fatalf("u1 again: status = %d, want 429", w.Code)
+	}
+
+	for i := 0; i < 10; i++ {
+		if w := get(r, "a1", RoleAdmin); w.Code != http.StatusOK {
+			t.Fatalf("admin request %d: status = %d, want 200", i, w.Code)
+		}
+	}
+}
+
+func TestLimitWithoutPolicyPassesThrough(t *testing.T) {
+	r := newLimitedRouter(ratelimit.Policies{})
+
+	w := get(r, "", "")
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200", w.Code)
+	}
+	if got := w.Header().Get(HeaderRateLimitLimit); got != "" {
+		t.Fatalf("unexpected %s header %q", HeaderRateLimitLimit, got)
+	}
+}
@@ -0,0 +1,36 @@
+package middleware
+
+import (
+	"api-gateway/internal/http/apierror"
+	"crypto/rand"
+	"encoding/hex"
+
+	"github.com/gin-gonic/gin"
+)
+
+const ContextRequestID = "request_id"
+
+// maxRequestIDLength bounds the ids accepted from clients, so a caller cannot
+// push arbitrary amounts of text into the logs.
+const maxRequestIDLength = 128
+
+// RequestID keeps the caller's X-Request-ID or assigns a new one, stores it
+// in the gin context and echoes it on the response.
+func RequestID() gin.HandlerFunc {
+
+	return func(c *gin.Context) {
+		id := c.GetHeader(apierror.HeaderRequestID)
+		if id == "" || len(id) > maxRequestIDLength {
+			id = newRequestID()
+		}
+		c.Set(ContextRequestID, id)
+		c.Header(apierror.HeaderRequestID, id)
+		c.Next()
+	}
+}
+
+func newRequestID() string {
+	b := make([]byte, 16)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,232 @@
+package hub
+
+import (
+	"api-gateway/logger"
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	pb "github.com/Bekzodbekk/paris2024_livestream_protos/genproto/livepb"
+	"github.com/sirupsen/logrus"
+)
+
+// DefaultBufferSize is the number of messages a subscriber may fall behind
+// before it is treated as a slow consumer and evicted.
+const DefaultBufferSize = 64
+
+// Bounds of the delay before a failed feed is reopened. It doubles with every
+// failure in a row and starts over once the feed delivers again.
+const (
+	minRetryDelay = 500 * time.Millisecond
+	maxRetryDelay = 30 * time.Second
+)
+
+// Feed streams the new entries of one event into publish until ctx is done
+// or the upstream fails.
+type Feed func(ctx context.Context, eventID string, publish func(*pb.LiveStream)) error
+
+// LiveClient opens live-service's SubscribeLiveStream.
+type LiveClient interface {
+	SubscribeLive(ctx context.Context, req *pb.GetStreamRequest) (pb.LiveStreamService_SubscribeLiveStreamClient, error)
+}
+
+// StreamFeed feeds the hub from live-service, so subscribers see entries
+// ingested through any gateway or live-service replica. Stored entries are
+// not replayed; clients read those from the timeline.
+func StreamFeed(client LiveClient) Feed {
+	return func(ctx context.Context, eventID string, publish func(*pb.LiveStream)) error {
+		stream, err := client.SubscribeLive(ctx, &pb.GetStreamRequest{Id: eventID, LiveOnly: true})
+		if err != nil {
+			return err
+		}
+		for {
+			msg, err := stream.Recv()
+			if err != nil {
+				return err
+			}
+			publish(msg)
+		}
+	}
+}
+
+type Subscriber struct {
+	eventID string
+	send    chan *pb.LiveStream
+	done    chan struct{}
+	once    sync.Once
+}
+
+// Messages returns the channel live updates for the subscribed event arrive on.
+func (s *Subscriber) Messages() <-chan *pb.LiveStream {
+	return s.send
+}
+
+// Done is closed once the subscriber is unsubscribed or evicted by the hub.
+func (s *Subscriber) Done() <-chan struct{} {
+	return s.done
+}
+
+func (s *Subscriber) close() {
+	s.once.Do(func() {
+		close(s.done)
+	})
+}
+
+// Hub fans out live stream messages to every subscriber of an event. With a
+// feed, the hub keeps one upstream stream open per event that has
+// subscribers and closes it when the last one leaves.
+type Hub struct {
+	mu         sync.RWMutex
+	topics     map[string]map[*Subscriber]struct{}
+	feeds      map[string]context.CancelFunc
+	feed       Feed
+	bufferSize int
+}
+
+// NewHub returns a hub fed by feed. A nil feed leaves publishing to the
+// caller.
+func NewHub(bufferSize int, feed Feed) *Hub {
+	if bufferSize <= 0 {
+		bufferSize = DefaultBufferSize
+	}
+	return &Hub{
+		topics:     make(map[string]map[*Subscriber]struct{}),
+		feeds:      make(map[string]context.CancelFunc),
+		feed:       feed,
+		bufferSize: bufferSize,
+	}
+}
+
+func (h *Hub) Subscribe(eventID string) *Subscriber {
+	sub := &Subscriber{
+		eventID: eventID,
+		send:    make(chan *pb.LiveStream, h.bufferSize),
+		done:    make(chan struct{}),
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	subs, ok := h.topics[eventID]
+	if !ok {
+		subs = make(map[*Subscriber]struct{})
+		h.topics[eventID] = subs
+		h.startFeed(eventID)
+	}
+	subs[sub] = struct{}{}
+	return sub
+}
+
+func (h *Hub) Unsubscribe(sub *Subscriber) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.remove(sub)
+}
+
+// Publish delivers msg to every subscriber of msg.EventId without blocking.
+// Subscribers whose buffer is full are evicted and returned in the count of
+// dropped subscribers.
+func (h *Hub) Publish(msg *pb.LiveStream) (delivered int, evicted int) {
+	var slow []*Subscriber
+
+	h.mu.RLock()
+	for sub := range h.topics[msg.EventId] {
+		select {
+		case sub.send <- msg:
+			delivered++
+		default:
+			slow = append(slow, sub)
+		}
+	}
+	h.mu.RUnlock()
+
+	if len(slow) == 0 {
+		return delivered, 0
+	}
+
+	h.mu.Lock()
+	for _, sub := range slow {
+		h.remove(sub)
+	}
+	h.mu.Unlock()
+
+	logger.Warn("Hub: evicted slow subscribers: ", logrus.Fields{
+		"event_id": msg.EventId,
+		"count":    len(slow),
+	})
+	return delivered, len(slow)
+}
+
+// Subscribers returns the number of active subscribers for an event.
+func (h *Hub) Subscribers(eventID string) int {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	return len(h.topics[eventID])
+}
+
+func (h *Hub) remove(sub *Subscriber) {
+	subs, ok := h.topics[sub.eventID]
+	if !ok {
+		return
+	}
+	if _, ok := subs[sub]; !ok {
+		return
+	}
+	delete(subs, sub)
+	if len(subs) == 0 {
+		delete(h.topics, sub.eventID)
+		if cancel, ok := h.feeds[sub.eventID]; ok {
+			cancel()
+			delete(h.feeds, sub.eventID)
+		}
+	}
+	sub.close()
+}
+
+// startFeed opens the upstream of an event that just got its first
+// subscriber. h.mu must be held.
+func (h *Hub) startFeed(eventID string) {
+	if h.feed == nil {
+		return
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	h.feeds[eventID] = cancel
+	go h.runFeed(ctx, eventID)
+}
+
+// runFeed keeps the feed of an event open until ctx is cancelled, reopening
+// it after failures. Entries stored while it is down are not sent.
+func (h *Hub) runFeed(ctx context.Context, eventID string) {
+	delay := minRetryDelay
+	for {
+		var delivered atomic.Bool
+		err := h.feed(ctx, eventID, func(msg *pb.LiveStream) {
+			// A feed replaced by a newer one may still be delivering.
+			if ctx.Err() == nil {
+				h.Publish(msg)
+				delivered.Store(true)
+			}
+		})
+		if ctx.Err() != nil {
+			return
+		}
+		if delivered.Load() {
+			delay = minRetryDelay
+		}
+		logger.Warn("Hub: live feed failed, reopening: ", logrus.Fields{
+			"event_id": eventID,
+			"error":    err,
+			"retry_in": delay.String(),
+		})
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(delay):
+		}
+		delay = min(delay*2, maxRetryDelay)
+	}
+}
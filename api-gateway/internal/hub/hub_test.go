@@ -0,0 +1,171 @@
+package hub
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	pb "github.com/Bekzodbekk/paris2024_livestream_protos/genproto/livepb"
+)
+
+func TestPublishFansOutPerEvent(t *testing.T) {
+	h := NewHub(4, nil)
+
+	a := h.Subscribe("event-1")
+	b := h.Subscribe("event-1")
+	other := h.Subscribe("event-2")
+
+	delivered, evicted := h.Publish(&pb.LiveStream{EventId: "event-1", LeftSide: "FRA"})
+	if delivered != 2 || evicted != 0 {
+		t.Fatalf("expected 2 delivered and 0 evicted, got %d and %d", delivered, evicted)
+	}
+
+	for _, sub := range []*Subscriber{a, b} {
+		select {
+		case msg := <-sub.Messages():
+			if msg.LeftSide != "FRA" {
+				t.Fatalf("unexpected message: %v", msg)
+			}
+		default:
+			t.Fatal("subscriber did not receive the message")
+		}
+	}
+
+	select {
+	case msg := <-other.Messages():
+		t.Fatalf("subscriber of another event received %v", msg)
+	default:
+	}
+}
+
+func TestPublishEvictsSlowConsumer(t *testing.T) {
+	h := NewHub(1, nil)
+
+	slow := h.Subscribe("event-1")
+	h.Publish(&pb.LiveStream{EventId: "event-1"})
+
+	_, evicted := h.Publish(&pb.LiveStream{EventId: "event-1"})
+	if evicted != 1 {
+		t.Fatalf("expected slow subscriber to be evicted, got %d", evicted)
+	}
+
+	select {
+	case <-slow.Done():
+	default:
+		t.Fatal("evicted subscriber was not closed")
+	}
+	if n := h.Subscribers("event-1"); n != 0 {
+		t.Fatalf("expected no subscribers left, got %d", n)
+	}
+}
+
+func TestUnsubscribeIsIdempotent(t *testing.T) {
+	h := NewHub(1, nil)
+
+	sub := h.Subscribe("event-1")
+	h.Unsubscribe(sub)
+	h.Unsubscribe(sub)
+
+	select {
+	case <-sub.Done():
+	default:
+		t.Fatal("unsubscribed subscriber was not closed")
+	}
+}
+
+// fakeFeed counts the feeds that are open and lets the test publish into
+// them.
+type fakeFeed struct {
+	mu      sync.Mutex
+	opened  int
+	open    map[string]func(*pb.LiveStream)
+	failing bool
+}
+
+func (f *fakeFeed) feed(ctx context.Context, eventID string, publish func(*pb.LiveStream)) error {
+	f.mu.Lock()
+	f.opened++
+	if f.failing {
+		f.mu.Unlock()
+		return errors.New("connection refused")
+	}
+	f.open[eventID] = publish
+	f.mu.Unlock()
+
+	<-ctx.Done()
+
+	f.mu.Lock()
+	delete(f.open, eventID)
+	f.mu.Unlock()
+	return ctx.Err()
+}
+
+func (f *fakeFeed) state(eventID string) (opened int, publish func(*pb.LiveStream)) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.opened, f.open[eventID]
+}
+
+func waitFor(t *testing.T, what string, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out waiting for %s", what)
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func TestFeedOpensOncePerEvent(t *testing.T) {
+	f := &fakeFeed{open: make(map[string]func(*pb.LiveStream))}
+	h := NewHub(4, f.feed)
+
+	a := h.Subscribe("event-1")
+	b := h.Subscribe("event-1")
+	waitFor(t, "the feed to open", func() bool {
+		_, publish := f.state("event-1")
+		return publish != nil
+	})
+
+	_, publish := f.state("event-1")
+	publish(&pb.LiveStream{EventId: "event-1", LeftSide: "FRA"})
+	for _, sub := range []*Subscriber{a, b} {
+		select {
+		case msg := <-sub.Messages():
+			if msg.LeftSide != "FRA" {
+				t.Fatalf("unexpected message: %v", msg)
+			}
+		case <-time.After(time.Second):
+			t.Fatal("subscriber did not receive the fed message")
+		}
+	}
+	if opened, _ := f.state("event-1"); opened != 1 {
+		t.Fatalf("expected one feed for the event, got %d", opened)
+	}
+
+	h.Unsubscribe(a)
+	if _, publish := f.state("event-1"); publish == nil {
+		t.Fatal("feed closed while the event still has a subscriber")
+	}
+	h.Unsubscribe(b)
+	waitFor(t, "the feed to close", func() bool {
+		_, publish := f.state("event-1")
+		return publish == nil
+	})
+}
+
+func TestFeedIsReopenedAfterFailure(t *testing.T) {
+	f := &fakeFeed{open: make(map[string]func(*pb.LiveStream)), failing: true}
+	h := NewHub(4, f.feed)
+
+	sub := h.Subscribe("event-1")
+	defer h.Unsubscribe(sub)
+
+	waitFor(t, "the feed to be retried", func() bool {
+		opened, _ := f.state("event-1")
+		return opened >= 2
+	})
+}
@@ -0,0 +1,175 @@
+// Package ical writes the event schedule as an iCalendar (RFC 5545) feed that
+// calendar apps can subscribe to.
+package ical
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+	_ "time/tzdata"
+	"unicode/utf8"
+)
+
+// TimeZone is the zone every event is scheduled in.
+const TimeZone = "Europe/Paris"
+
+// ContentType is the MIME type of a feed.
+const ContentType = "text/calendar; charset=utf-8"
+
+// vtimezone is the rule set calendar apps need to place Paris wall-clock times:
+// CEST from the last Sunday of March, CET from the last Sunday of October.
+const vtimezone = `BEGIN:VTIMEZONE
+TZID:Europe/Paris
+X-LIC-LOCATION:Europe/Paris
+BEGIN:DAYLIGHT
+TZOFFSETFROM:+0100
+TZOFFSETTO:+0200
+TZNAME:CEST
+DTSTART:19700329T020000
+RRULE:FREQ=YEARLY;BYMONTH=3;BYDAY=-1SU
+END:DAYLIGHT
+BEGIN:STANDARD
+TZOFFSETFROM:+0200
+TZOFFSETTO:+0100
+TZNAME:CET
+DTSTART:19701025T030000
+RRULE:FREQ=YEARLY;BYMONTH=10;BYDAY=-1SU
+END:STANDARD
+END:VTIMEZONE`
+
+// maxLine is the longest a content line may be, in octets, before it is
+// folded.
+const maxLine = 75
+
+// Location is the Europe/Paris time zone. time/tzdata is linked in, so it
+// resolves in images without a zoneinfo database too.
+var Location = mustLoadLocation(TimeZone)
+
+func mustLoadLocation(name string) *time.Location {
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		panic(err)
+	}
+	return loc
+}
+
+// Event is one VEVENT. UID must stay the same for the life of the event and
+// Sequence must grow with every revision, so subscribers replace their copy
+// instead of adding a second one.
+type Event struct {
+	UID         string
+	Sequence    int
+	Modified    time.Time
+	Start, End  time.Time
+	Summary     string
+	Location    string
+	Description string
+	Categories  []string
+	Cancelled   bool
+}
+
+// Writer streams a VCALENDAR one event at a time. Close must be called to
+// finish the calendar.
+type Writer struct {
+	w   *bufio.Writer
+	err error
+}
+
+// NewWriter writes the calendar header, named name, and the Europe/Paris
+// time zone definition.
+func NewWriter(w io.Writer, name string) *Writer {
+	cw := &Writer{w: bufio.NewWriter(w)}
+	cw.line("BEGIN:VCALENDAR")
+	cw.line("VERSION:2.0")
+	cw.line("PRODID:-//Paris 2024 Livestream//Event schedule//EN")
+	cw.line("CALSCALE:GREGORIAN")
+	cw.line("METHOD:PUBLISH")
+	cw.line("X-WR-CALNAME:" + Escape(name))
+	cw.line("X-WR-TIMEZONE:" + TimeZone)
+	for _, line := range strings.Split(vtimezone, "\n") {
+		cw.line(line)
+	}
+	return cw
+}
+
+// WriteEvent appends e to the calendar.
+func (cw *Writer) WriteEvent(e Event) error {
+	stamp := e.Modified.UTC().Format("20060102T150405Z")
+
+	cw.line("BEGIN:VEVENT")
+	cw.line("UID:" + Escape(e.UID))
+	cw.line("DTSTAMP:" + stamp)
+	cw.line("LAST-MODIFIED:" + stamp)
+	cw.line(fmt.Sprintf("SEQUENCE:%d", e.Sequence))
+	cw.line("DTSTART;TZID=" + TimeZone + ":" + e.Start.In(Location).Format("20060102T150405"))
+	cw.line("DTEND;TZID=" + TimeZone + ":" + e.End.In(Location).Format("20060102T150405"))
+	cw.line("SUMMARY:" + Escape(e.Summary))
+	if e.Location != "" {
+		cw.line("LOCATION:" + Escape(e.Location))
+	}
+	if e.Description != "" {
+		cw.line("DESCRIPTION:" + Escape(e.Description))
+	}
+	if len(e.Categories) > 0 {
+		categories := make([]string, len(e.Categories))
+		for i, category := range e.Categories {
+			categories[i] = Escape(category)
+		}
+		cw.line("CATEGORIES:" + strings.Join(categories, ","))
+	}
+	if e.Cancelled {
+		cw.line("STATUS:CANCELLED")
+	} else {
+		cw.line("STATUS:CONFIRMED")
+	}
+	cw.line("END:VEVENT")
+	return cw.err
+}
+
+// Flush pushes buffered events to the underlying writer.
+func (cw *Writer) Flush() error {
+	if cw.err != nil {
+		return cw.err
+	}
+	return cw.w.Flush()
+}
+
+// Close ends the calendar and flushes it.
+func (cw *Writer) Close() error {
+	cw.line("END:VCALENDAR")
+	return cw.Flush()
+}
+
+// line writes one content line, folded after 75 octets without splitting a
+// character, and terminated by CRLF.
+func (cw *Writer) line(s string) {
+	if cw.err != nil {
+		return
+	}
+	var b strings.Builder
+	limit := maxLine
+	for len(s) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(s[cut]) {
+			cut--
+		}
+		b.WriteString(s[:cut])
+		b.WriteString("\r\n ")
+		s = s[cut:]
+		// Continuation lines start with a space, which counts towards
+		// their length.
+		limit = maxLine - 1
+	}
+	b.WriteString(s)
+	b.WriteString("\r\n")
+	_, cw.err = cw.w.WriteString(b.String())
+}
+
+var escaper = strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`)
+
+// Escape quotes the characters that are special in TEXT values.
+func Escape(s string) string {
+	return escaper.Replace(s)
+}
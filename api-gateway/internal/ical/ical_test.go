@@ -0,0 +1,94 @@
+package ical
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWriteEvent(t *testing.T) {
+	var buf bytes.Buffer
+	w := NewWriter(&buf, "Athletics")
+	err := w.WriteEvent(Event{
+		UID:         "event-1@paris2024",
+		Sequence:    3,
+		Modified:    time.Date(2024, 8, 1, 9, 30, 0, 0, time.UTC),
+		Start:       time.Date(2024, 8, 4, 21, 50, 0, 0, Location),
+		End:         time.Date(2024, 8, 4, 22, 10, 0, 0, Location),
+		Summary:     "Men's 100m Final",
+		Location:    "Stade de France, Saint-Denis",
+		Description: "Status: delayed\nrain",
+		Categories:  []string{"Athletics"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	out := buf.String()
+
+	for _, want := range []string{
+		"BEGIN:VCALENDAR\r\n",
+		"X-WR-CALNAME:Athletics\r\n",
+		"TZID:Europe/Paris\r\n",
+		"UID:event-1@paris2024\r\n",
+		"DTSTAMP:20240801T093000Z\r\n",
+		"SEQUENCE:3\r\n",
+		"DTSTART;TZID=Europe/Paris:20240804T215000\r\n",
+		"DTEND;TZID=Europe/Paris:20240804T221000\r\n",
+		"LOCATION:Stade de France\\, Saint-Denis\r\n",
+		"DESCRIPTION:Status: delayed\\nrain\r\n",
+		"STATUS:CONFIRMED\r\n",
+		"END:VCALENDAR\r\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("calendar lacks %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestWriteEventInParisTime(t *testing.T) {
+	var buf bytes.Buffer
+	w := NewWriter(&buf, "")
+	// 19:50 UTC is 21:50 in Paris during summer time.
+	start := time.Date(2024, 8, 4, 19, 50, 0, 0, time.UTC)
+	if err := w.WriteEvent(Event{UID: "1", Start: start, End: start.Add(20 * time.Minute), Cancelled: true}); err != nil {
+		t.Fatal(err)
+	}
+	w.Close()
+
+	out := buf.String()
+	if !strings.Contains(out, "DTSTART;TZID=Europe/Paris:20240804T215000\r\n") {
+		t.Errorf("start not converted to Paris time:\n%s", out)
+	}
+	if !strings.Contains(out, "STATUS:CANCELLED\r\n") {
+		t.Errorf("cancelled event not marked:\n%s", out)
+	}
+}
+
+func TestFolding(t *testing.T) {
+	var buf bytes.Buffer
+	w := NewWriter(&buf, "")
+	summary := strings.Repeat("Épreuve ", 30)
+	if err := w.WriteEvent(Event{UID: "1", Summary: summary}); err != nil {
+		t.Fatal(err)
+	}
+	w.Close()
+
+	var unfolded strings.Builder
+	for i, line := range strings.Split(strings.TrimSuffix(buf.String(), "\r\n"), "\r\n") {
+		if len(line) > maxLine {
+			t.Errorf("line %d is %d octets long", i, len(line))
+		}
+		if strings.HasPrefix(line, " ") {
+			unfolded.WriteString(line[1:])
+			continue
+		}
+		unfolded.WriteString("\n" + line)
+	}
+	if !strings.Contains(unfolded.String(), "\nSUMMARY:"+summary+"\n") {
+		t.Errorf("summary does not survive unfolding:\n%s", unfolded.String())
+	}
+}
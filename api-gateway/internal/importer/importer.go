@@ -0,0 +1,159 @@
+package importer
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+type Format string
+
+const (
+	FormatCSV    Format = "csv"
+	FormatNDJSON Format = "ndjson"
+)
+
+// ErrTooManyRows is returned when an upload holds more records than allowed.
+var ErrTooManyRows = errors.New("too many rows")
+
+// Record is one row of an uploaded file. Line is the line it starts on, so
+// errors can be reported against what the user sees in their editor.
+type Record struct {
+	Line   int
+	Fields map[string]string
+	Err    error
+}
+
+// DetectFormat picks the upload format from an explicit format value or, when
+// that is empty, from the file extension.
+func DetectFormat(explicit, filename string) (Format, error) {
+	value := strings.ToLower(explicit)
+	if value == "" {
+		value = strings.TrimPrefix(strings.ToLower(filepath.Ext(filename)), ".")
+	}
+	switch value {
+	case "csv":
+		return FormatCSV, nil
+	case "ndjson", "jsonl", "json":
+		return FormatNDJSON, nil
+	}
+	return "", fmt.Errorf("unsupported import format %q: use csv or ndjson", value)
+}
+
+// Read decodes every record of r. A CSV file must start with a header row
+// naming the columns; NDJSON holds one flat JSON object per line. Malformed
+// NDJSON lines are returned as records with Err set so the rest of the file
+// is still validated.
+func Read(r io.Reader, format Format, maxRows int) ([]Record, error) {
+	switch format {
+	case FormatCSV:
+		return readCSV(r, maxRows)
+	case FormatNDJSON:
+		return readNDJSON(r, maxRows)
+	}
+	return nil, fmt.Errorf("unsupported import format %q", format)
+}
+
+func readCSV(r io.Reader, maxRows int) ([]Record, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+	reader.FieldsPerRecord = -1
+
+	header, err := reader.Read()
+	if err == io.EOF {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read csv header: %w", err)
+	}
+	for i := range header {
+		header[i] = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(header[i], "\ufeff")))
+	}
+
+	var records []Record
+	for {
+		row, err := reader.Read()
+		if err == io.EOF {
+			return records, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("read csv: %w", err)
+		}
+		if len(records) == maxRows {
+			return nil, ErrTooManyRows
+		}
+
+		line, _ := reader.FieldPos(0)
+		record := Record{Line: line, Fields: make(map[string]string, len(header))}
+		if len(row) != len(header) {
+			record.Err = fmt.Errorf("expected %d columns, got %d", len(header), len(row))
+		}
+		for i, value := range row {
+			if i < len(header) {
+				record.Fields[header[i]] = strings.TrimSpace(value)
+			}
+		}
+		records = append(records, record)
+	}
+}
+
+func readNDJSON(r io.Reader, maxRows int) ([]Record, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+
+	var records []Record
+	line := 0
+	for scanner.Scan() {
+		line++
+		text := bytes.TrimSpace(scanner.Bytes())
+		if len(text) == 0 {
+			continue
+		}
+		if len(records) == maxRows {
+			return nil, ErrTooManyRows
+		}
+		fields, err := decodeObject(text)
+		records = append(records, Record{Line: line, Fields: fields, Err: err})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("read ndjson: %w", err)
+	}
+	return records, nil
+}
+
+// decodeObject flattens a JSON object of scalars into strings, the same shape
+// a CSV row has.
+func decodeObject(data []byte) (map[string]string, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	var object map[string]interface{}
+	if err := decoder.Decode(&object); err != nil {
+		return nil, fmt.Errorf("invalid json: %w", err)
+	}
+
+	fields := make(map[string]string, len(object))
+	for key, value := range object {
+		key = strings.ToLower(key)
+		switch v := value.(type) {
+		case nil:
+			fields[key] = ""
+		case string:
+			fields[key] = strings.TrimSpace(v)
+		case json.Number:
+			fields[key] = v.String()
+		case bool:
+			fields[key] = strconv.FormatBool(v)
+		default:
+			return nil, fmt.Errorf("field %q must be a string or a number", key)
+		}
+	}
+	return fields, nil
+}
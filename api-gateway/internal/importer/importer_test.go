@@ -0,0 +1,73 @@
+package importer
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestReadCSV(t *testing.T) {
+	input := "name, flag ,region\nFrance,fr.png,Europe\nJapan,jp.png\n"
+
+	records, err := Read(strings.NewReader(input), FormatCSV, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(records) != 2 {
+		t.Fatalf("expected 2 records, got %d", len(records))
+	}
+	if records[0].Line != 2 || records[0].Fields["flag"] != "fr.png" || records[0].Err != nil {
+		t.Fatalf("unexpected first record: %+v", records[0])
+	}
+	if records[1].Line != 3 || records[1].Err == nil {
+		t.Fatalf("short row was not reported: %+v", records[1])
+	}
+}
+
+func TestReadNDJSON(t *testing.T) {
+	input := `{"name":"France","region":"Europe"}
+
+{"name":"Japan","type":2}
+{not json}
+{"name":["nested"]}
+`
+
+	records, err := Read(strings.NewReader(input), FormatNDJSON, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(records) != 4 {
+		t.Fatalf("expected 4 records, got %d", len(records))
+	}
+	if records[1].Line != 3 || records[1].Fields["type"] != "2" {
+		t.Fatalf("unexpected second record: %+v", records[1])
+	}
+	if records[2].Err == nil || records[3].Err == nil {
+		t.Fatal("malformed lines were not reported")
+	}
+}
+
+func TestReadRejectsTooManyRows(t *testing.T) {
+	_, err := Read(strings.NewReader("name\na\nb\nc\n"), FormatCSV, 2)
+	if !errors.Is(err, ErrTooManyRows) {
+		t.Fatalf("expected ErrTooManyRows, got %v", err)
+	}
+}
+
+func TestDetectFormat(t *testing.T) {
+	cases := map[[2]string]Format{
+		{"", "athletes.csv"}:      FormatCSV,
+		{"", "athletes.NDJSON"}:   FormatNDJSON,
+		{"ndjson", "upload.txt"}:  FormatNDJSON,
+		{"CSV", "athletes.jsonl"}: FormatCSV,
+	}
+	for in, want := range cases {
+		got, err := DetectFormat(in[0], in[1])
+		if err != nil || got != want {
+			t.Fatalf("DetectFormat(%q, %q) = %q, %v; want %q", in[0], in[1], got, err, want)
+		}
+	}
+	if _, err := DetectFormat("", "athletes.xml"); err == nil {
+		t.Fatal("expected an error for an unknown extension")
+	}
+}
@@ -1,23 +1,31 @@
 package athleteservice
 
 import (
+	"api-gateway/internal/pkg/downstream"
 	config "api-gateway/internal/pkg/load"
 	"fmt"
 
 	pb "github.com/Bekzodbekk/paris2024_livestream_protos/genproto/athletepb"
 
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
 )
 
 
-func DialWithAthleteService(cfg config.Config) (*pb.AthleteServiceClient, error) {
+func DialWithAthleteService(cfg config.Config, monitor *downstream.Monitor) (*pb.AthleteServiceClient, error) {
 
 	target := fmt.Sprintf("%s:%d", cfg.AthleteService.Host, cfg.AthleteService.Port)
-	conn, err := grpc.NewClient(target, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	conn, err := grpc.NewClient(target,
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithStatsHandler(otelgrpc.NewClientHandler()),
+		grpc.WithChainUnaryInterceptor(monitor.UnaryClientInterceptor("athlete-service")),
+		grpc.WithChainStreamInterceptor(monitor.StreamClientInterceptor("athlete-service")),
+	)
 	if err != nil {
 		return nil, err
 	}
+	monitor.Watch("athlete-service", conn)
 	clientService := pb.NewAthleteServiceClient(conn)
 	return &clientService, nil
 }
@@ -1,22 +1,30 @@
 package countryservice
 
 import (
+	"api-gateway/internal/pkg/downstream"
 	config "api-gateway/internal/pkg/load"
 	"fmt"
 
 	pb "github.com/Bekzodbekk/paris2024_livestream_protos/genproto/countrypb"
 
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
 )
 
-func DialWithCountryService(cfg config.Config) (*pb.CountryServiceClient, error) {
+func DialWithCountryService(cfg config.Config, monitor *downstream.Monitor) (*pb.CountryServiceClient, error) {
 
 	target := fmt.Sprintf("%s:%d", cfg.CountryService.Host, cfg.CountryService.Port)
-	conn, err := grpc.NewClient(target, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	conn, err := grpc.NewClient(target,
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithStatsHandler(otelgrpc.NewClientHandler()),
+		grpc.WithChainUnaryInterceptor(monitor.UnaryClientInterceptor("country-service")),
+		grpc.WithChainStreamInterceptor(monitor.StreamClientInterceptor("country-service")),
+	)
 	if err != nil {
 		return nil, err
 	}
+	monitor.Watch("country-service", conn)
 	clientService := pb.NewCountryServiceClient(conn)
 	return &clientService, nil
 }
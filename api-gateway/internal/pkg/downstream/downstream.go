@@ -0,0 +1,186 @@
+// Package downstream follows the grpc.health.v1 status of the services behind
+// the gateway, so that calls to a service known to be down fail straight
+// away instead of waiting on a broken connection.
+package downstream
+
+import (
+	"api-gateway/logger"
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// Delays between attempts to re-open a failed health watch.
+	minRetry = 1 * time.Second
+	maxRetry = 30 * time.Second
+)
+
+// Status values reported for a service. Services that have not answered yet
+// are Unknown; services whose health stream cannot be opened are Unreachable.
+const (
+	Serving     = "SERVING"
+	NotServing  = "NOT_SERVING"
+	Unknown     = "UNKNOWN"
+	Unreachable = "UNREACHABLE"
+)
+
+// healthService is the prefix of the health RPCs, which must get through
+// even while a service is marked down.
+const healthService = "/grpc.health.v1.Health/"
+
+type Monitor struct {
+	ctx    context.Context
+	cancel context.CancelFunc
+
+	mu       sync.RWMutex
+	statuses map[string]string
+}
+
+func NewMonitor() *Monitor {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &Monitor{
+		ctx:      ctx,
+		cancel:   cancel,
+		statuses: make(map[string]string),
+	}
+}
+
+// Close stops every watch.
+func (m *Monitor) Close() {
+	m.cancel()
+}
+
+// Watch follows the overall health of the service behind conn in the
+// background until the monitor is closed.
+func (m *Monitor) Watch(name string, conn grpc.ClientConnInterface) {
+	m.set(name, Unknown)
+	go m.watch(name, healthpb.NewHealthClient(conn))
+}
+
+func (m *Monitor) watch(name string, client healthpb.HealthClient) {
+	retry := minRetry
+	for {
+		stream, err := client.Watch(m.ctx, &healthpb.HealthCheckRequest{})
+		for err == nil {
+			var resp *healthpb.HealthCheckResponse
+			if resp, err = stream.Recv(); err == nil {
+				m.set(name, servingStatus(resp.Status))
+				retry = minRetry
+			}
+		}
+		if m.ctx.Err() != nil {
+			return
+		}
+
+		m.set(name, Unreachable)
+		logger.Warn("Health watch failed: ", logrus.Fields{
+			"service": name,
+			"error":   err,
+		})
+		select {
+		case <-m.ctx.Done():
+			return
+		case <-time.After(retry):
+		}
+		retry = min(retry*2, maxRetry)
+	}
+}
+
+func servingStatus(s healthpb.HealthCheckResponse_ServingStatus) string {
+	switch s {
+	case healthpb.HealthCheckResponse_SERVING:
+		return Serving
+	case healthpb.HealthCheckResponse_NOT_SERVING:
+		return NotServing
+	}
+	return Unknown
+}
+
+func (m *Monitor) set(name, status string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if old, ok := m.statuses[name]; ok && old != status {
+		logger.Info("Service health changed: ", logrus.Fields{
+			"service": name,
+			"from":    old,
+			"to":      status,
+		})
+	}
+	m.statuses[name] = status
+}
+
+// Status returns the last known status of the named service.
+func (m *Monitor) Status(name string) string {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	if s, ok := m.statuses[name]; ok {
+		return s
+	}
+	return Unknown
+}
+
+// Statuses returns the last known status of every watched service.
+func (m *Monitor) Statuses() map[string]string {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	statuses := make(map[string]string, len(m.statuses))
+	for name, s := range m.statuses {
+		statuses[name] = s
+	}
+	return statuses
+}
+
+// Ready reports whether every service in statuses is serving.
+func Ready(statuses map[string]string) bool {
+	for _, s := range statuses {
+		if s != Serving {
+			return false
+		}
+	}
+	return true
+}
+
+// check fails with Unavailable when the named service is known to be down.
+// A service that has not reported yet is given the benefit of the doubt.
+func (m *Monitor) check(name, method string) error {
+	if strings.HasPrefix(method, healthService) {
+		return nil
+	}
+	switch s := m.Status(name); s {
+	case NotServing, Unreachable:
+		return status.Errorf(codes.Unavailable, "%s is unavailable (%s)", name, strings.ToLower(s))
+	}
+	return nil
+}
+
+// UnaryClientInterceptor rejects calls to the named service while it is down.
+func (m *Monitor) UnaryClientInterceptor(name string) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		if err := m.check(name, method); err != nil {
+			return err
+		}
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+}
+
+// StreamClientInterceptor rejects streams to the named service while it is
+// down.
+func (m *Monitor) StreamClientInterceptor(name string) grpc.StreamClientInterceptor {
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		if err := m.check(name, method); err != nil {
+			return nil, err
+		}
+		return streamer(ctx, desc, cc, method, opts...)
+	}
+}
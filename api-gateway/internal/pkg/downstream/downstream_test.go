@@ -0,0 +1,113 @@
+package downstream
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// startHealthServer serves only grpc.health.v1 and returns a connection to it
+// that goes through the monitor's interceptors.
+func startHealthServer(t *testing.T, m *Monitor, name string) (*health.Server, *grpc.ClientConn) {
+	t.Helper()
+
+	listener := bufconn.Listen(1 << 20)
+	server := grpc.NewServer()
+	healthServer := health.NewServer()
+	healthpb.RegisterHealthServer(server, healthServer)
+	go server.Serve(listener)
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithChainUnaryInterceptor(m.UnaryClientInterceptor(name)),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return healthServer, conn
+}
+
+func waitForStatus(t *testing.T, m *Monitor, name, want string) {
+	t.Helper()
+
+	deadline := time.Now().Add(5 * time.Second)
+	for m.Status(name) != want {
+		if time.Now().After(deadline) {
+			t.Fatalf("%s: expected status %s, got %s", name, want, m.Status(name))
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestMonitorFollowsServingStatus(t *testing.T) {
+	m := NewMonitor()
+	defer m.Close()
+
+	healthServer, conn := startHealthServer(t, m, "athlete-service")
+	m.Watch("athlete-service", conn)
+	waitForStatus(t, m, "athlete-service", Serving)
+
+	if !Ready(m.Statuses()) {
+		t.Fatalf("expected ready, got %v", m.Statuses())
+	}
+
+	healthServer.SetServingStatus("", healthpb.HealthCheckResponse_NOT_SERVING)
+	waitForStatus(t, m, "athlete-service", NotServing)
+
+	if Ready(m.Statuses()) {
+		t.Fatal("expected not ready while a service is not serving")
+	}
+
+	healthServer.SetServingStatus("", healthpb.HealthCheckResponse_SERVING)
+	waitForStatus(t, m, "athlete-service", Serving)
+}
+
+func TestInterceptorFailsFastWhileDown(t *testing.T) {
+	m := NewMonitor()
+	defer m.Close()
+
+	healthServer, conn := startHealthServer(t, m, "medal-service")
+	m.Watch("medal-service", conn)
+	waitForStatus(t, m, "medal-service", Serving)
+
+	healthServer.SetServingStatus("", healthpb.HealthCheckResponse_NOT_SERVING)
+	waitForStatus(t, m, "medal-service", NotServing)
+
+	// Any RPC other than the health service is rejected before it is sent.
+	err := conn.Invoke(context.Background(), "/medalspb.MedalService/GetMedals", &healthpb.HealthCheckRequest{}, &healthpb.HealthCheckResponse{})
+	if status.Code(err) != codes.Unavailable {
+		t.Fatalf("expected Unavailable, got %v", err)
+	}
+
+	// The health service itself still gets through.
+	_, err = healthpb.NewHealthClient(conn).Check(context.Background(), &healthpb.HealthCheckRequest{})
+	if err != nil {
+		t.Fatalf("health check was rejected: %v", err)
+	}
+}
+
+func TestUnwatchedServiceIsNotRejected(t *testing.T) {
+	m := NewMonitor()
+	defer m.Close()
+
+	if err := m.check("user-service", "/userpb.UserService/Login"); err != nil {
+		t.Fatalf("expected unknown service to be let through, got %v", err)
+	}
+	if got := m.Status("user-service"); got != Unknown {
+		t.Fatalf("expected %s, got %s", Unknown, got)
+	}
+}
@@ -1,22 +1,30 @@
 package eventservice
 
 import (
+	"api-gateway/internal/pkg/downstream"
 	config "api-gateway/internal/pkg/load"
 	"fmt"
 
 	pb "github.com/Bekzodbekk/paris2024_livestream_protos/genproto/eventpb"
 
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
 )
 
-func DialWithEventService(cfg config.Config) (*pb.EventServiceClient, error) {
+func DialWithEventService(cfg config.Config, monitor *downstream.Monitor) (*pb.EventServiceClient, error) {
 
 	target := fmt.Sprintf("%s:%d", cfg.EventService.Host, cfg.EventService.Port)
-	conn, err := grpc.NewClient(target, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	conn, err := grpc.NewClient(target,
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithStatsHandler(otelgrpc.NewClientHandler()),
+		grpc.WithChainUnaryInterceptor(monitor.UnaryClientInterceptor("event-service")),
+		grpc.WithChainStreamInterceptor(monitor.StreamClientInterceptor("event-service")),
+	)
 	if err != nil {
 		return nil, err
 	}
+	monitor.Watch("event-service", conn)
 	clientService := pb.NewEventServiceClient(conn)
 	return &clientService, nil
 }
@@ -0,0 +1,156 @@
+// Package jwks fetches the public keys user-service signs tokens with, so
+// the gateway can verify RS256 and EdDSA tokens without a shared secret.
+package jwks
+
+import (
+	"api-gateway/logger"
+	"context"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// minRefetch spaces out the fetches triggered by unknown kids, so a flood of
+// forged tokens cannot turn into a flood of requests to user-service.
+const minRefetch = 10 * time.Second
+
+// ErrUnknownKey is returned for a kid that is not in the key set.
+var ErrUnknownKey = errors.New("unknown signing key")
+
+type jwk struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Alg string `json:"alg"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+}
+
+type key struct {
+	alg    string
+	public interface{}
+}
+
+// Keyset caches the JWKS document published at url. It is refetched once it
+// is older than maxAge, so retired keys stop verifying, and when a token
+// names a kid it does not know, so new keys are picked up straight away.
+type Keyset struct {
+	url    string
+	maxAge time.Duration
+	client *http.Client
+
+	mu      sync.Mutex
+	keys    map[string]key
+	fetched time.Time
+}
+
+func NewKeyset(url string, maxAge time.Duration) *Keyset {
+	return &Keyset{
+		url:    url,
+		maxAge: maxAge,
+		client: &http.Client{Timeout: 5 * time.Second},
+	}
+}
+
+// Key returns the public key with the given kid, provided it is meant for
+// alg. If the key set cannot be refreshed the cached keys keep being used.
+func (k *Keyset) Key(ctx context.Context, kid, alg string) (interface{}, error) {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	since := time.Since(k.fetched)
+	_, known := k.keys[kid]
+	if k.fetched.IsZero() || since > k.maxAge || (!known && since > minRefetch) {
+		if err := k.fetch(ctx); err != nil {
+			logger.WarnContext(ctx, "Failed to fetch signing keys: ", logrus.Fields{
+				"url":   k.url,
+				"error": err,
+			})
+		}
+	}
+
+	key, ok := k.keys[kid]
+	if !ok {
+		return nil, fmt.Errorf("%w %q", ErrUnknownKey, kid)
+	}
+	if key.alg != alg {
+		return nil, fmt.Errorf("key %s does not sign %s", kid, alg)
+	}
+	return key.public, nil
+}
+
+func (k *Keyset) fetch(ctx context.Context) error {
+	// Failed attempts count too, so an unreachable user-service is not
+	// asked again on every request.
+	k.fetched = time.Now()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, k.url, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := k.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status %s", resp.Status)
+	}
+
+	var set struct {
+		Keys []jwk `json:"keys"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&set); err != nil {
+		return err
+	}
+
+	keys := make(map[string]key, len(set.Keys))
+	for _, jwk := range set.Keys {
+		public, err := jwk.publicKey()
+		if err != nil {
+			logger.WarnContext(ctx, "Skipping signing key: ", logrus.Fields{
+				"kid":   jwk.Kid,
+				"error": err,
+			})
+			continue
+		}
+		keys[jwk.Kid] = key{alg: jwk.Alg, public: public}
+	}
+	k.keys = keys
+	return nil
+}
+
+func (j jwk) publicKey() (interface{}, error) {
+	switch {
+	case j.Kty == "RSA" && j.Alg == "RS256":
+		n, err := base64.RawURLEncoding.DecodeString(j.N)
+		if err != nil {
+			return nil, err
+		}
+		e, err := base64.RawURLEncoding.DecodeString(j.E)
+		if err != nil {
+			return nil, err
+		}
+		return &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(new(big.Int).SetBytes(e).Int64())}, nil
+	case j.Kty == "OKP" && j.Crv == "Ed25519" && j.Alg == "EdDSA":
+		x, err := base64.RawURLEncoding.DecodeString(j.X)
+		if err != nil {
+			return nil, err
+		}
+		if len(x) != ed25519.PublicKeySize {
+			return nil, errors.New("bad Ed25519 key size")
+		}
+		return ed25519.PublicKey(x), nil
+	}
+	return nil, fmt.Errorf("unsupported key type %s/%s", j.Kty, j.Alg)
+}
@@ -0,0 +1,95 @@
+package jwks
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// server publishes whatever keys holds and counts the requests.
+type server struct {
+	keys     atomic.Value
+	requests atomic.Int32
+}
+
+func (s *server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.requests.Add(1)
+	json.NewEncoder(w).Encode(map[string][]jwk{"keys": s.keys.Load().([]jwk)})
+}
+
+func edKey(t *testing.T, kid string) (jwk, ed25519.PublicKey) {
+	public, _, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return jwk{Kty: "OKP", Crv: "Ed25519", Alg: "EdDSA", Kid: kid, X: base64.RawURLEncoding.EncodeToString(public)}, public
+}
+
+func TestKeyset(t *testing.T) {
+	k1, public := edKey(t, "k1")
+	srv := &server{}
+	srv.keys.Store([]jwk{k1})
+	ts := httptest.NewServer(srv)
+	defer ts.Close()
+
+	keys := NewKeyset(ts.URL, time.Hour)
+	ctx := context.Background()
+
+	key, err := keys.Key(ctx, "k1", "EdDSA")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !public.Equal(key) {
+		t.Error("got a different key")
+	}
+	if _, err := keys.Key(ctx, "k1", "RS256"); err == nil {
+		t.Error("key accepted for the wrong algorithm")
+	}
+	if _, err := keys.Key(ctx, "k1", "EdDSA"); err != nil {
+		t.Fatal(err)
+	}
+	if n := srv.requests.Load(); n != 1 {
+		t.Errorf("%d fetches, want the key set to be cached", n)
+	}
+
+	// An unknown kid is refetched, but not more than once per minRefetch.
+	if _, err := keys.Key(ctx, "k2", "EdDSA"); !errors.Is(err, ErrUnknownKey) {
+		t.Errorf("got %v, want ErrUnknownKey", err)
+	}
+	if n := srv.requests.Load(); n != 1 {
+		t.Errorf("%d fetches, want unknown kids to be throttled", n)
+	}
+
+	k2, _ := edKey(t, "k2")
+	srv.keys.Store([]jwk{k1, k2})
+	keys.fetched = time.Now().Add(-minRefetch - time.Second)
+	if _, err := keys.Key(ctx, "k2", "EdDSA"); err != nil {
+		t.Errorf("rotated-in key: %v", err)
+	}
+}
+
+func TestKeysetKeepsKeysWhenUnreachable(t *testing.T) {
+	k1, _ := edKey(t, "k1")
+	srv := &server{}
+	srv.keys.Store([]jwk{k1})
+	ts := httptest.NewServer(srv)
+
+	keys := NewKeyset(ts.URL, time.Minute)
+	if _, err := keys.Key(context.Background(), "k1", "EdDSA"); err != nil {
+		t.Fatal(err)
+	}
+
+	ts.Close()
+	keys.fetched = time.Now().Add(-time.Hour)
+	if _, err := keys.Key(context.Background(), "k1", "EdDSA"); err != nil {
+		t.Errorf("cached key dropped after a failed refresh: %v", err)
+	}
+}
@@ -1,22 +1,30 @@
 package eventservice
 
 import (
+	"api-gateway/internal/pkg/downstream"
 	config "api-gateway/internal/pkg/load"
 	"fmt"
 
 	pb "github.com/Bekzodbekk/paris2024_livestream_protos/genproto/livepb"
 
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
 )
 
-func DialWithLiveService(cfg config.Config) (*pb.LiveStreamServiceClient, error) {
+func DialWithLiveService(cfg config.Config, monitor *downstream.Monitor) (*pb.LiveStreamServiceClient, error) {
 
 	target := fmt.Sprintf("%s:%d", cfg.LiveService.Host, cfg.LiveService.Port)
-	conn, err := grpc.NewClient(target, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	conn, err := grpc.NewClient(target,
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithStatsHandler(otelgrpc.NewClientHandler()),
+		grpc.WithChainUnaryInterceptor(monitor.UnaryClientInterceptor("live-service")),
+		grpc.WithChainStreamInterceptor(monitor.StreamClientInterceptor("live-service")),
+	)
 	if err != nil {
 		return nil, err
 	}
+	monitor.Watch("live-service", conn)
 	clientService := pb.NewLiveStreamServiceClient(conn)
 	return &clientService, nil
 }
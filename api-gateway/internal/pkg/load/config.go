@@ -1,12 +1,70 @@
 package load
 
-import "github.com/spf13/viper"
+import (
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+// defaultTimeout bounds calls to a service whose timeout is not configured.
+const defaultTimeout = 5 * time.Second
+
+// defaultImportTimeout bounds bulk imports, which validate and write every
+// row in one call.
+const defaultImportTimeout = 2 * time.Minute
 
 type ServiceConfig struct {
+	Host    string
+	Port    int
+	Timeout time.Duration
+}
+
+// JWTConfig says how tokens from user-service are verified: RS256 and EdDSA
+// tokens with the keys published at JWKSURL, refetched every JWKSRefresh,
+// and HS256 tokens with Secret. Either may be left empty to refuse those
+// algorithms.
+type JWTConfig struct {
+	Issuer      string
+	Secret      string
+	JWKSURL     string
+	JWKSRefresh time.Duration
+}
+
+// TracingConfig selects where spans are exported: otlp (a collector listening
+// on Endpoint), stdout or none.
+type TracingConfig struct {
+	Exporter    string
+	Endpoint    string
+	SampleRatio float64
+}
+
+type RedisConfig struct {
 	Host string
 	Port int
 }
 
+// RateLimitPolicy allows Rate requests per Period, with bursts of up to
+// Burst requests.
+type RateLimitPolicy struct {
+	Rate   int
+	Period time.Duration
+	Burst  int
+}
+
+// RateLimitConfig holds the policy of each route group, and overrides for
+// authenticated callers by role and by user id, each keyed by group.
+type RateLimitConfig struct {
+	Groups map[string]RateLimitPolicy
+	Roles  map[string]map[string]RateLimitPolicy
+	Users  map[string]map[string]RateLimitPolicy
+}
+
+// MetricsConfig controls the Prometheus endpoint served on the gateway port.
+type MetricsConfig struct {
+	Enabled bool
+	Path    string
+}
+
 type Config struct {
 	ServerHost     string
 	ServerPort     int
@@ -16,6 +74,12 @@ type Config struct {
 	EventService   ServiceConfig
 	AthleteService ServiceConfig
 	LiveService    ServiceConfig
+	ImportTimeout  time.Duration
+	JWT            JWTConfig
+	Tracing        TracingConfig
+	Metrics        MetricsConfig
+	Redis          RedisConfig
+	RateLimit      RateLimitConfig
 }
 
 func Load(path string) (*Config, error) {
@@ -23,6 +87,11 @@ func Load(path string) (*Config, error) {
 	viper.SetConfigType("yaml")
 	viper.AutomaticEnv()
 
+	viper.SetDefault("tracing.exporter", "none")
+	viper.SetDefault("tracing.sample_ratio", 1.0)
+	viper.SetDefault("metrics.path", "/metrics")
+	viper.SetDefault("jwt.jwks_refresh", "10m")
+
 	if err := viper.ReadInConfig(); err != nil {
 		return nil, err
 	}
@@ -32,29 +101,69 @@ func Load(path string) (*Config, error) {
 		ServerPort: viper.GetInt("server.port"),
 
 		UserService: ServiceConfig{
-			Host: viper.GetString("services.user_service.host"),
-			Port: viper.GetInt("services.user_service.port"),
+			Host:    viper.GetString("services.user_service.host"),
+			Port:    viper.GetInt("services.user_service.port"),
+			Timeout: durationOr(viper.GetDuration("services.user_service.timeout"), defaultTimeout),
 		},
 		MedalService: ServiceConfig{
-			Host: viper.GetString("services.medal_service.host"),
-			Port: viper.GetInt("services.medal_service.port"),
+			Host:    viper.GetString("services.medal_service.host"),
+			Port:    viper.GetInt("services.medal_service.port"),
+			Timeout: durationOr(viper.GetDuration("services.medal_service.timeout"), defaultTimeout),
 		},
 		CountryService: ServiceConfig{
-			Host: viper.GetString("services.country_service.host"),
-			Port: viper.GetInt("services.country_service.port"),
+			Host:    viper.GetString("services.country_service.host"),
+			Port:    viper.GetInt("services.country_service.port"),
+			Timeout: durationOr(viper.GetDuration("services.country_service.timeout"), defaultTimeout),
 		},
 		EventService: ServiceConfig{
-			Host: viper.GetString("services.event_service.host"),
-			Port: viper.GetInt("services.event_service.port"),
+			Host:    viper.GetString("services.event_service.host"),
+			Port:    viper.GetInt("services.event_service.port"),
+			Timeout: durationOr(viper.GetDuration("services.event_service.timeout"), defaultTimeout),
 		},
 		AthleteService: ServiceConfig{
-			Host: viper.GetString("services.athlete_service.host"),
-			Port: viper.GetInt("services.athlete_service.port"),
+			Host:    viper.GetString("services.athlete_service.host"),
+			Port:    viper.GetInt("services.athlete_service.port"),
+			Timeout: durationOr(viper.GetDuration("services.athlete_service.timeout"), defaultTimeout),
 		},
 		LiveService: ServiceConfig{
-			Host: viper.GetString("services.live_service.host"),
-			Port: viper.GetInt("services.live_service.port"),
+			Host:    viper.GetString("services.live_service.host"),
+			Port:    viper.GetInt("services.live_service.port"),
+			Timeout: durationOr(viper.GetDuration("services.live_service.timeout"), defaultTimeout),
+		},
+		ImportTimeout: durationOr(viper.GetDuration("services.import_timeout"), defaultImportTimeout),
+
+		JWT: JWTConfig{
+			Issuer:      viper.GetString("jwt.issuer"),
+			Secret:      viper.GetString("jwt.secret"),
+			JWKSURL:     viper.GetString("jwt.jwks_url"),
+			JWKSRefresh: viper.GetDuration("jwt.jwks_refresh"),
+		},
+
+		Tracing: TracingConfig{
+			Exporter:    viper.GetString("tracing.exporter"),
+			Endpoint:    viper.GetString("tracing.endpoint"),
+			SampleRatio: viper.GetFloat64("tracing.sample_ratio"),
+		},
+
+		Metrics: MetricsConfig{
+			Enabled: viper.GetBool("metrics.enabled"),
+			Path:    viper.GetString("metrics.path"),
 		},
+
+		Redis: RedisConfig{
+			Host: viper.GetString("redis.host"),
+			Port: viper.GetInt("redis.port"),
+		},
+	}
+	if err := viper.UnmarshalKey("rate_limit", &cfg.RateLimit); err != nil {
+		return nil, err
 	}
 	return &cfg, nil
 }
+
+func durationOr(d, fallback time.Duration) time.Duration {
+	if d <= 0 {
+		return fallback
+	}
+	return d
+}
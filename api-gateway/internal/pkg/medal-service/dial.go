@@ -1,21 +1,29 @@
 package medalservice
 
 import (
+	"api-gateway/internal/pkg/downstream"
 	config "api-gateway/internal/pkg/load"
 	"fmt"
 
 	pb "github.com/Bekzodbekk/paris2024_livestream_protos/genproto/medalspb"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
 )
 
-func DialWithMedalService(cfg config.Config) (*pb.MedalServiceClient, error) {
+func DialWithMedalService(cfg config.Config, monitor *downstream.Monitor) (*pb.MedalServiceClient, error) {
 
 	target := fmt.Sprintf("%s:%d", cfg.MedalService.Host, cfg.MedalService.Port)
-	conn, err := grpc.NewClient(target, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	conn, err := grpc.NewClient(target,
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithStatsHandler(otelgrpc.NewClientHandler()),
+		grpc.WithChainUnaryInterceptor(monitor.UnaryClientInterceptor("medal-service")),
+		grpc.WithChainStreamInterceptor(monitor.StreamClientInterceptor("medal-service")),
+	)
 	if err != nil {
 		return nil, err
 	}
+	monitor.Watch("medal-service", conn)
 	clientService := pb.NewMedalServiceClient(conn)
 	return &clientService, nil
 }
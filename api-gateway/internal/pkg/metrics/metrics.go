@@ -0,0 +1,55 @@
+// Package metrics holds the gateway's Prometheus metrics. They are served on
+// the gateway's own port under the configured path.
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	// Requests counts finished requests by route template, method and
+	// status code.
+	Requests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "http_requests_total",
+		Help: "HTTP requests handled, by route, method and status code.",
+	}, []string{"route", "method", "code"})
+
+	// RequestDuration times requests by route template and method.
+	RequestDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "http_request_duration_seconds",
+		Help:    "Time taken to handle an HTTP request, by route and method.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"route", "method"})
+
+	// InFlight is the number of requests being handled per route template.
+	InFlight = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "http_requests_in_flight",
+		Help: "HTTP requests currently being handled, by route.",
+	}, []string{"route"})
+
+	// RateLimited counts the requests rejected by the rate limiter.
+	RateLimited = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "http_rate_limited_total",
+		Help: "HTTP requests rejected by the rate limiter, by route.",
+	}, []string{"route"})
+
+	// LiveSubscribers is the number of clients following a live stream, by
+	// transport (websocket or sse).
+	LiveSubscribers = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "live_subscribers",
+		Help: "Clients subscribed to a live stream, by transport.",
+	}, []string{"transport"})
+)
+
+func init() {
+	prometheus.MustRegister(Requests, RequestDuration, InFlight, RateLimited, LiveSubscribers)
+}
+
+// Route is the route template of a request, such as /medals/:id, so that
+// the metrics are not split per id. Unmatched requests share one label.
+func Route(fullPath string) string {
+	if fullPath == "" {
+		return "unmatched"
+	}
+	return fullPath
+}
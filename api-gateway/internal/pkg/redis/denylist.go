@@ -0,0 +1,30 @@
+package redis
+
+import (
+	"context"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// denylistPrefix must match the keys user-service writes when it revokes a
+// session.
+const denylistPrefix = "auth:denylist:"
+
+// Denylist reads the access tokens user-service has revoked.
+type Denylist struct {
+	rdb redis.Cmdable
+}
+
+func NewDenylist(rdb redis.Cmdable) *Denylist {
+	return &Denylist{rdb: rdb}
+}
+
+// Revoked reports whether the token with the given jti was revoked. Entries
+// expire together with their token.
+func (d *Denylist) Revoked(ctx context.Context, jti string) (bool, error) {
+	n, err := d.rdb.Exists(ctx, denylistPrefix+jti).Result()
+	if err != nil {
+		return false, err
+	}
+	return n > 0, nil
+}
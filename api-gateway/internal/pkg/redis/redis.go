@@ -0,0 +1,24 @@
+package redis
+
+import (
+	config "api-gateway/internal/pkg/load"
+	"fmt"
+
+	"github.com/redis/go-redis/extra/redisotel/v9"
+	"github.com/redis/go-redis/v9"
+)
+
+// ConnectRedis returns a client for the shared rate limit store and the
+// access token denylist. The connection is made lazily, so a Redis that is
+// down at startup only means the limits are kept in memory, and revoked
+// tokens accepted, until it comes back.
+func ConnectRedis(cfg config.Config) (*redis.Client, error) {
+	target := fmt.Sprintf("%s:%d", cfg.Redis.Host, cfg.Redis.Port)
+	rdb := redis.NewClient(&redis.Options{
+		Addr: target,
+	})
+	if err := redisotel.InstrumentTracing(rdb); err != nil {
+		return nil, err
+	}
+	return rdb, nil
+}
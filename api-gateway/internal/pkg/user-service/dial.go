@@ -1,21 +1,29 @@
 package userservice
 
 import (
+	"api-gateway/internal/pkg/downstream"
 	config "api-gateway/internal/pkg/load"
 	"fmt"
 
 	pb "github.com/Bekzodbekk/paris2024_livestream_protos/genproto/userpb"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
 )
 
-func DialWithUserService(cfg config.Config) (*pb.UserServiceClient, error) {
+func DialWithUserService(cfg config.Config, monitor *downstream.Monitor) (*pb.UserServiceClient, error) {
 
 	target := fmt.Sprintf("%s:%d", cfg.UserService.Host, cfg.UserService.Port)
-	conn, err := grpc.NewClient(target, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	conn, err := grpc.NewClient(target,
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithStatsHandler(otelgrpc.NewClientHandler()),
+		grpc.WithChainUnaryInterceptor(monitor.UnaryClientInterceptor("user-service")),
+		grpc.WithChainStreamInterceptor(monitor.StreamClientInterceptor("user-service")),
+	)
 	if err != nil {
 		return nil, err
 	}
+	monitor.Watch("user-service", conn)
 	clientService := pb.NewUserServiceClient(conn)
 	return &clientService, nil
 }
@@ -0,0 +1,38 @@
+package ratelimit
+
+import (
+	"api-gateway/logger"
+	"context"
+	"sync/atomic"
+)
+
+// FallbackStore uses primary and switches to secondary for as long as
+// primary fails, so an unreachable Redis degrades to per-replica limits
+// instead of failing every request.
+type FallbackStore struct {
+	primary   Store
+	secondary Store
+	failing   atomic.Bool
+}
+
+func NewFallbackStore(primary, secondary Store) *FallbackStore {
+	return &FallbackStore{
+		primary:   primary,
+		secondary: secondary,
+	}
+}
+
+func (s *FallbackStore) Allow(ctx context.Context, key string, limit Limit) (Result, error) {
+	result, err := s.primary.Allow(ctx, key, limit)
+	if err == nil {
+		if s.failing.CompareAndSwap(true, false) {
+			logger.InfoContext(ctx, "Rate limit store recovered")
+		}
+		return result, nil
+	}
+
+	if s.failing.CompareAndSwap(false, true) {
+		logger.WarnContext(ctx, "Rate limit store failed, falling back to memory: ", err)
+	}
+	return s.secondary.Allow(ctx, key, limit)
+}
@@ -0,0 +1,60 @@
+package ratelimit
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// sweepInterval is how often the memory store drops expired keys.
+const sweepInterval = time.Minute
+
+// MemoryStore keeps the state of every key in process. A key expires once
+// its tat has passed, since from then on it behaves like a fresh key, so
+// memory is bounded by the number of clients seen within one burst window.
+type MemoryStore struct {
+	now func() time.Time
+
+	mu        sync.Mutex
+	tats      map[string]time.Time
+	lastSweep time.Time
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		now:  time.Now,
+		tats: make(map[string]time.Time),
+	}
+}
+
+func (s *MemoryStore) Allow(_ context.Context, key string, limit Limit) (Result, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	if now.Sub(s.lastSweep) >= sweepInterval {
+		s.sweep(now)
+	}
+
+	tat, result := gcra(s.tats[key], now, limit)
+	if result.Allowed {
+		s.tats[key] = tat
+	}
+	return result, nil
+}
+
+// Len returns the number of keys held.
+func (s *MemoryStore) Len() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.tats)
+}
+
+func (s *MemoryStore) sweep(now time.Time) {
+	for key, tat := range s.tats {
+		if !tat.After(now) {
+			delete(s.tats, key)
+		}
+	}
+	s.lastSweep = now
+}
@@ -0,0 +1,61 @@
+package ratelimit
+
+import (
+	config "api-gateway/internal/pkg/load"
+)
+
+// DefaultGroup holds the limits of route groups that have none of their own.
+const DefaultGroup = "default"
+
+// Policies holds the limits per route group, and the overrides per role and
+// per user id, each keyed by group.
+type Policies struct {
+	Groups map[string]Limit
+	Roles  map[string]map[string]Limit
+	Users  map[string]map[string]Limit
+}
+
+// PoliciesFromConfig converts the rate_limit section of the config.
+func PoliciesFromConfig(cfg config.RateLimitConfig) Policies {
+	overrides := func(in map[string]map[string]config.RateLimitPolicy) map[string]map[string]Limit {
+		out := make(map[string]map[string]Limit, len(in))
+		for name, groups := range in {
+			out[name] = limits(groups)
+		}
+		return out
+	}
+	return Policies{
+		Groups: limits(cfg.Groups),
+		Roles:  overrides(cfg.Roles),
+		Users:  overrides(cfg.Users),
+	}
+}
+
+func limits(in map[string]config.RateLimitPolicy) map[string]Limit {
+	out := make(map[string]Limit, len(in))
+	for group, p := range in {
+		out[group] = Limit{Rate: p.Rate, Period: p.Period, Burst: p.Burst}
+	}
+	return out
+}
+
+// Lookup returns the limit for a request to group: the user's own, then
+// the role's, then the group's. When group has none the default group is
+// searched the same way. The group whose limit was chosen is returned too,
+// since that is the quota the request is counted against. ok is false when
+// no limit applies.
+func (p Policies) Lookup(group, userID, role string) (limit Limit, matched string, ok bool) {
+	for _, g := range []string{group, DefaultGroup} {
+		candidates := []Limit{
+			p.Users[userID][g],
+			p.Roles[role][g],
+			p.Groups[g],
+		}
+		for _, l := range candidates {
+			if !l.IsZero() {
+				return l, g, true
+			}
+		}
+	}
+	return Limit{}, "", false
+}
@@ -0,0 +1,90 @@
+// Package ratelimit implements the generic cell rate algorithm (GCRA) over a
+// shared Redis store, with an in-memory store to fall back on when Redis is
+// unreachable.
+//
+// GCRA keeps a single timestamp per key, the theoretical arrival time (TAT)
+// of the next request, so a key costs the same whatever its limit is.
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+)
+
+// Limit allows Rate requests per Period, with bursts of up to Burst
+// requests.
+type Limit struct {
+	Rate   int
+	Period time.Duration
+	Burst  int
+}
+
+// IsZero reports whether the limit is unset.
+func (l Limit) IsZero() bool {
+	return l.Rate <= 0 || l.Period <= 0
+}
+
+func (l Limit) emissionInterval() time.Duration {
+	return l.Period / time.Duration(l.Rate)
+}
+
+// Capacity is the most requests allowed at once.
+func (l Limit) Capacity() int {
+	if l.Burst < 1 {
+		return 1
+	}
+	return l.Burst
+}
+
+// String formats the limit as a RateLimit-Policy value: the quota and its
+// window in whole seconds.
+func (l Limit) String() string {
+	return fmt.Sprintf("%d;w=%d", l.Rate, int(math.Ceil(l.Period.Seconds())))
+}
+
+// Result is the outcome of one request against a limit.
+type Result struct {
+	Allowed bool
+	// Remaining is how many more requests would be allowed right now.
+	Remaining int
+	// RetryAfter is how long a rejected request should wait. Zero when the
+	// request was allowed.
+	RetryAfter time.Duration
+	// ResetAfter is how long until the full burst is available again.
+	ResetAfter time.Duration
+}
+
+// Store counts requests per key.
+type Store interface {
+	Allow(ctx context.Context, key string, limit Limit) (Result, error)
+}
+
+// gcra applies one request arriving at now to the stored tat. It returns the
+// result and, when the request is allowed, the tat to store.
+func gcra(tat, now time.Time, limit Limit) (time.Time, Result) {
+	emission := limit.emissionInterval()
+	burstOffset := emission * time.Duration(limit.Capacity())
+
+	if tat.Before(now) {
+		tat = now
+	}
+	newTat := tat.Add(emission)
+	allowAt := newTat.Add(-burstOffset)
+	diff := now.Sub(allowAt)
+
+	if diff < 0 {
+		return tat, Result{
+			Allowed:    false,
+			Remaining:  0,
+			RetryAfter: -diff,
+			ResetAfter: tat.Sub(now),
+		}
+	}
+	return newTat, Result{
+		Allowed:    true,
+		Remaining:  int(diff / emission),
+		ResetAfter: newTat.Sub(now),
+	}
+}
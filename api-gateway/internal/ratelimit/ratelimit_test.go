@@ -0,0 +1,179 @@
+package ratelimit
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+)
+
+var perMinute = Limit{Rate: 1, Period: time.Minute, Burst: 3}
+
+type clock struct{ now time.Time }
+
+func (c *clock) Now() time.Time { return c.now }
+
+func newMemoryStore() (*MemoryStore, *clock) {
+	c := &clock{now: time.Date(2024, 7, 26, 20, 0, 0, 0, time.UTC)}
+	s := NewMemoryStore()
+	s.now = c.Now
+	return s, c
+}
+
+func allow(t *testing.T, s Store, key string, limit Limit) Result {
+	t.Helper()
+	result, err := s.Allow(context.Background(), key, limit)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return result
+}
+
+func TestMemoryStoreAllowsBurstThenRejects(t *testing.T) {
+	s, _ := newMemoryStore()
+
+	for want := 2; want >= 0; want-- {
+		result := allow(t, s, "k", perMinute)
+		if !result.Allowed || result.Remaining != want {
+			t.Fatalf("expected allowed with %d remaining, got %+v", want, result)
+		}
+	}
+
+	result := allow(t, s, "k", perMinute)
+	if result.Allowed {
+		t.Fatal("expected the request after the burst to be rejected")
+	}
+	if result.RetryAfter != time.Minute {
+		t.Fatalf("expected to retry after a minute, got %v", result.RetryAfter)
+	}
+	if result.ResetAfter != 3*time.Minute {
+		t.Fatalf("expected the burst back after 3 minutes, got %v", result.ResetAfter)
+	}
+
+	if other := allow(t, s, "other", perMinute); !other.Allowed {
+		t.Fatal("keys must not share a quota")
+	}
+}
+
+func TestMemoryStoreRefillsAtRate(t *testing.T) {
+	s, c := newMemoryStore()
+
+	for i := 0; i < 3; i++ {
+		allow(t, s, "k", perMinute)
+	}
+	c.now = c.now.Add(time.Minute)
+
+	if result := allow(t, s, "k", perMinute); !result.Allowed || result.Remaining != 0 {
+		t.Fatalf("expected one request back after a period, got %+v", result)
+	}
+	if result := allow(t, s, "k", perMinute); result.Allowed {
+		t.Fatal("expected only one request back after a period")
+	}
+}
+
+func TestMemoryStoreEvictsExpiredKeys(t *testing.T) {
+	s, c := newMemoryStore()
+
+	allow(t, s, "a", perMinute)
+	allow(t, s, "b", Limit{Rate: 1, Period: time.Hour})
+	if s.Len() != 2 {
+		t.Fatalf("expected 2 keys, got %d", s.Len())
+	}
+
+	c.now = c.now.Add(2 * time.Minute)
+	allow(t, s, "c", perMinute)
+	if s.Len() != 2 {
+		t.Fatalf("expected the expired key to be dropped, got %d keys", s.Len())
+	}
+	if _, ok := s.tats["a"]; ok {
+		t.Fatal("expected key a to be evicted")
+	}
+}
+
+func TestRedisStoreSharesQuota(t *testing.T) {
+	mr := miniredis.RunT(t)
+	rdb := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	defer rdb.Close()
+
+	// Two replicas pointing at the same Redis.
+	a := NewRedisStore(rdb, "ratelimit:")
+	b := NewRedisStore(rdb, "ratelimit:")
+
+	for i, s := range []Store{a, b, a} {
+		if result := allow(t, s, "user:1", perMinute); !result.Allowed || result.Remaining != 2-i {
+			t.Fatalf("request %d: expected allowed with %d remaining, got %+v", i, 2-i, result)
+		}
+	}
+
+	result := allow(t, b, "user:1", perMinute)
+	if result.Allowed {
+		t.Fatal("expected the shared burst to be used up")
+	}
+	if result.RetryAfter <= 0 || result.RetryAfter > time.Minute {
+		t.Fatalf("unexpected retry after: %v", result.RetryAfter)
+	}
+	if ttl := mr.TTL("ratelimit:user:1"); ttl <= 0 {
+		t.Fatalf("expected the key to expire, got ttl %v", ttl)
+	}
+}
+
+type failingStore struct{ err error }
+
+func (s failingStore) Allow(context.Context, string, Limit) (Result, error) {
+	return Result{}, s.err
+}
+
+func TestFallbackStoreUsesMemoryWhileRedisFails(t *testing.T) {
+	memory, _ := newMemoryStore()
+	s := NewFallbackStore(failingStore{err: errors.New("connection refused")}, memory)
+
+	for i := 0; i < 3; i++ {
+		if result := allow(t, s, "k", perMinute); !result.Allowed {
+			t.Fatalf("request %d rejected", i)
+		}
+	}
+	if result := allow(t, s, "k", perMinute); result.Allowed {
+		t.Fatal("expected the fallback store to enforce the limit")
+	}
+}
+
+func TestPoliciesLookup(t *testing.T) {
+	defaults := Limit{Rate: 10, Period: time.Second, Burst: 20}
+	imports := Limit{Rate: 5, Period: time.Minute, Burst: 2}
+	admin := Limit{Rate: 50, Period: time.Second, Burst: 100}
+	vip := Limit{Rate: 1000, Period: time.Second, Burst: 1000}
+
+	p := Policies{
+		Groups: map[string]Limit{DefaultGroup: defaults, "import": imports},
+		Roles:  map[string]map[string]Limit{"admin": {DefaultGroup: admin}},
+		Users:  map[string]map[string]Limit{"u1": {"import": vip}},
+	}
+
+	tests := []struct {
+		name              string
+		group, user, role string
+		want              Limit
+		wantGroup         string
+	}{
+		{"anonymous", "auth", "", "", defaults, DefaultGroup},
+		{"group policy", "import", "u2", "user", imports, "import"},
+		{"group policy beats role default", "import", "u2", "admin", imports, "import"},
+		{"role override", "medals", "u2", "admin", admin, DefaultGroup},
+		{"user override", "import", "u1", "admin", vip, "import"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, group, ok := p.Lookup(tt.group, tt.user, tt.role)
+			if !ok || got != tt.want || group != tt.wantGroup {
+				t.Fatalf("got %+v in %q (%v), want %+v in %q", got, group, ok, tt.want, tt.wantGroup)
+			}
+		})
+	}
+
+	if _, _, ok := (Policies{}).Lookup("import", "u1", "admin"); ok {
+		t.Fatal("expected no limit without policies")
+	}
+}
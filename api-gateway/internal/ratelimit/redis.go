@@ -0,0 +1,96 @@
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// gcraScript is gcra run inside Redis, so that replicas sharing the store
+// never race on a key. Times are seconds since the Redis clock's epoch
+// minus a fixed offset, to keep the floats precise.
+var gcraScript = redis.NewScript(`
+local key = KEYS[1]
+local emission = tonumber(ARGV[1])
+local burst_offset = tonumber(ARGV[2])
+
+local now = redis.call("TIME")
+now = (now[1] - 1700000000) + (now[2] / 1000000)
+
+local tat = tonumber(redis.call("GET", key))
+if not tat or tat < now then
+  tat = now
+end
+
+local new_tat = tat + emission
+local diff = now - (new_tat - burst_offset)
+
+if diff < 0 then
+  return {0, 0, tostring(-diff), tostring(tat - now)}
+end
+
+local reset_after = new_tat - now
+redis.call("SET", key, new_tat, "EX", math.ceil(reset_after))
+return {1, math.floor(diff / emission), "0", tostring(reset_after)}
+`)
+
+// RedisStore shares limits between every gateway replica using the same
+// Redis.
+type RedisStore struct {
+	rdb    redis.Scripter
+	prefix string
+}
+
+func NewRedisStore(rdb redis.Scripter, prefix string) *RedisStore {
+	return &RedisStore{
+		rdb:    rdb,
+		prefix: prefix,
+	}
+}
+
+func (s *RedisStore) Allow(ctx context.Context, key string, limit Limit) (Result, error) {
+	emission := limit.emissionInterval()
+	burstOffset := emission * time.Duration(limit.Capacity())
+
+	values, err := gcraScript.Run(ctx, s.rdb, []string{s.prefix + key},
+		emission.Seconds(), burstOffset.Seconds(),
+	).Slice()
+	if err != nil {
+		return Result{}, err
+	}
+	if len(values) != 4 {
+		return Result{}, fmt.Errorf("rate limit script returned %d values", len(values))
+	}
+
+	allowed, _ := values[0].(int64)
+	remaining, _ := values[1].(int64)
+	retryAfter, err := seconds(values[2])
+	if err != nil {
+		return Result{}, err
+	}
+	resetAfter, err := seconds(values[3])
+	if err != nil {
+		return Result{}, err
+	}
+	return Result{
+		Allowed:    allowed == 1,
+		Remaining:  int(remaining),
+		RetryAfter: retryAfter,
+		ResetAfter: resetAfter,
+	}, nil
+}
+
+func seconds(value interface{}) (time.Duration, error) {
+	s, ok := value.(string)
+	if !ok {
+		return 0, fmt.Errorf("rate limit script returned %T, want string", value)
+	}
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return 0, err
+	}
+	return time.Duration(f * float64(time.Second)), nil
+}
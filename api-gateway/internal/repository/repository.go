@@ -16,42 +16,79 @@ type ServiceRepository interface {
 	Register(ctx context.Context, req *pbUser.CreateUserRequest) (*pbUser.CreateUserResponse, error)
 	Login(ctx context.Context, req *pbUser.LoginRequest) (*pbUser.LoginResponse, error)
 	RefreshToken(ctx context.Context, req *pbUser.RefreshTokenRequest) (*pbUser.RefreshTokenResponse, error)
+	Logout(ctx context.Context, req *pbUser.LogoutRequest) (*pbUser.LogoutResponse, error)
+	LogoutAll(ctx context.Context, req *pbUser.LogoutAllRequest) (*pbUser.LogoutAllResponse, error)
 	UpdateUser(ctx context.Context, req *pbUser.UpdateUserRequest) (*pbUser.UpdateUserResponse, error)
 	DeleteUser(ctx context.Context, req *pbUser.DeleteUserRequest) (*pbUser.DeleteUserResponse, error)
 	GetUserById(ctx context.Context, req *pbUser.GetUserRequest) (*pbUser.GetUserResponse, error)
-	GetUsers(ctx context.Context, req *pbUser.Void) (*pbUser.GetUsersResponse, error)
+	GetUsers(ctx context.Context, req *pbUser.GetUsersRequest) (*pbUser.GetUsersResponse, error)
 	GetUserByFilter(ctx context.Context, req *pbUser.UserFilter) (*pbUser.GetUsersResponse, error)
 
 	//Model methods
-	CreateMedal(req *pbMedal.CreateMedalRequest) (*pbMedal.CreateMedalResponse, error)
-	UpdateMedal(req *pbMedal.UpdateMedalRequest) (*pbMedal.UpdateMedalResponse, error)
-	DeleteMedal(req *pbMedal.DeleteMedalRequest) (*pbMedal.DeleteMedalResponse, error)
-	GetMedalById(req *pbMedal.GetMedalByIdRequest) (*pbMedal.GetMedalByIdResponse, error)
-	GetMedals(req *pbMedal.VoidMedal) (*pbMedal.GetMedalsResponse, error)
-	GetMedalByFilter(req *pbMedal.GetMedalByFilterRequest) (pbMedal.GetMedalByFilterResponse, error)
+	CreateMedal(ctx context.Context, req *pbMedal.CreateMedalRequest) (*pbMedal.CreateMedalResponse, error)
+	UpdateMedal(ctx context.Context, req *pbMedal.UpdateMedalRequest) (*pbMedal.UpdateMedalResponse, error)
+	DeleteMedal(ctx context.Context, req *pbMedal.DeleteMedalRequest) (*pbMedal.DeleteMedalResponse, error)
+	GetMedalById(ctx context.Context, req *pbMedal.GetMedalByIdRequest) (*pbMedal.GetMedalByIdResponse, error)
+	GetMedals(ctx context.Context, req *pbMedal.GetMedalsRequest) (*pbMedal.GetMedalsResponse, error)
+	GetMedalByFilter(ctx context.Context, req *pbMedal.GetMedalByFilterRequest) (pbMedal.GetMedalByFilterResponse, error)
+	GetMedalTable(ctx context.Context, req *pbMedal.GetMedalTableRequest) (*pbMedal.GetMedalTableResponse, error)
+	ImportMedals(ctx context.Context, req *pbMedal.ImportMedalsRequest) (*pbMedal.ImportResponse, error)
+	ExportMedals(ctx context.Context, req *pbMedal.ExportMedalsRequest) (pbMedal.MedalService_ExportMedalsClient, error)
+	FinalizeEvent(ctx context.Context, req *pbMedal.FinalizeEventRequest) (*pbMedal.FinalizeEventResponse, error)
 
 	// Country methods
-	CreateCountry(req *pbUserCountry.CreateCountryRequest) (*pbUserCountry.Country, error)
-	GetCountry(req *pbUserCountry.GetCountryRequest) (*pbUserCountry.Country, error)
-	ListOfCountry(req *pbUserCountry.ListOfCountryRequest) (*pbUserCountry.ListOfCountryResponse, error)
-	UpdateCountry(req *pbUserCountry.UpdateCountryRequest) (*pbUserCountry.Country, error)
-	DeleteCountry(req *pbUserCountry.DeleteCountryRequest) (*pbUserCountry.DeleteCountryResponse, error)
+	CreateCountry(ctx context.Context, req *pbUserCountry.CreateCountryRequest) (*pbUserCountry.Country, error)
+	GetCountry(ctx context.Context, req *pbUserCountry.GetCountryRequest) (*pbUserCountry.Country, error)
+	ListOfCountry(ctx context.Context, req *pbUserCountry.ListOfCountryRequest) (*pbUserCountry.ListOfCountryResponse, error)
+	UpdateCountry(ctx context.Context, req *pbUserCountry.UpdateCountryRequest) (*pbUserCountry.Country, error)
+	DeleteCountry(ctx context.Context, req *pbUserCountry.DeleteCountryRequest) (*pbUserCountry.DeleteCountryResponse, error)
+	ImportCountries(ctx context.Context, req *pbUserCountry.ImportCountriesRequest) (*pbUserCountry.ImportResponse, error)
+	ExportCountries(ctx context.Context, req *pbUserCountry.ExportCountriesRequest) (pbUserCountry.CountryService_ExportCountriesClient, error)
 
 	// Event methods
-	CreateEvent(req *pbUserEvent.CreateEventRequest) (*pbUserEvent.Event, error)
-	GetEvent(req *pbUserEvent.GetEventRequest) (*pbUserEvent.Event, error)
-	ListOfEvent(req *pbUserEvent.ListOfEventRequest) (*pbUserEvent.ListOfEventResponse, error)
-	UpdateEvent(req *pbUserEvent.UpdateEventRequest) (*pbUserEvent.Event, error)
-	DeleteEvent(req *pbUserEvent.DeleteEventRequest) (*pbUserEvent.DeleteEventResponse, error)
+	CreateEvent(ctx context.Context, req *pbUserEvent.CreateEventRequest) (*pbUserEvent.Event, error)
+	GetEvent(ctx context.Context, req *pbUserEvent.GetEventRequest) (*pbUserEvent.Event, error)
+	ListOfEvent(ctx context.Context, req *pbUserEvent.ListOfEventRequest) (*pbUserEvent.ListOfEventResponse, error)
+	UpdateEvent(ctx context.Context, req *pbUserEvent.UpdateEventRequest) (*pbUserEvent.Event, error)
+	DeleteEvent(ctx context.Context, req *pbUserEvent.DeleteEventRequest) (*pbUserEvent.DeleteEventResponse, error)
+	TransitionEvent(ctx context.Context, req *pbUserEvent.TransitionEventRequest) (*pbUserEvent.Event, error)
+	GetSchedule(ctx context.Context, req *pbUserEvent.GetScheduleRequest) (*pbUserEvent.GetScheduleResponse, error)
+	ImportEvents(ctx context.Context, req *pbUserEvent.ImportEventsRequest) (*pbUserEvent.ImportResponse, error)
+	ExportEvents(ctx context.Context, req *pbUserEvent.ExportEventsRequest) (pbUserEvent.EventService_ExportEventsClient, error)
+
+	// Venue methods
+	CreateVenue(ctx context.Context, req *pbUserEvent.CreateVenueRequest) (*pbUserEvent.Venue, error)
+	GetVenue(ctx context.Context, req *pbUserEvent.GetVenueRequest) (*pbUserEvent.Venue, error)
+	ListVenues(ctx context.Context, req *pbUserEvent.ListVenuesRequest) (*pbUserEvent.ListVenuesResponse, error)
+	UpdateVenue(ctx context.Context, req *pbUserEvent.UpdateVenueRequest) (*pbUserEvent.Venue, error)
+	DeleteVenue(ctx context.Context, req *pbUserEvent.DeleteVenueRequest) (*pbUserEvent.DeleteVenueResponse, error)
+
+	// Competition structure methods
+	CreatePhase(ctx context.Context, req *pbUserEvent.CreatePhaseRequest) (*pbUserEvent.Phase, error)
+	GetPhase(ctx context.Context, req *pbUserEvent.GetPhaseRequest) (*pbUserEvent.Phase, error)
+	ListPhases(ctx context.Context, req *pbUserEvent.ListPhasesRequest) (*pbUserEvent.ListPhasesResponse, error)
+	DeletePhase(ctx context.Context, req *pbUserEvent.DeletePhaseRequest) (*pbUserEvent.DeletePhaseResponse, error)
+	SetStartList(ctx context.Context, req *pbUserEvent.SetStartListRequest) (*pbUserEvent.Unit, error)
+	RecordResults(ctx context.Context, req *pbUserEvent.RecordResultsRequest) (*pbUserEvent.Unit, error)
+	AdvancePhase(ctx context.Context, req *pbUserEvent.AdvancePhaseRequest) (*pbUserEvent.AdvancePhaseResponse, error)
+	GenerateBracket(ctx context.Context, req *pbUserEvent.GenerateBracketRequest) (*pbUserEvent.GenerateBracketResponse, error)
 
 	// Athlete methods
-	CreateAthlete(req *pbUserAthlete.CreateAthleteRequest) (*pbUserAthlete.Athlete, error)
-	GetAthlete(req *pbUserAthlete.GetAthleteRequest) (*pbUserAthlete.Athlete, error)
-	ListAthletes(req *pbUserAthlete.ListOfAthleteRequest) (*pbUserAthlete.ListOfAthleteResponse, error)
-	UpdateAthlete(req *pbUserAthlete.UpdateAthleteRequest) (*pbUserAthlete.Athlete, error)
-	DeleteAthlete(req *pbUserAthlete.DeleteAthleteRequest) (*pbUserAthlete.DeleteAthleteResponse, error)
+	CreateAthlete(ctx context.Context, req *pbUserAthlete.CreateAthleteRequest) (*pbUserAthlete.Athlete, error)
+	GetAthlete(ctx context.Context, req *pbUserAthlete.GetAthleteRequest) (*pbUserAthlete.Athlete, error)
+	ListAthletes(ctx context.Context, req *pbUserAthlete.ListOfAthleteRequest) (*pbUserAthlete.ListOfAthleteResponse, error)
+	UpdateAthlete(ctx context.Context, req *pbUserAthlete.UpdateAthleteRequest) (*pbUserAthlete.Athlete, error)
+	DeleteAthlete(ctx context.Context, req *pbUserAthlete.DeleteAthleteRequest) (*pbUserAthlete.DeleteAthleteResponse, error)
+	ImportAthletes(ctx context.Context, req *pbUserAthlete.ImportAthletesRequest) (*pbUserAthlete.ImportResponse, error)
+	ExportAthletes(ctx context.Context, req *pbUserAthlete.ExportAthletesRequest) (pbUserAthlete.AthleteService_ExportAthletesClient, error)
+	CreateTeam(ctx context.Context, req *pbUserAthlete.CreateTeamRequest) (*pbUserAthlete.Team, error)
+	GetTeam(ctx context.Context, req *pbUserAthlete.GetTeamRequest) (*pbUserAthlete.Team, error)
+	ListTeams(ctx context.Context, req *pbUserAthlete.ListTeamsRequest) (*pbUserAthlete.ListTeamsResponse, error)
+	UpdateTeam(ctx context.Context, req *pbUserAthlete.UpdateTeamRequest) (*pbUserAthlete.Team, error)
+	DeleteTeam(ctx context.Context, req *pbUserAthlete.DeleteTeamRequest) (*pbUserAthlete.DeleteTeamResponse, error)
 
 	// Live methods
-	CreateLiveStream(req *livepb.LiveStream) (*livepb.ResponseMessage, error)
-	GetLiveStream(req *livepb.GetStreamRequest) (*livepb.LiveStream, error)
+	CreateLiveStream(ctx context.Context, req *livepb.LiveStream) (*livepb.ResponseMessage, error)
+	GetLiveStream(ctx context.Context, req *livepb.GetStreamRequest) (*livepb.LiveStream, error)
+	ListLiveStream(ctx context.Context, req *livepb.ListLiveStreamRequest) (*livepb.ListLiveStreamResponse, error)
 }
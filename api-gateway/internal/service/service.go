@@ -1,7 +1,9 @@
 package service
 
 import (
+	config "api-gateway/internal/pkg/load"
 	"context"
+	"time"
 
 	pbAthlete "github.com/Bekzodbekk/paris2024_livestream_protos/genproto/athletepb"
 	pbCountry "github.com/Bekzodbekk/paris2024_livestream_protos/genproto/countrypb"
@@ -19,6 +21,15 @@ type ServiceRepositoryClient struct {
 	eventClient   pbEvent.EventServiceClient
 	athleteClient pbAthlete.AthleteServiceClient
 	liveClient    pbLive.LiveStreamServiceClient
+	timeouts      timeouts
+}
+
+// timeouts bound every unary call to a service. Streaming calls live as long
+// as the request that opened them, since an export may legitimately run for
+// minutes.
+type timeouts struct {
+	user, medal, country, event, athlete, live time.Duration
+	imports                                    time.Duration
 }
 
 func NewServiceRepositoryClient(
@@ -28,6 +39,7 @@ func NewServiceRepositoryClient(
 	conn4 *pbEvent.EventServiceClient,
 	conn5 *pbAthlete.AthleteServiceClient,
 	conn6 *pbLive.LiveStreamServiceClient,
+	cfg config.Config,
 ) *ServiceRepositoryClient {
 	return &ServiceRepositoryClient{
 		userClient:    *conn1,
@@ -36,137 +48,414 @@ func NewServiceRepositoryClient(
 		eventClient:   *conn4,
 		athleteClient: *conn5,
 		liveClient:    *conn6,
+		timeouts: timeouts{
+			user:    cfg.UserService.Timeout,
+			medal:   cfg.MedalService.Timeout,
+			country: cfg.CountryService.Timeout,
+			event:   cfg.EventService.Timeout,
+			athlete: cfg.AthleteService.Timeout,
+			live:    cfg.LiveService.Timeout,
+			imports: cfg.ImportTimeout,
+		},
+	}
+}
+
+// withTimeout derives the context of one call from the request context, so
+// a client that goes away cancels the backend work as well.
+func withTimeout(ctx context.Context, d time.Duration) (context.Context, context.CancelFunc) {
+	if d <= 0 {
+		return context.WithCancel(ctx)
 	}
+	return context.WithTimeout(ctx, d)
 }
 
 //User methods
 
 func (s *ServiceRepositoryClient) Register(ctx context.Context, req *pbUser.CreateUserRequest) (*pbUser.CreateUserResponse, error) {
+	ctx, cancel := withTimeout(ctx, s.timeouts.user)
+	defer cancel()
 	return s.userClient.Register(ctx, req)
 }
 
 func (s *ServiceRepositoryClient) Login(ctx context.Context, req *pbUser.LoginRequest) (*pbUser.LoginResponse, error) {
+	ctx, cancel := withTimeout(ctx, s.timeouts.user)
+	defer cancel()
 	return s.userClient.Login(ctx, req)
 }
 
 func (s *ServiceRepositoryClient) RefreshToken(ctx context.Context, req *pbUser.RefreshTokenRequest) (*pbUser.RefreshTokenResponse, error) {
+	ctx, cancel := withTimeout(ctx, s.timeouts.user)
+	defer cancel()
 	return s.userClient.RefreshToken(ctx, req)
 }
 
+func (s *ServiceRepositoryClient) Logout(ctx context.Context, req *pbUser.LogoutRequest) (*pbUser.LogoutResponse, error) {
+	ctx, cancel := withTimeout(ctx, s.timeouts.user)
+	defer cancel()
+	return s.userClient.Logout(ctx, req)
+}
+
+func (s *ServiceRepositoryClient) LogoutAll(ctx context.Context, req *pbUser.LogoutAllRequest) (*pbUser.LogoutAllResponse, error) {
+	ctx, cancel := withTimeout(ctx, s.timeouts.user)
+	defer cancel()
+	return s.userClient.LogoutAll(ctx, req)
+}
+
 func (s *ServiceRepositoryClient) UpdateUser(ctx context.Context, req *pbUser.UpdateUserRequest) (*pbUser.UpdateUserResponse, error) {
+	ctx, cancel := withTimeout(ctx, s.timeouts.user)
+	defer cancel()
 	return s.userClient.UpdateUser(ctx, req)
 }
 
 func (s *ServiceRepositoryClient) DeleteUser(ctx context.Context, req *pbUser.DeleteUserRequest) (*pbUser.DeleteUserResponse, error) {
+	ctx, cancel := withTimeout(ctx, s.timeouts.user)
+	defer cancel()
 	return s.userClient.DeleteUser(ctx, req)
 }
 
 func (s *ServiceRepositoryClient) GetUserById(ctx context.Context, req *pbUser.GetUserRequest) (*pbUser.GetUserResponse, error) {
+	ctx, cancel := withTimeout(ctx, s.timeouts.user)
+	defer cancel()
 	return s.userClient.GetUserById(ctx, req)
 }
 
-func (s *ServiceRepositoryClient) GetUsers(ctx context.Context, req *pbUser.Void) (*pbUser.GetUsersResponse, error) {
+func (s *ServiceRepositoryClient) GetUsers(ctx context.Context, req *pbUser.GetUsersRequest) (*pbUser.GetUsersResponse, error) {
+	ctx, cancel := withTimeout(ctx, s.timeouts.user)
+	defer cancel()
 	return s.userClient.GetUsers(ctx, req)
 }
 
 func (s *ServiceRepositoryClient) GetUserByFilter(ctx context.Context, req *pbUser.UserFilter) (*pbUser.GetUsersResponse, error) {
+	ctx, cancel := withTimeout(ctx, s.timeouts.user)
+	defer cancel()
 	return s.userClient.GetUserByFilter(ctx, req)
 }
 
 // Medal methods
 func (s *ServiceRepositoryClient) CreateMedal(ctx context.Context, req *pbMedal.CreateMedalRequest) (*pbMedal.CreateMedalResponse, error) {
+	ctx, cancel := withTimeout(ctx, s.timeouts.medal)
+	defer cancel()
 	return s.medalClient.CreateMedal(ctx, req)
 }
 
 func (s *ServiceRepositoryClient) UpdateMedal(ctx context.Context, req *pbMedal.UpdateMedalRequest) (*pbMedal.UpdateMedalResponse, error) {
+	ctx, cancel := withTimeout(ctx, s.timeouts.medal)
+	defer cancel()
 	return s.medalClient.UpdateMedal(ctx, req)
 }
 
 func (s *ServiceRepositoryClient) DeleteMedal(ctx context.Context, req *pbMedal.DeleteMedalRequest) (*pbMedal.DeleteMedalResponse, error) {
+	ctx, cancel := withTimeout(ctx, s.timeouts.medal)
+	defer cancel()
 	return s.medalClient.DeleteMedal(ctx, req)
 }
 
 func (s *ServiceRepositoryClient) GetMedalById(ctx context.Context, req *pbMedal.GetMedalByIdRequest) (*pbMedal.GetMedalByIdResponse, error) {
+	ctx, cancel := withTimeout(ctx, s.timeouts.medal)
+	defer cancel()
 	return s.medalClient.GetMedalById(ctx, req)
 }
 
-func (s *ServiceRepositoryClient) GetMedals(ctx context.Context, req *pbMedal.VoidMedal) (*pbMedal.GetMedalsResponse, error) {
+func (s *ServiceRepositoryClient) GetMedals(ctx context.Context, req *pbMedal.GetMedalsRequest) (*pbMedal.GetMedalsResponse, error) {
+	ctx, cancel := withTimeout(ctx, s.timeouts.medal)
+	defer cancel()
 	return s.medalClient.GetMedals(ctx, req)
 }
 
 func (s *ServiceRepositoryClient) GetMedalByFilter(ctx context.Context, req *pbMedal.GetMedalByFilterRequest) (*pbMedal.GetMedalByFilterResponse, error) {
+	ctx, cancel := withTimeout(ctx, s.timeouts.medal)
+	defer cancel()
 	return s.medalClient.GetMedalByFilter(ctx, req)
 }
 
+func (s *ServiceRepositoryClient) GetMedalTable(ctx context.Context, req *pbMedal.GetMedalTableRequest) (*pbMedal.GetMedalTableResponse, error) {
+	ctx, cancel := withTimeout(ctx, s.timeouts.medal)
+	defer cancel()
+	return s.medalClient.GetMedalTable(ctx, req)
+}
+
+func (s *ServiceRepositoryClient) ImportMedals(ctx context.Context, req *pbMedal.ImportMedalsRequest) (*pbMedal.ImportResponse, error) {
+	ctx, cancel := withTimeout(ctx, s.timeouts.imports)
+	defer cancel()
+	return s.medalClient.ImportMedals(ctx, req)
+}
+
+func (s *ServiceRepositoryClient) ExportMedals(ctx context.Context, req *pbMedal.ExportMedalsRequest) (pbMedal.MedalService_ExportMedalsClient, error) {
+	return s.medalClient.ExportMedals(ctx, req)
+}
+
+func (s *ServiceRepositoryClient) FinalizeEvent(ctx context.Context, req *pbMedal.FinalizeEventRequest) (*pbMedal.FinalizeEventResponse, error) {
+	ctx, cancel := withTimeout(ctx, s.timeouts.medal)
+	defer cancel()
+	return s.medalClient.FinalizeEvent(ctx, req)
+}
+
 // Country methods
-func (s *ServiceRepositoryClient) CreateCountry(req *pbCountry.CreateCountryRequest) (*pbCountry.Country, error) {
-	return s.countryClient.CreateCountry(context.Background(), req)
+func (s *ServiceRepositoryClient) CreateCountry(ctx context.Context, req *pbCountry.CreateCountryRequest) (*pbCountry.Country, error) {
+	ctx, cancel := withTimeout(ctx, s.timeouts.country)
+	defer cancel()
+	return s.countryClient.CreateCountry(ctx, req)
+}
+
+func (s *ServiceRepositoryClient) GetCountry(ctx context.Context, req *pbCountry.GetCountryRequest) (*pbCountry.Country, error) {
+	ctx, cancel := withTimeout(ctx, s.timeouts.country)
+	defer cancel()
+	return s.countryClient.GetCountry(ctx, req)
 }
 
-func (s *ServiceRepositoryClient) GetCountry(req *pbCountry.GetCountryRequest) (*pbCountry.Country, error) {
-	return s.countryClient.GetCountry(context.Background(), req)
+func (s *ServiceRepositoryClient) ListOfCountry(ctx context.Context, req *pbCountry.ListOfCountryRequest) (*pbCountry.ListOfCountryResponse, error) {
+	ctx, cancel := withTimeout(ctx, s.timeouts.country)
+	defer cancel()
+	return s.countryClient.ListOfCountry(ctx, req)
 }
 
-func (s *ServiceRepositoryClient) ListOfCountry(req *pbCountry.ListOfCountryRequest) (*pbCountry.ListOfCountryResponse, error) {
-	return s.countryClient.ListOfCountry(context.Background(), req)
+func (s *ServiceRepositoryClient) UpdateCountry(ctx context.Context, req *pbCountry.UpdateCountryRequest) (*pbCountry.Country, error) {
+	ctx, cancel := withTimeout(ctx, s.timeouts.country)
+	defer cancel()
+	return s.countryClient.UpdateCountry(ctx, req)
 }
 
-func (s *ServiceRepositoryClient) UpdateCountry(req *pbCountry.UpdateCountryRequest) (*pbCountry.Country, error) {
-	return s.countryClient.UpdateCountry(context.Background(), req)
+func (s *ServiceRepositoryClient) DeleteCountry(ctx context.Context, req *pbCountry.DeleteCountryRequest) (*pbCountry.DeleteCountryResponse, error) {
+	ctx, cancel := withTimeout(ctx, s.timeouts.country)
+	defer cancel()
+	return s.countryClient.DeleteCountry(ctx, req)
 }
 
-func (s *ServiceRepositoryClient) DeleteCountry(req *pbCountry.DeleteCountryRequest) (*pbCountry.DeleteCountryResponse, error) {
-	return s.countryClient.DeleteCountry(context.Background(), req)
+func (s *ServiceRepositoryClient) ImportCountries(ctx context.Context, req *pbCountry.ImportCountriesRequest) (*pbCountry.ImportResponse, error) {
+	ctx, cancel := withTimeout(ctx, s.timeouts.imports)
+	defer cancel()
+	return s.countryClient.ImportCountries(ctx, req)
+}
+
+func (s *ServiceRepositoryClient) ExportCountries(ctx context.Context, req *pbCountry.ExportCountriesRequest) (pbCountry.CountryService_ExportCountriesClient, error) {
+	return s.countryClient.ExportCountries(ctx, req)
 }
 
 // Event methods
-func (s *ServiceRepositoryClient) CreateEvent(req *pbEvent.CreateEventRequest) (*pbEvent.Event, error) {
-	return s.eventClient.CreateEvent(context.Background(), req)
+func (s *ServiceRepositoryClient) CreateEvent(ctx context.Context, req *pbEvent.CreateEventRequest) (*pbEvent.Event, error) {
+	ctx, cancel := withTimeout(ctx, s.timeouts.event)
+	defer cancel()
+	return s.eventClient.CreateEvent(ctx, req)
+}
+
+func (s *ServiceRepositoryClient) GetEvent(ctx context.Context, req *pbEvent.GetEventRequest) (*pbEvent.Event, error) {
+	ctx, cancel := withTimeout(ctx, s.timeouts.event)
+	defer cancel()
+	return s.eventClient.GetEvent(ctx, req)
+}
+
+func (s *ServiceRepositoryClient) ListOfEvent(ctx context.Context, req *pbEvent.ListOfEventRequest) (*pbEvent.ListOfEventResponse, error) {
+	ctx, cancel := withTimeout(ctx, s.timeouts.event)
+	defer cancel()
+	return s.eventClient.ListOfEvent(ctx, req)
+}
+
+func (s *ServiceRepositoryClient) UpdateEvent(ctx context.Context, req *pbEvent.UpdateEventRequest) (*pbEvent.Event, error) {
+	ctx, cancel := withTimeout(ctx, s.timeouts.event)
+	defer cancel()
+	return s.eventClient.UpdateEvent(ctx, req)
+}
+
+func (s *ServiceRepositoryClient) DeleteEvent(ctx context.Context, req *pbEvent.DeleteEventRequest) (*pbEvent.DeleteEventResponse, error) {
+	ctx, cancel := withTimeout(ctx, s.timeouts.event)
+	defer cancel()
+	return s.eventClient.DeleteEvent(ctx, req)
+}
+
+func (s *ServiceRepositoryClient) TransitionEvent(ctx context.Context, req *pbEvent.TransitionEventRequest) (*pbEvent.Event, error) {
+	ctx, cancel := withTimeout(ctx, s.timeouts.event)
+	defer cancel()
+	return s.eventClient.TransitionEvent(ctx, req)
+}
+
+func (s *ServiceRepositoryClient) GetSchedule(ctx context.Context, req *pbEvent.GetScheduleRequest) (*pbEvent.GetScheduleResponse, error) {
+	ctx, cancel := withTimeout(ctx, s.timeouts.event)
+	defer cancel()
+	return s.eventClient.GetSchedule(ctx, req)
+}
+
+func (s *ServiceRepositoryClient) ImportEvents(ctx context.Context, req *pbEvent.ImportEventsRequest) (*pbEvent.ImportResponse, error) {
+	ctx, cancel := withTimeout(ctx, s.timeouts.imports)
+	defer cancel()
+	return s.eventClient.ImportEvents(ctx, req)
+}
+
+func (s *ServiceRepositoryClient) ExportEvents(ctx context.Context, req *pbEvent.ExportEventsRequest) (pbEvent.EventService_ExportEventsClient, error) {
+	return s.eventClient.ExportEvents(ctx, req)
 }
 
-func (s *ServiceRepositoryClient) GetEvent(req *pbEvent.GetEventRequest) (*pbEvent.Event, error) {
-	return s.eventClient.GetEvent(context.Background(), req)
+// Venue methods
+func (s *ServiceRepositoryClient) CreateVenue(ctx context.Context, req *pbEvent.CreateVenueRequest) (*pbEvent.Venue, error) {
+	ctx, cancel := withTimeout(ctx, s.timeouts.event)
+	defer cancel()
+	return s.eventClient.CreateVenue(ctx, req)
 }
 
-func (s *ServiceRepositoryClient) ListOfEvent(req *pbEvent.ListOfEventRequest) (*pbEvent.ListOfEventResponse, error) {
-	return s.eventClient.ListOfEvent(context.Background(), req)
+func (s *ServiceRepositoryClient) GetVenue(ctx context.Context, req *pbEvent.GetVenueRequest) (*pbEvent.Venue, error) {
+	ctx, cancel := withTimeout(ctx, s.timeouts.event)
+	defer cancel()
+	return s.eventClient.GetVenue(ctx, req)
 }
 
-func (s *ServiceRepositoryClient) UpdateEvent(req *pbEvent.UpdateEventRequest) (*pbEvent.Event, error) {
-	return s.eventClient.UpdateEvent(context.Background(), req)
+func (s *ServiceRepositoryClient) ListVenues(ctx context.Context, req *pbEvent.ListVenuesRequest) (*pbEvent.ListVenuesResponse, error) {
+	ctx, cancel := withTimeout(ctx, s.timeouts.event)
+	defer cancel()
+	return s.eventClient.ListVenues(ctx, req)
 }
 
-func (s *ServiceRepositoryClient) DeleteEvent(req *pbEvent.DeleteEventRequest) (*pbEvent.DeleteEventResponse, error) {
-	return s.eventClient.DeleteEvent(context.Background(), req)
+func (s *ServiceRepositoryClient) UpdateVenue(ctx context.Context, req *pbEvent.UpdateVenueRequest) (*pbEvent.Venue, error) {
+	ctx, cancel := withTimeout(ctx, s.timeouts.event)
+	defer cancel()
+	return s.eventClient.UpdateVenue(ctx, req)
+}
+
+func (s *ServiceRepositoryClient) DeleteVenue(ctx context.Context, req *pbEvent.DeleteVenueRequest) (*pbEvent.DeleteVenueResponse, error) {
+	ctx, cancel := withTimeout(ctx, s.timeouts.event)
+	defer cancel()
+	return s.eventClient.DeleteVenue(ctx, req)
+}
+
+// Competition structure methods
+func (s *ServiceRepositoryClient) CreatePhase(ctx context.Context, req *pbEvent.CreatePhaseRequest) (*pbEvent.Phase, error) {
+	ctx, cancel := withTimeout(ctx, s.timeouts.event)
+	defer cancel()
+	return s.eventClient.CreatePhase(ctx, req)
+}
+
+func (s *ServiceRepositoryClient) GetPhase(ctx context.Context, req *pbEvent.GetPhaseRequest) (*pbEvent.Phase, error) {
+	ctx, cancel := withTimeout(ctx, s.timeouts.event)
+	defer cancel()
+	return s.eventClient.GetPhase(ctx, req)
+}
+
+func (s *ServiceRepositoryClient) ListPhases(ctx context.Context, req *pbEvent.ListPhasesRequest) (*pbEvent.ListPhasesResponse, error) {
+	ctx, cancel := withTimeout(ctx, s.timeouts.event)
+	defer cancel()
+	return s.eventClient.ListPhases(ctx, req)
+}
+
+func (s *ServiceRepositoryClient) DeletePhase(ctx context.Context, req *pbEvent.DeletePhaseRequest) (*pbEvent.DeletePhaseResponse, error) {
+	ctx, cancel := withTimeout(ctx, s.timeouts.event)
+	defer cancel()
+	return s.eventClient.DeletePhase(ctx, req)
+}
+
+func (s *ServiceRepositoryClient) SetStartList(ctx context.Context, req *pbEvent.SetStartListRequest) (*pbEvent.Unit, error) {
+	ctx, cancel := withTimeout(ctx, s.timeouts.event)
+	defer cancel()
+	return s.eventClient.SetStartList(ctx, req)
+}
+
+func (s *ServiceRepositoryClient) RecordResults(ctx context.Context, req *pbEvent.RecordResultsRequest) (*pbEvent.Unit, error) {
+	ctx, cancel := withTimeout(ctx, s.timeouts.event)
+	defer cancel()
+	return s.eventClient.RecordResults(ctx, req)
+}
+
+func (s *ServiceRepositoryClient) AdvancePhase(ctx context.Context, req *pbEvent.AdvancePhaseRequest) (*pbEvent.AdvancePhaseResponse, error) {
+	ctx, cancel := withTimeout(ctx, s.timeouts.event)
+	defer cancel()
+	return s.eventClient.AdvancePhase(ctx, req)
+}
+
+func (s *ServiceRepositoryClient) GenerateBracket(ctx context.Context, req *pbEvent.GenerateBracketRequest) (*pbEvent.GenerateBracketResponse, error) {
+	ctx, cancel := withTimeout(ctx, s.timeouts.event)
+	defer cancel()
+	return s.eventClient.GenerateBracket(ctx, req)
 }
 
 // Athlete methods
-func (s *ServiceRepositoryClient) CreateAthlete(req *pbAthlete.CreateAthleteRequest) (*pbAthlete.Athlete, error) {
-	return s.athleteClient.CreateAthlete(context.Background(), req)
+func (s *ServiceRepositoryClient) CreateAthlete(ctx context.Context, req *pbAthlete.CreateAthleteRequest) (*pbAthlete.Athlete, error) {
+	ctx, cancel := withTimeout(ctx, s.timeouts.athlete)
+	defer cancel()
+	return s.athleteClient.CreateAthlete(ctx, req)
 }
 
-func (s *ServiceRepositoryClient) GetAthlete(req *pbAthlete.GetAthleteRequest) (*pbAthlete.GetAthleteResponse, error) {
-	return s.athleteClient.GetAthlete(context.Background(), req)
+func (s *ServiceRepositoryClient) GetAthlete(ctx context.Context, req *pbAthlete.GetAthleteRequest) (*pbAthlete.GetAthleteResponse, error) {
+	ctx, cancel := withTimeout(ctx, s.timeouts.athlete)
+	defer cancel()
+	return s.athleteClient.GetAthlete(ctx, req)
 }
 
-func (s *ServiceRepositoryClient) ListOfAthlete(req *pbAthlete.ListOfAthleteRequest) (*pbAthlete.ListOfAthleteResponse, error) {
-	return s.athleteClient.ListOfAthlete(context.Background(), req)
+func (s *ServiceRepositoryClient) ListOfAthlete(ctx context.Context, req *pbAthlete.ListOfAthleteRequest) (*pbAthlete.ListOfAthleteResponse, error) {
+	ctx, cancel := withTimeout(ctx, s.timeouts.athlete)
+	defer cancel()
+	return s.athleteClient.ListOfAthlete(ctx, req)
 }
 
-func (s *ServiceRepositoryClient) UpdateAthlete(req *pbAthlete.UpdateAthleteRequest) (*pbAthlete.Athlete, error) {
-	return s.athleteClient.UpdateAthlete(context.Background(), req)
+func (s *ServiceRepositoryClient) UpdateAthlete(ctx context.Context, req *pbAthlete.UpdateAthleteRequest) (*pbAthlete.Athlete, error) {
+	ctx, cancel := withTimeout(ctx, s.timeouts.athlete)
+	defer cancel()
+	return s.athleteClient.UpdateAthlete(ctx, req)
 }
 
-func (s *ServiceRepositoryClient) DeleteAthlete(req *pbAthlete.DeleteAthleteRequest) (*pbAthlete.DeleteAthleteResponse, error) {
-	return s.athleteClient.DeleteAthlete(context.Background(), req)
+func (s *ServiceRepositoryClient) DeleteAthlete(ctx context.Context, req *pbAthlete.DeleteAthleteRequest) (*pbAthlete.DeleteAthleteResponse, error) {
+	ctx, cancel := withTimeout(ctx, s.timeouts.athlete)
+	defer cancel()
+	return s.athleteClient.DeleteAthlete(ctx, req)
+}
+
+func (s *ServiceRepositoryClient) ImportAthletes(ctx context.Context, req *pbAthlete.ImportAthletesRequest) (*pbAthlete.ImportResponse, error) {
+	ctx, cancel := withTimeout(ctx, s.timeouts.imports)
+	defer cancel()
+	return s.athleteClient.ImportAthletes(ctx, req)
+}
+
+func (s *ServiceRepositoryClient) ExportAthletes(ctx context.Context, req *pbAthlete.ExportAthletesRequest) (pbAthlete.AthleteService_ExportAthletesClient, error) {
+	return s.athleteClient.ExportAthletes(ctx, req)
+}
+
+func (s *ServiceRepositoryClient) CreateTeam(ctx context.Context, req *pbAthlete.CreateTeamRequest) (*pbAthlete.Team, error) {
+	ctx, cancel := withTimeout(ctx, s.timeouts.athlete)
+	defer cancel()
+	return s.athleteClient.CreateTeam(ctx, req)
+}
+
+func (s *ServiceRepositoryClient) GetTeam(ctx context.Context, req *pbAthlete.GetTeamRequest) (*pbAthlete.Team, error) {
+	ctx, cancel := withTimeout(ctx, s.timeouts.athlete)
+	defer cancel()
+	return s.athleteClient.GetTeam(ctx, req)
+}
+
+func (s *ServiceRepositoryClient) ListTeams(ctx context.Context, req *pbAthlete.ListTeamsRequest) (*pbAthlete.ListTeamsResponse, error) {
+	ctx, cancel := withTimeout(ctx, s.timeouts.athlete)
+	defer cancel()
+	return s.athleteClient.ListTeams(ctx, req)
+}
+
+func (s *ServiceRepositoryClient) UpdateTeam(ctx context.Context, req *pbAthlete.UpdateTeamRequest) (*pbAthlete.Team, error) {
+	ctx, cancel := withTimeout(ctx, s.timeouts.athlete)
+	defer cancel()
+	return s.athleteClient.UpdateTeam(ctx, req)
+}
+
+func (s *ServiceRepositoryClient) DeleteTeam(ctx context.Context, req *pbAthlete.DeleteTeamRequest) (*pbAthlete.DeleteTeamResponse, error) {
+	ctx, cancel := withTimeout(ctx, s.timeouts.athlete)
+	defer cancel()
+	return s.athleteClient.DeleteTeam(ctx, req)
 }
 
 // Live methods
 
-func(s *ServiceRepositoryClient) CreateLive(req *livepb.LiveStream) (*livepb.ResponseMessage, error){
-	return s.liveClient.CreateLiveStream(context.Background(), req)
+func(s *ServiceRepositoryClient) CreateLive(ctx context.Context, req *livepb.LiveStream) (*livepb.ResponseMessage, error) {
+	ctx, cancel := withTimeout(ctx, s.timeouts.live)
+	defer cancel()
+	return s.liveClient.CreateLiveStream(ctx, req)
 }
 
-func(s *ServiceRepositoryClient) GetLive(req *livepb.GetStreamRequest) (*livepb.LiveStream, error){
-	return s.liveClient.GetLiveStream(context.Background(), req)
-}
\ No newline at end of file
+func(s *ServiceRepositoryClient) GetLive(ctx context.Context, req *livepb.GetStreamRequest) (*livepb.LiveStream, error) {
+	ctx, cancel := withTimeout(ctx, s.timeouts.live)
+	defer cancel()
+	return s.liveClient.GetLiveStream(ctx, req)
+}
+
+func(s *ServiceRepositoryClient) ListLive(ctx context.Context, req *livepb.ListLiveStreamRequest) (*livepb.ListLiveStreamResponse, error) {
+	ctx, cancel := withTimeout(ctx, s.timeouts.live)
+	defer cancel()
+	return s.liveClient.ListLiveStream(ctx, req)
+}
+
+// SubscribeLive is a streaming call, so it lives as long as ctx.
+func (s *ServiceRepositoryClient) SubscribeLive(ctx context.Context, req *livepb.GetStreamRequest) (livepb.LiveStreamService_SubscribeLiveStreamClient, error) {
+	return s.liveClient.SubscribeLiveStream(ctx, req)
+}
@@ -16,6 +16,7 @@ func InitLog() {
 		logrus.Fatal(err) 
 	}
 	logrus.SetOutput(io.MultiWriter(os.Stdout, logFile)) 
+	logrus.AddHook(traceHook{})
 }
 
 func SetOutput(output io.Writer) {
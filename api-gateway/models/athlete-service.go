@@ -31,10 +31,18 @@ type GetAthleteResponse struct {
 	DeletedAt   int64  `json:"deleted_at"`
 }
 
-type ListOfAthleteRequest struct{}
+type ListOfAthleteRequest struct {
+	PageSize  int32  `json:"page_size,omitempty"`
+	PageToken string `json:"page_token,omitempty"`
+	OrderBy   string `json:"order_by,omitempty"`
+	SportType string `json:"sport_type,omitempty"`
+	CountryID string `json:"country_id,omitempty"`
+}
 
 type ListOfAthleteResponse struct {
-	Athletes []GetAthleteResponse `json:"athletes"`
+	Athletes      []GetAthleteResponse `json:"athletes"`
+	NextPageToken string               `json:"next_page_token,omitempty"`
+	TotalCount    int64                `json:"total_count"`
 }
 
 type UpdateAthleteRequest struct {
@@ -51,3 +59,39 @@ type DeleteAthleteRequest struct {
 type DeleteAthleteResponse struct {
 	Status string `json:"status"`
 }
+
+type Team struct {
+	ID         string   `json:"id"`
+	Name       string   `json:"name"`
+	CountryID  string   `json:"country_id"`
+	SportType  string   `json:"sport_type"`
+	AthleteIDs []string `json:"athlete_ids"`
+	CreatedAt  string   `json:"created_at"`
+	UpdatedAt  string   `json:"updated_at"`
+	DeletedAt  int64    `json:"deleted_at"`
+}
+
+type CreateTeamRequest struct {
+	Name       string   `json:"name"`
+	CountryID  string   `json:"country_id"`
+	SportType  string   `json:"sport_type"`
+	AthleteIDs []string `json:"athlete_ids"`
+}
+
+type UpdateTeamRequest struct {
+	ID         string   `json:"id"`
+	Name       string   `json:"name"`
+	CountryID  string   `json:"country_id"`
+	SportType  string   `json:"sport_type"`
+	AthleteIDs []string `json:"athlete_ids"`
+}
+
+type ListTeamsResponse struct {
+	Teams         []Team `json:"teams"`
+	NextPageToken string `json:"next_page_token,omitempty"`
+	TotalCount    int64  `json:"total_count"`
+}
+
+type DeleteTeamResponse struct {
+	Status string `json:"status"`
+}
@@ -20,10 +20,17 @@ type GetCountryRequest struct {
 	ID string `json:"id"`
 }
 
-type ListOfCountryRequest struct{}
+type ListOfCountryRequest struct {
+	PageSize  int32  `json:"page_size,omitempty"`
+	PageToken string `json:"page_token,omitempty"`
+	OrderBy   string `json:"order_by,omitempty"`
+	Region    string `json:"region,omitempty"`
+}
 
 type ListOfCountryResponse struct {
-	Countries []Country `json:"countries"`
+	Countries     []Country `json:"countries"`
+	NextPageToken string    `json:"next_page_token,omitempty"`
+	TotalCount    int64     `json:"total_count"`
 }
 
 type UpdateCountryRequest struct {
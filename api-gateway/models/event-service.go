@@ -1,16 +1,32 @@
 package models
 
+// Event.Status is one of scheduled, live, delayed, completed or cancelled.
+// Sequence counts the revisions of the event since it was created.
+// Conflicts lists the sessions an event saved with allow_conflict overlaps
+// at its venue.
 type Event struct {
-	ID        string `json:"id"`
+	ID           string          `json:"id"`
+	Name         string          `json:"name"`
+	SportType    string          `json:"sport_type"`
+	Location     string          `json:"location"`
+	Date         string          `json:"date"`
+	StartTime    string          `json:"start_time"`
+	EndTime      string          `json:"end_time"`
+	VenueID      string          `json:"venue_id,omitempty"`
+	Status       string          `json:"status"`
+	StatusReason string          `json:"status_reason,omitempty"`
+	Sequence     int32           `json:"sequence"`
+	Conflicts    []VenueConflict `json:"conflicts,omitempty"`
+	CreatedAt    string          `json:"created_at"`
+	UpdatedAt    string          `json:"updated_at"`
+	DeletedAt    int64           `json:"deleted_at,omitempty"` // Optional field
+}
+
+type VenueConflict struct {
+	EventID   string `json:"event_id"`
 	Name      string `json:"name"`
-	SportType string `json:"sport_type"`
-	Location  string `json:"location"`
-	Date      string `json:"date"`
 	StartTime string `json:"start_time"`
 	EndTime   string `json:"end_time"`
-	CreatedAt string `json:"created_at"`
-	UpdatedAt string `json:"updated_at"`
-	DeletedAt int64 `json:"deleted_at,omitempty"` // Optional field
 }
 
 type CreateEventRequest struct {
@@ -20,26 +36,45 @@ type CreateEventRequest struct {
 	Date      string `json:"date"`
 	StartTime string `json:"start_time"`
 	EndTime   string `json:"end_time"`
+	// VenueID books the event into a venue, whose name becomes its location.
+	VenueID string `json:"venue_id,omitempty"`
+	// AllowConflict saves an event that overlaps another session at its
+	// venue instead of rejecting it.
+	AllowConflict bool `json:"allow_conflict,omitempty"`
 }
 
 type GetEventRequest struct {
 	ID string `json:"id"`
 }
 
-type ListOfEventRequest struct{}
+type ListOfEventRequest struct {
+	PageSize  int32  `json:"page_size,omitempty"`
+	PageToken string `json:"page_token,omitempty"`
+	OrderBy   string `json:"order_by,omitempty"`
+	SportType string `json:"sport_type,omitempty"`
+	Location  string `json:"location,omitempty"`
+	DateFrom  string `json:"date_from,omitempty"`
+	DateTo    string `json:"date_to,omitempty"`
+	VenueID   string `json:"venue_id,omitempty"`
+	Status    string `json:"status,omitempty"`
+}
 
 type ListOfEventResponse struct {
-	Events []Event `json:"events"`
+	Events        []Event `json:"events"`
+	NextPageToken string  `json:"next_page_token,omitempty"`
+	TotalCount    int64   `json:"total_count"`
 }
 
 type UpdateEventRequest struct {
-	ID        string `json:"id"`
-	Name      string `json:"name"`
-	SportType string `json:"sport_type"`
-	Location  string `json:"location"`
-	Date      string `json:"date"`
-	StartTime string `json:"start_time"`
-	EndTime   string `json:"end_time"`
+	ID            string `json:"id"`
+	Name          string `json:"name"`
+	SportType     string `json:"sport_type"`
+	Location      string `json:"location"`
+	Date          string `json:"date"`
+	StartTime     string `json:"start_time"`
+	EndTime       string `json:"end_time"`
+	VenueID       string `json:"venue_id,omitempty"`
+	AllowConflict bool   `json:"allow_conflict,omitempty"`
 }
 
 type DeleteEventRequest struct {
@@ -49,3 +84,144 @@ type DeleteEventRequest struct {
 type DeleteEventResponse struct {
 	Status string `json:"status"`
 }
+
+type TransitionEventRequest struct {
+	Status string `json:"status"`
+	Reason string `json:"reason,omitempty"`
+}
+
+type ScheduleResponse struct {
+	Date   string          `json:"date"`
+	Venues []VenueSchedule `json:"venues"`
+}
+
+// VenueSchedule holds one venue's sessions of the day in start order. Events
+// without a venue are grouped by location and have no venue_id.
+type VenueSchedule struct {
+	VenueID string  `json:"venue_id,omitempty"`
+	Name    string  `json:"name"`
+	Events  []Event `json:"events"`
+}
+
+type Venue struct {
+	ID        string `json:"id"`
+	Name      string `json:"name"`
+	City      string `json:"city"`
+	Capacity  int32  `json:"capacity"`
+	CreatedAt string `json:"created_at"`
+	UpdatedAt string `json:"updated_at"`
+	DeletedAt int64  `json:"deleted_at,omitempty"`
+}
+
+type CreateVenueRequest struct {
+	Name     string `json:"name"`
+	City     string `json:"city"`
+	Capacity int32  `json:"capacity"`
+}
+
+type UpdateVenueRequest struct {
+	Name     string `json:"name"`
+	City     string `json:"city"`
+	Capacity int32  `json:"capacity"`
+}
+
+type ListVenuesResponse struct {
+	Venues        []Venue `json:"venues"`
+	NextPageToken string  `json:"next_page_token,omitempty"`
+	TotalCount    int64   `json:"total_count"`
+}
+
+type DeleteVenueResponse struct {
+	Status string `json:"status"`
+}
+
+// Phase is one round of an event: heats, a semifinal, a final or a round of
+// a knockout bracket. Heats advance the first advance_per_unit of every unit
+// (Q) and the advance_fastest best of the rest (q); knockout rounds advance
+// match winners.
+type Phase struct {
+	ID             string `json:"id"`
+	EventID        string `json:"event_id"`
+	Name           string `json:"name"`
+	Position       int32  `json:"position"`
+	Format         string `json:"format"`
+	ResultType     string `json:"result_type"`
+	AdvancePerUnit int32  `json:"advance_per_unit"`
+	AdvanceFastest int32  `json:"advance_fastest"`
+	Units          []Unit `json:"units"`
+	CreatedAt      string `json:"created_at"`
+	UpdatedAt      string `json:"updated_at"`
+}
+
+// Unit is a heat, race or match. Its status is scheduled until the results
+// are recorded, then completed.
+type Unit struct {
+	ID       string      `json:"id"`
+	PhaseID  string      `json:"phase_id"`
+	Name     string      `json:"name"`
+	Position int32       `json:"position"`
+	Status   string      `json:"status"`
+	Entries  []UnitEntry `json:"entries"`
+}
+
+// UnitEntry is an athlete, or a country in team events, on a start list,
+// with its result once there is one. Irm is DNS, DNF or DSQ; qualified is Q
+// or q.
+type UnitEntry struct {
+	AthleteID string  `json:"athlete_id,omitempty"`
+	CountryID string  `json:"country_id"`
+	Lane      int32   `json:"lane,omitempty"`
+	Seed      int32   `json:"seed,omitempty"`
+	Mark      string  `json:"mark,omitempty"`
+	Value     float64 `json:"value,omitempty"`
+	Rank      int32   `json:"rank,omitempty"`
+	Irm       string  `json:"irm,omitempty"`
+	Qualified string  `json:"qualified,omitempty"`
+}
+
+type CreatePhaseRequest struct {
+	Name string `json:"name"`
+	// Format is heats (default) or knockout.
+	Format string `json:"format,omitempty"`
+	// ResultType is time, score or distance; it decides whether lower or
+	// higher values rank first.
+	ResultType     string `json:"result_type,omitempty"`
+	AdvancePerUnit int32  `json:"advance_per_unit,omitempty"`
+	AdvanceFastest int32  `json:"advance_fastest,omitempty"`
+	// Units is the number of heats or matches, 1 by default.
+	Units int32 `json:"units,omitempty"`
+}
+
+type ListPhasesResponse struct {
+	Phases []Phase `json:"phases"`
+}
+
+type DeletePhaseResponse struct {
+	Status string `json:"status"`
+}
+
+type SetStartListRequest struct {
+	Entries []UnitEntry `json:"entries"`
+}
+
+// RecordResultsRequest gives each entry's mark and value, or an irm. Ranks
+// are worked out from the values unless every result carries one.
+type RecordResultsRequest struct {
+	Results []UnitEntry `json:"results"`
+}
+
+type AdvancePhaseResponse struct {
+	Qualified []UnitEntry `json:"qualified"`
+	Next      Phase       `json:"next"`
+}
+
+type GenerateBracketRequest struct {
+	// Competitors are listed best seed first.
+	Competitors []UnitEntry `json:"competitors"`
+	BronzeMatch bool        `json:"bronze_match,omitempty"`
+	ResultType  string      `json:"result_type,omitempty"`
+}
+
+type GenerateBracketResponse struct {
+	Phases []Phase `json:"phases"`
+}
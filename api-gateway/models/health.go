@@ -0,0 +1,8 @@
+package models
+
+// Health is the body of /healthz and /readyz: the gateway's verdict and the
+// last known status of every downstream service.
+type Health struct {
+	Status   string            `json:"status"`
+	Services map[string]string `json:"services"`
+}
@@ -0,0 +1,14 @@
+package models
+
+type ImportRowError struct {
+	Row     int    `json:"row"`
+	Message string `json:"message"`
+}
+
+type ImportResponse struct {
+	Rows      int              `json:"rows"`
+	Accepted  int              `json:"accepted"`
+	Committed bool             `json:"committed"`
+	DryRun    bool             `json:"dry_run"`
+	Errors    []ImportRowError `json:"errors"`
+}
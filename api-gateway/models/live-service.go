@@ -1,9 +1,15 @@
 package models 
 
 type LiveStream struct {
+	ID        string            `json:"id"`
 	EventID   string            `json:"event_id"`
 	LeftSide  string            `json:"left_side"`
 	RightSide string            `json:"right_side"`
 	Action    map[string]string `json:"action"`
 	Timestamp string            `json:"timestamp"`
 }
+
+type ListLiveStreamResponse struct {
+	LiveStreams   []LiveStream `json:"live_streams"`
+	NextPageToken string       `json:"next_page_token,omitempty"`
+}
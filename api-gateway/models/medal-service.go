@@ -13,7 +13,9 @@ type Medal struct {
 	CountryID string    `json:"country_id"`
 	Type      MedalType `json:"type"`
 	EventID   string    `json:"event_id"`
-	AthleteID string    `json:"athlete_id"`
+	AthleteID string    `json:"athlete_id,omitempty"`
+	TeamID    string    `json:"team_id,omitempty"`
+	MemberIDs []string  `json:"member_ids,omitempty"`
 	CreatedAt string    `json:"created_at"`
 	UpdatedAt string    `json:"updated_at"`
 	DeletedAt int64     `json:"deleted_at"`
@@ -23,7 +25,8 @@ type CreateMedalRequest struct {
 	CountryID string    `json:"country_id"`
 	Type      MedalType `json:"type"`
 	EventID   string    `json:"event_id"`
-	AthleteID string    `json:"athlete_id"`
+	AthleteID string    `json:"athlete_id,omitempty"`
+	TeamID    string    `json:"team_id,omitempty"`
 }
 
 type CreateMedalResponse struct {
@@ -31,7 +34,9 @@ type CreateMedalResponse struct {
 	CountryID string    `json:"country_id"`
 	Type      MedalType `json:"type"`
 	EventID   string    `json:"event_id"`
-	AthleteID string    `json:"athlete_id"`
+	AthleteID string    `json:"athlete_id,omitempty"`
+	TeamID    string    `json:"team_id,omitempty"`
+	MemberIDs []string  `json:"member_ids,omitempty"`
 	CreatedAt string    `json:"created_at"`
 	UpdatedAt string    `json:"updated_at"`
 	DeletedAt int64     `json:"deleted_at"`
@@ -46,21 +51,28 @@ type GetMedalByIdResponse struct {
 	CountryID string    `json:"country_id"`
 	Type      MedalType `json:"type"`
 	EventID   string    `json:"event_id"`
-	AthleteID string    `json:"athlete_id"`
+	AthleteID string    `json:"athlete_id,omitempty"`
+	TeamID    string    `json:"team_id,omitempty"`
+	MemberIDs []string  `json:"member_ids,omitempty"`
 	CreatedAt string    `json:"created_at"`
 	UpdatedAt string    `json:"updated_at"`
 	DeletedAt int64     `json:"deleted_at"`
 }
 
 type GetMedalsResponse struct {
-	Medals []Medal `json:"medals"`
+	Medals        []Medal `json:"medals"`
+	NextPageToken string  `json:"next_page_token,omitempty"`
+	TotalCount    int64   `json:"total_count"`
 }
 
 type GetMedalByFilterRequest struct {
-	CountryID string    `json:"country_id,omitempty"`
-	Type      MedalType `json:"type,omitempty"`
-	EventID   string    `json:"event_id,omitempty"`
-	AthleteID string    `json:"athlete_id,omitempty"`
+	CountryIDs  []string    `json:"country_ids,omitempty"`
+	Types       []MedalType `json:"types,omitempty"`
+	EventIDs    []string    `json:"event_ids,omitempty"`
+	AthleteIDs  []string    `json:"athlete_ids,omitempty"`
+	TeamIDs     []string    `json:"team_ids,omitempty"`
+	CreatedFrom string      `json:"created_from,omitempty"`
+	CreatedTo   string      `json:"created_to,omitempty"`
 }
 
 type GetMedalByFilterResponse struct {
@@ -72,7 +84,8 @@ type UpdateMedalRequest struct {
 	CountryID string    `json:"country_id"`
 	Type      MedalType `json:"type"`
 	EventID   string    `json:"event_id"`
-	AthleteID string    `json:"athlete_id"`
+	AthleteID string    `json:"athlete_id,omitempty"`
+	TeamID    string    `json:"team_id,omitempty"`
 }
 
 type UpdateMedalResponse struct {
@@ -80,7 +93,9 @@ type UpdateMedalResponse struct {
 	CountryID string    `json:"country_id"`
 	Type      MedalType `json:"type"`
 	EventID   string    `json:"event_id"`
-	AthleteID string    `json:"athlete_id"`
+	AthleteID string    `json:"athlete_id,omitempty"`
+	TeamID    string    `json:"team_id,omitempty"`
+	MemberIDs []string  `json:"member_ids,omitempty"`
 	CreatedAt string    `json:"created_at"`
 	UpdatedAt string    `json:"updated_at"`
 	DeletedAt int64     `json:"deleted_at"`
@@ -94,4 +109,46 @@ type DeleteMedalResponse struct {
 	Success bool `json:"success"`
 }
 
-type VoidMedal struct{}
+type GetMedalsRequest struct {
+	PageSize  int32  `json:"page_size,omitempty"`
+	PageToken string `json:"page_token,omitempty"`
+	OrderBy   string `json:"order_by,omitempty"`
+	CountryID string `json:"country_id,omitempty"`
+	EventID   string `json:"event_id,omitempty"`
+	AthleteID string `json:"athlete_id,omitempty"`
+	TeamID    string `json:"team_id,omitempty"`
+}
+
+type MedalTableRow struct {
+	Rank        int32  `json:"rank"`
+	CountryID   string `json:"country_id"`
+	CountryName string `json:"country_name"`
+	Flag        string `json:"flag"`
+	Gold        int32  `json:"gold"`
+	Silver      int32  `json:"silver"`
+	Bronze      int32  `json:"bronze"`
+	Total       int32  `json:"total"`
+}
+
+type GetMedalTableResponse struct {
+	RankBy string          `json:"rank_by"`
+	Rows   []MedalTableRow `json:"rows"`
+}
+
+type MedalAward struct {
+	MedalID   string    `json:"medal_id,omitempty"`
+	Type      MedalType `json:"type"`
+	CountryID string    `json:"country_id"`
+	AthleteID string    `json:"athlete_id,omitempty"`
+	TeamID    string    `json:"team_id,omitempty"`
+	Action    string    `json:"action"`
+}
+
+type FinalizeEventResponse struct {
+	EventID   string       `json:"event_id"`
+	Awards    []MedalAward `json:"awards"`
+	Created   int32        `json:"created"`
+	Kept      int32        `json:"kept"`
+	Removed   int32        `json:"removed"`
+	Committed bool         `json:"committed"`
+}
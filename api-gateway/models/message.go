@@ -1,5 +1,11 @@
 package models
 
+// Message is the body of every error response. Err keeps its original key for
+// existing clients; Code is the machine-readable gRPC code (e.g. NOT_FOUND)
+// and RequestID matches the X-Request-ID header and the gateway's logs.
 type Message struct {
-	Err string
-}
\ No newline at end of file
+	Err       string
+	Code      string   `json:"code,omitempty"`
+	Details   []string `json:"details,omitempty"`
+	RequestID string   `json:"request_id,omitempty"`
+}
@@ -11,11 +11,21 @@ type RefreshTokenResponse struct {
 	RefreshToken string `json:"refresh_token,omitempty"`
 }
 
+type LogoutResponse struct {
+	Success bool   `json:"success"`
+	Message string `json:"message"`
+}
+
+type LogoutAllResponse struct {
+	Success  bool   `json:"success"`
+	Message  string `json:"message"`
+	Sessions int32  `json:"sessions"`
+}
+
 type User struct {
 	ID        string `json:"id"`
 	Username  string `json:"username"`
 	Role      string `json:"role"`
-	Password  string `json:"password"`
 	CreatedAt string `json:"created_at"`
 	UpdatedAt string `json:"updated_at"`
 	DeletedAt int64  `json:"deleted_at"`
@@ -63,12 +73,19 @@ type GetUserResponse struct {
 }
 
 type GetUsersResponse struct {
-	Success bool   `json:"success"`
-	Message string `json:"message"`
-	Users   []User `json:"users"`
+	Success       bool   `json:"success"`
+	Message       string `json:"message"`
+	Users         []User `json:"users"`
+	NextPageToken string `json:"next_page_token,omitempty"`
+	TotalCount    int64  `json:"total_count"`
 }
 
-type Void struct{}
+type GetUsersRequest struct {
+	PageSize  int32  `json:"page_size,omitempty"`
+	PageToken string `json:"page_token,omitempty"`
+	OrderBy   string `json:"order_by,omitempty"`
+	Role      string `json:"role,omitempty"`
+}
 
 type UserFilter struct {
 	Username string `json:"username"`
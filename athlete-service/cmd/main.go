@@ -1,20 +1,19 @@
 package main
 
 import (
+	"athlete-service/internal/athlete/pkg/healthcheck"
 	config "athlete-service/internal/athlete/pkg/load"
+	"athlete-service/internal/athlete/pkg/metrics"
+	"athlete-service/internal/athlete/pkg/tracing"
 	pq "athlete-service/internal/athlete/pkg/postgres"
 	rpc "athlete-service/internal/athlete/pkg/register-service"
 	athleteRepo "athlete-service/internal/athlete/repository"
 	athleteService "athlete-service/internal/athlete/service"
 	"athlete-service/logger"
 	"context"
-	"os"
 	"os/signal"
-	"sync"
 	"syscall"
 	"time"
-
-	"google.golang.org/grpc"
 )
 
 func main() {
@@ -27,40 +26,51 @@ func main() {
 	}
 	logger.Info("Configuration loaded successfully")
 
+	shutdownTracing, err := tracing.Init(context.Background(), "athlete-service", *cfg)
+	if err != nil {
+		logger.Fatal("Failed to set up tracing: ", err)
+	}
+
 	db, err := pq.ConnectDB(*cfg)
 	if err != nil {
 		logger.Fatal("Failed to connect to database: ", err)
 	}
 	logger.Info("Connected to the database successfully")
+	metrics.RegisterDB(db, cfg.Postgres.Database)
 
 	repo := athleteRepo.NewPostgresAthleteRepository(db)
 
 	service := athleteService.NewAthleteService(repo)
-	r := rpc.NewGrpcService(service)
-
-	var wg sync.WaitGroup
-	wg.Add(1)
+	r := rpc.NewGrpcService(service, healthcheck.Checks{
+		"postgres": db.PingContext,
+	})
 
-	gServer := grpc.NewServer()
-	go func() {
-		defer wg.Done()
-		if err = r.RUN(*cfg); err != nil {
-			logger.Fatal("Failed to run gRPC service: ", err)
-		}
-	}()
+	app, err := r.RUN(*cfg)
+	if err != nil {
+		logger.Fatal("Failed to run gRPC service: ", err)
+	}
+	app.Own("log file", func(context.Context) error { return logger.Close() })
+	app.Own("tracing", shutdownTracing)
+	app.OwnCloser("postgres", db)
 	logger.Info("Athlete service started successfully")
 
-	sigChan := make(chan os.Signal, 1)
-	signal.Notify(sigChan, syscall.SIGINT, syscall.SIGTERM)
+	app.ServeHTTP("metrics listener", metrics.Server(*cfg))
 
-	sig := <-sigChan
-	logger.Info("Received signal:", sig)
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
 
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-	defer cancel()
+	if err := app.Wait(ctx); err != nil {
+		logger.Error("gRPC server stopped: ", err)
+	} else {
+		logger.Info("Received shutdown signal")
+	}
 
-	gServer.GracefulStop()
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
 
-	<-ctx.Done()
+	if err := app.Shutdown(shutdownCtx); err != nil {
+		logger.Error("Shutdown incomplete: ", err)
+		return
+	}
 	logger.Info("Graceful shutdown complete.")
 }
@@ -0,0 +1,20 @@
+CREATE TABLE teams (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    name VARCHAR(255) NOT NULL,
+    country_id UUID NOT NULL,
+    sport_type VARCHAR(255),
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    deleted_at BIGINT DEFAULT 0
+);
+
+-- The roster of a team, in the order it was given. Members may represent
+-- other countries than the team, as in mixed-nation pairs.
+CREATE TABLE team_members (
+    team_id UUID NOT NULL REFERENCES teams(id) ON DELETE CASCADE,
+    athlete_id UUID NOT NULL REFERENCES athletes(id),
+    position INT NOT NULL,
+    PRIMARY KEY (team_id, athlete_id)
+);
+
+CREATE INDEX team_members_athlete_id_idx ON team_members (athlete_id);
@@ -10,11 +10,30 @@ type PostgresConfig struct {
 	Database string
 }
 
+// TracingConfig selects where spans are exported: otlp (a collector listening
+// on Endpoint), stdout or none.
+type TracingConfig struct {
+	Exporter    string
+	Endpoint    string
+	SampleRatio float64
+}
+
+// MetricsConfig controls the Prometheus listener. It serves Path on its own
+// Port so scrapes never go through the gRPC server.
+type MetricsConfig struct {
+	Enabled bool
+	Port    int
+	Path    string
+}
+
 type Config struct {
 	Postgres PostgresConfig
 
 	ServerHost string
 	ServerPort int
+
+	Tracing TracingConfig
+	Metrics MetricsConfig
 }
 
 func Load(path string) (*Config, error) {
@@ -22,6 +41,10 @@ func Load(path string) (*Config, error) {
 	viper.SetConfigFile(path)
 	viper.SetConfigType("yaml")
 
+	viper.SetDefault("tracing.exporter", "none")
+	viper.SetDefault("tracing.sample_ratio", 1.0)
+	viper.SetDefault("metrics.path", "/metrics")
+
 	if err := viper.ReadInConfig(); err != nil {
 		return nil, err
 	}
@@ -36,6 +59,18 @@ func Load(path string) (*Config, error) {
 		},
 		ServerHost: viper.GetString("server.host"),
 		ServerPort: viper.GetInt("server.port"),
+
+		Tracing: TracingConfig{
+			Exporter:    viper.GetString("tracing.exporter"),
+			Endpoint:    viper.GetString("tracing.endpoint"),
+			SampleRatio: viper.GetFloat64("tracing.sample_ratio"),
+		},
+
+		Metrics: MetricsConfig{
+			Enabled: viper.GetBool("metrics.enabled"),
+			Port:    viper.GetInt("metrics.port"),
+			Path:    viper.GetString("metrics.path"),
+		},
 	}
 	return &cfg, nil
 }
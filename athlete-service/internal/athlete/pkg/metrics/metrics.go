@@ -0,0 +1,41 @@
+// Package metrics exposes the service's Prometheus metrics on their own HTTP
+// listener, next to the gRPC port.
+package metrics
+
+import (
+	"database/sql"
+	"fmt"
+	"net/http"
+
+	config "athlete-service/internal/athlete/pkg/load"
+
+	grpcprom "github.com/grpc-ecosystem/go-grpc-middleware/providers/prometheus"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// GRPC counts and times every RPC handled by the server. Its interceptors
+// are installed when the gRPC server is created.
+var GRPC = grpcprom.NewServerMetrics(grpcprom.WithServerHandlingTimeHistogram())
+
+func init() {
+	prometheus.MustRegister(GRPC)
+}
+
+// RegisterDB exports the connection pool stats of db under the given name.
+func RegisterDB(db *sql.DB, name string) {
+	prometheus.MustRegister(collectors.NewDBStatsCollector(db, name))
+}
+
+// Server returns the listener that answers scrapes on the configured port,
+// or nil when metrics are disabled.
+func Server(cfg config.Config) *http.Server {
+	if !cfg.Metrics.Enabled {
+		return nil
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle(cfg.Metrics.Path, promhttp.Handler())
+	return &http.Server{Addr: fmt.Sprintf(":%d", cfg.Metrics.Port), Handler: mux}
+}
@@ -1,10 +1,13 @@
 package repository
 
 import (
+	"context"
 	pb "github.com/Bekzodbekk/paris2024_livestream_protos/genproto/athletepb"
 	"athlete-service/logger"
 	"database/sql"
 	"errors"
+	"shared/paging"
+	"strings"
 
 	"github.com/sirupsen/logrus"
 )
@@ -19,7 +22,7 @@ func NewPostgresAthleteRepository(db *sql.DB) AthleteRepository {
 	}
 }
 
-func (db *PostgresAthleteRepository) CreateAthlete(req *pb.CreateAthleteRequest) (*pb.Athlete, error) {
+func (db *PostgresAthleteRepository) CreateAthlete(ctx context.Context, req *pb.CreateAthleteRequest) (*pb.Athlete, error) {
 
 	resp := pb.Athlete{}
 	query := `
@@ -27,7 +30,7 @@ func (db *PostgresAthleteRepository) CreateAthlete(req *pb.CreateAthleteRequest)
 	VALUES($1, $2, $3)
 	RETURNING id, name, country_id, sport_type, created_at, updated_at, deleted_at`
 
-	err := db.DB.QueryRow(query, req.Name, req.CountryId, req.SportType).Scan(
+	err := db.DB.QueryRowContext(ctx, query, req.Name, req.CountryId, req.SportType).Scan(
 		&resp.Id,
 		&resp.Name,
 		&resp.CountryId,
@@ -38,20 +41,20 @@ func (db *PostgresAthleteRepository) CreateAthlete(req *pb.CreateAthleteRequest)
 	)
 
 	if err != nil {
-		logger.Error("Creating athlete failed", logrus.Fields{
+		logger.ErrorContext(ctx, "Creating athlete failed", logrus.Fields{
             "error": err,
         })
 		return nil, err
 	}
 
-	logger.Info("Athlete created successfully", logrus.Fields{
+	logger.InfoContext(ctx, "Athlete created successfully", logrus.Fields{
         "athlete_id": resp.Id, 
         "name": resp.Name,
     })
 	return &resp, nil
 }
 
-func (db *PostgresAthleteRepository) GetAthlete(req *pb.GetAthleteRequest) (*pb.GetAthleteResponse, error) {
+func (db *PostgresAthleteRepository) GetAthlete(ctx context.Context, req *pb.GetAthleteRequest) (*pb.GetAthleteResponse, error) {
 
 	resp := pb.GetAthleteResponse{}
 	query := `
@@ -59,7 +62,7 @@ func (db *PostgresAthleteRepository) GetAthlete(req *pb.GetAthleteRequest) (*pb.
 	FROM athletes 
 	WHERE id=$1 AND deleted_at=0`
 
-	err := db.DB.QueryRow(query, req.Id).Scan(
+	err := db.DB.QueryRowContext(ctx, query, req.Id).Scan(
 		&resp.Id,
 		&resp.Name,
 		&resp.CountryId,
@@ -70,29 +73,54 @@ func (db *PostgresAthleteRepository) GetAthlete(req *pb.GetAthleteRequest) (*pb.
 	)
 
 	if err != nil {
-		logger.Error("Retrieving athlete failed", logrus.Fields{
+		logger.ErrorContext(ctx, "Retrieving athlete failed", logrus.Fields{
             "error": err, "athlete_id": req.Id,
         })
 		return nil, err
 	}
 
-	logger.Info("Athlete retrieved successfully", logrus.Fields{
+	logger.InfoContext(ctx, "Athlete retrieved successfully", logrus.Fields{
         "athlete_id": resp.Id, 
         "name": resp.Name,
     })
 	return &resp, nil
 }
 
-func (db *PostgresAthleteRepository) ListAthletes(req *pb.ListOfAthleteRequest) (*pb.ListOfAthleteResponse, error) {
+// athleteOrderColumns maps the order_by fields accepted by ListAthletes to columns.
+var athleteOrderColumns = map[string]string{
+	"name":       "name",
+	"sport_type": "sport_type",
+	"country_id": "country_id",
+	"created_at": "created_at",
+}
+
+func (db *PostgresAthleteRepository) ListAthletes(ctx context.Context, req *pb.ListOfAthleteRequest) (*pb.ListOfAthleteResponse, error) {
+
+	p, err := paging.New(req.PageSize, req.PageToken, req.OrderBy, athleteOrderColumns, "name")
+	if err != nil {
+		return nil, err
+	}
+
+	filter := paging.NewConditions("deleted_at=0")
+	if req.SportType != "" {
+		filter.Add("sport_type=$%d", req.SportType)
+	}
+	if req.CountryId != "" {
+		filter.Add("country_id=$%d", req.CountryId)
+	}
 
 	resp := pb.ListOfAthleteResponse{}
+	if err := db.DB.QueryRowContext(ctx, "SELECT COUNT(*) FROM athletes WHERE "+filter.String(), filter.Args()...).Scan(&resp.TotalCount); err != nil {
+		logger.ErrorContext(ctx, "Counting athletes failed", logrus.Fields{"error": err})
+		return nil, err
+	}
+
 	query := 
-	`SELECT * 
-	FROM athletes 
-	WHERE deleted_at=0`
-	rows, err := db.DB.Query(query)
+	`SELECT id, name, country_id, sport_type, created_at, updated_at, deleted_at 
+	FROM athletes` + p.Clause(filter)
+	rows, err := db.DB.QueryContext(ctx, query, filter.Args()...)
 	if err != nil {
-		logger.Error("Listing athletes failed", logrus.Fields{"error": err})
+		logger.ErrorContext(ctx, "Listing athletes failed", logrus.Fields{"error": err})
 		return nil, err
 	}
 	defer rows.Close()
@@ -109,21 +137,45 @@ func (db *PostgresAthleteRepository) ListAthletes(req *pb.ListOfAthleteRequest)
 			&item.DeletedAt,
 		)
 		if err != nil {
-			logger.Error("Decoding athlete failed", logrus.Fields{
+			logger.ErrorContext(ctx, "Decoding athlete failed", logrus.Fields{
                 "error": err,
             })
 			return nil, err
 		}
 		resp.Athletes = append(resp.Athletes, &item)
 	}
+	if err := rows.Err(); err != nil {
+		logger.ErrorContext(ctx, "Listing athletes failed", logrus.Fields{"error": err})
+		return nil, err
+	}
 
-	logger.Info("Athletes listed successfully", logrus.Fields{
+	if len(resp.Athletes) > p.Size {
+		resp.Athletes = resp.Athletes[:p.Size]
+		last := resp.Athletes[p.Size-1]
+		resp.NextPageToken = p.NextToken(athleteSortValue(last, p.Column), last.Id)
+	}
+
+	logger.InfoContext(ctx, "Athletes listed successfully", logrus.Fields{
         "athletes_count": len(resp.Athletes),
+        "total_count":    resp.TotalCount,
     })
 	return &resp, nil
 }
 
-func (db *PostgresAthleteRepository) UpdateAthlete(req *pb.UpdateAthleteRequest) (*pb.Athlete, error) {
+func athleteSortValue(a *pb.GetAthleteResponse, column string) string {
+	switch column {
+	case "sport_type":
+		return a.SportType
+	case "country_id":
+		return a.CountryId
+	case "created_at":
+		return a.CreatedAt
+	default:
+		return a.Name
+	}
+}
+
+func (db *PostgresAthleteRepository) UpdateAthlete(ctx context.Context, req *pb.UpdateAthleteRequest) (*pb.Athlete, error) {
 
 	resp := pb.Athlete{}
 	query := `
@@ -132,7 +184,7 @@ func (db *PostgresAthleteRepository) UpdateAthlete(req *pb.UpdateAthleteRequest)
 	WHERE id=$4 AND deleted_at=0
 	RETURNING id, name, country_id, sport_type, created_at, updated_at, deleted_at`
 
-	err := db.DB.QueryRow(query, req.Name, req.CountryId, req.SportType, req.Id).Scan(
+	err := db.DB.QueryRowContext(ctx, query, req.Name, req.CountryId, req.SportType, req.Id).Scan(
 		&resp.Id,
 		&resp.Name,
 		&resp.CountryId,
@@ -143,21 +195,21 @@ func (db *PostgresAthleteRepository) UpdateAthlete(req *pb.UpdateAthleteRequest)
 	)
 
 	if err != nil {
-		logger.Error("Updating athlete failed", logrus.Fields{
+		logger.ErrorContext(ctx, "Updating athlete failed", logrus.Fields{
             "error": err, 
             "athlete_id": req.Id,
         })
 		return nil, err
 	}
 
-	logger.Info("Athlete updated successfully", logrus.Fields{
+	logger.InfoContext(ctx, "Athlete updated successfully", logrus.Fields{
         "athlete_id": resp.Id, 
         "name": resp.Name,
     })
 	return &resp, nil
 }
 
-func (db *PostgresAthleteRepository) DeleteAthlete(req *pb.DeleteAthleteRequest) (*pb.DeleteAthleteResponse, error) {
+func (db *PostgresAthleteRepository) DeleteAthlete(ctx context.Context, req *pb.DeleteAthleteRequest) (*pb.DeleteAthleteResponse, error) {
 
 	resp := pb.DeleteAthleteResponse{}
 	query := `
@@ -165,9 +217,9 @@ func (db *PostgresAthleteRepository) DeleteAthlete(req *pb.DeleteAthleteRequest)
 	SET deleted_at=DATE_PART('epoch', CURRENT_TIMESTAMP)::INT 
 	WHERE id=$1`
 
-	result, err := db.DB.Exec(query, req.Id)
+	result, err := db.DB.ExecContext(ctx, query, req.Id)
 	if err != nil {
-		logger.Error("Deleting athlete failed", logrus.Fields{
+		logger.ErrorContext(ctx, "Deleting athlete failed", logrus.Fields{
             "error": err, 
             "athlete_id": req.Id,
         })
@@ -176,22 +228,117 @@ func (db *PostgresAthleteRepository) DeleteAthlete(req *pb.DeleteAthleteRequest)
 
 	num, err := result.RowsAffected()
 	if err != nil {
-		logger.Error("Getting affected rows failed", logrus.Fields{
+		logger.ErrorContext(ctx, "Getting affected rows failed", logrus.Fields{
             "error": err,
         })
 		return nil, err
 	}
 
 	if num == 0 {
-		logger.Warn("No rows affected for deletion", logrus.Fields{
+		logger.WarnContext(ctx, "No rows affected for deletion", logrus.Fields{
             "athlete_id": req.Id,
         })
-		return nil, errors.New("no rows affected")
+		return nil, sql.ErrNoRows
 	}
 
 	resp.Status = "deleted successfully"
-	logger.Info("Athlete deleted successfully", logrus.Fields{
+	logger.InfoContext(ctx, "Athlete deleted successfully", logrus.Fields{
         "athlete_id": req.Id},
     )
 	return &resp, nil
 }
+
+// ImportAthletes inserts a batch of athletes in one transaction. Whether the
+// referenced countries exist is checked by the caller; here every row must
+// carry a name, a country and a sport.
+func (db *PostgresAthleteRepository) ImportAthletes(ctx context.Context, req *pb.ImportAthletesRequest) (*pb.ImportResponse, error) {
+
+	accepted, failed, committed, err := importRows(ctx, db.DB, len(req.Athletes), req.DryRun, func(tx *sql.Tx, i int) error {
+		athlete := req.Athletes[i]
+		switch {
+		case strings.TrimSpace(athlete.Name) == "":
+			return errors.New("name is required")
+		case athlete.CountryId == "":
+			return errors.New("country_id is required")
+		case strings.TrimSpace(athlete.SportType) == "":
+			return errors.New("sport_type is required")
+		}
+
+		_, err := tx.ExecContext(ctx, `
+		INSERT INTO athletes(name, country_id, sport_type) 
+		VALUES($1, $2, $3)`, athlete.Name, athlete.CountryId, athlete.SportType)
+		return err
+	})
+	if err != nil {
+		logger.ErrorContext(ctx, "Importing athletes failed", logrus.Fields{"error": err})
+		return nil, err
+	}
+
+	resp := pb.ImportResponse{
+		Accepted:  int32(accepted),
+		Committed: committed,
+	}
+	for _, row := range failed {
+		resp.Errors = append(resp.Errors, &pb.ImportRowError{
+			Index:   int32(row.index),
+			Message: row.err.Error(),
+		})
+	}
+
+	logger.InfoContext(ctx, "Athletes imported", logrus.Fields{
+		"rows":      len(req.Athletes),
+		"accepted":  accepted,
+		"failed":    len(failed),
+		"committed": committed,
+		"dry_run":   req.DryRun,
+	})
+	return &resp, nil
+}
+
+// ExportAthletes hands every athlete to send as it is read, so the caller can
+// stream the table without holding it in memory.
+func (db *PostgresAthleteRepository) ExportAthletes(ctx context.Context, req *pb.ExportAthletesRequest, send func(*pb.Athlete) error) error {
+
+	query := 
+	`SELECT id, name, country_id, sport_type, created_at, updated_at, deleted_at 
+	FROM athletes 
+	WHERE deleted_at=0
+	ORDER BY name, id`
+	rows, err := db.DB.QueryContext(ctx, query)
+	if err != nil {
+		logger.ErrorContext(ctx, "Exporting athletes failed", logrus.Fields{"error": err})
+		return err
+	}
+	defer rows.Close()
+
+	count := 0
+	for rows.Next() {
+		item := pb.Athlete{}
+		err := rows.Scan(
+			&item.Id,
+			&item.Name,
+			&item.CountryId,
+			&item.SportType,
+			&item.CreatedAt,
+			&item.UpdatedAt,
+			&item.DeletedAt,
+		)
+		if err != nil {
+			logger.ErrorContext(ctx, "Decoding athlete failed", logrus.Fields{"error": err})
+			return err
+		}
+		if err := send(&item); err != nil {
+			return err
+		}
+		count++
+	}
+	if err := rows.Err(); err != nil {
+		logger.ErrorContext(ctx, "Exporting athletes failed", logrus.Fields{"error": err})
+		return err
+	}
+
+	logger.InfoContext(ctx, "Athletes exported successfully", logrus.Fields{
+		"athletes_count": count,
+	})
+	return nil
+}
@@ -1,6 +1,8 @@
 package repository
 
 import (
+	"context"
+	"shared/paging"
 	"testing"
 
 	"github.com/DATA-DOG/go-sqlmock"
@@ -27,12 +29,6 @@ func TestCreateAthlete(t *testing.T) {
 		SportType: "SportType",
 	}
 
-	// Mock the country check
-	mock.ExpectQuery(`SELECT id, name FROM countries WHERE id=\$1 AND deleted_at=0`).
-		WithArgs(req.CountryId).
-		WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).
-			AddRow(req.CountryId, "CountryName"))
-
 	// Mock the athlete creation
 	rows := sqlmock.NewRows([]string{"id", "name", "country_id", "sport_type", "created_at", "updated_at", "deleted_at"}).
 		AddRow(1, req.Name, req.CountryId, req.SportType, "2024-08-07T00:00:00Z", "2024-08-07T00:00:00Z", 0)
@@ -41,7 +37,7 @@ func TestCreateAthlete(t *testing.T) {
 		WithArgs(req.Name, req.CountryId, req.SportType).
 		WillReturnRows(rows)
 
-	athlete, err := repo.CreateAthlete(req)
+	athlete, err := repo.CreateAthlete(context.Background(), req)
 	assert.NoError(t, err)
 	assert.Equal(t, "1", athlete.Id)
 	assert.Equal(t, req.Name, athlete.Name)
@@ -53,18 +49,17 @@ func TestGetAthlete(t *testing.T) {
 	repo, mock := setupTestDB(t)
 
 	req := &pb.GetAthleteRequest{Id: "1"}
-	rows := sqlmock.NewRows([]string{"id", "name", "country_name", "country_id", "sport_type", "created_at", "updated_at", "deleted_at"}).
-		AddRow(req.Id, "AthleteName", "CountryName", 1, "SportType", "2024-08-07T00:00:00Z", "2024-08-07T00:00:00Z", 0)
+	rows := sqlmock.NewRows([]string{"id", "name", "country_id", "sport_type", "created_at", "updated_at", "deleted_at"}).
+		AddRow(req.Id, "AthleteName", 1, "SportType", "2024-08-07T00:00:00Z", "2024-08-07T00:00:00Z", 0)
 
-	mock.ExpectQuery(`SELECT a.id, a.name, c.name AS country_name, a.country_id, a.sport_type, a.created_at, a.updated_at, a.deleted_at FROM athletes AS a INNER JOIN countries AS c ON a.country_id = c.id WHERE a.id=\$1 AND a.deleted_at=0 AND c.deleted_at=0`).
+	mock.ExpectQuery(`SELECT \* FROM athletes WHERE id=\$1 AND deleted_at=0`).
 		WithArgs(req.Id).
 		WillReturnRows(rows)
 
-	athlete, err := repo.GetAthlete(req)
+	athlete, err := repo.GetAthlete(context.Background(), req)
 	assert.NoError(t, err)
 	assert.Equal(t, req.Id, athlete.Id)
 	assert.Equal(t, "AthleteName", athlete.Name)
-	assert.Equal(t, "CountryName", athlete.CountryName)
 	assert.Equal(t, "1", athlete.CountryId)
 	assert.Equal(t, "SportType", athlete.SportType)
 }
@@ -72,22 +67,46 @@ func TestGetAthlete(t *testing.T) {
 func TestListAthletes(t *testing.T) {
 	repo, mock := setupTestDB(t)
 
-	rows := sqlmock.NewRows([]string{"id", "name", "country_name", "country_id", "sport_type", "created_at", "updated_at", "deleted_at"}).
-		AddRow(1, "Athlete1", "Country1", 1, "SportType1", "2024-08-07T00:00:00Z", "2024-08-07T00:00:00Z", 0).
-		AddRow(2, "Athlete2", "Country2", 2, "SportType2", "2024-08-07T00:00:00Z", "2024-08-07T00:00:00Z", 0)
+	rows := sqlmock.NewRows([]string{"id", "name", "country_id", "sport_type", "created_at", "updated_at", "deleted_at"}).
+		AddRow(1, "Athlete1", 1, "SportType1", "2024-08-07T00:00:00Z", "2024-08-07T00:00:00Z", 0).
+		AddRow(2, "Athlete2", 2, "SportType2", "2024-08-07T00:00:00Z", "2024-08-07T00:00:00Z", 0)
 
-	mock.ExpectQuery(`SELECT a.id, a.name, c.name AS country_name, a.country_id, a.sport_type, a.created_at, a.updated_at, a.deleted_at FROM athletes AS a INNER JOIN countries AS c ON a.country_id = c.id WHERE a.deleted_at=0 AND c.deleted_at=0`).
+	mock.ExpectQuery(`SELECT COUNT\(\*\) FROM athletes WHERE deleted_at=0`).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(2))
+	mock.ExpectQuery(`SELECT id, name, country_id, sport_type, created_at, updated_at, deleted_at FROM athletes WHERE deleted_at=0 ORDER BY name ASC, id ASC LIMIT \$1`).
+		WithArgs(paging.DefaultPageSize + 1).
 		WillReturnRows(rows)
 
-	resp, err := repo.ListAthletes(&pb.ListOfAthleteRequest{})
+	resp, err := repo.ListAthletes(context.Background(), &pb.ListOfAthleteRequest{})
 	assert.NoError(t, err)
 	assert.Equal(t, 2, len(resp.Athletes))
+	assert.Equal(t, int64(2), resp.TotalCount)
 	assert.Equal(t, "1", resp.Athletes[0].Id)
 	assert.Equal(t, "Athlete1", resp.Athletes[0].Name)
-	assert.Equal(t, "Country1", resp.Athletes[0].CountryName)
 	assert.Equal(t, "SportType1", resp.Athletes[0].SportType)
 }
 
+func TestListAthletesFiltered(t *testing.T) {
+	repo, mock := setupTestDB(t)
+
+	rows := sqlmock.NewRows([]string{"id", "name", "country_id", "sport_type", "created_at", "updated_at", "deleted_at"}).
+		AddRow(1, "Athlete1", 1, "Judo", "2024-08-07T00:00:00Z", "2024-08-07T00:00:00Z", 0).
+		AddRow(3, "Athlete3", 1, "Judo", "2024-08-07T00:00:00Z", "2024-08-07T00:00:00Z", 0)
+
+	mock.ExpectQuery(`SELECT COUNT\(\*\) FROM athletes WHERE deleted_at=0 AND sport_type=\$1 AND country_id=\$2`).
+		WithArgs("Judo", "1").
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(5))
+	mock.ExpectQuery(`FROM athletes WHERE deleted_at=0 AND sport_type=\$1 AND country_id=\$2 ORDER BY created_at DESC, id DESC LIMIT \$3`).
+		WithArgs("Judo", "1", 2).
+		WillReturnRows(rows)
+
+	resp, err := repo.ListAthletes(context.Background(), &pb.ListOfAthleteRequest{PageSize: 1, OrderBy: "created_at desc", SportType: "Judo", CountryId: "1"})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(resp.Athletes))
+	assert.Equal(t, int64(5), resp.TotalCount)
+	assert.NotEmpty(t, resp.NextPageToken)
+}
+
 func TestUpdateAthlete(t *testing.T) {
 	repo, mock := setupTestDB(t)
 
@@ -105,7 +124,7 @@ func TestUpdateAthlete(t *testing.T) {
 		WithArgs(req.Name, req.CountryId, req.SportType, req.Id).
 		WillReturnRows(rows)
 
-	athlete, err := repo.UpdateAthlete(req)
+	athlete, err := repo.UpdateAthlete(context.Background(), req)
 	assert.NoError(t, err)
 	assert.Equal(t, req.Id, athlete.Id)
 	assert.Equal(t, req.Name, athlete.Name)
@@ -121,7 +140,146 @@ func TestDeleteAthlete(t *testing.T) {
 		WithArgs(req.Id).
 		WillReturnResult(sqlmock.NewResult(1, 1))
 
-	resp, err := repo.DeleteAthlete(req)
+	resp, err := repo.DeleteAthlete(context.Background(), req)
 	assert.NoError(t, err)
 	assert.Equal(t, "deleted successfully", resp.Status)
 }
+
+func TestImportAthletes(t *testing.T) {
+	repo, mock := setupTestDB(t)
+
+	req := &pb.ImportAthletesRequest{
+		Athletes: []*pb.CreateAthleteRequest{
+			{Name: "Athlete1", CountryId: "1", SportType: "Judo"},
+			{Name: "Athlete2", CountryId: "2", SportType: "Fencing"},
+		},
+	}
+
+	mock.ExpectBegin()
+	for _, athlete := range req.Athletes {
+		mock.ExpectExec(`SAVEPOINT import_row`).WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec(`INSERT INTO athletes`).WithArgs(athlete.Name, athlete.CountryId, athlete.SportType).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectExec(`RELEASE SAVEPOINT import_row`).WillReturnResult(sqlmock.NewResult(0, 0))
+	}
+	mock.ExpectCommit()
+
+	resp, err := repo.ImportAthletes(context.Background(), req)
+	assert.NoError(t, err)
+	assert.Equal(t, int32(2), resp.Accepted)
+	assert.True(t, resp.Committed)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestImportAthletesDryRun(t *testing.T) {
+	repo, mock := setupTestDB(t)
+
+	req := &pb.ImportAthletesRequest{
+		Athletes: []*pb.CreateAthleteRequest{
+			{Name: "Athlete1", CountryId: "1", SportType: "Judo"},
+		},
+		DryRun: true,
+	}
+
+	mock.ExpectBegin()
+	mock.ExpectExec(`SAVEPOINT import_row`).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(`INSERT INTO athletes`).WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec(`RELEASE SAVEPOINT import_row`).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectRollback()
+
+	resp, err := repo.ImportAthletes(context.Background(), req)
+	assert.NoError(t, err)
+	assert.Equal(t, int32(1), resp.Accepted)
+	assert.False(t, resp.Committed)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestExportAthletes(t *testing.T) {
+	repo, mock := setupTestDB(t)
+
+	rows := sqlmock.NewRows([]string{"id", "name", "country_id", "sport_type", "created_at", "updated_at", "deleted_at"}).
+		AddRow(1, "Athlete1", 1, "Judo", "2024-08-07T00:00:00Z", "2024-08-07T00:00:00Z", 0).
+		AddRow(2, "Athlete2", 2, "Fencing", "2024-08-07T00:00:00Z", "2024-08-07T00:00:00Z", 0)
+
+	mock.ExpectQuery(`SELECT id, name, country_id, sport_type, created_at, updated_at, deleted_at FROM athletes WHERE deleted_at=0 ORDER BY name, id`).
+		WillReturnRows(rows)
+
+	var sent []*pb.Athlete
+	err := repo.ExportAthletes(context.Background(), &pb.ExportAthletesRequest{}, func(athlete *pb.Athlete) error {
+		sent = append(sent, athlete)
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(sent))
+	assert.Equal(t, "Fencing", sent[1].SportType)
+}
+
+var teamColumnNames = []string{"id", "name", "country_id", "sport_type", "created_at", "updated_at", "deleted_at"}
+
+func TestCreateTeam(t *testing.T) {
+	repo, mock := setupTestDB(t)
+
+	req := &pb.CreateTeamRequest{
+		Name:       "Mixed 4x100m Medley",
+		CountryId:  "1",
+		SportType:  "Swimming",
+		AthleteIds: []string{"a1", "a2", "a3", "a4"},
+	}
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`SELECT id FROM athletes WHERE id = ANY`).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("a1").AddRow("a2").AddRow("a3").AddRow("a4"))
+	mock.ExpectQuery(`INSERT INTO teams`).WithArgs(req.Name, req.CountryId, req.SportType).
+		WillReturnRows(sqlmock.NewRows(teamColumnNames).AddRow("t1", req.Name, req.CountryId, req.SportType, "2024-08-07T00:00:00Z", "2024-08-07T00:00:00Z", 0))
+	mock.ExpectExec(`INSERT INTO team_members`).WillReturnResult(sqlmock.NewResult(0, 4))
+	mock.ExpectCommit()
+
+	team, err := repo.CreateTeam(context.Background(), req)
+	assert.NoError(t, err)
+	assert.Equal(t, "t1", team.Id)
+	assert.Equal(t, req.AthleteIds, team.AthleteIds)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCreateTeamInvalid(t *testing.T) {
+	repo, mock := setupTestDB(t)
+
+	_, err := repo.CreateTeam(context.Background(), &pb.CreateTeamRequest{Name: "Pair", CountryId: "1", AthleteIds: []string{"a1", "a1"}})
+	assert.ErrorIs(t, err, ErrInvalidTeam)
+
+	_, err = repo.CreateTeam(context.Background(), &pb.CreateTeamRequest{Name: "Solo", CountryId: "1", AthleteIds: []string{"a1"}})
+	assert.ErrorIs(t, err, ErrInvalidTeam)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`SELECT id FROM athletes WHERE id = ANY`).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("a1"))
+	mock.ExpectRollback()
+
+	_, err = repo.CreateTeam(context.Background(), &pb.CreateTeamRequest{Name: "Pair", CountryId: "1", AthleteIds: []string{"a1", "a2"}})
+	assert.ErrorIs(t, err, ErrInvalidTeam)
+	assert.ErrorContains(t, err, "a2")
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestListTeamsByAthlete(t *testing.T) {
+	repo, mock := setupTestDB(t)
+
+	mock.ExpectQuery(`SELECT COUNT\(\*\) FROM teams WHERE deleted_at=0 AND id IN \(SELECT team_id FROM team_members WHERE athlete_id=\$1\)`).
+		WithArgs("a2").
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(2))
+	mock.ExpectQuery(`SELECT (.+) FROM teams WHERE deleted_at=0 AND id IN`).
+		WithArgs("a2", 51).
+		WillReturnRows(sqlmock.NewRows(teamColumnNames).
+			AddRow("t1", "Relay", "1", "Swimming", "2024-08-07T00:00:00Z", "2024-08-07T00:00:00Z", 0).
+			AddRow("t2", "Pair", "1", "Badminton", "2024-08-07T00:00:00Z", "2024-08-07T00:00:00Z", 0))
+	mock.ExpectQuery(`SELECT team_id, athlete_id FROM team_members`).
+		WillReturnRows(sqlmock.NewRows([]string{"team_id", "athlete_id"}).
+			AddRow("t1", "a1").AddRow("t1", "a2").AddRow("t2", "a2").AddRow("t2", "a3"))
+
+	resp, err := repo.ListTeams(context.Background(), &pb.ListTeamsRequest{AthleteId: "a2"})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), resp.TotalCount)
+	assert.Equal(t, []string{"a1", "a2"}, resp.Teams[0].AthleteIds)
+	assert.Equal(t, []string{"a2", "a3"}, resp.Teams[1].AthleteIds)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -1,13 +1,21 @@
 package repository
 
 import (
+    "context"
     pb "github.com/Bekzodbekk/paris2024_livestream_protos/genproto/athletepb"
 )
 
 type AthleteRepository interface {
-    CreateAthlete(req *pb.CreateAthleteRequest) (*pb.Athlete, error)
-    GetAthlete(req *pb.GetAthleteRequest) (*pb.GetAthleteResponse, error)
-    ListAthletes(req *pb.ListOfAthleteRequest) (*pb.ListOfAthleteResponse, error)
-    UpdateAthlete(req *pb.UpdateAthleteRequest) (*pb.Athlete, error)
-    DeleteAthlete(req *pb.DeleteAthleteRequest) (*pb.DeleteAthleteResponse, error)
+    CreateAthlete(ctx context.Context, req *pb.CreateAthleteRequest) (*pb.Athlete, error)
+    GetAthlete(ctx context.Context, req *pb.GetAthleteRequest) (*pb.GetAthleteResponse, error)
+    ListAthletes(ctx context.Context, req *pb.ListOfAthleteRequest) (*pb.ListOfAthleteResponse, error)
+    UpdateAthlete(ctx context.Context, req *pb.UpdateAthleteRequest) (*pb.Athlete, error)
+    DeleteAthlete(ctx context.Context, req *pb.DeleteAthleteRequest) (*pb.DeleteAthleteResponse, error)
+    ImportAthletes(ctx context.Context, req *pb.ImportAthletesRequest) (*pb.ImportResponse, error)
+    ExportAthletes(ctx context.Context, req *pb.ExportAthletesRequest, send func(*pb.Athlete) error) error
+    CreateTeam(ctx context.Context, req *pb.CreateTeamRequest) (*pb.Team, error)
+    GetTeam(ctx context.Context, req *pb.GetTeamRequest) (*pb.Team, error)
+    ListTeams(ctx context.Context, req *pb.ListTeamsRequest) (*pb.ListTeamsResponse, error)
+    UpdateTeam(ctx context.Context, req *pb.UpdateTeamRequest) (*pb.Team, error)
+    DeleteTeam(ctx context.Context, req *pb.DeleteTeamRequest) (*pb.DeleteTeamResponse, error)
 }
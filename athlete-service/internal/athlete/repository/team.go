@@ -0,0 +1,396 @@
+package repository
+
+import (
+	"athlete-service/logger"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"shared/paging"
+	"strings"
+
+	pb "github.com/Bekzodbekk/paris2024_livestream_protos/genproto/athletepb"
+	"github.com/lib/pq"
+	"github.com/sirupsen/logrus"
+)
+
+// ErrInvalidTeam is returned when a team has no name, country or roster, or
+// its roster names an athlete twice or one that does not exist.
+var ErrInvalidTeam = errors.New("invalid team")
+
+// minTeamSize is the smallest roster a team can have: a pair.
+const minTeamSize = 2
+
+const teamColumns = `id, name, country_id, sport_type, created_at, updated_at, deleted_at`
+
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+type queryer interface {
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+}
+
+func scanTeam(row rowScanner) (*pb.Team, error) {
+	team := pb.Team{}
+	err := row.Scan(
+		&team.Id,
+		&team.Name,
+		&team.CountryId,
+		&team.SportType,
+		&team.CreatedAt,
+		&team.UpdatedAt,
+		&team.DeletedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &team, nil
+}
+
+func validateTeam(name, countryID string, athleteIDs []string) error {
+	switch {
+	case strings.TrimSpace(name) == "":
+		return fmt.Errorf("%w: name is required", ErrInvalidTeam)
+	case countryID == "":
+		return fmt.Errorf("%w: country_id is required", ErrInvalidTeam)
+	case len(athleteIDs) < minTeamSize:
+		return fmt.Errorf("%w: a team needs at least %d athletes", ErrInvalidTeam, minTeamSize)
+	}
+	seen := make(map[string]bool, len(athleteIDs))
+	for _, id := range athleteIDs {
+		if seen[id] {
+			return fmt.Errorf("%w: athlete %s is listed twice", ErrInvalidTeam, id)
+		}
+		seen[id] = true
+	}
+	return nil
+}
+
+// checkMembers makes sure every athlete of a roster exists.
+func checkMembers(ctx context.Context, tx *sql.Tx, athleteIDs []string) error {
+	rows, err := tx.QueryContext(ctx, `SELECT id FROM athletes WHERE id = ANY($1::uuid[]) AND deleted_at=0`, pq.Array(athleteIDs))
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	found := make(map[string]bool, len(athleteIDs))
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return err
+		}
+		found[id] = true
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+	for _, id := range athleteIDs {
+		if !found[id] {
+			return fmt.Errorf("%w: athlete %s does not exist", ErrInvalidTeam, id)
+		}
+	}
+	return nil
+}
+
+func insertMembers(ctx context.Context, tx *sql.Tx, teamID string, athleteIDs []string) error {
+	_, err := tx.ExecContext(ctx, `
+	INSERT INTO team_members(team_id, athlete_id, position)
+	SELECT $1, m.id, m.position FROM unnest($2::uuid[]) WITH ORDINALITY AS m(id, position)`, teamID, pq.Array(athleteIDs))
+	return err
+}
+
+// loadMembers fills in the rosters of teams.
+func loadMembers(ctx context.Context, q queryer, teams []*pb.Team) error {
+	if len(teams) == 0 {
+		return nil
+	}
+	byID := make(map[string]*pb.Team, len(teams))
+	ids := make([]string, len(teams))
+	for i, team := range teams {
+		byID[team.Id] = team
+		ids[i] = team.Id
+	}
+
+	rows, err := q.QueryContext(ctx, `
+	SELECT team_id, athlete_id
+	FROM team_members
+	WHERE team_id = ANY($1::uuid[])
+	ORDER BY team_id, position`, pq.Array(ids))
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var teamID, athleteID string
+		if err := rows.Scan(&teamID, &athleteID); err != nil {
+			return err
+		}
+		if team, ok := byID[teamID]; ok {
+			team.AthleteIds = append(team.AthleteIds, athleteID)
+		}
+	}
+	return rows.Err()
+}
+
+// CreateTeam stores a team and its roster. The roster's athletes must exist;
+// the team's country is checked by the caller.
+func (db *PostgresAthleteRepository) CreateTeam(ctx context.Context, req *pb.CreateTeamRequest) (*pb.Team, error) {
+
+	if err := validateTeam(req.Name, req.CountryId, req.AthleteIds); err != nil {
+		return nil, err
+	}
+
+	tx, err := db.DB.BeginTx(ctx, nil)
+	if err != nil {
+		logger.ErrorContext(ctx, "Creating team failed", logrus.Fields{"error": err})
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	if err := checkMembers(ctx, tx, req.AthleteIds); err != nil {
+		logger.WarnContext(ctx, "Team roster rejected", logrus.Fields{"error": err})
+		return nil, err
+	}
+
+	team, err := scanTeam(tx.QueryRowContext(ctx, `
+	INSERT INTO teams(name, country_id, sport_type)
+	VALUES($1, $2, $3)
+	RETURNING `+teamColumns, req.Name, req.CountryId, req.SportType))
+	if err != nil {
+		logger.ErrorContext(ctx, "Creating team failed", logrus.Fields{"error": err})
+		return nil, err
+	}
+	if err := insertMembers(ctx, tx, team.Id, req.AthleteIds); err != nil {
+		logger.ErrorContext(ctx, "Storing team roster failed", logrus.Fields{
+			"error":   err,
+			"team_id": team.Id,
+		})
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		logger.ErrorContext(ctx, "Creating team failed", logrus.Fields{"error": err})
+		return nil, err
+	}
+	team.AthleteIds = req.AthleteIds
+
+	logger.InfoContext(ctx, "Team created successfully", logrus.Fields{
+		"team_id": team.Id,
+		"name":    team.Name,
+		"members": len(team.AthleteIds),
+	})
+	return team, nil
+}
+
+func (db *PostgresAthleteRepository) GetTeam(ctx context.Context, req *pb.GetTeamRequest) (*pb.Team, error) {
+
+	team, err := scanTeam(db.DB.QueryRowContext(ctx, `
+	SELECT `+teamColumns+`
+	FROM teams
+	WHERE id=$1 AND deleted_at=0`, req.Id))
+	if err != nil {
+		logger.ErrorContext(ctx, "Retrieving team failed", logrus.Fields{
+			"error":   err,
+			"team_id": req.Id,
+		})
+		return nil, err
+	}
+	if err := loadMembers(ctx, db.DB, []*pb.Team{team}); err != nil {
+		logger.ErrorContext(ctx, "Retrieving team roster failed", logrus.Fields{
+			"error":   err,
+			"team_id": req.Id,
+		})
+		return nil, err
+	}
+
+	logger.InfoContext(ctx, "Team retrieved successfully", logrus.Fields{
+		"team_id": team.Id,
+		"name":    team.Name,
+	})
+	return team, nil
+}
+
+// teamOrderColumns maps the order_by fields accepted by ListTeams to columns.
+var teamOrderColumns = map[string]string{
+	"name":       "name",
+	"sport_type": "sport_type",
+	"country_id": "country_id",
+	"created_at": "created_at",
+}
+
+// ListTeams lists teams with their rosters. athlete_id keeps the teams an
+// athlete belongs to.
+func (db *PostgresAthleteRepository) ListTeams(ctx context.Context, req *pb.ListTeamsRequest) (*pb.ListTeamsResponse, error) {
+
+	p, err := paging.New(req.PageSize, req.PageToken, req.OrderBy, teamOrderColumns, "name")
+	if err != nil {
+		return nil, err
+	}
+
+	filter := paging.NewConditions("deleted_at=0")
+	if req.SportType != "" {
+		filter.Add("sport_type=$%d", req.SportType)
+	}
+	if req.CountryId != "" {
+		filter.Add("country_id=$%d", req.CountryId)
+	}
+	if req.AthleteId != "" {
+		filter.Add("id IN (SELECT team_id FROM team_members WHERE athlete_id=$%d)", req.AthleteId)
+	}
+
+	resp := pb.ListTeamsResponse{}
+	if err := db.DB.QueryRowContext(ctx, "SELECT COUNT(*) FROM teams WHERE "+filter.String(), filter.Args()...).Scan(&resp.TotalCount); err != nil {
+		logger.ErrorContext(ctx, "Counting teams failed", logrus.Fields{"error": err})
+		return nil, err
+	}
+
+	rows, err := db.DB.QueryContext(ctx, "SELECT "+teamColumns+" FROM teams"+p.Clause(filter), filter.Args()...)
+	if err != nil {
+		logger.ErrorContext(ctx, "Listing teams failed", logrus.Fields{"error": err})
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		team, err := scanTeam(rows)
+		if err != nil {
+			logger.ErrorContext(ctx, "Decoding team failed", logrus.Fields{"error": err})
+			return nil, err
+		}
+		resp.Teams = append(resp.Teams, team)
+	}
+	if err := rows.Err(); err != nil {
+		logger.ErrorContext(ctx, "Listing teams failed", logrus.Fields{"error": err})
+		return nil, err
+	}
+	rows.Close()
+
+	if len(resp.Teams) > p.Size {
+		resp.Teams = resp.Teams[:p.Size]
+		last := resp.Teams[p.Size-1]
+		resp.NextPageToken = p.NextToken(teamSortValue(last, p.Column), last.Id)
+	}
+	if err := loadMembers(ctx, db.DB, resp.Teams); err != nil {
+		logger.ErrorContext(ctx, "Retrieving team rosters failed", logrus.Fields{"error": err})
+		return nil, err
+	}
+
+	logger.InfoContext(ctx, "Teams listed successfully", logrus.Fields{
+		"teams_count": len(resp.Teams),
+		"total_count": resp.TotalCount,
+	})
+	return &resp, nil
+}
+
+func teamSortValue(t *pb.Team, column string) string {
+	switch column {
+	case "sport_type":
+		return t.SportType
+	case "country_id":
+		return t.CountryId
+	case "created_at":
+		return t.CreatedAt
+	default:
+		return t.Name
+	}
+}
+
+// UpdateTeam replaces a team's details and roster.
+func (db *PostgresAthleteRepository) UpdateTeam(ctx context.Context, req *pb.UpdateTeamRequest) (*pb.Team, error) {
+
+	if err := validateTeam(req.Name, req.CountryId, req.AthleteIds); err != nil {
+		return nil, err
+	}
+
+	tx, err := db.DB.BeginTx(ctx, nil)
+	if err != nil {
+		logger.ErrorContext(ctx, "Updating team failed", logrus.Fields{
+			"error":   err,
+			"team_id": req.Id,
+		})
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	if err := checkMembers(ctx, tx, req.AthleteIds); err != nil {
+		logger.WarnContext(ctx, "Team roster rejected", logrus.Fields{
+			"error":   err,
+			"team_id": req.Id,
+		})
+		return nil, err
+	}
+
+	team, err := scanTeam(tx.QueryRowContext(ctx, `
+	UPDATE teams
+	SET name=$1, country_id=$2, sport_type=$3, updated_at=NOW()
+	WHERE id=$4 AND deleted_at=0
+	RETURNING `+teamColumns, req.Name, req.CountryId, req.SportType, req.Id))
+	if err != nil {
+		logger.ErrorContext(ctx, "Updating team failed", logrus.Fields{
+			"error":   err,
+			"team_id": req.Id,
+		})
+		return nil, err
+	}
+	if _, err := tx.ExecContext(ctx, `DELETE FROM team_members WHERE team_id=$1`, team.Id); err != nil {
+		logger.ErrorContext(ctx, "Storing team roster failed", logrus.Fields{
+			"error":   err,
+			"team_id": team.Id,
+		})
+		return nil, err
+	}
+	if err := insertMembers(ctx, tx, team.Id, req.AthleteIds); err != nil {
+		logger.ErrorContext(ctx, "Storing team roster failed", logrus.Fields{
+			"error":   err,
+			"team_id": team.Id,
+		})
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		logger.ErrorContext(ctx, "Updating team failed", logrus.Fields{
+			"error":   err,
+			"team_id": req.Id,
+		})
+		return nil, err
+	}
+	team.AthleteIds = req.AthleteIds
+
+	logger.InfoContext(ctx, "Team updated successfully", logrus.Fields{
+		"team_id": team.Id,
+		"name":    team.Name,
+		"members": len(team.AthleteIds),
+	})
+	return team, nil
+}
+
+// DeleteTeam soft-deletes a team. Medals already awarded to it keep the
+// roster they were awarded with.
+func (db *PostgresAthleteRepository) DeleteTeam(ctx context.Context, req *pb.DeleteTeamRequest) (*pb.DeleteTeamResponse, error) {
+
+	result, err := db.DB.ExecContext(ctx, `
+	UPDATE teams
+	SET deleted_at=DATE_PART('epoch', CURRENT_TIMESTAMP)::INT
+	WHERE id=$1 AND deleted_at=0`, req.Id)
+	if err != nil {
+		logger.ErrorContext(ctx, "Deleting team failed", logrus.Fields{
+			"error":   err,
+			"team_id": req.Id,
+		})
+		return nil, err
+	}
+
+	num, err := result.RowsAffected()
+	if err != nil {
+		logger.ErrorContext(ctx, "Getting affected rows failed", logrus.Fields{"error": err})
+		return nil, err
+	}
+	if num == 0 {
+		logger.WarnContext(ctx, "No rows affected for deletion", logrus.Fields{"team_id": req.Id})
+		return nil, sql.ErrNoRows
+	}
+
+	logger.InfoContext(ctx, "Team deleted successfully", logrus.Fields{"team_id": req.Id})
+	return &pb.DeleteTeamResponse{Status: "deleted successfully"}, nil
+}
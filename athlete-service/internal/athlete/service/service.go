@@ -18,21 +18,60 @@ func NewAthleteService(repo repository.AthleteRepository) *AthleteService {
 }
 
 func(s *AthleteService) CreateAthlete(ctx context.Context, req *pb.CreateAthleteRequest) (*pb.Athlete, error) {
-	return s.Repo.CreateAthlete(req)
+	resp, err := s.Repo.CreateAthlete(ctx, req)
+	return resp, statusError(err, "athlete "+req.Name)
 }
 
 func(s *AthleteService) GetAthlete(ctx context.Context, req *pb.GetAthleteRequest) (*pb.GetAthleteResponse, error) {
-	return s.Repo.GetAthlete(req)
+	resp, err := s.Repo.GetAthlete(ctx, req)
+	return resp, statusError(err, "athlete "+req.Id)
 }
 
 func(s *AthleteService) ListOfAthlete(ctx context.Context, req *pb.ListOfAthleteRequest) (*pb.ListOfAthleteResponse, error) {
-	return s.Repo.ListAthletes(req)
+	resp, err := s.Repo.ListAthletes(ctx, req)
+	return resp, statusError(err, "athletes")
 }
 
 func(s *AthleteService) UpdateAthlete(ctx context.Context, req *pb.UpdateAthleteRequest) (*pb.Athlete, error) {
-	return s.Repo.UpdateAthlete(req)
+	resp, err := s.Repo.UpdateAthlete(ctx, req)
+	return resp, statusError(err, "athlete "+req.Id)
 }
 
 func(s *AthleteService) DeleteAthlete(ctx context.Context, req *pb.DeleteAthleteRequest) (*pb.DeleteAthleteResponse, error) {
-	return s.Repo.DeleteAthlete(req)
+	resp, err := s.Repo.DeleteAthlete(ctx, req)
+	return resp, statusError(err, "athlete "+req.Id)
+}
+
+func(s *AthleteService) ImportAthletes(ctx context.Context, req *pb.ImportAthletesRequest) (*pb.ImportResponse, error) {
+	resp, err := s.Repo.ImportAthletes(ctx, req)
+	return resp, statusError(err, "athletes")
+}
+
+func(s *AthleteService) ExportAthletes(req *pb.ExportAthletesRequest, stream pb.AthleteService_ExportAthletesServer) error {
+	return statusError(s.Repo.ExportAthletes(stream.Context(), req, stream.Send), "athletes")
+}
+
+func(s *AthleteService) CreateTeam(ctx context.Context, req *pb.CreateTeamRequest) (*pb.Team, error) {
+	resp, err := s.Repo.CreateTeam(ctx, req)
+	return resp, statusError(err, "team "+req.Name)
+}
+
+func(s *AthleteService) GetTeam(ctx context.Context, req *pb.GetTeamRequest) (*pb.Team, error) {
+	resp, err := s.Repo.GetTeam(ctx, req)
+	return resp, statusError(err, "team "+req.Id)
+}
+
+func(s *AthleteService) ListTeams(ctx context.Context, req *pb.ListTeamsRequest) (*pb.ListTeamsResponse, error) {
+	resp, err := s.Repo.ListTeams(ctx, req)
+	return resp, statusError(err, "teams")
+}
+
+func(s *AthleteService) UpdateTeam(ctx context.Context, req *pb.UpdateTeamRequest) (*pb.Team, error) {
+	resp, err := s.Repo.UpdateTeam(ctx, req)
+	return resp, statusError(err, "team "+req.Id)
+}
+
+func(s *AthleteService) DeleteTeam(ctx context.Context, req *pb.DeleteTeamRequest) (*pb.DeleteTeamResponse, error) {
+	resp, err := s.Repo.DeleteTeam(ctx, req)
+	return resp, statusError(err, "team "+req.Id)
 }
@@ -2,19 +2,18 @@ package main
 
 import (
 	"context"
+	"country-service/internal/country/pkg/healthcheck"
 	config "country-service/internal/country/pkg/load"
+	"country-service/internal/country/pkg/metrics"
+	"country-service/internal/country/pkg/tracing"
 	pq "country-service/internal/country/pkg/postgres"
 	rpc "country-service/internal/country/pkg/register-service"
 	countryRepo "country-service/internal/country/repository"
 	countryService "country-service/internal/country/service"
 	"country-service/logger"
-	"os"
 	"os/signal"
-	"sync"
 	"syscall"
 	"time"
-
-	"google.golang.org/grpc"
 )
 
 func main() {
@@ -27,40 +26,51 @@ func main() {
 	}
 	logger.Info("Configuration loaded successfully")
 
+	shutdownTracing, err := tracing.Init(context.Background(), "country-service", *cfg)
+	if err != nil {
+		logger.Fatal("Failed to set up tracing: ", err)
+	}
+
 	db, err := pq.ConnectDB(*cfg)
 	if err != nil {
 		logger.Fatal("Failed to connect to database: ", err)
 	}
 	logger.Info("Connected to the database successfully")
+	metrics.RegisterDB(db, cfg.Postgres.Database)
 
 	repo := countryRepo.NewPostgresCountryRepository(db)
 	service := countryService.NewCountryService(repo)
 
-	var wg sync.WaitGroup
-	wg.Add(1)
-
-	r := rpc.NewGrpcService(service)
+	r := rpc.NewGrpcService(service, healthcheck.Checks{
+		"postgres": db.PingContext,
+	})
 
-	gServer := grpc.NewServer()
-	go func() {
-		defer wg.Done()
-		if err := r.RUN(*cfg); err != nil {
-			logger.Fatal("Failed to run gRPC service: ", err)
-		}
-	}()
+	app, err := r.RUN(*cfg)
+	if err != nil {
+		logger.Fatal("Failed to run gRPC service: ", err)
+	}
+	app.Own("log file", func(context.Context) error { return logger.Close() })
+	app.Own("tracing", shutdownTracing)
+	app.OwnCloser("postgres", db)
 	logger.Info("Country service started successfully")
 
-	sigChan := make(chan os.Signal, 1)
-	signal.Notify(sigChan, syscall.SIGINT, syscall.SIGTERM)
+	app.ServeHTTP("metrics listener", metrics.Server(*cfg))
 
-	sig := <-sigChan
-	logger.Info("Received signal:", sig)
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
 
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-	defer cancel()
+	if err := app.Wait(ctx); err != nil {
+		logger.Error("gRPC server stopped: ", err)
+	} else {
+		logger.Info("Received shutdown signal")
+	}
 
-	gServer.GracefulStop()
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
 
-	<-ctx.Done()
+	if err := app.Shutdown(shutdownCtx); err != nil {
+		logger.Error("Shutdown incomplete: ", err)
+		return
+	}
 	logger.Info("Graceful shutdown complete.")
 }
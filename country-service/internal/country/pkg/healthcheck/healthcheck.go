@@ -0,0 +1,66 @@
+// Package healthcheck keeps the grpc.health.v1 status of the service in step
+// with the dependencies it cannot work without.
+package healthcheck
+
+import (
+	"context"
+	"country-service/logger"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+const (
+	// interval is how often the dependencies are pinged.
+	interval = 5 * time.Second
+	// timeout bounds a single ping.
+	timeout = 2 * time.Second
+)
+
+// Check pings one dependency and returns an error when it cannot be used.
+type Check func(ctx context.Context) error
+
+// Checks names the dependencies of the service.
+type Checks map[string]Check
+
+// Monitor pings checks every few seconds until ctx is done. The overall
+// status ("") and each of services are SERVING while every check passes and
+// NOT_SERVING otherwise.
+func Monitor(ctx context.Context, srv *health.Server, checks Checks, services ...string) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		status := healthpb.HealthCheckResponse_SERVING
+		for name, check := range checks {
+			if err := ping(ctx, check); err != nil {
+				logger.Warn("Health check failed: ", logrus.Fields{
+					"dependency": name,
+					"error":      err,
+				})
+				status = healthpb.HealthCheckResponse_NOT_SERVING
+			}
+		}
+		if ctx.Err() != nil {
+			return
+		}
+		srv.SetServingStatus("", status)
+		for _, service := range services {
+			srv.SetServingStatus(service, status)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func ping(ctx context.Context, check Check) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	return check(ctx)
+}
@@ -0,0 +1,50 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+)
+
+// rowError is the failure of a single row of a bulk import.
+type rowError struct {
+	index int
+	err   error
+}
+
+// importRows runs insert for every row of a batch inside one transaction.
+// Each row gets its own savepoint, so a failing row is recorded and rolled
+// back without hiding the errors of the rows after it. The transaction is
+// committed only when every row succeeded and dryRun is false.
+func importRows(ctx context.Context, db *sql.DB, rows int, dryRun bool, insert func(tx *sql.Tx, i int) error) (accepted int, failed []rowError, committed bool, err error) {
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, nil, false, fmt.Errorf("begin import: %w", err)
+	}
+	defer tx.Rollback()
+
+	for i := 0; i < rows; i++ {
+		if _, err := tx.ExecContext(ctx, "SAVEPOINT import_row"); err != nil {
+			return 0, nil, false, fmt.Errorf("savepoint row %d: %w", i, err)
+		}
+		if err := insert(tx, i); err != nil {
+			failed = append(failed, rowError{index: i, err: err})
+			if _, err := tx.ExecContext(ctx, "ROLLBACK TO SAVEPOINT import_row"); err != nil {
+				return 0, nil, false, fmt.Errorf("rollback row %d: %w", i, err)
+			}
+			continue
+		}
+		if _, err := tx.ExecContext(ctx, "RELEASE SAVEPOINT import_row"); err != nil {
+			return 0, nil, false, fmt.Errorf("release row %d: %w", i, err)
+		}
+		accepted++
+	}
+
+	if dryRun || len(failed) > 0 {
+		return accepted, failed, false, nil
+	}
+	if err := tx.Commit(); err != nil {
+		return 0, nil, false, fmt.Errorf("commit import: %w", err)
+	}
+	return accepted, failed, true, nil
+}
@@ -1,9 +1,13 @@
 package repository
 
 import (
+	"context"
 	"country-service/logger"
 	"database/sql"
 	"errors"
+	"fmt"
+	"shared/paging"
+	"strings"
 
 	pb "github.com/Bekzodbekk/paris2024_livestream_protos/genproto/countrypb"
 	"github.com/sirupsen/logrus"
@@ -19,7 +23,7 @@ func NewPostgresCountryRepository(db *sql.DB) CountryRepository {
 	}
 }
 
-func (db *PostgresCountryRepository) CreateCountry(req *pb.CreateCountryRequest) (*pb.Country, error) {
+func (db *PostgresCountryRepository) CreateCountry(ctx context.Context, req *pb.CreateCountryRequest) (*pb.Country, error) {
 
 	resp := pb.Country{}
 	query := `
@@ -27,7 +31,7 @@ func (db *PostgresCountryRepository) CreateCountry(req *pb.CreateCountryRequest)
 	VALUES($1, $2, $3)
 	RETURNING id, name, flag, region, created_at, updated_at, deleted_at`
 
-	err := db.DB.QueryRow(query, req.Name, req.Flag, req.Region).Scan(
+	err := db.DB.QueryRowContext(ctx, query, req.Name, req.Flag, req.Region).Scan(
 		&resp.Id,
 		&resp.Name,
 		&resp.Flag,
@@ -37,13 +41,13 @@ func (db *PostgresCountryRepository) CreateCountry(req *pb.CreateCountryRequest)
 		&resp.DeletedAt,
 	)
 	if err != nil {
-		logger.Error("Creating country failed", logrus.Fields{
+		logger.ErrorContext(ctx, "Creating country failed", logrus.Fields{
 			"error": err,
 		})
 		return nil, err
 	}
 
-	logger.Info("Country created successfully", logrus.Fields{
+	logger.InfoContext(ctx, "Country created successfully", logrus.Fields{
 		"country_id": resp.Id,
 		"name":       resp.Name,
 	})
@@ -51,7 +55,7 @@ func (db *PostgresCountryRepository) CreateCountry(req *pb.CreateCountryRequest)
 	return &resp, nil
 }
 
-func (db *PostgresCountryRepository) GetCountry(req *pb.GetCountryRequest) (*pb.Country, error) {
+func (db *PostgresCountryRepository) GetCountry(ctx context.Context, req *pb.GetCountryRequest) (*pb.Country, error) {
 
 	resp := pb.Country{}
 	query := `
@@ -59,7 +63,7 @@ func (db *PostgresCountryRepository) GetCountry(req *pb.GetCountryRequest) (*pb.
 	FROM countries 
 	WHERE id=$1 AND deleted_at=0`
 
-	err := db.DB.QueryRow(query, req.Id).Scan(
+	err := db.DB.QueryRowContext(ctx, query, req.Id).Scan(
 		&resp.Id,
 		&resp.Name,
 		&resp.Flag,
@@ -69,14 +73,14 @@ func (db *PostgresCountryRepository) GetCountry(req *pb.GetCountryRequest) (*pb.
 		&resp.DeletedAt,
 	)
 	if err != nil {
-		logger.Error("Retrieving country failed", logrus.Fields{
+		logger.ErrorContext(ctx, "Retrieving country failed", logrus.Fields{
 			"error":      err,
 			"country_id": req.Id,
 		})
 		return nil, err
 	}
 
-	logger.Info("Country retrieved successfully", logrus.Fields{
+	logger.InfoContext(ctx, "Country retrieved successfully", logrus.Fields{
 		"country_id": resp.Id,
 		"name":       resp.Name,
 	})
@@ -84,15 +88,39 @@ func (db *PostgresCountryRepository) GetCountry(req *pb.GetCountryRequest) (*pb.
 	return &resp, nil
 }
 
-func (db *PostgresCountryRepository) ListOfCountry(req *pb.ListOfCountryRequest) (*pb.ListOfCountryResponse, error) {
+// countryOrderColumns maps the order_by fields accepted by ListOfCountry to columns.
+var countryOrderColumns = map[string]string{
+	"name":       "name",
+	"region":     "region",
+	"created_at": "created_at",
+}
+
+func (db *PostgresCountryRepository) ListOfCountry(ctx context.Context, req *pb.ListOfCountryRequest) (*pb.ListOfCountryResponse, error) {
+
+	p, err := paging.New(req.PageSize, req.PageToken, req.OrderBy, countryOrderColumns, "name")
+	if err != nil {
+		return nil, err
+	}
+
+	filter := paging.NewConditions("deleted_at=0")
+	if req.Region != "" {
+		filter.Add("region=$%d", req.Region)
+	}
 
 	resp := pb.ListOfCountryResponse{}
-	rows, err := db.DB.Query(`
+	if err := db.DB.QueryRowContext(ctx, "SELECT COUNT(*) FROM countries WHERE "+filter.String(), filter.Args()...).Scan(&resp.TotalCount); err != nil {
+		logger.ErrorContext(ctx, "Counting countries failed", logrus.Fields{
+			"error": err,
+		})
+		return nil, err
+	}
+
+	query := `
 	SELECT id, name, flag, region, created_at, updated_at, deleted_at 
-	FROM countries
-	WHERE deleted_at=0`)
+	FROM countries` + p.Clause(filter)
+	rows, err := db.DB.QueryContext(ctx, query, filter.Args()...)
 	if err != nil {
-		logger.Error("Listing countries failed", logrus.Fields{
+		logger.ErrorContext(ctx, "Listing countries failed", logrus.Fields{
 			"error": err,
 		})
 		return nil, err
@@ -111,22 +139,46 @@ func (db *PostgresCountryRepository) ListOfCountry(req *pb.ListOfCountryRequest)
 			&item.DeletedAt,
 		)
 		if err != nil {
-			logger.Error("Decoding country failed", logrus.Fields{
+			logger.ErrorContext(ctx, "Decoding country failed", logrus.Fields{
 				"error": err,
 			})
 			return nil, err
 		}
 		resp.Countries = append(resp.Countries, &item)
 	}
+	if err := rows.Err(); err != nil {
+		logger.ErrorContext(ctx, "Listing countries failed", logrus.Fields{
+			"error": err,
+		})
+		return nil, err
+	}
+
+	if len(resp.Countries) > p.Size {
+		resp.Countries = resp.Countries[:p.Size]
+		last := resp.Countries[p.Size-1]
+		resp.NextPageToken = p.NextToken(countrySortValue(last, p.Column), last.Id)
+	}
 
-	logger.Info("Countries listed successfully", logrus.Fields{
+	logger.InfoContext(ctx, "Countries listed successfully", logrus.Fields{
 		"countries_count": len(resp.Countries),
+		"total_count":     resp.TotalCount,
 	})
 
 	return &resp, nil
 }
 
-func (db *PostgresCountryRepository) UpdateCountry(req *pb.UpdateCountryRequest) (*pb.Country, error) {
+func countrySortValue(c *pb.Country, column string) string {
+	switch column {
+	case "region":
+		return c.Region
+	case "created_at":
+		return c.CreatedAt
+	default:
+		return c.Name
+	}
+}
+
+func (db *PostgresCountryRepository) UpdateCountry(ctx context.Context, req *pb.UpdateCountryRequest) (*pb.Country, error) {
 
 	resp := pb.Country{}
 	query := `
@@ -135,7 +187,7 @@ func (db *PostgresCountryRepository) UpdateCountry(req *pb.UpdateCountryRequest)
 	WHERE id=$4 AND deleted_at=0
 	RETURNING id, name, flag, region, created_at, updated_at, deleted_at`
 
-	err := db.DB.QueryRow(query, req.Name, req.Flag, req.Region, req.Id).Scan(
+	err := db.DB.QueryRowContext(ctx, query, req.Name, req.Flag, req.Region, req.Id).Scan(
 		&resp.Id,
 		&resp.Name,
 		&resp.Flag,
@@ -145,14 +197,14 @@ func (db *PostgresCountryRepository) UpdateCountry(req *pb.UpdateCountryRequest)
 		&resp.DeletedAt,
 	)
 	if err != nil {
-		logger.Error("Updating country failed", logrus.Fields{
+		logger.ErrorContext(ctx, "Updating country failed", logrus.Fields{
 			"error":      err,
 			"country_id": req.Id,
 		})
 		return nil, err
 	}
 
-	logger.Info("Country updated successfully", logrus.Fields{
+	logger.InfoContext(ctx, "Country updated successfully", logrus.Fields{
 		"country_id": resp.Id,
 		"name":       resp.Name,
 	})
@@ -160,7 +212,7 @@ func (db *PostgresCountryRepository) UpdateCountry(req *pb.UpdateCountryRequest)
 	return &resp, nil
 }
 
-func (db *PostgresCountryRepository) DeleteCountry(req *pb.DeleteCountryRequest) (*pb.DeleteCountryResponse, error) {
+func (db *PostgresCountryRepository) DeleteCountry(ctx context.Context, req *pb.DeleteCountryRequest) (*pb.DeleteCountryResponse, error) {
 
 	resp := pb.DeleteCountryResponse{}
 	query := `
@@ -168,9 +220,9 @@ func (db *PostgresCountryRepository) DeleteCountry(req *pb.DeleteCountryRequest)
 	SET deleted_at=DATE_PART('epoch', CURRENT_TIMESTAMP)::INT  
 	WHERE id=$1`
 
-	result, err := db.DB.Exec(query, req.Id)
+	result, err := db.DB.ExecContext(ctx, query, req.Id)
 	if err != nil {
-		logger.Error("Deleting country failed", logrus.Fields{
+		logger.ErrorContext(ctx, "Deleting country failed", logrus.Fields{
 			"error":      err,
 			"country_id": req.Id,
 		})
@@ -179,23 +231,132 @@ func (db *PostgresCountryRepository) DeleteCountry(req *pb.DeleteCountryRequest)
 
 	num, err := result.RowsAffected()
 	if err != nil {
-		logger.Error("Getting affected rows failed", logrus.Fields{
+		logger.ErrorContext(ctx, "Getting affected rows failed", logrus.Fields{
 			"error": err,
 		})
 		return nil, err
 	}
 
 	if num == 0 {
-		logger.Warn("No rows affected for deletion", logrus.Fields{
+		logger.WarnContext(ctx, "No rows affected for deletion", logrus.Fields{
 			"country_id": req.Id,
 		})
-		return nil, errors.New("no rows affected")
+		return nil, sql.ErrNoRows
 	}
 
 	resp.Status = "deleted successfully"
-	logger.Info("Country deleted successfully", logrus.Fields{
+	logger.InfoContext(ctx, "Country deleted successfully", logrus.Fields{
 		"country_id": req.Id,
 	})
 
 	return &resp, nil
 }
+
+// ImportCountries inserts a batch of countries in one transaction. Rows with
+// a missing name or a name that is already taken are reported and the batch
+// is not committed.
+func (db *PostgresCountryRepository) ImportCountries(ctx context.Context, req *pb.ImportCountriesRequest) (*pb.ImportResponse, error) {
+
+	accepted, failed, committed, err := importRows(ctx, db.DB, len(req.Countries), req.DryRun, func(tx *sql.Tx, i int) error {
+		country := req.Countries[i]
+		if strings.TrimSpace(country.Name) == "" {
+			return errors.New("name is required")
+		}
+
+		var exists bool
+		err := tx.QueryRowContext(ctx, `
+		SELECT EXISTS(SELECT 1 FROM countries WHERE LOWER(name)=LOWER($1) AND deleted_at=0)`,
+			country.Name).Scan(&exists)
+		if err != nil {
+			return err
+		}
+		if exists {
+			return fmt.Errorf("country %q already exists", country.Name)
+		}
+
+		_, err = tx.ExecContext(ctx, `
+		INSERT INTO countries(name, flag, region) 
+		VALUES($1, $2, $3)`, country.Name, country.Flag, country.Region)
+		return err
+	})
+	if err != nil {
+		logger.ErrorContext(ctx, "Importing countries failed", logrus.Fields{
+			"error": err,
+		})
+		return nil, err
+	}
+
+	resp := pb.ImportResponse{
+		Accepted:  int32(accepted),
+		Committed: committed,
+	}
+	for _, row := range failed {
+		resp.Errors = append(resp.Errors, &pb.ImportRowError{
+			Index:   int32(row.index),
+			Message: row.err.Error(),
+		})
+	}
+
+	logger.InfoContext(ctx, "Countries imported", logrus.Fields{
+		"rows":      len(req.Countries),
+		"accepted":  accepted,
+		"failed":    len(failed),
+		"committed": committed,
+		"dry_run":   req.DryRun,
+	})
+
+	return &resp, nil
+}
+
+// ExportCountries hands every country to send as it is read, so the caller
+// can stream the table without holding it in memory.
+func (db *PostgresCountryRepository) ExportCountries(ctx context.Context, req *pb.ExportCountriesRequest, send func(*pb.Country) error) error {
+
+	rows, err := db.DB.QueryContext(ctx, `
+	SELECT id, name, flag, region, created_at, updated_at, deleted_at 
+	FROM countries
+	WHERE deleted_at=0
+	ORDER BY name, id`)
+	if err != nil {
+		logger.ErrorContext(ctx, "Exporting countries failed", logrus.Fields{
+			"error": err,
+		})
+		return err
+	}
+	defer rows.Close()
+
+	count := 0
+	for rows.Next() {
+		item := pb.Country{}
+		err := rows.Scan(
+			&item.Id,
+			&item.Name,
+			&item.Flag,
+			&item.Region,
+			&item.CreatedAt,
+			&item.UpdatedAt,
+			&item.DeletedAt,
+		)
+		if err != nil {
+			logger.ErrorContext(ctx, "Decoding country failed", logrus.Fields{
+				"error": err,
+			})
+			return err
+		}
+		if err := send(&item); err != nil {
+			return err
+		}
+		count++
+	}
+	if err := rows.Err(); err != nil {
+		logger.ErrorContext(ctx, "Exporting countries failed", logrus.Fields{
+			"error": err,
+		})
+		return err
+	}
+
+	logger.InfoContext(ctx, "Countries exported successfully", logrus.Fields{
+		"countries_count": count,
+	})
+	return nil
+}
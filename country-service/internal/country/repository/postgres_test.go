@@ -1,6 +1,8 @@
 package repository
 
 import (
+	"context"
+	"shared/paging"
 	"testing"
 
 	"github.com/DATA-DOG/go-sqlmock"
@@ -35,7 +37,7 @@ func TestCreateCountry(t *testing.T) {
 		WithArgs(req.Name, req.Flag, req.Region).
 		WillReturnRows(rows)
 
-	country, err := repo.CreateCountry(req)
+	country, err := repo.CreateCountry(context.Background(), req)
 	assert.NoError(t, err)
 	assert.Equal(t, "1", country.Id)
 	assert.Equal(t, req.Name, country.Name)
@@ -54,7 +56,7 @@ func TestGetCountry(t *testing.T) {
 		WithArgs(req.Id).
 		WillReturnRows(rows)
 
-	country, err := repo.GetCountry(req)
+	country, err := repo.GetCountry(context.Background(), req)
 	assert.NoError(t, err)
 	assert.Equal(t, req.Id, country.Id)
 	assert.Equal(t, "CountryName", country.Name)
@@ -69,18 +71,50 @@ func TestListOfCountry(t *testing.T) {
 		AddRow(1, "Country1", "FlagURL1", "Region1", "2024-08-07T00:00:00Z", "2024-08-07T00:00:00Z", 0).
 		AddRow(2, "Country2", "FlagURL2", "Region2", "2024-08-07T00:00:00Z", "2024-08-07T00:00:00Z", 0)
 
-	mock.ExpectQuery(`SELECT id, name, flag, region, created_at, updated_at, deleted_at FROM countries WHERE deleted_at=0`).
+	mock.ExpectQuery(`SELECT COUNT\(\*\) FROM countries WHERE deleted_at=0`).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(2))
+	mock.ExpectQuery(`SELECT id, name, flag, region, created_at, updated_at, deleted_at FROM countries WHERE deleted_at=0 ORDER BY name ASC, id ASC LIMIT \$1`).
+		WithArgs(paging.DefaultPageSize + 1).
 		WillReturnRows(rows)
 
-	resp, err := repo.ListOfCountry(&pb.ListOfCountryRequest{})
+	resp, err := repo.ListOfCountry(context.Background(), &pb.ListOfCountryRequest{})
 	assert.NoError(t, err)
 	assert.Equal(t, int(2), len(resp.Countries))
+	assert.Equal(t, int64(2), resp.TotalCount)
 	assert.Equal(t, "1", resp.Countries[0].Id)
 	assert.Equal(t, "Country1", resp.Countries[0].Name)
 	assert.Equal(t, "FlagURL1", resp.Countries[0].Flag)
 	assert.Equal(t, "Region1", resp.Countries[0].Region)
 }
 
+func TestListOfCountryByRegion(t *testing.T) {
+	repo, mock := setupTestDB(t)
+
+	rows := sqlmock.NewRows([]string{"id", "name", "flag", "region", "created_at", "updated_at", "deleted_at"}).
+		AddRow(3, "Country3", "FlagURL3", "Europe", "2024-08-07T00:00:00Z", "2024-08-07T00:00:00Z", 0)
+
+	mock.ExpectQuery(`SELECT COUNT\(\*\) FROM countries WHERE deleted_at=0 AND region=\$1`).
+		WithArgs("Europe").
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(2))
+	mock.ExpectQuery(`FROM countries WHERE deleted_at=0 AND region=\$1 AND \(name, id\) > \(\$2, \$3\) ORDER BY name ASC, id ASC LIMIT \$4`).
+		WithArgs("Europe", "Country2", "2", 11).
+		WillReturnRows(rows)
+
+	token := (&paging.Page{}).NextToken("Country2", "2")
+	resp, err := repo.ListOfCountry(context.Background(), &pb.ListOfCountryRequest{PageSize: 10, PageToken: token, Region: "Europe"})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(resp.Countries))
+	assert.Equal(t, "Country3", resp.Countries[0].Name)
+	assert.Empty(t, resp.NextPageToken)
+}
+
+func TestListOfCountryInvalidToken(t *testing.T) {
+	repo, _ := setupTestDB(t)
+
+	_, err := repo.ListOfCountry(context.Background(), &pb.ListOfCountryRequest{PageToken: "not a token"})
+	assert.ErrorIs(t, err, paging.ErrInvalidPageToken)
+}
+
 func TestUpdateCountry(t *testing.T) {
 	repo, mock := setupTestDB(t)
 
@@ -98,7 +132,7 @@ func TestUpdateCountry(t *testing.T) {
 		WithArgs(req.Name, req.Flag, req.Region, req.Id).
 		WillReturnRows(rows)
 
-	country, err := repo.UpdateCountry(req)
+	country, err := repo.UpdateCountry(context.Background(), req)
 	assert.NoError(t, err)
 	assert.Equal(t, req.Id, country.Id)
 	assert.Equal(t, req.Name, country.Name)
@@ -114,7 +148,84 @@ func TestDeleteCountry(t *testing.T) {
 		WithArgs(req.Id).
 		WillReturnResult(sqlmock.NewResult(1, 1))
 
-	resp, err := repo.DeleteCountry(req)
+	resp, err := repo.DeleteCountry(context.Background(), req)
 	assert.NoError(t, err)
 	assert.Equal(t, "deleted successfully", resp.Status)
 }
+
+func TestImportCountries(t *testing.T) {
+	repo, mock := setupTestDB(t)
+
+	req := &pb.ImportCountriesRequest{
+		Countries: []*pb.CreateCountryRequest{
+			{Name: "France", Flag: "fr.png", Region: "Europe"},
+			{Name: "Japan", Flag: "jp.png", Region: "Asia"},
+		},
+	}
+
+	mock.ExpectBegin()
+	for _, country := range req.Countries {
+		mock.ExpectExec(`SAVEPOINT import_row`).WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectQuery(`SELECT EXISTS`).WithArgs(country.Name).
+			WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+		mock.ExpectExec(`INSERT INTO countries`).WithArgs(country.Name, country.Flag, country.Region).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectExec(`RELEASE SAVEPOINT import_row`).WillReturnResult(sqlmock.NewResult(0, 0))
+	}
+	mock.ExpectCommit()
+
+	resp, err := repo.ImportCountries(context.Background(), req)
+	assert.NoError(t, err)
+	assert.Equal(t, int32(2), resp.Accepted)
+	assert.True(t, resp.Committed)
+	assert.Empty(t, resp.Errors)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestImportCountriesReportsRowErrors(t *testing.T) {
+	repo, mock := setupTestDB(t)
+
+	req := &pb.ImportCountriesRequest{
+		Countries: []*pb.CreateCountryRequest{
+			{Name: ""},
+			{Name: "France", Region: "Europe"},
+		},
+	}
+
+	mock.ExpectBegin()
+	mock.ExpectExec(`SAVEPOINT import_row`).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(`ROLLBACK TO SAVEPOINT import_row`).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(`SAVEPOINT import_row`).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery(`SELECT EXISTS`).WithArgs("France").
+		WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+	mock.ExpectExec(`ROLLBACK TO SAVEPOINT import_row`).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectRollback()
+
+	resp, err := repo.ImportCountries(context.Background(), req)
+	assert.NoError(t, err)
+	assert.Equal(t, int32(0), resp.Accepted)
+	assert.False(t, resp.Committed)
+	assert.Len(t, resp.Errors, 2)
+	assert.Equal(t, int32(0), resp.Errors[0].Index)
+	assert.Equal(t, int32(1), resp.Errors[1].Index)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestExportCountries(t *testing.T) {
+	repo, mock := setupTestDB(t)
+
+	rows := sqlmock.NewRows([]string{"id", "name", "flag", "region", "created_at", "updated_at", "deleted_at"}).
+		AddRow(1, "Country1", "FlagURL1", "Region1", "2024-08-07T00:00:00Z", "2024-08-07T00:00:00Z", 0).
+		AddRow(2, "Country2", "FlagURL2", "Region2", "2024-08-07T00:00:00Z", "2024-08-07T00:00:00Z", 0)
+
+	mock.ExpectQuery(`SELECT id, name, flag, region, created_at, updated_at, deleted_at FROM countries WHERE deleted_at=0 ORDER BY name, id`).
+		WillReturnRows(rows)
+
+	var names []string
+	err := repo.ExportCountries(context.Background(), &pb.ExportCountriesRequest{}, func(country *pb.Country) error {
+		names = append(names, country.Name)
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"Country1", "Country2"}, names)
+}
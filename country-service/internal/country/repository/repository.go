@@ -1,13 +1,16 @@
 package repository
 
 import (
+	"context"
 	pb "github.com/Bekzodbekk/paris2024_livestream_protos/genproto/countrypb"
 )
 
 type CountryRepository interface {
-	CreateCountry(req *pb.CreateCountryRequest) (*pb.Country, error)
-	GetCountry(req *pb.GetCountryRequest) (*pb.Country, error)
-	ListOfCountry(req *pb.ListOfCountryRequest) (*pb.ListOfCountryResponse, error)
-	UpdateCountry(req *pb.UpdateCountryRequest) (*pb.Country, error)
-	DeleteCountry(req *pb.DeleteCountryRequest) (*pb.DeleteCountryResponse, error)
+	CreateCountry(ctx context.Context, req *pb.CreateCountryRequest) (*pb.Country, error)
+	GetCountry(ctx context.Context, req *pb.GetCountryRequest) (*pb.Country, error)
+	ListOfCountry(ctx context.Context, req *pb.ListOfCountryRequest) (*pb.ListOfCountryResponse, error)
+	UpdateCountry(ctx context.Context, req *pb.UpdateCountryRequest) (*pb.Country, error)
+	DeleteCountry(ctx context.Context, req *pb.DeleteCountryRequest) (*pb.DeleteCountryResponse, error)
+	ImportCountries(ctx context.Context, req *pb.ImportCountriesRequest) (*pb.ImportResponse, error)
+	ExportCountries(ctx context.Context, req *pb.ExportCountriesRequest, send func(*pb.Country) error) error
 }
@@ -0,0 +1,48 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"shared/paging"
+
+	"github.com/lib/pq"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// statusError turns a repository error into the gRPC status the gateway
+// translates for its clients. what names the record involved, e.g.
+// "country 42", for NotFound and AlreadyExists messages. Errors that already
+// carry a status are returned unchanged.
+func statusError(err error, what string) error {
+	if err == nil {
+		return nil
+	}
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+
+	var pqErr *pq.Error
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		return status.Errorf(codes.NotFound, "%s not found", what)
+	case errors.Is(err, paging.ErrInvalidPageToken), errors.Is(err, paging.ErrInvalidOrderBy):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, context.Canceled):
+		return status.Error(codes.Canceled, err.Error())
+	case errors.Is(err, context.DeadlineExceeded):
+		return status.Error(codes.DeadlineExceeded, err.Error())
+	case errors.As(err, &pqErr):
+		switch {
+		case pqErr.Code == "23505":
+			return status.Errorf(codes.AlreadyExists, "%s already exists", what)
+		case pqErr.Code.Class() == "22":
+			// Data exceptions: a malformed uuid, date or number.
+			return status.Errorf(codes.InvalidArgument, "invalid %s: %s", what, pqErr.Message)
+		case pqErr.Code.Class() == "23":
+			return status.Error(codes.FailedPrecondition, pqErr.Message)
+		}
+	}
+	return status.Error(codes.Internal, err.Error())
+}
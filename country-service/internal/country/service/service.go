@@ -18,21 +18,35 @@ func NewCountryService(repo repository.CountryRepository) *CountryService {
 }
 
 func (s *CountryService) CreateCountry(ctx context.Context, req *pb.CreateCountryRequest) (*pb.Country, error) {
-	return s.Repo.CreateCountry(req)
+	resp, err := s.Repo.CreateCountry(ctx, req)
+	return resp, statusError(err, "country "+req.Name)
 }
 
 func (s *CountryService) GetCountry(ctx context.Context, req *pb.GetCountryRequest) (*pb.Country, error) {
-	return s.Repo.GetCountry(req)
+	resp, err := s.Repo.GetCountry(ctx, req)
+	return resp, statusError(err, "country "+req.Id)
 }
 
 func (s *CountryService) ListOfCountry(ctx context.Context, req *pb.ListOfCountryRequest) (*pb.ListOfCountryResponse, error) {
-	return s.Repo.ListOfCountry(req)
+	resp, err := s.Repo.ListOfCountry(ctx, req)
+	return resp, statusError(err, "countries")
 }
 
 func (s *CountryService) UpdateCountry(ctx context.Context, req *pb.UpdateCountryRequest) (*pb.Country, error) {
-	return s.Repo.UpdateCountry(req)
+	resp, err := s.Repo.UpdateCountry(ctx, req)
+	return resp, statusError(err, "country "+req.Id)
 }
 
 func (s *CountryService) DeleteCountry(ctx context.Context, req *pb.DeleteCountryRequest) (*pb.DeleteCountryResponse, error) {
-	return s.Repo.DeleteCountry(req)
+	resp, err := s.Repo.DeleteCountry(ctx, req)
+	return resp, statusError(err, "country "+req.Id)
+}
+
+func (s *CountryService) ImportCountries(ctx context.Context, req *pb.ImportCountriesRequest) (*pb.ImportResponse, error) {
+	resp, err := s.Repo.ImportCountries(ctx, req)
+	return resp, statusError(err, "countries")
+}
+
+func (s *CountryService) ExportCountries(req *pb.ExportCountriesRequest, stream pb.CountryService_ExportCountriesServer) error {
+	return statusError(s.Repo.ExportCountries(stream.Context(), req, stream.Send), "countries")
 }
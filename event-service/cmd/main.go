@@ -2,19 +2,18 @@ package main
 
 import (
 	"context"
+	"event-service/internal/event/pkg/healthcheck"
 	config "event-service/internal/event/pkg/load"
+	"event-service/internal/event/pkg/metrics"
+	"event-service/internal/event/pkg/tracing"
 	pq "event-service/internal/event/pkg/postgres"
 	rpc "event-service/internal/event/pkg/register-service"
 	eventRepo "event-service/internal/event/repository"
 	eventService "event-service/internal/event/service"
 	"event-service/logger"
-	"os"
 	"os/signal"
-	"sync"
 	"syscall"
 	"time"
-
-	"google.golang.org/grpc"
 )
 
 func main() {
@@ -27,39 +26,51 @@ func main() {
 	}
 	logger.Info("Configuration loaded successfully")
 
+	shutdownTracing, err := tracing.Init(context.Background(), "event-service", *cfg)
+	if err != nil {
+		logger.Fatal("Failed to set up tracing: ", err)
+	}
+
 	db, err := pq.ConnectDB(*cfg)
 	if err != nil {
 		logger.Fatal("Failed to connect to database: ", err)
 	}
 	logger.Info("Connected to the database successfully")
+	metrics.RegisterDB(db, cfg.Postgres.Database)
 
 	repo := eventRepo.NewPostgresEventRepository(db)
 	service := eventService.NewEventService(repo)
 
-	var wg sync.WaitGroup
-	wg.Add(1)
-	r := rpc.NewGrpcService(service)
+	r := rpc.NewGrpcService(service, healthcheck.Checks{
+		"postgres": db.PingContext,
+	})
 
-	gServer := grpc.NewServer()
-	go func() {
-		defer wg.Done()
-		if err = r.RUN(*cfg); err != nil {
-			logger.Fatal("Failed to run gRPC service: ", err)
-		}
-	}()
+	app, err := r.RUN(*cfg)
+	if err != nil {
+		logger.Fatal("Failed to run gRPC service: ", err)
+	}
+	app.Own("log file", func(context.Context) error { return logger.Close() })
+	app.Own("tracing", shutdownTracing)
+	app.OwnCloser("postgres", db)
 	logger.Info("Event service started successfully")
-	
-	sigChan := make(chan os.Signal, 1)
-	signal.Notify(sigChan, syscall.SIGINT, syscall.SIGTERM)
 
-	sig := <-sigChan
-	logger.Info("Received signal:", sig)
+	app.ServeHTTP("metrics listener", metrics.Server(*cfg))
 
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-	defer cancel()
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
 
-	gServer.GracefulStop()
+	if err := app.Wait(ctx); err != nil {
+		logger.Error("gRPC server stopped: ", err)
+	} else {
+		logger.Info("Received shutdown signal")
+	}
 
-	<-ctx.Done()
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := app.Shutdown(shutdownCtx); err != nil {
+		logger.Error("Shutdown incomplete: ", err)
+		return
+	}
 	logger.Info("Graceful shutdown complete.")
 }
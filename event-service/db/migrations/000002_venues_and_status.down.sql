@@ -0,0 +1,9 @@
+DROP INDEX IF EXISTS events_venue_date_idx;
+
+ALTER TABLE events
+    DROP CONSTRAINT IF EXISTS events_end_after_start,
+    DROP COLUMN IF EXISTS status_reason,
+    DROP COLUMN IF EXISTS status,
+    DROP COLUMN IF EXISTS venue_id;
+
+DROP TABLE IF EXISTS venues;
@@ -0,0 +1,46 @@
+CREATE TABLE IF NOT EXISTS venues (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    name VARCHAR(255) NOT NULL,
+    city VARCHAR(255) NOT NULL DEFAULT '',
+    capacity INTEGER NOT NULL DEFAULT 0 CHECK (capacity >= 0),
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    deleted_at BIGINT DEFAULT 0
+);
+
+-- Deleted venues keep their names without blocking them for new ones.
+CREATE UNIQUE INDEX IF NOT EXISTS venues_name_lower_key
+    ON venues (lower(name))
+    WHERE deleted_at = 0;
+
+-- Every distinct location already in use becomes a venue of the same name,
+-- and its events are booked into it.
+INSERT INTO venues (name)
+SELECT DISTINCT ON (lower(trim(location))) trim(location)
+FROM events
+WHERE deleted_at = 0 AND trim(coalesce(location, '')) <> ''
+ORDER BY lower(trim(location)), trim(location)
+ON CONFLICT DO NOTHING;
+
+ALTER TABLE events
+    ADD COLUMN IF NOT EXISTS venue_id UUID REFERENCES venues (id),
+    ADD COLUMN IF NOT EXISTS status VARCHAR(16) NOT NULL DEFAULT 'scheduled'
+        CHECK (status IN ('scheduled', 'live', 'delayed', 'completed', 'cancelled')),
+    ADD COLUMN IF NOT EXISTS status_reason TEXT NOT NULL DEFAULT '';
+
+UPDATE events
+SET venue_id = venues.id,
+    location = venues.name
+FROM venues
+WHERE lower(trim(events.location)) = lower(venues.name)
+  AND venues.deleted_at = 0
+  AND events.deleted_at = 0;
+
+-- Existing rows may predate the check, so it only applies to new writes.
+ALTER TABLE events
+    ADD CONSTRAINT events_end_after_start CHECK (end_time > start_time) NOT VALID;
+
+-- Conflict detection looks up the other sessions of a venue on one day.
+CREATE INDEX IF NOT EXISTS events_venue_date_idx
+    ON events (venue_id, date, start_time)
+    WHERE deleted_at = 0;
@@ -0,0 +1,4 @@
+-- Revision of each event, published as the iCalendar SEQUENCE so calendar
+-- apps replace their copy when an event is edited, cancelled or deleted.
+ALTER TABLE events
+    ADD COLUMN IF NOT EXISTS sequence INTEGER NOT NULL DEFAULT 0;
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS unit_entries;
+DROP TABLE IF EXISTS units;
+DROP TABLE IF EXISTS phases;
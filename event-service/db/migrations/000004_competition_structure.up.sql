@@ -0,0 +1,53 @@
+-- A competition runs through phases, such as heats, semifinals and a final or
+-- the rounds of a knockout bracket, and each phase through units: a heat, a
+-- race or a match.
+CREATE TABLE IF NOT EXISTS phases (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    event_id UUID NOT NULL REFERENCES events (id),
+    name VARCHAR(64) NOT NULL,
+    position INTEGER NOT NULL CHECK (position > 0),
+    format VARCHAR(16) NOT NULL DEFAULT 'heats'
+        CHECK (format IN ('heats', 'knockout')),
+    result_type VARCHAR(16) NOT NULL DEFAULT 'time'
+        CHECK (result_type IN ('time', 'score', 'distance')),
+    advance_per_unit INTEGER NOT NULL DEFAULT 0 CHECK (advance_per_unit >= 0),
+    advance_fastest INTEGER NOT NULL DEFAULT 0 CHECK (advance_fastest >= 0),
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    deleted_at BIGINT DEFAULT 0
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS phases_event_position_key
+    ON phases (event_id, position)
+    WHERE deleted_at = 0;
+
+CREATE TABLE IF NOT EXISTS units (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    phase_id UUID NOT NULL REFERENCES phases (id) ON DELETE CASCADE,
+    name VARCHAR(64) NOT NULL,
+    position INTEGER NOT NULL CHECK (position > 0),
+    status VARCHAR(16) NOT NULL DEFAULT 'scheduled'
+        CHECK (status IN ('scheduled', 'completed')),
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (phase_id, position)
+);
+
+-- The start list of a unit: athletes or, in team events, countries, with
+-- their lane and seed and, once the unit is over, their result. value is the
+-- comparable form of mark and is NULL until there is a result.
+CREATE TABLE IF NOT EXISTS unit_entries (
+    unit_id UUID NOT NULL REFERENCES units (id) ON DELETE CASCADE,
+    athlete_id UUID,
+    country_id UUID NOT NULL,
+    lane INTEGER NOT NULL DEFAULT 0 CHECK (lane >= 0),
+    seed INTEGER NOT NULL DEFAULT 0 CHECK (seed >= 0),
+    mark VARCHAR(32) NOT NULL DEFAULT '',
+    value DOUBLE PRECISION,
+    rank INTEGER NOT NULL DEFAULT 0 CHECK (rank >= 0),
+    irm VARCHAR(3) NOT NULL DEFAULT '' CHECK (irm IN ('', 'DNS', 'DNF', 'DSQ')),
+    qualified VARCHAR(1) NOT NULL DEFAULT '' CHECK (qualified IN ('', 'Q', 'q'))
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS unit_entries_competitor_key
+    ON unit_entries (unit_id, COALESCE(athlete_id, country_id));
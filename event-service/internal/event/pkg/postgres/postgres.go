@@ -5,7 +5,9 @@ import (
 	"fmt"
 	config "event-service/internal/event/pkg/load"
 
+	"github.com/XSAM/otelsql"
 	_ "github.com/lib/pq"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
 )
 
 func ConnectDB(cfg config.Config) (*sql.DB, error) {
@@ -17,7 +19,7 @@ func ConnectDB(cfg config.Config) (*sql.DB, error) {
 	cfg.Postgres.Password, 
 	cfg.Postgres.Database)
 
-	db, err := sql.Open("postgres", dataSourceName)
+	db, err := otelsql.Open("postgres", dataSourceName, otelsql.WithAttributes(semconv.DBSystemPostgreSQL))
 	if err != nil {
 		return nil, err
 	}
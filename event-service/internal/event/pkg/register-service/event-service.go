@@ -5,30 +5,55 @@ import (
 	"fmt"
 	"net"
 
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 	pb "github.com/Bekzodbekk/paris2024_livestream_protos/genproto/eventpb"
+	"event-service/internal/event/pkg/healthcheck"
+	"shared/lifecycle"
 	config "event-service/internal/event/pkg/load"
+	"event-service/internal/event/pkg/metrics"
 )
 
 type Service struct {
 	EventService *serve.EventService
+	Checks       healthcheck.Checks
 }
 
-func NewGrpcService(s *serve.EventService) *Service {
-	return &Service{EventService: s}
+func NewGrpcService(s *serve.EventService, checks healthcheck.Checks) *Service {
+	return &Service{EventService: s, Checks: checks}
 }
 
-func (srv *Service) RUN(cfg config.Config) error {
+// RUN listens on the configured port and serves the gRPC API in the
+// background. The returned app owns the server: hand it the service's other
+// resources and call Shutdown on exit.
+func (srv *Service) RUN(cfg config.Config) (*lifecycle.App, error) {
 
 	address := fmt.Sprintf(":%d", cfg.ServerPort)
 	listener, err := net.Listen("tcp", address)
 	if err != nil {
-		return err
+		return nil, err
 	}
-	grpcServer := grpc.NewServer()
+	grpcServer, healthServer := srv.NewServer()
+
+	app := lifecycle.New(grpcServer, listener, healthServer)
+	go healthcheck.Monitor(app.Context(), healthServer, srv.Checks, pb.EventService_ServiceDesc.ServiceName)
+	app.Start()
+	return app, nil
+}
+
+// NewServer builds the gRPC server with the service, the health service and
+// the metrics interceptors registered.
+func (srv *Service) NewServer() (*grpc.Server, *health.Server) {
+	grpcServer := grpc.NewServer(
+		grpc.StatsHandler(otelgrpc.NewServerHandler()),
+		grpc.ChainUnaryInterceptor(metrics.GRPC.UnaryServerInterceptor()),
+		grpc.ChainStreamInterceptor(metrics.GRPC.StreamServerInterceptor()),
+	)
 	pb.RegisterEventServiceServer(grpcServer, srv.EventService)
-	if err := grpcServer.Serve(listener); err != nil {
-		return err
-	}
-	return nil
+	healthServer := health.NewServer()
+	healthpb.RegisterHealthServer(grpcServer, healthServer)
+	metrics.GRPC.InitializeMetrics(grpcServer)
+	return grpcServer, healthServer
 }
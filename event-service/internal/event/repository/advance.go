@@ -0,0 +1,349 @@
+package repository
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	pb "github.com/Bekzodbekk/paris2024_livestream_protos/genproto/eventpb"
+)
+
+// lanePreference is the order lanes are handed out in, best seed first, so
+// the fastest qualifiers run in the middle of the track or pool.
+var lanePreference = []int32{4, 5, 3, 6, 2, 7, 1, 8}
+
+// hasResult reports whether an entry has a mark or an IRM (DNS, DNF, DSQ).
+func hasResult(e *pb.UnitEntry) bool {
+	return e.Mark != "" || e.Irm != ""
+}
+
+// better reports whether value a beats value b.
+func better(resultType string, a, b float64) bool {
+	if resultType == ResultTime {
+		return a < b
+	}
+	return a > b
+}
+
+// applyResults copies the results onto the matching entries of a unit. A
+// result without a mark or IRM gets its value as mark.
+func applyResults(unit *pb.Unit, results []*pb.UnitEntry) error {
+	if len(results) == 0 {
+		return errors.New("no results given")
+	}
+	entries := make(map[string]*pb.UnitEntry)
+	for _, e := range unit.Entries {
+		entries[competitorKey(e)] = e
+	}
+	for _, r := range results {
+		e, ok := entries[competitorKey(r)]
+		if !ok {
+			return fmt.Errorf("%s is not on the start list of %s", competitorKey(r), unit.Name)
+		}
+		irm := strings.ToUpper(strings.TrimSpace(r.Irm))
+		switch irm {
+		case "", "DNS", "DNF", "DSQ":
+		default:
+			return fmt.Errorf("irm %q is not DNS, DNF or DSQ", r.Irm)
+		}
+		if r.Rank < 0 {
+			return errors.New("rank cannot be negative")
+		}
+		mark := strings.TrimSpace(r.Mark)
+		if mark == "" && irm == "" {
+			mark = strconv.FormatFloat(r.Value, 'f', -1, 64)
+		}
+		e.Mark, e.Value, e.Irm, e.Rank = mark, r.Value, irm, r.Rank
+	}
+	return nil
+}
+
+// rankEntries ranks the entries of a unit that have a mark. Ties share a
+// rank and the next one is skipped, as in 1, 2, 2, 4; entries with an IRM or
+// no result are not ranked. When every ranked entry already has a rank, as
+// in sports decided by judges or tie-breaks, the given ranks are kept.
+func rankEntries(resultType string, entries []*pb.UnitEntry) {
+	var ranked []*pb.UnitEntry
+	given := true
+	for _, e := range entries {
+		if !hasResult(e) || e.Irm != "" {
+			e.Rank = 0
+			continue
+		}
+		ranked = append(ranked, e)
+		given = given && e.Rank > 0
+	}
+	if given {
+		return
+	}
+
+	sort.SliceStable(ranked, func(i, j int) bool {
+		return better(resultType, ranked[i].Value, ranked[j].Value)
+	})
+	for i, e := range ranked {
+		e.Rank = int32(i + 1)
+		if i > 0 && e.Value == ranked[i-1].Value {
+			e.Rank = ranked[i-1].Rank
+		}
+	}
+}
+
+// checkWinner rejects a knockout match that ends level, which only ranks
+// given with the results can settle.
+func checkWinner(format string, unit *pb.Unit) error {
+	if format != FormatKnockout {
+		return nil
+	}
+	winners := 0
+	for _, e := range unit.Entries {
+		if e.Rank == 1 {
+			winners++
+		}
+	}
+	if winners > 1 {
+		return fmt.Errorf("%s is tied; give the ranks", unit.Name)
+	}
+	return nil
+}
+
+// sortEntries orders entries as loadUnits does: ranked first, then by lane.
+func sortEntries(entries []*pb.UnitEntry) {
+	sort.SliceStable(entries, func(i, j int) bool {
+		a, b := entries[i], entries[j]
+		if (a.Rank == 0) != (b.Rank == 0) {
+			return b.Rank == 0
+		}
+		if a.Rank != b.Rank {
+			return a.Rank < b.Rank
+		}
+		if a.Lane != b.Lane {
+			return a.Lane < b.Lane
+		}
+		return a.Seed < b.Seed
+	})
+}
+
+// qualifier is an entry going through to the next phase.
+type qualifier struct {
+	unitID string
+	entry  *pb.UnitEntry
+}
+
+// advance works out who goes from a completed phase to the next one. It
+// marks the qualifiers of phase and returns them, best first, with the start
+// lists of next's units.
+func advance(phase, next *pb.Phase) ([]qualifier, [][]*pb.UnitEntry, error) {
+	for _, unit := range phase.Units {
+		if unit.Status != UnitCompleted {
+			return nil, nil, fmt.Errorf("%w: %s has no results yet", ErrPhaseIncomplete, unit.Name)
+		}
+		for _, e := range unit.Entries {
+			e.Qualified = ""
+		}
+	}
+	for _, unit := range next.Units {
+		if unit.Status == UnitCompleted {
+			return nil, nil, fmt.Errorf("%w: %s", ErrUnitCompleted, unit.Name)
+		}
+	}
+	if len(next.Units) == 0 {
+		return nil, nil, fmt.Errorf("%w: %s has no units", ErrInvalidPhase, next.Name)
+	}
+
+	if phase.Format == FormatKnockout {
+		return advanceKnockout(phase, next)
+	}
+
+	qualified := heatQualifiers(phase)
+	if len(qualified) == 0 {
+		return nil, nil, fmt.Errorf("%w: %s advances nobody; set advance_per_unit or advance_fastest", ErrInvalidPhase, phase.Name)
+	}
+	return qualified, seedUnits(qualified, next), nil
+}
+
+// heatQualifiers marks Q the first advance_per_unit of every unit and q the
+// advance_fastest best marks among the rest, along with anyone tied with the
+// last of them. Qs come first, by place and then mark, followed by the qs.
+func heatQualifiers(phase *pb.Phase) []qualifier {
+	var byPlace, rest []qualifier
+	for _, unit := range phase.Units {
+		for _, e := range unit.Entries {
+			if e.Rank == 0 {
+				continue
+			}
+			if e.Rank <= phase.AdvancePerUnit {
+				e.Qualified = "Q"
+				byPlace = append(byPlace, qualifier{unitID: unit.Id, entry: e})
+			} else {
+				rest = append(rest, qualifier{unitID: unit.Id, entry: e})
+			}
+		}
+	}
+
+	sort.SliceStable(byPlace, func(i, j int) bool {
+		a, b := byPlace[i].entry, byPlace[j].entry
+		if a.Rank != b.Rank {
+			return a.Rank < b.Rank
+		}
+		return better(phase.ResultType, a.Value, b.Value)
+	})
+	sort.SliceStable(rest, func(i, j int) bool {
+		return better(phase.ResultType, rest[i].entry.Value, rest[j].entry.Value)
+	})
+
+	taken := 0
+	for i, q := range rest {
+		if i >= int(phase.AdvanceFastest) && (i == 0 || q.entry.Value != rest[i-1].entry.Value) {
+			break
+		}
+		q.entry.Qualified = "q"
+		taken++
+	}
+	return append(byPlace, rest[:taken]...)
+}
+
+// seedUnits spreads the qualifiers, best first, over the units of next in
+// serpentine order: 1 to n, then n back to 1. In heats the best of each unit
+// get the middle lanes; in a knockout round the two of a match are slots 1
+// and 2, so the best seed meets the worst.
+func seedUnits(qualified []qualifier, next *pb.Phase) [][]*pb.UnitEntry {
+	n := len(next.Units)
+	lists := make([][]*pb.UnitEntry, n)
+	for i, q := range qualified {
+		slot := i % n
+		if (i/n)%2 == 1 {
+			slot = n - 1 - slot
+		}
+		lists[slot] = append(lists[slot], &pb.UnitEntry{
+			AthleteId: q.entry.AthleteId,
+			CountryId: q.entry.CountryId,
+			Seed:      int32(i + 1),
+		})
+	}
+	for _, list := range lists {
+		for j, e := range list {
+			e.Lane = int32(j + 1)
+			if next.Format != FormatKnockout && j < len(lanePreference) {
+				e.Lane = lanePreference[j]
+			}
+		}
+	}
+	return lists
+}
+
+// advanceKnockout sends the winner of every match to the next round: matches
+// 1 and 2 feed match 1, 3 and 4 feed match 2, and so on, the winner of the
+// odd match taking slot 1. If the next round has a match beyond those, it is
+// the bronze medal match and the losers go there.
+func advanceKnockout(phase, next *pb.Phase) ([]qualifier, [][]*pb.UnitEntry, error) {
+	matches := (len(phase.Units) + 1) / 2
+	if len(next.Units) < matches {
+		return nil, nil, fmt.Errorf("%w: %s has %d units for %d winners", ErrInvalidPhase, next.Name, len(next.Units), len(phase.Units))
+	}
+	bronze := len(next.Units) > matches
+
+	var qualified []qualifier
+	lists := make([][]*pb.UnitEntry, len(next.Units))
+	for i, unit := range phase.Units {
+		var winner, loser *pb.UnitEntry
+		for _, e := range unit.Entries {
+			switch e.Rank {
+			case 1:
+				winner = e
+			case 2:
+				loser = e
+			}
+		}
+		if winner == nil {
+			return nil, nil, fmt.Errorf("%w: %s has no winner", ErrPhaseIncomplete, unit.Name)
+		}
+		winner.Qualified = "Q"
+		qualified = append(qualified, qualifier{unitID: unit.Id, entry: winner})
+
+		lane := int32(i%2 + 1)
+		lists[i/2] = append(lists[i/2], &pb.UnitEntry{AthleteId: winner.AthleteId, CountryId: winner.CountryId, Seed: winner.Seed, Lane: lane})
+		if bronze && loser != nil {
+			last := len(lists) - 1
+			lists[last] = append(lists[last], &pb.UnitEntry{AthleteId: loser.AthleteId, CountryId: loser.CountryId, Seed: loser.Seed, Lane: lane})
+		}
+	}
+	return qualified, lists, nil
+}
+
+// bracketOrder lists the seeds of a bracket with size slots in draw order,
+// 1, 8, 4, 5, 2, 7, 3, 6 for eight, so the top two seeds can only meet in the
+// final and the top four in the semifinals.
+func bracketOrder(size int) []int {
+	order := []int{1}
+	for n := 2; n <= size; n *= 2 {
+		next := make([]int, 0, n)
+		for _, seed := range order {
+			next = append(next, seed, n+1-seed)
+		}
+		order = next
+	}
+	return order
+}
+
+// roundName names the knockout round with n competitors left.
+func roundName(n int) string {
+	switch n {
+	case 2:
+		return "Final"
+	case 4:
+		return "Semifinals"
+	case 8:
+		return "Quarterfinals"
+	default:
+		return fmt.Sprintf("Round of %d", n)
+	}
+}
+
+// bracket lays out the rounds of a single-elimination bracket for the
+// competitors, given best seed first. Only the first round has start lists;
+// a seed drawn without an opponent has a bye, recorded as a won match.
+func bracket(competitors []*pb.UnitEntry, bronzeMatch bool) []*pb.Phase {
+	size := 2
+	for size < len(competitors) {
+		size *= 2
+	}
+	order := bracketOrder(size)
+
+	var phases []*pb.Phase
+	for n := size; n >= 2; n /= 2 {
+		phase := &pb.Phase{Name: roundName(n), Format: FormatKnockout}
+		for m := 0; m < n/2; m++ {
+			unit := &pb.Unit{Name: fmt.Sprintf("Match %d", m+1), Position: int32(m + 1), Status: UnitScheduled}
+			if n == size {
+				for slot := 0; slot < 2; slot++ {
+					seed := order[2*m+slot]
+					if seed > len(competitors) {
+						continue
+					}
+					c := competitors[seed-1]
+					unit.Entries = append(unit.Entries, &pb.UnitEntry{
+						AthleteId: c.AthleteId,
+						CountryId: c.CountryId,
+						Seed:      int32(seed),
+						Lane:      int32(slot + 1),
+					})
+				}
+				if len(unit.Entries) == 1 {
+					unit.Entries[0].Mark, unit.Entries[0].Rank = "BYE", 1
+					unit.Status = UnitCompleted
+				}
+			}
+			phase.Units = append(phase.Units, unit)
+		}
+		if n == 2 {
+			phase.Units[0].Name = "Gold Medal Match"
+			if bronzeMatch {
+				phase.Units = append(phase.Units, &pb.Unit{Name: "Bronze Medal Match", Position: 2, Status: UnitScheduled})
+			}
+		}
+		phases = append(phases, phase)
+	}
+	return phases
+}
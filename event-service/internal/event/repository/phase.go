@@ -0,0 +1,756 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"event-service/logger"
+	"fmt"
+	"strings"
+
+	pb "github.com/Bekzodbekk/paris2024_livestream_protos/genproto/eventpb"
+	"github.com/lib/pq"
+	"github.com/sirupsen/logrus"
+)
+
+// Phase formats. Heats advance the best of every unit and, optionally, the
+// best of the rest; knockout rounds advance the winner of every match.
+const (
+	FormatHeats    = "heats"
+	FormatKnockout = "knockout"
+)
+
+// Result types. Times are better when lower, scores and distances when
+// higher.
+const (
+	ResultTime     = "time"
+	ResultScore    = "score"
+	ResultDistance = "distance"
+)
+
+// Unit statuses. A unit is completed once its results are recorded.
+const (
+	UnitScheduled = "scheduled"
+	UnitCompleted = "completed"
+)
+
+// maxUnits caps the units of one phase.
+const maxUnits = 64
+
+var (
+	// ErrInvalidPhase is returned for a phase or bracket the request cannot
+	// describe.
+	ErrInvalidPhase = errors.New("invalid phase")
+	// ErrInvalidEntry is returned for a start list or result that does not fit
+	// its unit.
+	ErrInvalidEntry = errors.New("invalid entry")
+	// ErrUnitCompleted is returned when the start list of a unit with results
+	// would change.
+	ErrUnitCompleted = errors.New("unit already has results")
+	// ErrPhaseIncomplete is returned when a phase is advanced before all its
+	// units have results.
+	ErrPhaseIncomplete = errors.New("phase is not complete")
+	// ErrNoNextPhase is returned when the last phase of an event is advanced.
+	ErrNoNextPhase = errors.New("phase is the last of its event")
+)
+
+const phaseColumns = `id, event_id, name, position, format, result_type, advance_per_unit, advance_fastest, created_at, updated_at`
+
+const unitColumns = `id, phase_id, name, position, status`
+
+const entryColumns = `unit_id, COALESCE(athlete_id::text, ''), country_id, lane, seed, mark, COALESCE(value, 0), rank, irm, qualified`
+
+// queryer is satisfied by both *sql.DB and *sql.Tx.
+type queryer interface {
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+}
+
+// validatePhase fills in the defaults of a new phase and checks its fields.
+func validatePhase(req *pb.CreatePhaseRequest) error {
+	req.Name = strings.TrimSpace(req.Name)
+	req.Format = strings.ToLower(strings.TrimSpace(req.Format))
+	req.ResultType = strings.ToLower(strings.TrimSpace(req.ResultType))
+	if req.Name == "" {
+		return errors.New("name is required")
+	}
+	switch req.Format {
+	case "":
+		req.Format = FormatHeats
+	case FormatHeats, FormatKnockout:
+	default:
+		return fmt.Errorf("format %q is not heats or knockout", req.Format)
+	}
+	if err := validateResultType(&req.ResultType, req.Format); err != nil {
+		return err
+	}
+	if req.Units == 0 {
+		req.Units = 1
+	}
+	if req.Units < 0 || req.Units > maxUnits {
+		return fmt.Errorf("units must be between 1 and %d", maxUnits)
+	}
+	if req.AdvancePerUnit < 0 || req.AdvanceFastest < 0 {
+		return errors.New("advancement counts cannot be negative")
+	}
+	return nil
+}
+
+// validateResultType defaults knockout rounds to scores and everything else
+// to times.
+func validateResultType(resultType *string, format string) error {
+	switch *resultType {
+	case "":
+		*resultType = ResultTime
+		if format == FormatKnockout {
+			*resultType = ResultScore
+		}
+	case ResultTime, ResultScore, ResultDistance:
+	default:
+		return fmt.Errorf("result_type %q is not time, score or distance", *resultType)
+	}
+	return nil
+}
+
+// competitorKey identifies an entry within its unit: the athlete or, for a
+// team entry, the country.
+func competitorKey(e *pb.UnitEntry) string {
+	if e.AthleteId != "" {
+		return e.AthleteId
+	}
+	return e.CountryId
+}
+
+// validateEntries checks a start list, or the competitors of a bracket.
+func validateEntries(entries []*pb.UnitEntry) error {
+	seen := make(map[string]bool)
+	for _, e := range entries {
+		if e.CountryId == "" {
+			return errors.New("country_id is required for every entry")
+		}
+		if e.Lane < 0 || e.Seed < 0 {
+			return errors.New("lane and seed cannot be negative")
+		}
+		key := competitorKey(e)
+		if seen[key] {
+			return fmt.Errorf("%s is entered twice", key)
+		}
+		seen[key] = true
+	}
+	return nil
+}
+
+func (db *PostgresEventRepository) CreatePhase(ctx context.Context, req *pb.CreatePhaseRequest) (*pb.Phase, error) {
+
+	if err := validatePhase(req); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidPhase, err)
+	}
+
+	tx, err := db.DB.BeginTx(ctx, nil)
+	if err != nil {
+		logger.ErrorContext(ctx, "Creating phase failed", logrus.Fields{
+			"error":    err,
+			"event_id": req.EventId,
+		})
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	phase := &pb.Phase{
+		EventId:        req.EventId,
+		Name:           req.Name,
+		Format:         req.Format,
+		ResultType:     req.ResultType,
+		AdvancePerUnit: req.AdvancePerUnit,
+		AdvanceFastest: req.AdvanceFastest,
+	}
+	for i, name := range unitNames(req.Format, req.Name, int(req.Units)) {
+		phase.Units = append(phase.Units, &pb.Unit{Name: name, Position: int32(i + 1), Status: UnitScheduled})
+	}
+
+	phase.Position, err = nextPosition(ctx, tx, req.EventId)
+	if err == nil {
+		err = insertPhase(ctx, tx, phase)
+	}
+	if err == nil {
+		err = tx.Commit()
+	}
+	if err != nil {
+		logger.ErrorContext(ctx, "Creating phase failed", logrus.Fields{
+			"error":    err,
+			"event_id": req.EventId,
+		})
+		return nil, err
+	}
+
+	logger.InfoContext(ctx, "Phase created successfully", logrus.Fields{
+		"phase_id": phase.Id,
+		"event_id": phase.EventId,
+		"name":     phase.Name,
+	})
+
+	return phase, nil
+}
+
+// unitNames names the units of a new phase: a single unit after the phase
+// itself, otherwise numbered heats or matches.
+func unitNames(format, phase string, n int) []string {
+	if n == 1 {
+		return []string{phase}
+	}
+	prefix := "Heat"
+	if format == FormatKnockout {
+		prefix = "Match"
+	}
+	names := make([]string, n)
+	for i := range names {
+		names[i] = fmt.Sprintf("%s %d", prefix, i+1)
+	}
+	return names
+}
+
+// nextPosition locks the event, so phases are added to it one at a time, and
+// returns the position after its last phase.
+func nextPosition(ctx context.Context, tx *sql.Tx, eventID string) (int32, error) {
+	var id string
+	err := tx.QueryRowContext(ctx, `SELECT id FROM events WHERE id=$1 AND deleted_at=0 FOR UPDATE`, eventID).Scan(&id)
+	if err != nil {
+		return 0, err
+	}
+	var position int32
+	err = tx.QueryRowContext(ctx, `
+	SELECT COALESCE(MAX(position), 0) + 1
+	FROM phases
+	WHERE event_id=$1 AND deleted_at=0`, eventID).Scan(&position)
+	return position, err
+}
+
+// insertPhase inserts the phase with its units and their start lists, and
+// fills in the generated ids and timestamps.
+func insertPhase(ctx context.Context, tx *sql.Tx, phase *pb.Phase) error {
+	err := scanPhase(tx.QueryRowContext(ctx, `
+	INSERT INTO phases(event_id, name, position, format, result_type, advance_per_unit, advance_fastest)
+	VALUES($1, $2, $3, $4, $5, $6, $7)
+	RETURNING `+phaseColumns,
+		phase.EventId,
+		phase.Name,
+		phase.Position,
+		phase.Format,
+		phase.ResultType,
+		phase.AdvancePerUnit,
+		phase.AdvanceFastest), phase)
+	if err != nil {
+		return err
+	}
+
+	for _, unit := range phase.Units {
+		err := tx.QueryRowContext(ctx, `
+		INSERT INTO units(phase_id, name, position, status)
+		VALUES($1, $2, $3, $4)
+		RETURNING id`, phase.Id, unit.Name, unit.Position, unit.Status).Scan(&unit.Id)
+		if err != nil {
+			return err
+		}
+		unit.PhaseId = phase.Id
+		if err := insertEntries(ctx, tx, unit.Id, unit.Entries); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func insertEntries(ctx context.Context, tx *sql.Tx, unitID string, entries []*pb.UnitEntry) error {
+	for _, e := range entries {
+		_, err := tx.ExecContext(ctx, `
+		INSERT INTO unit_entries(unit_id, athlete_id, country_id, lane, seed, mark, value, rank, irm, qualified)
+		VALUES($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)`,
+			unitID,
+			nullIfEmpty(e.AthleteId),
+			e.CountryId,
+			e.Lane,
+			e.Seed,
+			e.Mark,
+			entryValue(e),
+			e.Rank,
+			e.Irm,
+			e.Qualified)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// entryValue stores the value of an entry without a result as NULL.
+func entryValue(e *pb.UnitEntry) interface{} {
+	if !hasResult(e) || e.Irm != "" {
+		return nil
+	}
+	return e.Value
+}
+
+func scanPhase(row rowScanner, p *pb.Phase) error {
+	return row.Scan(
+		&p.Id,
+		&p.EventId,
+		&p.Name,
+		&p.Position,
+		&p.Format,
+		&p.ResultType,
+		&p.AdvancePerUnit,
+		&p.AdvanceFastest,
+		&p.CreatedAt,
+		&p.UpdatedAt,
+	)
+}
+
+// loadPhases reads the phases matching condition, in event order, with their
+// units and start lists.
+func loadPhases(ctx context.Context, q queryer, condition string, args ...interface{}) ([]*pb.Phase, error) {
+	rows, err := q.QueryContext(ctx, `
+	SELECT `+phaseColumns+`
+	FROM phases
+	WHERE deleted_at=0 AND `+condition+`
+	ORDER BY event_id, position`, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var phases []*pb.Phase
+	byID := make(map[string]*pb.Phase)
+	for rows.Next() {
+		phase := pb.Phase{}
+		if err := scanPhase(rows, &phase); err != nil {
+			return nil, err
+		}
+		phases = append(phases, &phase)
+		byID[phase.Id] = &phase
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	if len(phases) == 0 {
+		return nil, nil
+	}
+
+	ids := make([]string, len(phases))
+	for i, phase := range phases {
+		ids[i] = phase.Id
+	}
+	units, err := loadUnits(ctx, q, "phase_id = ANY($1::uuid[])", pq.Array(ids))
+	if err != nil {
+		return nil, err
+	}
+	for _, unit := range units {
+		byID[unit.PhaseId].Units = append(byID[unit.PhaseId].Units, unit)
+	}
+	return phases, nil
+}
+
+// loadUnits reads the units matching condition, in phase order, with their
+// start lists: ranked entries first, then by lane.
+func loadUnits(ctx context.Context, q queryer, condition string, args ...interface{}) ([]*pb.Unit, error) {
+	rows, err := q.QueryContext(ctx, `
+	SELECT `+unitColumns+`
+	FROM units
+	WHERE `+condition+`
+	ORDER BY phase_id, position`, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var units []*pb.Unit
+	byID := make(map[string]*pb.Unit)
+	for rows.Next() {
+		unit := pb.Unit{}
+		if err := rows.Scan(&unit.Id, &unit.PhaseId, &unit.Name, &unit.Position, &unit.Status); err != nil {
+			return nil, err
+		}
+		units = append(units, &unit)
+		byID[unit.Id] = &unit
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	if len(units) == 0 {
+		return nil, nil
+	}
+
+	ids := make([]string, len(units))
+	for i, unit := range units {
+		ids[i] = unit.Id
+	}
+	entries, err := q.QueryContext(ctx, `
+	SELECT `+entryColumns+`
+	FROM unit_entries
+	WHERE unit_id = ANY($1::uuid[])
+	ORDER BY unit_id, rank = 0, rank, lane, seed`, pq.Array(ids))
+	if err != nil {
+		return nil, err
+	}
+	defer entries.Close()
+
+	for entries.Next() {
+		var unitID string
+		e := pb.UnitEntry{}
+		err := entries.Scan(&unitID, &e.AthleteId, &e.CountryId, &e.Lane, &e.Seed, &e.Mark, &e.Value, &e.Rank, &e.Irm, &e.Qualified)
+		if err != nil {
+			return nil, err
+		}
+		byID[unitID].Entries = append(byID[unitID].Entries, &e)
+	}
+	return units, entries.Err()
+}
+
+func (db *PostgresEventRepository) GetPhase(ctx context.Context, req *pb.GetPhaseRequest) (*pb.Phase, error) {
+
+	phases, err := loadPhases(ctx, db.DB, "id=$1", req.Id)
+	if err != nil {
+		logger.ErrorContext(ctx, "Retrieving phase failed", logrus.Fields{
+			"error":    err,
+			"phase_id": req.Id,
+		})
+		return nil, err
+	}
+	if len(phases) == 0 {
+		return nil, sql.ErrNoRows
+	}
+
+	return phases[0], nil
+}
+
+func (db *PostgresEventRepository) ListPhases(ctx context.Context, req *pb.ListPhasesRequest) (*pb.ListPhasesResponse, error) {
+
+	phases, err := loadPhases(ctx, db.DB, "event_id=$1", req.EventId)
+	if err != nil {
+		logger.ErrorContext(ctx, "Listing phases failed", logrus.Fields{
+			"error":    err,
+			"event_id": req.EventId,
+		})
+		return nil, err
+	}
+
+	return &pb.ListPhasesResponse{Phases: phases}, nil
+}
+
+func (db *PostgresEventRepository) DeletePhase(ctx context.Context, req *pb.DeletePhaseRequest) (*pb.DeletePhaseResponse, error) {
+
+	result, err := db.DB.ExecContext(ctx, `
+	UPDATE phases
+	SET deleted_at=DATE_PART('epoch', CURRENT_TIMESTAMP)::INT
+	WHERE id=$1 AND deleted_at=0`, req.Id)
+	if err != nil {
+		logger.ErrorContext(ctx, "Deleting phase failed", logrus.Fields{
+			"error":    err,
+			"phase_id": req.Id,
+		})
+		return nil, err
+	}
+	num, err := result.RowsAffected()
+	if err != nil {
+		return nil, err
+	}
+	if num == 0 {
+		return nil, sql.ErrNoRows
+	}
+
+	logger.InfoContext(ctx, "Phase deleted successfully", logrus.Fields{
+		"phase_id": req.Id,
+	})
+
+	return &pb.DeletePhaseResponse{Status: "deleted successfully"}, nil
+}
+
+// lockUnit locks a unit of a live phase and returns its status with the
+// phase's format and result type.
+func lockUnit(ctx context.Context, tx *sql.Tx, unitID string) (status, format, resultType string, err error) {
+	err = tx.QueryRowContext(ctx, `
+	SELECT u.status, p.format, p.result_type
+	FROM units u
+	JOIN phases p ON p.id = u.phase_id
+	WHERE u.id=$1 AND p.deleted_at=0
+	FOR UPDATE OF u`, unitID).Scan(&status, &format, &resultType)
+	return status, format, resultType, err
+}
+
+// SetStartList replaces the start list of a unit that has no results yet.
+func (db *PostgresEventRepository) SetStartList(ctx context.Context, req *pb.SetStartListRequest) (*pb.Unit, error) {
+
+	if err := validateEntries(req.Entries); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidEntry, err)
+	}
+	for _, e := range req.Entries {
+		e.Mark, e.Value, e.Rank, e.Irm, e.Qualified = "", 0, 0, "", ""
+	}
+
+	tx, err := db.DB.BeginTx(ctx, nil)
+	if err != nil {
+		logger.ErrorContext(ctx, "Setting start list failed", logrus.Fields{
+			"error":   err,
+			"unit_id": req.UnitId,
+		})
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	status, _, _, err := lockUnit(ctx, tx, req.UnitId)
+	if err != nil {
+		return nil, err
+	}
+	if status == UnitCompleted {
+		return nil, ErrUnitCompleted
+	}
+
+	_, err = tx.ExecContext(ctx, `DELETE FROM unit_entries WHERE unit_id=$1`, req.UnitId)
+	if err == nil {
+		err = insertEntries(ctx, tx, req.UnitId, req.Entries)
+	}
+	var units []*pb.Unit
+	if err == nil {
+		units, err = loadUnits(ctx, tx, "id=$1", req.UnitId)
+	}
+	if err == nil {
+		err = tx.Commit()
+	}
+	if err != nil {
+		logger.ErrorContext(ctx, "Setting start list failed", logrus.Fields{
+			"error":   err,
+			"unit_id": req.UnitId,
+		})
+		return nil, err
+	}
+
+	logger.InfoContext(ctx, "Start list set successfully", logrus.Fields{
+		"unit_id": req.UnitId,
+		"entries": len(req.Entries),
+	})
+
+	return units[0], nil
+}
+
+// RecordResults stores the results of a unit's entries, ranks the unit and
+// marks it completed. Recording again corrects earlier results.
+func (db *PostgresEventRepository) RecordResults(ctx context.Context, req *pb.RecordResultsRequest) (*pb.Unit, error) {
+
+	tx, err := db.DB.BeginTx(ctx, nil)
+	if err != nil {
+		logger.ErrorContext(ctx, "Recording results failed", logrus.Fields{
+			"error":   err,
+			"unit_id": req.UnitId,
+		})
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	_, format, resultType, err := lockUnit(ctx, tx, req.UnitId)
+	if err != nil {
+		return nil, err
+	}
+	units, err := loadUnits(ctx, tx, "id=$1", req.UnitId)
+	if err != nil {
+		return nil, err
+	}
+	unit := units[0]
+
+	if err := applyResults(unit, req.Results); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidEntry, err)
+	}
+	rankEntries(resultType, unit.Entries)
+	if err := checkWinner(format, unit); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidEntry, err)
+	}
+
+	for _, e := range unit.Entries {
+		_, err = tx.ExecContext(ctx, `
+		UPDATE unit_entries
+		SET mark=$1, value=$2, rank=$3, irm=$4
+		WHERE unit_id=$5 AND COALESCE(athlete_id, country_id)=$6`,
+			e.Mark, entryValue(e), e.Rank, e.Irm, unit.Id, competitorKey(e))
+		if err != nil {
+			break
+		}
+	}
+	if err == nil {
+		_, err = tx.ExecContext(ctx, `UPDATE units SET status=$1, updated_at=NOW() WHERE id=$2`, UnitCompleted, unit.Id)
+	}
+	if err == nil {
+		unit.Status = UnitCompleted
+		err = tx.Commit()
+	}
+	if err != nil {
+		logger.ErrorContext(ctx, "Recording results failed", logrus.Fields{
+			"error":   err,
+			"unit_id": req.UnitId,
+		})
+		return nil, err
+	}
+
+	logger.InfoContext(ctx, "Results recorded successfully", logrus.Fields{
+		"unit_id": unit.Id,
+		"results": len(req.Results),
+	})
+
+	sortEntries(unit.Entries)
+	return unit, nil
+}
+
+// AdvancePhase promotes the qualifiers of a completed phase to the start
+// lists of the next phase of its event, replacing whatever was there.
+func (db *PostgresEventRepository) AdvancePhase(ctx context.Context, req *pb.AdvancePhaseRequest) (*pb.AdvancePhaseResponse, error) {
+
+	tx, err := db.DB.BeginTx(ctx, nil)
+	if err != nil {
+		logger.ErrorContext(ctx, "Advancing phase failed", logrus.Fields{
+			"error":    err,
+			"phase_id": req.PhaseId,
+		})
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	// Locking every unit of the event holds off results and start lists
+	// written while the qualifiers are worked out.
+	_, err = tx.ExecContext(ctx, `
+	SELECT u.id
+	FROM units u
+	JOIN phases p ON p.id = u.phase_id
+	WHERE p.event_id = (SELECT event_id FROM phases WHERE id=$1)
+	FOR UPDATE OF u`, req.PhaseId)
+	if err != nil {
+		return nil, err
+	}
+	phases, err := loadPhases(ctx, tx, "event_id = (SELECT event_id FROM phases WHERE id=$1 AND deleted_at=0)", req.PhaseId)
+	if err != nil {
+		return nil, err
+	}
+	current := -1
+	for i, phase := range phases {
+		if phase.Id == req.PhaseId {
+			current = i
+		}
+	}
+	if current < 0 {
+		return nil, sql.ErrNoRows
+	}
+	if current == len(phases)-1 {
+		return nil, ErrNoNextPhase
+	}
+	phase, next := phases[current], phases[current+1]
+
+	qualified, startLists, err := advance(phase, next)
+	if err != nil {
+		return nil, err
+	}
+
+	var ids []string
+	for _, unit := range phase.Units {
+		ids = append(ids, unit.Id)
+	}
+	_, err = tx.ExecContext(ctx, `UPDATE unit_entries SET qualified='' WHERE unit_id = ANY($1::uuid[])`, pq.Array(ids))
+	for _, q := range qualified {
+		if err != nil {
+			break
+		}
+		_, err = tx.ExecContext(ctx, `
+		UPDATE unit_entries
+		SET qualified=$1
+		WHERE unit_id=$2 AND COALESCE(athlete_id, country_id)=$3`, q.entry.Qualified, q.unitID, competitorKey(q.entry))
+	}
+	for i, unit := range next.Units {
+		if err != nil {
+			break
+		}
+		if _, err = tx.ExecContext(ctx, `DELETE FROM unit_entries WHERE unit_id=$1`, unit.Id); err == nil {
+			err = insertEntries(ctx, tx, unit.Id, startLists[i])
+		}
+	}
+	var reloaded []*pb.Phase
+	if err == nil {
+		reloaded, err = loadPhases(ctx, tx, "id=$1", next.Id)
+	}
+	if err == nil {
+		err = tx.Commit()
+	}
+	if err != nil {
+		logger.ErrorContext(ctx, "Advancing phase failed", logrus.Fields{
+			"error":    err,
+			"phase_id": req.PhaseId,
+		})
+		return nil, err
+	}
+
+	resp := pb.AdvancePhaseResponse{Next: reloaded[0]}
+	for _, q := range qualified {
+		resp.Qualified = append(resp.Qualified, q.entry)
+	}
+
+	logger.InfoContext(ctx, "Phase advanced successfully", logrus.Fields{
+		"phase_id":  phase.Id,
+		"next_id":   next.Id,
+		"qualified": len(qualified),
+	})
+
+	return &resp, nil
+}
+
+// GenerateBracket appends the rounds of a single-elimination bracket to an
+// event. Competitors are given best seed first and drawn so the top seeds
+// can only meet late; seeds without an opponent get a bye into the second
+// round. The last round has the gold medal match and, if asked for, a bronze
+// medal match between the losing semifinalists.
+func (db *PostgresEventRepository) GenerateBracket(ctx context.Context, req *pb.GenerateBracketRequest) (*pb.GenerateBracketResponse, error) {
+
+	if len(req.Competitors) < 2 {
+		return nil, fmt.Errorf("%w: a bracket needs at least two competitors", ErrInvalidPhase)
+	}
+	if err := validateEntries(req.Competitors); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidEntry, err)
+	}
+	req.ResultType = strings.ToLower(strings.TrimSpace(req.ResultType))
+	if err := validateResultType(&req.ResultType, FormatKnockout); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidPhase, err)
+	}
+
+	tx, err := db.DB.BeginTx(ctx, nil)
+	if err != nil {
+		logger.ErrorContext(ctx, "Generating bracket failed", logrus.Fields{
+			"error":    err,
+			"event_id": req.EventId,
+		})
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	position, err := nextPosition(ctx, tx, req.EventId)
+	if err != nil {
+		return nil, err
+	}
+	phases := bracket(req.Competitors, req.BronzeMatch)
+	for i, phase := range phases {
+		phase.EventId = req.EventId
+		phase.Position = position + int32(i)
+		phase.ResultType = req.ResultType
+		if err = insertPhase(ctx, tx, phase); err != nil {
+			break
+		}
+	}
+	if err == nil {
+		err = tx.Commit()
+	}
+	if err != nil {
+		logger.ErrorContext(ctx, "Generating bracket failed", logrus.Fields{
+			"error":    err,
+			"event_id": req.EventId,
+		})
+		return nil, err
+	}
+
+	logger.InfoContext(ctx, "Bracket generated successfully", logrus.Fields{
+		"event_id":    req.EventId,
+		"competitors": len(req.Competitors),
+		"rounds":      len(phases),
+	})
+
+	return &pb.GenerateBracketResponse{Phases: phases}, nil
+}
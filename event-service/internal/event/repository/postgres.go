@@ -1,10 +1,16 @@
 package repository
 
 import (
+	"context"
 	"database/sql"
 	"errors"
+	"fmt"
+	"shared/paging"
+	"strings"
+	"time"
 	pb "github.com/Bekzodbekk/paris2024_livestream_protos/genproto/eventpb"
 	"event-service/logger" 
+	"github.com/lib/pq"
 	"github.com/sirupsen/logrus"
 )
 
@@ -18,74 +24,128 @@ func NewPostgresEventRepository(db *sql.DB) EventRepository {
 	}
 }
 
-func (db *PostgresEventRepository) CreateEvent(req *pb.CreateEventRequest) (*pb.Event, error) {
+// eventColumns is the select list scanEvent reads. The date and times are
+// formatted here: scanned as they are, lib/pq hands them over as RFC 3339
+// timestamps such as "0000-01-01T10:00:00Z", which neither clients nor a
+// page token can use as a date or a time of day.
+const eventColumns = `id, name, sport_type, location, to_char(date, 'YYYY-MM-DD'), to_char(start_time, 'HH24:MI:SS'), to_char(end_time, 'HH24:MI:SS'), COALESCE(venue_id::text, ''), status, status_reason, sequence, created_at, updated_at, deleted_at`
 
-	resp := pb.Event{}
-	query := `
-	INSERT INTO events(name, sport_type, location, date, start_time, end_time) 
-	VALUES($1, $2, $3, $4, $5, $6)
-	RETURNING id, name, sport_type, location, date, start_time, end_time, created_at, updated_at, deleted_at`
-	err := db.DB.QueryRow(query,
-		req.Name,
-		req.SportType,
-		req.Location,
-		req.Date,
-		req.StartTime,
-		req.EndTime).Scan(
-		&resp.Id,
-		&resp.Name,
-		&resp.SportType,
-		&resp.Location,
-		&resp.Date,
-		&resp.StartTime,
-		&resp.EndTime,
-		&resp.CreatedAt,
-		&resp.UpdatedAt,
-		&resp.DeletedAt,
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanEvent(row rowScanner, e *pb.Event) error {
+	return row.Scan(
+		&e.Id,
+		&e.Name,
+		&e.SportType,
+		&e.Location,
+		&e.Date,
+		&e.StartTime,
+		&e.EndTime,
+		&e.VenueId,
+		&e.Status,
+		&e.StatusReason,
+		&e.Sequence,
+		&e.CreatedAt,
+		&e.UpdatedAt,
+		&e.DeletedAt,
 	)
+}
+
+// nullIfEmpty stores an empty id as NULL.
+func nullIfEmpty(id string) interface{} {
+	if id == "" {
+		return nil
+	}
+	return id
+}
+
+func (db *PostgresEventRepository) CreateEvent(ctx context.Context, req *pb.CreateEventRequest) (*pb.Event, error) {
+
+	if err := validateEvent(req); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidEvent, err)
+	}
+
+	tx, err := db.DB.BeginTx(ctx, nil)
 	if err != nil {
-		logger.Error("Creating event failed", logrus.Fields{
+		logger.ErrorContext(ctx, "Creating event failed", logrus.Fields{
 			"error": err,
 		})
 		return nil, err
 	}
+	defer tx.Rollback()
 
-	logger.Info("Event created successfully", logrus.Fields{
-		"event_id": resp.Id,
-		"name":     resp.Name,
+	resp, err := insertEvent(ctx, tx, req)
+	if err == nil {
+		err = tx.Commit()
+	}
+	if err != nil {
+		logger.ErrorContext(ctx, "Creating event failed", logrus.Fields{
+			"error":    err,
+			"venue_id": req.VenueId,
+		})
+		return nil, err
+	}
+
+	logger.InfoContext(ctx, "Event created successfully", logrus.Fields{
+		"event_id":  resp.Id,
+		"name":      resp.Name,
+		"conflicts": len(resp.Conflicts),
 	})
 
+	return resp, nil
+}
+
+// insertEvent books the event's venue, if it has one, and inserts it. The
+// event takes the venue's name as its location.
+func insertEvent(ctx context.Context, tx *sql.Tx, req *pb.CreateEventRequest) (*pb.Event, error) {
+	location, conflicts := req.Location, []*pb.VenueConflict(nil)
+	if req.VenueId != "" {
+		var err error
+		location, conflicts, err = bookVenue(ctx, tx, req.VenueId, req.Date, req.StartTime, req.EndTime, "", req.AllowConflict)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	resp := pb.Event{}
+	query := `
+	INSERT INTO events(name, sport_type, location, date, start_time, end_time, venue_id) 
+	VALUES($1, $2, $3, $4, $5, $6, $7)
+	RETURNING ` + eventColumns
+	err := scanEvent(tx.QueryRowContext(ctx, query,
+		req.Name,
+		req.SportType,
+		location,
+		req.Date,
+		req.StartTime,
+		req.EndTime,
+		nullIfEmpty(req.VenueId)), &resp)
+	if err != nil {
+		return nil, err
+	}
+	resp.Conflicts = conflicts
 	return &resp, nil
 }
 
-func (db *PostgresEventRepository) GetEvent(req *pb.GetEventRequest) (*pb.Event, error) {
+func (db *PostgresEventRepository) GetEvent(ctx context.Context, req *pb.GetEventRequest) (*pb.Event, error) {
 
 	resp := pb.Event{}
 	query := `
-	SELECT id, name, sport_type, location, date, start_time, end_time, created_at, updated_at, deleted_at 
+	SELECT ` + eventColumns + ` 
 	FROM events 
 	WHERE id=$1 AND deleted_at=0`
-	err := db.DB.QueryRow(query, req.Id).Scan(
-		&resp.Id,
-		&resp.Name,
-		&resp.SportType,
-		&resp.Location,
-		&resp.Date,
-		&resp.StartTime,
-		&resp.EndTime,
-		&resp.CreatedAt,
-		&resp.UpdatedAt,
-		&resp.DeletedAt,
-	)
+	err := scanEvent(db.DB.QueryRowContext(ctx, query, req.Id), &resp)
 	if err != nil {
-		logger.Error("Retrieving event failed", logrus.Fields{
+		logger.ErrorContext(ctx, "Retrieving event failed", logrus.Fields{
 			"error":    err,
 			"event_id": req.Id,
 		})
 		return nil, err
 	}
 
-	logger.Info("Event retrieved successfully", logrus.Fields{
+	logger.InfoContext(ctx, "Event retrieved successfully", logrus.Fields{
 		"event_id": resp.Id,
 		"name":     resp.Name,
 	})
@@ -93,15 +153,59 @@ func (db *PostgresEventRepository) GetEvent(req *pb.GetEventRequest) (*pb.Event,
 	return &resp, nil
 }
 
-func (db *PostgresEventRepository) ListOfEvent(req *pb.ListOfEventRequest) (*pb.ListOfEventResponse, error) {
+// eventOrderColumns maps the order_by fields accepted by ListOfEvent to columns.
+var eventOrderColumns = map[string]string{
+	"name":       "name",
+	"sport_type": "sport_type",
+	"date":       "date",
+	"start_time": "start_time",
+	"created_at": "created_at",
+}
+
+func (db *PostgresEventRepository) ListOfEvent(ctx context.Context, req *pb.ListOfEventRequest) (*pb.ListOfEventResponse, error) {
+
+	p, err := paging.New(req.PageSize, req.PageToken, req.OrderBy, eventOrderColumns, "date")
+	if err != nil {
+		return nil, err
+	}
+
+	filter := paging.NewConditions("deleted_at=0")
+	if req.SportType != "" {
+		filter.Add("sport_type=$%d", req.SportType)
+	}
+	if req.Location != "" {
+		filter.Add("location=$%d", req.Location)
+	}
+	if req.DateFrom != "" {
+		filter.Add("date>=$%d", req.DateFrom)
+	}
+	if req.DateTo != "" {
+		filter.Add("date<=$%d", req.DateTo)
+	}
+	if req.VenueId != "" {
+		filter.Add("venue_id=$%d", req.VenueId)
+	}
+	if req.Status != "" {
+		if _, ok := transitions[req.Status]; !ok {
+			return nil, fmt.Errorf("%w %q", ErrInvalidStatus, req.Status)
+		}
+		filter.Add("status=$%d", req.Status)
+	}
 
 	resp := pb.ListOfEventResponse{}
-	rows, err := db.DB.Query(`
-	SELECT id, name, sport_type, location, date, start_time, end_time, created_at, updated_at, deleted_at 
-	FROM events
-	WHERE deleted_at=0`)
+	if err := db.DB.QueryRowContext(ctx, "SELECT COUNT(*) FROM events WHERE "+filter.String(), filter.Args()...).Scan(&resp.TotalCount); err != nil {
+		logger.ErrorContext(ctx, "Counting events failed", logrus.Fields{
+			"error": err,
+		})
+		return nil, err
+	}
+
+	query := `
+	SELECT ` + eventColumns + ` 
+	FROM events` + p.Clause(filter)
+	rows, err := db.DB.QueryContext(ctx, query, filter.Args()...)
 	if err != nil {
-		logger.Error("Listing events failed", logrus.Fields{
+		logger.ErrorContext(ctx, "Listing events failed", logrus.Fields{
 			"error": err,
 		})
 		return nil, err
@@ -110,87 +214,193 @@ func (db *PostgresEventRepository) ListOfEvent(req *pb.ListOfEventRequest) (*pb.
 
 	for rows.Next() {
 		item := pb.Event{}
-		err := rows.Scan(
-			&item.Id,
-			&item.Name,
-			&item.SportType,
-			&item.Location,
-				&item.Date,
-			&item.StartTime,
-			&item.EndTime,
-			&item.CreatedAt,
-			&item.UpdatedAt,
-			&item.DeletedAt,
-		)
-		if err != nil {
-			logger.Error("Decoding event failed", logrus.Fields{
+		if err := scanEvent(rows, &item); err != nil {
+			logger.ErrorContext(ctx, "Decoding event failed", logrus.Fields{
 				"error": err,
 			})
 			return nil, err
 		}
 		resp.Events = append(resp.Events, &item)
 	}
+	if err := rows.Err(); err != nil {
+		logger.ErrorContext(ctx, "Listing events failed", logrus.Fields{
+			"error": err,
+		})
+		return nil, err
+	}
+
+	if len(resp.Events) > p.Size {
+		resp.Events = resp.Events[:p.Size]
+		last := resp.Events[p.Size-1]
+		resp.NextPageToken = p.NextToken(eventSortValue(last, p.Column), last.Id)
+	}
 
-	logger.Info("Events listed successfully", logrus.Fields{
+	logger.InfoContext(ctx, "Events listed successfully", logrus.Fields{
 		"events_count": len(resp.Events),
+		"total_count":  resp.TotalCount,
 	})
 
 	return &resp, nil
 }
 
-func (db *PostgresEventRepository) UpdateEvent(req *pb.UpdateEventRequest) (*pb.Event, error) {
+func eventSortValue(e *pb.Event, column string) string {
+	switch column {
+	case "name":
+		return e.Name
+	case "sport_type":
+		return e.SportType
+	case "start_time":
+		return e.StartTime
+	case "created_at":
+		return e.CreatedAt
+	default:
+		return e.Date
+	}
+}
+
+func (db *PostgresEventRepository) UpdateEvent(ctx context.Context, req *pb.UpdateEventRequest) (*pb.Event, error) {
+
+	err := validateEvent(&pb.CreateEventRequest{
+		Name:      req.Name,
+		SportType: req.SportType,
+		Date:      req.Date,
+		StartTime: req.StartTime,
+		EndTime:   req.EndTime,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidEvent, err)
+	}
+
+	tx, err := db.DB.BeginTx(ctx, nil)
+	if err != nil {
+		logger.ErrorContext(ctx, "Updating event failed", logrus.Fields{
+			"error":    err,
+			"event_id": req.Id,
+		})
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	location, conflicts := req.Location, []*pb.VenueConflict(nil)
+	if req.VenueId != "" {
+		location, conflicts, err = bookVenue(ctx, tx, req.VenueId, req.Date, req.StartTime, req.EndTime, req.Id, req.AllowConflict)
+	}
 
 	resp := pb.Event{}
-	query := `
+	if err == nil {
+		query := `
 	UPDATE events 
-	SET name=$1, sport_type=$2, location=$3, date=$4, start_time=$5, end_time=$6, updated_at=NOW() 
-	WHERE id=$7 AND deleted_at=0
-	RETURNING id, name, sport_type, location, date, start_time, end_time, created_at, updated_at, deleted_at`
-	err := db.DB.QueryRow(query,
-		req.Name,
-		req.SportType,
-		req.Location,
-		req.Date,
-		req.StartTime,
-		req.EndTime,
-		req.Id).Scan(
-		&resp.Id,
-		&resp.Name,
-		&resp.SportType,
-		&resp.Location,
-		&resp.Date,
-		&resp.StartTime,
-		&resp.EndTime,
-		&resp.CreatedAt,
-		&resp.UpdatedAt,
-		&resp.DeletedAt,
-	)
+	SET name=$1, sport_type=$2, location=$3, date=$4, start_time=$5, end_time=$6, venue_id=$7, sequence=sequence+1, updated_at=NOW() 
+	WHERE id=$8 AND deleted_at=0
+	RETURNING ` + eventColumns
+		err = scanEvent(tx.QueryRowContext(ctx, query,
+			req.Name,
+			req.SportType,
+			location,
+			req.Date,
+			req.StartTime,
+			req.EndTime,
+			nullIfEmpty(req.VenueId),
+			req.Id), &resp)
+	}
+	if err == nil {
+		err = tx.Commit()
+	}
 	if err != nil {
-		logger.Error("Updating event failed", logrus.Fields{
+		logger.ErrorContext(ctx, "Updating event failed", logrus.Fields{
 			"error":    err,
 			"event_id": req.Id,
 		})
 		return nil, err
 	}
+	resp.Conflicts = conflicts
 
-	logger.Info("Event updated successfully", logrus.Fields{
+	logger.InfoContext(ctx, "Event updated successfully", logrus.Fields{
+		"event_id":  resp.Id,
+		"name":      resp.Name,
+		"conflicts": len(resp.Conflicts),
+	})
+
+	return &resp, nil
+}
+
+// TransitionEvent moves an event to another status of its lifecycle. The row
+// is locked while the transition is checked, so two concurrent transitions
+// cannot both start from the same status.
+func (db *PostgresEventRepository) TransitionEvent(ctx context.Context, req *pb.TransitionEventRequest) (*pb.Event, error) {
+
+	tx, err := db.DB.BeginTx(ctx, nil)
+	if err != nil {
+		logger.ErrorContext(ctx, "Changing event status failed", logrus.Fields{
+			"error":    err,
+			"event_id": req.Id,
+		})
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	current := pb.Event{}
+	err = scanEvent(tx.QueryRowContext(ctx, `
+	SELECT `+eventColumns+` 
+	FROM events 
+	WHERE id=$1 AND deleted_at=0
+	FOR UPDATE`, req.Id), &current)
+	if err != nil {
+		logger.ErrorContext(ctx, "Changing event status failed", logrus.Fields{
+			"error":    err,
+			"event_id": req.Id,
+		})
+		return nil, err
+	}
+
+	if err := checkTransition(current.Status, req.Status); err != nil {
+		logger.WarnContext(ctx, "Event status change refused", logrus.Fields{
+			"event_id": req.Id,
+			"from":     current.Status,
+			"to":       req.Status,
+		})
+		return nil, err
+	}
+	if current.Status == req.Status {
+		return &current, nil
+	}
+
+	resp := pb.Event{}
+	err = scanEvent(tx.QueryRowContext(ctx, `
+	UPDATE events 
+	SET status=$1, status_reason=$2, sequence=sequence+1, updated_at=NOW() 
+	WHERE id=$3
+	RETURNING `+eventColumns, req.Status, req.Reason, req.Id), &resp)
+	if err == nil {
+		err = tx.Commit()
+	}
+	if err != nil {
+		logger.ErrorContext(ctx, "Changing event status failed", logrus.Fields{
+			"error":    err,
+			"event_id": req.Id,
+		})
+		return nil, err
+	}
+
+	logger.InfoContext(ctx, "Event status changed", logrus.Fields{
 		"event_id": resp.Id,
-		"name":     resp.Name,
+		"from":     current.Status,
+		"to":       resp.Status,
 	})
 
 	return &resp, nil
 }
 
-func (db *PostgresEventRepository) DeleteEvent(req *pb.DeleteEventRequest) (*pb.DeleteEventResponse, error) {
+func (db *PostgresEventRepository) DeleteEvent(ctx context.Context, req *pb.DeleteEventRequest) (*pb.DeleteEventResponse, error) {
 
 	resp := pb.DeleteEventResponse{}
 	query := `
 	UPDATE events 
-	SET deleted_at=DATE_PART('epoch', CURRENT_TIMESTAMP)::INT  
+	SET deleted_at=DATE_PART('epoch', CURRENT_TIMESTAMP)::INT, sequence=sequence+1 
 	WHERE id=$1`
-	result, err := db.DB.Exec(query, req.Id)
+	result, err := db.DB.ExecContext(ctx, query, req.Id)
 	if err != nil {
-		logger.Error("Deleting event failed", logrus.Fields{
+		logger.ErrorContext(ctx, "Deleting event failed", logrus.Fields{
 			"error":    err,
 			"event_id": req.Id,
 		})
@@ -199,23 +409,161 @@ func (db *PostgresEventRepository) DeleteEvent(req *pb.DeleteEventRequest) (*pb.
 
 	num, err := result.RowsAffected()
 	if err != nil {
-		logger.Error("Getting affected rows failed", logrus.Fields{
+		logger.ErrorContext(ctx, "Getting affected rows failed", logrus.Fields{
 			"error": err,
 		})
 		return nil, err
 	}
 
 	if num == 0 {
-		logger.Warn("No rows affected for deletion", logrus.Fields{
+		logger.WarnContext(ctx, "No rows affected for deletion", logrus.Fields{
 			"event_id": req.Id,
 		})
-		return nil, errors.New("no rows affected")
+		return nil, sql.ErrNoRows
 	}
 
 	resp.Status = "deleted successfully"
-	logger.Info("Event deleted successfully", logrus.Fields{
+	logger.InfoContext(ctx, "Event deleted successfully", logrus.Fields{
 		"event_id": req.Id,
 	})
 
 	return &resp, nil
 }
+
+// ImportEvents inserts a batch of events in one transaction.
+func (db *PostgresEventRepository) ImportEvents(ctx context.Context, req *pb.ImportEventsRequest) (*pb.ImportResponse, error) {
+
+	accepted, failed, committed, err := importRows(ctx, db.DB, len(req.Events), req.DryRun, func(tx *sql.Tx, i int) error {
+		event := req.Events[i]
+		if err := validateEvent(event); err != nil {
+			return err
+		}
+
+		_, err := insertEvent(ctx, tx, event)
+		return err
+	})
+	if err != nil {
+		logger.ErrorContext(ctx, "Importing events failed", logrus.Fields{
+			"error": err,
+		})
+		return nil, err
+	}
+
+	resp := pb.ImportResponse{
+		Accepted:  int32(accepted),
+		Committed: committed,
+	}
+	for _, row := range failed {
+		resp.Errors = append(resp.Errors, &pb.ImportRowError{
+			Index:   int32(row.index),
+			Message: row.err.Error(),
+		})
+	}
+
+	logger.InfoContext(ctx, "Events imported", logrus.Fields{
+		"rows":      len(req.Events),
+		"accepted":  accepted,
+		"failed":    len(failed),
+		"committed": committed,
+		"dry_run":   req.DryRun,
+	})
+
+	return &resp, nil
+}
+
+// ErrInvalidEvent is returned when an event is written with a missing or
+// malformed field.
+var ErrInvalidEvent = errors.New("invalid event")
+
+// validateEvent checks the fields of an event before it reaches the database,
+// so the error names the field rather than a SQL cast.
+func validateEvent(event *pb.CreateEventRequest) error {
+	if strings.TrimSpace(event.Name) == "" {
+		return errors.New("name is required")
+	}
+	if strings.TrimSpace(event.SportType) == "" {
+		return errors.New("sport_type is required")
+	}
+	if _, err := time.Parse("2006-01-02", event.Date); err != nil {
+		return fmt.Errorf("date %q is not a YYYY-MM-DD date", event.Date)
+	}
+	start, err := parseClock(event.StartTime)
+	if err != nil {
+		return fmt.Errorf("start_time %q is not a HH:MM time", event.StartTime)
+	}
+	end, err := parseClock(event.EndTime)
+	if err != nil {
+		return fmt.Errorf("end_time %q is not a HH:MM time", event.EndTime)
+	}
+	if !end.After(start) {
+		return errors.New("end_time must be after start_time")
+	}
+	return nil
+}
+
+func parseClock(value string) (time.Time, error) {
+	if t, err := time.Parse("15:04:05", value); err == nil {
+		return t, nil
+	}
+	return time.Parse("15:04", value)
+}
+
+// ExportEvents hands every event to send as it is read, so the caller can
+// stream the table without holding it in memory. The request narrows the
+// events down for calendar feeds, which also ask for deleted events so
+// subscribers learn they were called off.
+func (db *PostgresEventRepository) ExportEvents(ctx context.Context, req *pb.ExportEventsRequest, send func(*pb.Event) error) error {
+
+	filter := paging.NewConditions()
+	if !req.IncludeDeleted {
+		filter.Add("deleted_at=0")
+	}
+	if len(req.SportTypes) > 0 {
+		filter.Add("sport_type=ANY($%d)", pq.Array(req.SportTypes))
+	}
+	if req.Venue != "" {
+		filter.Add(venueCondition, req.Venue)
+	}
+	if len(req.Ids) > 0 {
+		filter.Add("id::text=ANY($%d)", pq.Array(req.Ids))
+	}
+
+	rows, err := db.DB.QueryContext(ctx, `
+	SELECT `+eventColumns+` 
+	FROM events
+	WHERE `+filter.String()+`
+	ORDER BY date, start_time, id`, filter.Args()...)
+	if err != nil {
+		logger.ErrorContext(ctx, "Exporting events failed", logrus.Fields{
+			"error": err,
+		})
+		return err
+	}
+	defer rows.Close()
+
+	count := 0
+	for rows.Next() {
+		item := pb.Event{}
+		if err := scanEvent(rows, &item); err != nil {
+			logger.ErrorContext(ctx, "Decoding event failed", logrus.Fields{
+				"error": err,
+			})
+			return err
+		}
+		if err := send(&item); err != nil {
+			return err
+		}
+		count++
+	}
+	if err := rows.Err(); err != nil {
+		logger.ErrorContext(ctx, "Exporting events failed", logrus.Fields{
+			"error": err,
+		})
+		return err
+	}
+
+	logger.InfoContext(ctx, "Events exported successfully", logrus.Fields{
+		"events_count": count,
+	})
+	return nil
+}
@@ -1,14 +1,20 @@
 package repository
 
 import (
+	"context"
+	"fmt"
+	"shared/paging"
 	"testing"
 	"time"
 
 	pb "github.com/Bekzodbekk/paris2024_livestream_protos/genproto/eventpb"
 	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/lib/pq"
 	"github.com/stretchr/testify/assert"
 )
 
+var eventColumnNames = []string{"id", "name", "sport_type", "location", "date", "start_time", "end_time", "venue_id", "status", "status_reason", "sequence", "created_at", "updated_at", "deleted_at"}
+
 func setupTest(t *testing.T) (*PostgresEventRepository, sqlmock.Sqlmock, func()) {
 	db, mock, err := sqlmock.New()
 	if err != nil {
@@ -35,12 +41,14 @@ func TestCreateEvent(t *testing.T) {
 		EndTime:   "17:00",
 	}
 
+	mock.ExpectBegin()
 	mock.ExpectQuery("INSERT INTO events").
-		WithArgs(req.Name, req.SportType, req.Location, req.Date, req.StartTime, req.EndTime).
-		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "sport_type", "location", "date", "start_time", "end_time", "created_at", "updated_at", "deleted_at"}).
-			AddRow("1", req.Name, req.SportType, req.Location, req.Date, req.StartTime, req.EndTime, time.Now().Format(time.RFC3339), time.Now().Format(time.RFC3339), 0))
+		WithArgs(req.Name, req.SportType, req.Location, req.Date, req.StartTime, req.EndTime, nil).
+		WillReturnRows(sqlmock.NewRows(eventColumnNames).
+			AddRow("1", req.Name, req.SportType, req.Location, req.Date, req.StartTime, req.EndTime, "", "scheduled", "", 0, time.Now().Format(time.RFC3339), time.Now().Format(time.RFC3339), 0))
+	mock.ExpectCommit()
 
-	resp, err := repo.CreateEvent(req)
+	resp, err := repo.CreateEvent(context.Background(), req)
 
 	assert.NoError(t, err)
 	assert.Equal(t, "1", resp.Id)
@@ -55,10 +63,10 @@ func TestGetEvent(t *testing.T) {
 
 	mock.ExpectQuery("SELECT (.+) FROM events").
 		WithArgs(req.Id).
-		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "sport_type", "location", "date", "start_time", "end_time", "created_at", "updated_at", "deleted_at"}).
-			AddRow("1", "Football Match", "Football", "Stadium", "2024-09-01", "15:00", "17:00", time.Now().Format(time.RFC3339), time.Now().Format(time.RFC3339), 0))
+		WillReturnRows(sqlmock.NewRows(eventColumnNames).
+			AddRow("1", "Football Match", "Football", "Stadium", "2024-09-01", "15:00", "17:00", "", "scheduled", "", 0, time.Now().Format(time.RFC3339), time.Now().Format(time.RFC3339), 0))
 
-	resp, err := repo.GetEvent(req)
+	resp, err := repo.GetEvent(context.Background(), req)
 
 	assert.NoError(t, err)
 	assert.Equal(t, "1", resp.Id)
@@ -69,19 +77,104 @@ func TestListOfEvent(t *testing.T) {
 	repo, mock, teardown := setupTest(t)
 	defer teardown()
 
-	mock.ExpectQuery("SELECT (.+) FROM events").
-		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "sport_type", "location", "date", "start_time", "end_time", "created_at", "updated_at", "deleted_at"}).
-			AddRow("1", "Football Match", "Football", "Stadium", "2024-09-01", "15:00", "17:00", time.Now().Format(time.RFC3339), time.Now().Format(time.RFC3339), 0).
-			AddRow("2", "Basketball Game", "Basketball", "Arena", "2024-09-02", "18:00", "20:00", time.Now().Format(time.RFC3339), time.Now().Format(time.RFC3339), 0))
+	mock.ExpectQuery("SELECT COUNT(.+) FROM events WHERE deleted_at=0").
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(2))
+	mock.ExpectQuery("SELECT (.+) FROM events WHERE deleted_at=0 ORDER BY date ASC, id ASC LIMIT").
+		WithArgs(paging.DefaultPageSize + 1).
+		WillReturnRows(sqlmock.NewRows(eventColumnNames).
+			AddRow("1", "Football Match", "Football", "Stadium", "2024-09-01", "15:00", "17:00", "", "scheduled", "", 0, time.Now().Format(time.RFC3339), time.Now().Format(time.RFC3339), 0).
+			AddRow("2", "Basketball Game", "Basketball", "Arena", "2024-09-02", "18:00", "20:00", "", "scheduled", "", 0, time.Now().Format(time.RFC3339), time.Now().Format(time.RFC3339), 0))
 
-	resp, err := repo.ListOfEvent(&pb.ListOfEventRequest{})
+	resp, err := repo.ListOfEvent(context.Background(), &pb.ListOfEventRequest{})
 
 	assert.NoError(t, err)
 	assert.Len(t, resp.Events, 2)
+	assert.Equal(t, int64(2), resp.TotalCount)
+	assert.Empty(t, resp.NextPageToken)
 	assert.Equal(t, "Football Match", resp.Events[0].Name)
 	assert.Equal(t, "Basketball Game", resp.Events[1].Name)
 }
 
+func TestListOfEventPaged(t *testing.T) {
+	repo, mock, teardown := setupTest(t)
+	defer teardown()
+
+	mock.ExpectQuery("SELECT COUNT(.+) FROM events WHERE deleted_at=0 AND sport_type=\\$1").
+		WithArgs("Football").
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(3))
+	mock.ExpectQuery("SELECT (.+) FROM events WHERE deleted_at=0 AND sport_type=\\$1 ORDER BY name DESC, id DESC LIMIT \\$2").
+		WithArgs("Football", 2).
+		WillReturnRows(sqlmock.NewRows(eventColumnNames).
+			AddRow("3", "Semi Final", "Football", "Stadium", "2024-09-03", "15:00", "17:00", "", "scheduled", "", 0, time.Now().Format(time.RFC3339), time.Now().Format(time.RFC3339), 0).
+			AddRow("1", "Quarter Final", "Football", "Stadium", "2024-09-01", "15:00", "17:00", "", "scheduled", "", 0, time.Now().Format(time.RFC3339), time.Now().Format(time.RFC3339), 0))
+
+	resp, err := repo.ListOfEvent(context.Background(), &pb.ListOfEventRequest{PageSize: 1, OrderBy: "name desc", SportType: "Football"})
+
+	assert.NoError(t, err)
+	assert.Len(t, resp.Events, 1)
+	assert.Equal(t, int64(3), resp.TotalCount)
+	assert.NotEmpty(t, resp.NextPageToken)
+
+	mock.ExpectQuery("SELECT COUNT(.+) FROM events").
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(3))
+	mock.ExpectQuery("SELECT (.+) FROM events WHERE deleted_at=0 AND sport_type=\\$1 AND \\(name, id\\) < \\(\\$2, \\$3\\)").
+		WithArgs("Football", "Semi Final", "3", 2).
+		WillReturnRows(sqlmock.NewRows(eventColumnNames).
+			AddRow("1", "Quarter Final", "Football", "Stadium", "2024-09-01", "15:00", "17:00", "", "scheduled", "", 0, time.Now().Format(time.RFC3339), time.Now().Format(time.RFC3339), 0))
+
+	resp, err = repo.ListOfEvent(context.Background(), &pb.ListOfEventRequest{PageSize: 1, OrderBy: "name desc", SportType: "Football", PageToken: resp.NextPageToken})
+
+	assert.NoError(t, err)
+	assert.Len(t, resp.Events, 1)
+	assert.Equal(t, "Quarter Final", resp.Events[0].Name)
+	assert.Empty(t, resp.NextPageToken)
+}
+
+// The times come back as the to_char text of the select list, so the page
+// token holds a value the keyset comparison can cast back to a TIME.
+func TestListOfEventPagedByStartTime(t *testing.T) {
+	repo, mock, teardown := setupTest(t)
+	defer teardown()
+
+	mock.ExpectQuery("SELECT COUNT(.+) FROM events WHERE deleted_at=0").
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(2))
+	mock.ExpectQuery("SELECT id, name, sport_type, location, to_char\\(date, 'YYYY-MM-DD'\\), to_char\\(start_time, 'HH24:MI:SS'\\), to_char\\(end_time, 'HH24:MI:SS'\\), (.+) FROM events WHERE deleted_at=0 ORDER BY start_time ASC, id ASC LIMIT \\$1").
+		WithArgs(2).
+		WillReturnRows(sqlmock.NewRows(eventColumnNames).
+			AddRow("1", "Heats", "Swimming", "Pool", "2024-07-27", "10:00:00", "12:00:00", "", "scheduled", "", 0, time.Now().Format(time.RFC3339), time.Now().Format(time.RFC3339), 0).
+			AddRow("2", "Final", "Swimming", "Pool", "2024-07-27", "20:30:00", "21:00:00", "", "scheduled", "", 0, time.Now().Format(time.RFC3339), time.Now().Format(time.RFC3339), 0))
+
+	resp, err := repo.ListOfEvent(context.Background(), &pb.ListOfEventRequest{PageSize: 1, OrderBy: "start_time"})
+
+	assert.NoError(t, err)
+	assert.Len(t, resp.Events, 1)
+	assert.Equal(t, "10:00:00", resp.Events[0].StartTime)
+	assert.NotEmpty(t, resp.NextPageToken)
+
+	mock.ExpectQuery("SELECT COUNT(.+) FROM events").
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(2))
+	mock.ExpectQuery("SELECT (.+) FROM events WHERE deleted_at=0 AND \\(start_time, id\\) > \\(\\$1, \\$2\\) ORDER BY start_time ASC, id ASC LIMIT \\$3").
+		WithArgs("10:00:00", "1", 2).
+		WillReturnRows(sqlmock.NewRows(eventColumnNames).
+			AddRow("2", "Final", "Swimming", "Pool", "2024-07-27", "20:30:00", "21:00:00", "", "scheduled", "", 0, time.Now().Format(time.RFC3339), time.Now().Format(time.RFC3339), 0))
+
+	resp, err = repo.ListOfEvent(context.Background(), &pb.ListOfEventRequest{PageSize: 1, OrderBy: "start_time", PageToken: resp.NextPageToken})
+
+	assert.NoError(t, err)
+	assert.Len(t, resp.Events, 1)
+	assert.Equal(t, "Final", resp.Events[0].Name)
+	assert.Empty(t, resp.NextPageToken)
+}
+
+func TestListOfEventInvalidOrderBy(t *testing.T) {
+	repo, _, teardown := setupTest(t)
+	defer teardown()
+
+	_, err := repo.ListOfEvent(context.Background(), &pb.ListOfEventRequest{OrderBy: "location"})
+
+	assert.ErrorIs(t, err, paging.ErrInvalidOrderBy)
+}
+
 func TestUpdateEvent(t *testing.T) {
 	repo, mock, teardown := setupTest(t)
 	defer teardown()
@@ -96,12 +189,14 @@ func TestUpdateEvent(t *testing.T) {
 		EndTime:   "18:00",
 	}
 
+	mock.ExpectBegin()
 	mock.ExpectQuery("UPDATE events SET").
-		WithArgs(req.Name, req.SportType, req.Location, req.Date, req.StartTime, req.EndTime, req.Id).
-		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "sport_type", "location", "date", "start_time", "end_time", "created_at", "updated_at", "deleted_at"}).
-			AddRow("1", req.Name, req.SportType, req.Location, req.Date, req.StartTime, req.EndTime, time.Now().Format(time.RFC3339), time.Now().Format(time.RFC3339), 0))
+		WithArgs(req.Name, req.SportType, req.Location, req.Date, req.StartTime, req.EndTime, nil, req.Id).
+		WillReturnRows(sqlmock.NewRows(eventColumnNames).
+			AddRow("1", req.Name, req.SportType, req.Location, req.Date, req.StartTime, req.EndTime, "", "scheduled", "", 0, time.Now().Format(time.RFC3339), time.Now().Format(time.RFC3339), 0))
+	mock.ExpectCommit()
 
-	resp, err := repo.UpdateEvent(req)
+	resp, err := repo.UpdateEvent(context.Background(), req)
 
 	assert.NoError(t, err)
 	assert.Equal(t, "1", resp.Id)
@@ -115,12 +210,569 @@ func TestDeleteEvent(t *testing.T) {
 	req := &pb.DeleteEventRequest{Id: "1"}
 
 	// To'g'ri SQL so'rovini aniqlang
-	mock.ExpectExec(`UPDATE events SET deleted_at=DATE_PART\('epoch', CURRENT_TIMESTAMP\)::INT WHERE id=\$1`).
+	mock.ExpectExec(`UPDATE events SET deleted_at=DATE_PART\('epoch', CURRENT_TIMESTAMP\)::INT, sequence=sequence\+1 WHERE id=\$1`).
 		WithArgs(req.Id).
 		WillReturnResult(sqlmock.NewResult(1, 1))
 
-	resp, err := repo.DeleteEvent(req)
+	resp, err := repo.DeleteEvent(context.Background(), req)
 
 	assert.NoError(t, err)
 	assert.Equal(t, "deleted successfully", resp.Status)
 }
+
+func TestImportEvents(t *testing.T) {
+	repo, mock, teardown := setupTest(t)
+	defer teardown()
+
+	event := &pb.CreateEventRequest{
+		Name:      "Football Match",
+		SportType: "Football",
+		Location:  "Stadium",
+		Date:      "2024-08-01",
+		StartTime: "15:00",
+		EndTime:   "17:00",
+	}
+
+	mock.ExpectBegin()
+	mock.ExpectExec("SAVEPOINT import_row").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery("INSERT INTO events").
+		WithArgs(event.Name, event.SportType, event.Location, event.Date, event.StartTime, event.EndTime, nil).
+		WillReturnRows(sqlmock.NewRows(eventColumnNames).
+			AddRow("1", event.Name, event.SportType, event.Location, event.Date, event.StartTime, event.EndTime, "", "scheduled", "", 0, time.Now().Format(time.RFC3339), time.Now().Format(time.RFC3339), 0))
+	mock.ExpectExec("RELEASE SAVEPOINT import_row").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("SAVEPOINT import_row").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("ROLLBACK TO SAVEPOINT import_row").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectRollback()
+
+	resp, err := repo.ImportEvents(context.Background(), &pb.ImportEventsRequest{
+		Events: []*pb.CreateEventRequest{
+			event,
+			{Name: "Basketball Game", SportType: "Basketball", Date: "2024-08-02", StartTime: "20:00", EndTime: "18:00"},
+		},
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, int32(1), resp.Accepted)
+	assert.False(t, resp.Committed)
+	assert.Len(t, resp.Errors, 1)
+	assert.Equal(t, int32(1), resp.Errors[0].Index)
+	assert.Equal(t, "end_time must be after start_time", resp.Errors[0].Message)
+}
+
+func TestExportEvents(t *testing.T) {
+	repo, mock, teardown := setupTest(t)
+	defer teardown()
+
+	mock.ExpectQuery("SELECT (.+) FROM events WHERE deleted_at=0 ORDER BY date, start_time, id").
+		WillReturnRows(sqlmock.NewRows(eventColumnNames).
+			AddRow("1", "Football Match", "Football", "Stadium", "2024-09-01", "15:00", "17:00", "", "scheduled", "", 0, time.Now().Format(time.RFC3339), time.Now().Format(time.RFC3339), 0).
+			AddRow("2", "Basketball Game", "Basketball", "Arena", "2024-09-02", "18:00", "20:00", "", "scheduled", "", 0, time.Now().Format(time.RFC3339), time.Now().Format(time.RFC3339), 0))
+
+	var names []string
+	err := repo.ExportEvents(context.Background(), &pb.ExportEventsRequest{}, func(event *pb.Event) error {
+		names = append(names, event.Name)
+		return nil
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"Football Match", "Basketball Game"}, names)
+}
+
+func TestExportEventsForCalendar(t *testing.T) {
+	repo, mock, teardown := setupTest(t)
+	defer teardown()
+
+	mock.ExpectQuery("SELECT (.+) FROM events WHERE sport_type=ANY\\(\\$1\\) AND venue_id IN (.+) AND id::text=ANY\\(\\$3\\) ORDER BY date, start_time, id").
+		WithArgs(pq.Array([]string{"Athletics"}), "v1", pq.Array([]string{"1", "2"})).
+		WillReturnRows(sqlmock.NewRows(eventColumnNames).
+			AddRow("1", "Men's 100m Final", "Athletics", "Stade de France", "2024-08-04", "21:50", "22:10", "v1", "scheduled", "", 2, time.Now().Format(time.RFC3339), time.Now().Format(time.RFC3339), 0).
+			AddRow("2", "Men's 200m Final", "Athletics", "Stade de France", "2024-08-08", "20:30", "20:50", "v1", "scheduled", "", 1, time.Now().Format(time.RFC3339), time.Now().Format(time.RFC3339), 1722700000))
+
+	var events []*pb.Event
+	err := repo.ExportEvents(context.Background(), &pb.ExportEventsRequest{
+		IncludeDeleted: true,
+		SportTypes:     []string{"Athletics"},
+		Venue:          "v1",
+		Ids:            []string{"1", "2"},
+	}, func(event *pb.Event) error {
+		events = append(events, event)
+		return nil
+	})
+
+	assert.NoError(t, err)
+	assert.Len(t, events, 2)
+	assert.Equal(t, int32(2), events[0].Sequence)
+	assert.Equal(t, int64(1722700000), events[1].DeletedAt)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func expectBooking(mock sqlmock.Sqlmock, venueID string, conflicts *sqlmock.Rows) {
+	mock.ExpectExec("SELECT pg_advisory_xact_lock").
+		WithArgs(venueID).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery("SELECT name FROM venues").
+		WithArgs(venueID).
+		WillReturnRows(sqlmock.NewRows([]string{"name"}).AddRow("Stade de France"))
+	mock.ExpectQuery("SELECT id, name, to_char\\(start_time, 'HH24:MI:SS'\\), to_char\\(end_time, 'HH24:MI:SS'\\) FROM events").
+		WillReturnRows(conflicts)
+}
+
+func TestCreateEventVenueConflict(t *testing.T) {
+	repo, mock, teardown := setupTest(t)
+	defer teardown()
+
+	req := &pb.CreateEventRequest{
+		Name:      "Men's 100m Final",
+		SportType: "Athletics",
+		VenueId:   "v1",
+		Date:      "2024-08-04",
+		StartTime: "21:50",
+		EndTime:   "22:10",
+	}
+
+	mock.ExpectBegin()
+	expectBooking(mock, "v1", sqlmock.NewRows([]string{"id", "name", "start_time", "end_time"}).
+		AddRow("2", "Women's 3000m Steeplechase Final", "21:00", "22:00"))
+	mock.ExpectRollback()
+
+	_, err := repo.CreateEvent(context.Background(), req)
+
+	var conflict *ConflictError
+	assert.ErrorAs(t, err, &conflict)
+	assert.Len(t, conflict.Conflicts, 1)
+	assert.Equal(t, "2", conflict.Conflicts[0].EventId)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCreateEventAllowConflict(t *testing.T) {
+	repo, mock, teardown := setupTest(t)
+	defer teardown()
+
+	req := &pb.CreateEventRequest{
+		Name:          "Men's 100m Final",
+		SportType:     "Athletics",
+		Location:      "ignored",
+		VenueId:       "v1",
+		Date:          "2024-08-04",
+		StartTime:     "21:50",
+		EndTime:       "22:10",
+		AllowConflict: true,
+	}
+
+	mock.ExpectBegin()
+	expectBooking(mock, "v1", sqlmock.NewRows([]string{"id", "name", "start_time", "end_time"}).
+		AddRow("2", "Women's 3000m Steeplechase Final", "21:00", "22:00"))
+	mock.ExpectQuery("INSERT INTO events").
+		WithArgs(req.Name, req.SportType, "Stade de France", req.Date, req.StartTime, req.EndTime, "v1").
+		WillReturnRows(sqlmock.NewRows(eventColumnNames).
+			AddRow("1", req.Name, req.SportType, "Stade de France", req.Date, req.StartTime, req.EndTime, "v1", "scheduled", "", 0, time.Now().Format(time.RFC3339), time.Now().Format(time.RFC3339), 0))
+	mock.ExpectCommit()
+
+	resp, err := repo.CreateEvent(context.Background(), req)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "Stade de France", resp.Location)
+	assert.Len(t, resp.Conflicts, 1)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCreateEventInvalid(t *testing.T) {
+	repo, _, teardown := setupTest(t)
+	defer teardown()
+
+	_, err := repo.CreateEvent(context.Background(), &pb.CreateEventRequest{
+		Name:      "Football Match",
+		SportType: "Football",
+		Date:      "2024-09-01",
+		StartTime: "17:00",
+		EndTime:   "15:00",
+	})
+
+	assert.ErrorIs(t, err, ErrInvalidEvent)
+}
+
+func TestTransitionEvent(t *testing.T) {
+	repo, mock, teardown := setupTest(t)
+	defer teardown()
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT (.+) FROM events WHERE id=\\$1 AND deleted_at=0 FOR UPDATE").
+		WithArgs("1").
+		WillReturnRows(sqlmock.NewRows(eventColumnNames).
+			AddRow("1", "Football Match", "Football", "Stadium", "2024-09-01", "15:00", "17:00", "", "scheduled", "", 0, time.Now().Format(time.RFC3339), time.Now().Format(time.RFC3339), 0))
+	mock.ExpectQuery("UPDATE events SET status=\\$1, status_reason=\\$2").
+		WithArgs(StatusDelayed, "rain", "1").
+		WillReturnRows(sqlmock.NewRows(eventColumnNames).
+			AddRow("1", "Football Match", "Football", "Stadium", "2024-09-01", "15:00", "17:00", "", "delayed", "rain", 0, time.Now().Format(time.RFC3339), time.Now().Format(time.RFC3339), 0))
+	mock.ExpectCommit()
+
+	resp, err := repo.TransitionEvent(context.Background(), &pb.TransitionEventRequest{Id: "1", Status: StatusDelayed, Reason: "rain"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, StatusDelayed, resp.Status)
+	assert.Equal(t, "rain", resp.StatusReason)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestTransitionEventRefused(t *testing.T) {
+	repo, mock, teardown := setupTest(t)
+	defer teardown()
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT (.+) FROM events WHERE id=\\$1 AND deleted_at=0 FOR UPDATE").
+		WithArgs("1").
+		WillReturnRows(sqlmock.NewRows(eventColumnNames).
+			AddRow("1", "Football Match", "Football", "Stadium", "2024-09-01", "15:00", "17:00", "", "completed", "", 0, time.Now().Format(time.RFC3339), time.Now().Format(time.RFC3339), 0))
+	mock.ExpectRollback()
+
+	_, err := repo.TransitionEvent(context.Background(), &pb.TransitionEventRequest{Id: "1", Status: StatusLive})
+
+	var transition *TransitionError
+	assert.ErrorAs(t, err, &transition)
+	assert.Equal(t, StatusCompleted, transition.From)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCheckTransition(t *testing.T) {
+	assert.NoError(t, checkTransition(StatusScheduled, StatusLive))
+	assert.NoError(t, checkTransition(StatusLive, StatusDelayed))
+	assert.NoError(t, checkTransition(StatusDelayed, StatusLive))
+	assert.NoError(t, checkTransition(StatusLive, StatusCompleted))
+	assert.NoError(t, checkTransition(StatusLive, StatusLive))
+
+	assert.Error(t, checkTransition(StatusScheduled, StatusCompleted))
+	assert.Error(t, checkTransition(StatusCancelled, StatusScheduled))
+	assert.Error(t, checkTransition(StatusCompleted, StatusCancelled))
+	assert.ErrorIs(t, checkTransition(StatusScheduled, "postponed"), ErrInvalidStatus)
+}
+
+func TestGetSchedule(t *testing.T) {
+	repo, mock, teardown := setupTest(t)
+	defer teardown()
+
+	mock.ExpectQuery("SELECT (.+) FROM events WHERE deleted_at=0 AND date=\\$1 AND venue_id IN (.+) AND sport_type=\\$3 ORDER BY lower\\(location\\), start_time, id").
+		WithArgs("2024-08-04", "stade de france", "Athletics").
+		WillReturnRows(sqlmock.NewRows(eventColumnNames).
+			AddRow("1", "Women's 3000m Steeplechase Final", "Athletics", "Stade de France", "2024-08-04", "21:00", "22:00", "v1", "scheduled", "", 0, time.Now().Format(time.RFC3339), time.Now().Format(time.RFC3339), 0).
+			AddRow("2", "Men's 100m Final", "Athletics", "Stade de France", "2024-08-04", "21:50", "22:10", "v1", "scheduled", "", 0, time.Now().Format(time.RFC3339), time.Now().Format(time.RFC3339), 0))
+
+	resp, err := repo.GetSchedule(context.Background(), &pb.GetScheduleRequest{Date: "2024-08-04", Venue: "stade de france", SportType: "Athletics"})
+
+	assert.NoError(t, err)
+	assert.Len(t, resp.Venues, 1)
+	assert.Equal(t, "v1", resp.Venues[0].VenueId)
+	assert.Equal(t, "Stade de France", resp.Venues[0].Name)
+	assert.Len(t, resp.Venues[0].Events, 2)
+}
+
+func TestCreateVenue(t *testing.T) {
+	repo, mock, teardown := setupTest(t)
+	defer teardown()
+
+	req := &pb.CreateVenueRequest{Name: "Stade de France", City: "Saint-Denis", Capacity: 77083}
+
+	mock.ExpectQuery("INSERT INTO venues").
+		WithArgs(req.Name, req.City, req.Capacity).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "city", "capacity", "created_at", "updated_at", "deleted_at"}).
+			AddRow("v1", req.Name, req.City, req.Capacity, time.Now().Format(time.RFC3339), time.Now().Format(time.RFC3339), 0))
+
+	resp, err := repo.CreateVenue(context.Background(), req)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "v1", resp.Id)
+	assert.Equal(t, int32(77083), resp.Capacity)
+}
+
+func TestDeleteVenueInUse(t *testing.T) {
+	repo, mock, teardown := setupTest(t)
+	defer teardown()
+
+	mock.ExpectBegin()
+	mock.ExpectExec("SELECT pg_advisory_xact_lock").
+		WithArgs("v1").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery("SELECT COUNT(.+) FROM events WHERE venue_id=\\$1").
+		WithArgs("v1").
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(2))
+	mock.ExpectRollback()
+
+	_, err := repo.DeleteVenue(context.Background(), &pb.DeleteVenueRequest{Id: "v1"})
+
+	assert.ErrorIs(t, err, ErrVenueInUse)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCreatePhase(t *testing.T) {
+	repo, mock, teardown := setupTest(t)
+	defer teardown()
+
+	now := time.Now().Format(time.RFC3339)
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT id FROM events WHERE id=\\$1 AND deleted_at=0 FOR UPDATE").
+		WithArgs("e1").
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("e1"))
+	mock.ExpectQuery("SELECT COALESCE(.+) FROM phases").
+		WithArgs("e1").
+		WillReturnRows(sqlmock.NewRows([]string{"position"}).AddRow(2))
+	mock.ExpectQuery("INSERT INTO phases").
+		WithArgs("e1", "Semifinals", 2, FormatHeats, ResultTime, 2, 2).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "event_id", "name", "position", "format", "result_type", "advance_per_unit", "advance_fastest", "created_at", "updated_at"}).
+			AddRow("p1", "e1", "Semifinals", 2, FormatHeats, ResultTime, 2, 2, now, now))
+	for i := 1; i <= 3; i++ {
+		mock.ExpectQuery("INSERT INTO units").
+			WithArgs("p1", fmt.Sprintf("Heat %d", i), i, UnitScheduled).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(fmt.Sprintf("u%d", i)))
+	}
+	mock.ExpectCommit()
+
+	resp, err := repo.CreatePhase(context.Background(), &pb.CreatePhaseRequest{
+		EventId:        "e1",
+		Name:           " Semifinals ",
+		AdvancePerUnit: 2,
+		AdvanceFastest: 2,
+		Units:          3,
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, int32(2), resp.Position)
+	assert.Len(t, resp.Units, 3)
+	assert.Equal(t, "u3", resp.Units[2].Id)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCreatePhaseInvalid(t *testing.T) {
+	repo, _, teardown := setupTest(t)
+	defer teardown()
+
+	for _, req := range []*pb.CreatePhaseRequest{
+		{EventId: "e1"},
+		{EventId: "e1", Name: "Final", Format: "league"},
+		{EventId: "e1", Name: "Final", ResultType: "points"},
+		{EventId: "e1", Name: "Final", Units: maxUnits + 1},
+		{EventId: "e1", Name: "Final", AdvanceFastest: -1},
+	} {
+		_, err := repo.CreatePhase(context.Background(), req)
+		assert.ErrorIs(t, err, ErrInvalidPhase)
+	}
+}
+
+func TestRecordResults(t *testing.T) {
+	repo, mock, teardown := setupTest(t)
+	defer teardown()
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT u.status, p.format, p.result_type FROM units u").
+		WithArgs("u1").
+		WillReturnRows(sqlmock.NewRows([]string{"status", "format", "result_type"}).AddRow(UnitScheduled, FormatHeats, ResultTime))
+	mock.ExpectQuery("SELECT (.+) FROM units WHERE id=\\$1").
+		WithArgs("u1").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "phase_id", "name", "position", "status"}).AddRow("u1", "p1", "Heat 1", 1, UnitScheduled))
+	mock.ExpectQuery("SELECT (.+) FROM unit_entries").
+		WillReturnRows(sqlmock.NewRows([]string{"unit_id", "athlete_id", "country_id", "lane", "seed", "mark", "value", "rank", "irm", "qualified"}).
+			AddRow("u1", "a1", "c1", 4, 1, "", 0, 0, "", "").
+			AddRow("u1", "a2", "c2", 5, 2, "", 0, 0, "", "").
+			AddRow("u1", "a3", "c3", 3, 3, "", 0, 0, "", ""))
+	mock.ExpectExec("UPDATE unit_entries SET mark=\\$1").
+		WithArgs("9.91", 9.91, 2, "", "u1", "a1").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("UPDATE unit_entries SET mark=\\$1").
+		WithArgs("9.85", 9.85, 1, "", "u1", "a2").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("UPDATE unit_entries SET mark=\\$1").
+		WithArgs("", nil, 0, "DNF", "u1", "a3").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("UPDATE units SET status=\\$1").
+		WithArgs(UnitCompleted, "u1").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	resp, err := repo.RecordResults(context.Background(), &pb.RecordResultsRequest{
+		UnitId: "u1",
+		Results: []*pb.UnitEntry{
+			{AthleteId: "a1", Value: 9.91},
+			{AthleteId: "a2", Mark: "9.85", Value: 9.85},
+			{AthleteId: "a3", Irm: "dnf"},
+		},
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, UnitCompleted, resp.Status)
+	assert.Equal(t, "a2", resp.Entries[0].AthleteId)
+	assert.Equal(t, "DNF", resp.Entries[2].Irm)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRecordResultsNotOnStartList(t *testing.T) {
+	repo, mock, teardown := setupTest(t)
+	defer teardown()
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT u.status, p.format, p.result_type FROM units u").
+		WithArgs("u1").
+		WillReturnRows(sqlmock.NewRows([]string{"status", "format", "result_type"}).AddRow(UnitScheduled, FormatKnockout, ResultScore))
+	mock.ExpectQuery("SELECT (.+) FROM units WHERE id=\\$1").
+		WithArgs("u1").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "phase_id", "name", "position", "status"}).AddRow("u1", "p1", "Match 1", 1, UnitScheduled))
+	mock.ExpectQuery("SELECT (.+) FROM unit_entries").
+		WillReturnRows(sqlmock.NewRows([]string{"unit_id", "athlete_id", "country_id", "lane", "seed", "mark", "value", "rank", "irm", "qualified"}).
+			AddRow("u1", "a1", "c1", 1, 1, "", 0, 0, "", ""))
+	mock.ExpectRollback()
+
+	_, err := repo.RecordResults(context.Background(), &pb.RecordResultsRequest{
+		UnitId:  "u1",
+		Results: []*pb.UnitEntry{{AthleteId: "a9", Value: 3}},
+	})
+
+	assert.ErrorIs(t, err, ErrInvalidEntry)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSetStartListCompleted(t *testing.T) {
+	repo, mock, teardown := setupTest(t)
+	defer teardown()
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT u.status, p.format, p.result_type FROM units u").
+		WithArgs("u1").
+		WillReturnRows(sqlmock.NewRows([]string{"status", "format", "result_type"}).AddRow(UnitCompleted, FormatHeats, ResultTime))
+	mock.ExpectRollback()
+
+	_, err := repo.SetStartList(context.Background(), &pb.SetStartListRequest{
+		UnitId:  "u1",
+		Entries: []*pb.UnitEntry{{AthleteId: "a1", CountryId: "c1", Lane: 4}},
+	})
+
+	assert.ErrorIs(t, err, ErrUnitCompleted)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRankEntries(t *testing.T) {
+	entries := []*pb.UnitEntry{
+		{AthleteId: "a", Mark: "21.50", Value: 21.5},
+		{AthleteId: "b", Mark: "8.10", Value: 8.1},
+		{AthleteId: "c", Mark: "8.10", Value: 8.1},
+		{AthleteId: "d", Irm: "DSQ", Rank: 1},
+		{AthleteId: "e"},
+	}
+	rankEntries(ResultDistance, entries)
+
+	var ranks []int32
+	for _, e := range entries {
+		ranks = append(ranks, e.Rank)
+	}
+	assert.Equal(t, []int32{1, 2, 2, 0, 0}, ranks)
+
+	// Ranks given for every result are kept.
+	judged := []*pb.UnitEntry{
+		{AthleteId: "a", Mark: "3", Value: 3, Rank: 2},
+		{AthleteId: "b", Mark: "3", Value: 3, Rank: 1},
+	}
+	rankEntries(ResultScore, judged)
+	assert.Equal(t, int32(2), judged[0].Rank)
+	assert.Equal(t, int32(1), judged[1].Rank)
+}
+
+func TestAdvanceHeats(t *testing.T) {
+	entry := func(id string, rank int32, value float64) *pb.UnitEntry {
+		return &pb.UnitEntry{AthleteId: id, CountryId: "c" + id, Mark: "x", Value: value, Rank: rank}
+	}
+	phase := &pb.Phase{
+		Name:           "Heats",
+		Format:         FormatHeats,
+		ResultType:     ResultTime,
+		AdvancePerUnit: 1,
+		AdvanceFastest: 2,
+		Units: []*pb.Unit{
+			{Id: "h1", Name: "Heat 1", Status: UnitCompleted, Entries: []*pb.UnitEntry{entry("a", 1, 10.0), entry("b", 2, 10.2), entry("c", 3, 10.5)}},
+			{Id: "h2", Name: "Heat 2", Status: UnitCompleted, Entries: []*pb.UnitEntry{entry("d", 1, 9.9), entry("e", 2, 10.3), entry("f", 3, 10.3)}},
+		},
+	}
+	next := &pb.Phase{Name: "Final", Format: FormatHeats, Units: []*pb.Unit{{Id: "f1", Name: "Final", Status: UnitScheduled}}}
+
+	qualified, startLists, err := advance(phase, next)
+	assert.NoError(t, err)
+
+	// d and a win their heats; b is fastest of the rest and e and f tie for
+	// the last place on time, so both go through.
+	var ids, marks []string
+	for _, q := range qualified {
+		ids = append(ids, q.entry.AthleteId)
+		marks = append(marks, q.entry.Qualified)
+	}
+	assert.Equal(t, []string{"d", "a", "b", "e", "f"}, ids)
+	assert.Equal(t, []string{"Q", "Q", "q", "q", "q"}, marks)
+	assert.Equal(t, "", phase.Units[0].Entries[2].Qualified)
+
+	assert.Len(t, startLists, 1)
+	assert.Equal(t, "d", startLists[0][0].AthleteId)
+	assert.Equal(t, int32(4), startLists[0][0].Lane)
+	assert.Equal(t, int32(5), startLists[0][1].Lane)
+	assert.Equal(t, int32(5), startLists[0][4].Seed)
+}
+
+func TestAdvanceIncomplete(t *testing.T) {
+	phase := &pb.Phase{Units: []*pb.Unit{{Name: "Heat 1", Status: UnitScheduled}}}
+	next := &pb.Phase{Units: []*pb.Unit{{Name: "Final", Status: UnitScheduled}}}
+
+	_, _, err := advance(phase, next)
+	assert.ErrorIs(t, err, ErrPhaseIncomplete)
+}
+
+func TestAdvanceKnockoutBronze(t *testing.T) {
+	match := func(id, winner, loser string) *pb.Unit {
+		return &pb.Unit{Id: id, Name: id, Status: UnitCompleted, Entries: []*pb.UnitEntry{
+			{AthleteId: winner, CountryId: "c" + winner, Rank: 1, Mark: "2"},
+			{AthleteId: loser, CountryId: "c" + loser, Rank: 2, Mark: "1"},
+		}}
+	}
+	semis := &pb.Phase{Name: "Semifinals", Format: FormatKnockout, Units: []*pb.Unit{match("sf1", "a", "b"), match("sf2", "c", "d")}}
+	final := &pb.Phase{Name: "Final", Format: FormatKnockout, Units: []*pb.Unit{
+		{Name: "Gold Medal Match", Status: UnitScheduled},
+		{Name: "Bronze Medal Match", Status: UnitScheduled},
+	}}
+
+	qualified, startLists, err := advance(semis, final)
+	assert.NoError(t, err)
+	assert.Len(t, qualified, 2)
+	assert.Equal(t, []string{"a", "c"}, []string{startLists[0][0].AthleteId, startLists[0][1].AthleteId})
+	assert.Equal(t, []int32{1, 2}, []int32{startLists[0][0].Lane, startLists[0][1].Lane})
+	assert.Equal(t, []string{"b", "d"}, []string{startLists[1][0].AthleteId, startLists[1][1].AthleteId})
+}
+
+func TestBracket(t *testing.T) {
+	assert.Equal(t, []int{1, 8, 4, 5, 2, 7, 3, 6}, bracketOrder(8))
+
+	var competitors []*pb.UnitEntry
+	for i := 1; i <= 5; i++ {
+		competitors = append(competitors, &pb.UnitEntry{CountryId: fmt.Sprintf("c%d", i)})
+	}
+	phases := bracket(competitors, true)
+
+	var names []string
+	for _, phase := range phases {
+		names = append(names, phase.Name)
+	}
+	assert.Equal(t, []string{"Quarterfinals", "Semifinals", "Final"}, names)
+
+	// Seeds 1 to 3 have no opponent and go through on a bye; 4 meets 5.
+	first := phases[0].Units
+	assert.Len(t, first, 4)
+	assert.Equal(t, UnitCompleted, first[0].Status)
+	assert.Equal(t, "BYE", first[0].Entries[0].Mark)
+	assert.Equal(t, UnitScheduled, first[1].Status)
+	assert.Equal(t, []int32{4, 5}, []int32{first[1].Entries[0].Seed, first[1].Entries[1].Seed})
+	assert.Empty(t, phases[1].Units[0].Entries)
+	assert.Len(t, phases[2].Units, 2)
+	assert.Equal(t, "Bronze Medal Match", phases[2].Units[1].Name)
+}
+
+func TestGenerateBracketTooFew(t *testing.T) {
+	repo, _, teardown := setupTest(t)
+	defer teardown()
+
+	_, err := repo.GenerateBracket(context.Background(), &pb.GenerateBracketRequest{
+		EventId:     "e1",
+		Competitors: []*pb.UnitEntry{{CountryId: "c1"}},
+	})
+	assert.ErrorIs(t, err, ErrInvalidPhase)
+}
@@ -1,12 +1,32 @@
 package repository 
 
 import (
+	"context"
 	pb "github.com/Bekzodbekk/paris2024_livestream_protos/genproto/eventpb"
 )
 type EventRepository interface {
-	CreateEvent(req *pb.CreateEventRequest) (*pb.Event, error)
-	GetEvent(req *pb.GetEventRequest) (*pb.Event, error)
-	ListOfEvent(req *pb.ListOfEventRequest) (*pb.ListOfEventResponse, error)
-	UpdateEvent(*pb.UpdateEventRequest) (*pb.Event, error)
-	DeleteEvent(req *pb.DeleteEventRequest) (*pb.DeleteEventResponse, error)
+	CreateEvent(ctx context.Context, req *pb.CreateEventRequest) (*pb.Event, error)
+	GetEvent(ctx context.Context, req *pb.GetEventRequest) (*pb.Event, error)
+	ListOfEvent(ctx context.Context, req *pb.ListOfEventRequest) (*pb.ListOfEventResponse, error)
+	UpdateEvent(ctx context.Context, req *pb.UpdateEventRequest) (*pb.Event, error)
+	DeleteEvent(ctx context.Context, req *pb.DeleteEventRequest) (*pb.DeleteEventResponse, error)
+	TransitionEvent(ctx context.Context, req *pb.TransitionEventRequest) (*pb.Event, error)
+	GetSchedule(ctx context.Context, req *pb.GetScheduleRequest) (*pb.GetScheduleResponse, error)
+	ImportEvents(ctx context.Context, req *pb.ImportEventsRequest) (*pb.ImportResponse, error)
+	ExportEvents(ctx context.Context, req *pb.ExportEventsRequest, send func(*pb.Event) error) error
+
+	CreateVenue(ctx context.Context, req *pb.CreateVenueRequest) (*pb.Venue, error)
+	GetVenue(ctx context.Context, req *pb.GetVenueRequest) (*pb.Venue, error)
+	ListVenues(ctx context.Context, req *pb.ListVenuesRequest) (*pb.ListVenuesResponse, error)
+	UpdateVenue(ctx context.Context, req *pb.UpdateVenueRequest) (*pb.Venue, error)
+	DeleteVenue(ctx context.Context, req *pb.DeleteVenueRequest) (*pb.DeleteVenueResponse, error)
+
+	CreatePhase(ctx context.Context, req *pb.CreatePhaseRequest) (*pb.Phase, error)
+	GetPhase(ctx context.Context, req *pb.GetPhaseRequest) (*pb.Phase, error)
+	ListPhases(ctx context.Context, req *pb.ListPhasesRequest) (*pb.ListPhasesResponse, error)
+	DeletePhase(ctx context.Context, req *pb.DeletePhaseRequest) (*pb.DeletePhaseResponse, error)
+	SetStartList(ctx context.Context, req *pb.SetStartListRequest) (*pb.Unit, error)
+	RecordResults(ctx context.Context, req *pb.RecordResultsRequest) (*pb.Unit, error)
+	AdvancePhase(ctx context.Context, req *pb.AdvancePhaseRequest) (*pb.AdvancePhaseResponse, error)
+	GenerateBracket(ctx context.Context, req *pb.GenerateBracketRequest) (*pb.GenerateBracketResponse, error)
 }
\ No newline at end of file
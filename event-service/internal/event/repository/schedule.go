@@ -0,0 +1,147 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"event-service/logger"
+	"fmt"
+	"shared/paging"
+
+	pb "github.com/Bekzodbekk/paris2024_livestream_protos/genproto/eventpb"
+	"github.com/sirupsen/logrus"
+)
+
+// ErrUnknownVenue is returned when an event is booked into a venue that does
+// not exist or has been deleted.
+var ErrUnknownVenue = errors.New("unknown venue")
+
+// ConflictError is returned when an event would overlap sessions already
+// booked into the same venue.
+type ConflictError struct {
+	VenueID   string
+	Conflicts []*pb.VenueConflict
+}
+
+func (e *ConflictError) Error() string {
+	return fmt.Sprintf("venue %s is already booked by %d event(s) at that time", e.VenueID, len(e.Conflicts))
+}
+
+// venueCondition matches the events of a venue given by id or,
+// case-insensitively, by name.
+const venueCondition = "venue_id IN (SELECT id FROM venues WHERE deleted_at=0 AND (id::text=$%d OR lower(name)=lower($%[1]d)))"
+
+// bookVenue takes a transaction-scoped advisory lock on the venue, so events
+// at one venue are checked and written one at a time, and returns the venue's
+// name with the events that overlap the slot. Overlaps are a ConflictError
+// unless allowConflict is set, in which case they are returned as a warning.
+// Cancelled events free their slot; exclude leaves out an event being
+// updated.
+func bookVenue(ctx context.Context, tx *sql.Tx, venueID, date, start, end, exclude string, allowConflict bool) (string, []*pb.VenueConflict, error) {
+	if _, err := tx.ExecContext(ctx, `SELECT pg_advisory_xact_lock(hashtext($1))`, venueID); err != nil {
+		return "", nil, err
+	}
+
+	var name string
+	err := tx.QueryRowContext(ctx, `SELECT name FROM venues WHERE id = $1 AND deleted_at = 0`, venueID).Scan(&name)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", nil, fmt.Errorf("%w %s", ErrUnknownVenue, venueID)
+	}
+	if err != nil {
+		return "", nil, err
+	}
+
+	// Two sessions overlap when each starts before the other ends, so one
+	// may start the minute the previous one finishes.
+	rows, err := tx.QueryContext(ctx, `
+		SELECT id, name, to_char(start_time, 'HH24:MI:SS'), to_char(end_time, 'HH24:MI:SS')
+		FROM events
+		WHERE venue_id = $1 AND date = $2 AND start_time < $4 AND end_time > $3
+		  AND deleted_at = 0 AND status <> 'cancelled' AND id::text <> $5
+		ORDER BY start_time, id`, venueID, date, start, end, exclude)
+	if err != nil {
+		return "", nil, err
+	}
+	defer rows.Close()
+
+	var conflicts []*pb.VenueConflict
+	for rows.Next() {
+		var conflict pb.VenueConflict
+		if err := rows.Scan(&conflict.EventId, &conflict.Name, &conflict.StartTime, &conflict.EndTime); err != nil {
+			return "", nil, err
+		}
+		conflicts = append(conflicts, &conflict)
+	}
+	if err := rows.Err(); err != nil {
+		return "", nil, err
+	}
+
+	if len(conflicts) > 0 && !allowConflict {
+		return "", nil, &ConflictError{VenueID: venueID, Conflicts: conflicts}
+	}
+	return name, conflicts, nil
+}
+
+// GetSchedule returns the events of one day grouped by venue, each venue's
+// sessions in the order they start. Events without a venue are grouped by
+// their location.
+func (db *PostgresEventRepository) GetSchedule(ctx context.Context, req *pb.GetScheduleRequest) (*pb.GetScheduleResponse, error) {
+
+	filter := paging.NewConditions("deleted_at=0")
+	filter.Add("date=$%d", req.Date)
+	if req.Venue != "" {
+		filter.Add(venueCondition, req.Venue)
+	}
+	if req.SportType != "" {
+		filter.Add("sport_type=$%d", req.SportType)
+	}
+
+	query := `SELECT ` + eventColumns + ` FROM events WHERE ` + filter.String() + ` ORDER BY lower(location), start_time, id`
+	rows, err := db.DB.QueryContext(ctx, query, filter.Args()...)
+	if err != nil {
+		logger.ErrorContext(ctx, "Building schedule failed", logrus.Fields{
+			"error": err,
+			"date":  req.Date,
+		})
+		return nil, err
+	}
+	defer rows.Close()
+
+	resp := pb.GetScheduleResponse{Date: req.Date}
+	venues := make(map[string]*pb.VenueSchedule)
+	for rows.Next() {
+		item := pb.Event{}
+		if err := scanEvent(rows, &item); err != nil {
+			logger.ErrorContext(ctx, "Decoding event failed", logrus.Fields{
+				"error": err,
+			})
+			return nil, err
+		}
+
+		key := "venue:" + item.VenueId
+		if item.VenueId == "" {
+			key = "location:" + item.Location
+		}
+		venue, ok := venues[key]
+		if !ok {
+			venue = &pb.VenueSchedule{VenueId: item.VenueId, Name: item.Location}
+			venues[key] = venue
+			resp.Venues = append(resp.Venues, venue)
+		}
+		venue.Events = append(venue.Events, &item)
+	}
+	if err := rows.Err(); err != nil {
+		logger.ErrorContext(ctx, "Building schedule failed", logrus.Fields{
+			"error": err,
+			"date":  req.Date,
+		})
+		return nil, err
+	}
+
+	logger.InfoContext(ctx, "Schedule built successfully", logrus.Fields{
+		"date":   req.Date,
+		"venues": len(resp.Venues),
+	})
+
+	return &resp, nil
+}
@@ -0,0 +1,60 @@
+package repository
+
+import (
+	"errors"
+	"fmt"
+)
+
+// The statuses an event moves through. New events are scheduled.
+const (
+	StatusScheduled = "scheduled"
+	StatusLive      = "live"
+	StatusDelayed   = "delayed"
+	StatusCompleted = "completed"
+	StatusCancelled = "cancelled"
+)
+
+// transitions lists the statuses each status may move to. A delayed event
+// either starts, is put back on the schedule or is called off; a live one can
+// be interrupted, which delays it until it resumes. Completed and cancelled
+// events are final.
+var transitions = map[string][]string{
+	StatusScheduled: {StatusLive, StatusDelayed, StatusCancelled},
+	StatusDelayed:   {StatusLive, StatusScheduled, StatusCancelled},
+	StatusLive:      {StatusDelayed, StatusCompleted, StatusCancelled},
+	StatusCompleted: nil,
+	StatusCancelled: nil,
+}
+
+// ErrInvalidStatus is returned for a status that is not part of the lifecycle.
+var ErrInvalidStatus = errors.New("invalid status")
+
+// TransitionError is returned when an event cannot move from its current
+// status to the requested one.
+type TransitionError struct {
+	From, To string
+}
+
+func (e *TransitionError) Error() string {
+	if len(transitions[e.From]) == 0 {
+		return fmt.Sprintf("event is %s and can no longer change status", e.From)
+	}
+	return fmt.Sprintf("event cannot go from %s to %s", e.From, e.To)
+}
+
+// checkTransition validates a move from one status to another. Staying in
+// the same status is allowed, so a retried request is harmless.
+func checkTransition(from, to string) error {
+	if _, ok := transitions[to]; !ok {
+		return fmt.Errorf("%w %q", ErrInvalidStatus, to)
+	}
+	if from == to {
+		return nil
+	}
+	for _, next := range transitions[from] {
+		if next == to {
+			return nil
+		}
+	}
+	return &TransitionError{From: from, To: to}
+}
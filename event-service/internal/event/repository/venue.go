@@ -0,0 +1,261 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"event-service/logger"
+	"fmt"
+	"shared/paging"
+	"strconv"
+
+	pb "github.com/Bekzodbekk/paris2024_livestream_protos/genproto/eventpb"
+	"github.com/sirupsen/logrus"
+)
+
+// ErrVenueInUse is returned when a venue still hosting events is deleted.
+var ErrVenueInUse = errors.New("venue is in use")
+
+const venueColumns = `id, name, city, capacity, created_at, updated_at, deleted_at`
+
+func scanVenue(row rowScanner, v *pb.Venue) error {
+	return row.Scan(
+		&v.Id,
+		&v.Name,
+		&v.City,
+		&v.Capacity,
+		&v.CreatedAt,
+		&v.UpdatedAt,
+		&v.DeletedAt,
+	)
+}
+
+func (db *PostgresEventRepository) CreateVenue(ctx context.Context, req *pb.CreateVenueRequest) (*pb.Venue, error) {
+
+	resp := pb.Venue{}
+	query := `
+	INSERT INTO venues(name, city, capacity)
+	VALUES($1, $2, $3)
+	RETURNING ` + venueColumns
+	err := scanVenue(db.DB.QueryRowContext(ctx, query, req.Name, req.City, req.Capacity), &resp)
+	if err != nil {
+		logger.ErrorContext(ctx, "Creating venue failed", logrus.Fields{
+			"error": err,
+		})
+		return nil, err
+	}
+
+	logger.InfoContext(ctx, "Venue created successfully", logrus.Fields{
+		"venue_id": resp.Id,
+		"name":     resp.Name,
+	})
+
+	return &resp, nil
+}
+
+func (db *PostgresEventRepository) GetVenue(ctx context.Context, req *pb.GetVenueRequest) (*pb.Venue, error) {
+
+	resp := pb.Venue{}
+	query := `
+	SELECT ` + venueColumns + `
+	FROM venues
+	WHERE id=$1 AND deleted_at=0`
+	err := scanVenue(db.DB.QueryRowContext(ctx, query, req.Id), &resp)
+	if err != nil {
+		logger.ErrorContext(ctx, "Retrieving venue failed", logrus.Fields{
+			"error":    err,
+			"venue_id": req.Id,
+		})
+		return nil, err
+	}
+
+	return &resp, nil
+}
+
+// venueOrderColumns maps the order_by fields accepted by ListVenues to columns.
+var venueOrderColumns = map[string]string{
+	"name":       "name",
+	"city":       "city",
+	"capacity":   "capacity",
+	"created_at": "created_at",
+}
+
+func (db *PostgresEventRepository) ListVenues(ctx context.Context, req *pb.ListVenuesRequest) (*pb.ListVenuesResponse, error) {
+
+	p, err := paging.New(req.PageSize, req.PageToken, req.OrderBy, venueOrderColumns, "name")
+	if err != nil {
+		return nil, err
+	}
+
+	filter := paging.NewConditions("deleted_at=0")
+	if req.City != "" {
+		filter.Add("city=$%d", req.City)
+	}
+
+	resp := pb.ListVenuesResponse{}
+	if err := db.DB.QueryRowContext(ctx, "SELECT COUNT(*) FROM venues WHERE "+filter.String(), filter.Args()...).Scan(&resp.TotalCount); err != nil {
+		logger.ErrorContext(ctx, "Counting venues failed", logrus.Fields{
+			"error": err,
+		})
+		return nil, err
+	}
+
+	query := `
+	SELECT ` + venueColumns + `
+	FROM venues` + p.Clause(filter)
+	rows, err := db.DB.QueryContext(ctx, query, filter.Args()...)
+	if err != nil {
+		logger.ErrorContext(ctx, "Listing venues failed", logrus.Fields{
+			"error": err,
+		})
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		item := pb.Venue{}
+		if err := scanVenue(rows, &item); err != nil {
+			logger.ErrorContext(ctx, "Decoding venue failed", logrus.Fields{
+				"error": err,
+			})
+			return nil, err
+		}
+		resp.Venues = append(resp.Venues, &item)
+	}
+	if err := rows.Err(); err != nil {
+		logger.ErrorContext(ctx, "Listing venues failed", logrus.Fields{
+			"error": err,
+		})
+		return nil, err
+	}
+
+	if len(resp.Venues) > p.Size {
+		resp.Venues = resp.Venues[:p.Size]
+		last := resp.Venues[p.Size-1]
+		resp.NextPageToken = p.NextToken(venueSortValue(last, p.Column), last.Id)
+	}
+
+	return &resp, nil
+}
+
+func venueSortValue(v *pb.Venue, column string) string {
+	switch column {
+	case "city":
+		return v.City
+	case "capacity":
+		return strconv.Itoa(int(v.Capacity))
+	case "created_at":
+		return v.CreatedAt
+	default:
+		return v.Name
+	}
+}
+
+// UpdateVenue renames the venue's events along with it, since they carry its
+// name as their location.
+func (db *PostgresEventRepository) UpdateVenue(ctx context.Context, req *pb.UpdateVenueRequest) (*pb.Venue, error) {
+
+	tx, err := db.DB.BeginTx(ctx, nil)
+	if err != nil {
+		logger.ErrorContext(ctx, "Updating venue failed", logrus.Fields{
+			"error":    err,
+			"venue_id": req.Id,
+		})
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	resp := pb.Venue{}
+	err = scanVenue(tx.QueryRowContext(ctx, `
+	UPDATE venues
+	SET name=$1, city=$2, capacity=$3, updated_at=NOW()
+	WHERE id=$4 AND deleted_at=0
+	RETURNING `+venueColumns, req.Name, req.City, req.Capacity, req.Id), &resp)
+	if err == nil {
+		_, err = tx.ExecContext(ctx, `
+		UPDATE events
+		SET location=$1, sequence=sequence+1, updated_at=NOW()
+		WHERE venue_id=$2 AND deleted_at=0 AND location IS DISTINCT FROM $1`, resp.Name, resp.Id)
+	}
+	if err == nil {
+		err = tx.Commit()
+	}
+	if err != nil {
+		logger.ErrorContext(ctx, "Updating venue failed", logrus.Fields{
+			"error":    err,
+			"venue_id": req.Id,
+		})
+		return nil, err
+	}
+
+	logger.InfoContext(ctx, "Venue updated successfully", logrus.Fields{
+		"venue_id": resp.Id,
+		"name":     resp.Name,
+	})
+
+	return &resp, nil
+}
+
+// DeleteVenue refuses to delete a venue while events that have not finished
+// are booked into it. It takes the venue's booking lock, so no event can be
+// booked in the meantime.
+func (db *PostgresEventRepository) DeleteVenue(ctx context.Context, req *pb.DeleteVenueRequest) (*pb.DeleteVenueResponse, error) {
+
+	tx, err := db.DB.BeginTx(ctx, nil)
+	if err != nil {
+		logger.ErrorContext(ctx, "Deleting venue failed", logrus.Fields{
+			"error":    err,
+			"venue_id": req.Id,
+		})
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `SELECT pg_advisory_xact_lock(hashtext($1))`, req.Id); err != nil {
+		return nil, err
+	}
+
+	var booked int
+	err = tx.QueryRowContext(ctx, `
+	SELECT COUNT(*)
+	FROM events
+	WHERE venue_id=$1 AND deleted_at=0 AND status IN ('scheduled', 'live', 'delayed')`, req.Id).Scan(&booked)
+	if err != nil {
+		logger.ErrorContext(ctx, "Deleting venue failed", logrus.Fields{
+			"error":    err,
+			"venue_id": req.Id,
+		})
+		return nil, err
+	}
+	if booked > 0 {
+		return nil, fmt.Errorf("%w: %d event(s) are still booked into it", ErrVenueInUse, booked)
+	}
+
+	result, err := tx.ExecContext(ctx, `
+	UPDATE venues
+	SET deleted_at=DATE_PART('epoch', CURRENT_TIMESTAMP)::INT
+	WHERE id=$1 AND deleted_at=0`, req.Id)
+	if err != nil {
+		logger.ErrorContext(ctx, "Deleting venue failed", logrus.Fields{
+			"error":    err,
+			"venue_id": req.Id,
+		})
+		return nil, err
+	}
+	num, err := result.RowsAffected()
+	if err != nil {
+		return nil, err
+	}
+	if num == 0 {
+		return nil, sql.ErrNoRows
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	logger.InfoContext(ctx, "Venue deleted successfully", logrus.Fields{
+		"venue_id": req.Id,
+	})
+
+	return &pb.DeleteVenueResponse{Status: "deleted successfully"}, nil
+}
@@ -0,0 +1,79 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"event-service/internal/event/repository"
+	"fmt"
+	"shared/paging"
+
+	"github.com/lib/pq"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/protoadapt"
+)
+
+// statusError turns a repository error into the gRPC status the gateway
+// translates for its clients. what names the record involved, e.g.
+// "event 42", for NotFound and AlreadyExists messages. Errors that already
+// carry a status are returned unchanged.
+func statusError(err error, what string) error {
+	if err == nil {
+		return nil
+	}
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+
+	var pqErr *pq.Error
+	var conflict *repository.ConflictError
+	var transition *repository.TransitionError
+	switch {
+	case errors.As(err, &conflict):
+		failure := &errdetails.PreconditionFailure{}
+		for _, c := range conflict.Conflicts {
+			failure.Violations = append(failure.Violations, &errdetails.PreconditionFailure_Violation{
+				Type:        "VENUE_CONFLICT",
+				Subject:     "event " + c.EventId,
+				Description: fmt.Sprintf("%s is booked from %s to %s", c.Name, c.StartTime, c.EndTime),
+			})
+		}
+		return withDetails(codes.FailedPrecondition, err.Error(), failure)
+	case errors.As(err, &transition), errors.Is(err, repository.ErrUnknownVenue), errors.Is(err, repository.ErrVenueInUse),
+		errors.Is(err, repository.ErrUnitCompleted), errors.Is(err, repository.ErrPhaseIncomplete), errors.Is(err, repository.ErrNoNextPhase):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	case errors.Is(err, sql.ErrNoRows):
+		return status.Errorf(codes.NotFound, "%s not found", what)
+	case errors.Is(err, paging.ErrInvalidPageToken), errors.Is(err, paging.ErrInvalidOrderBy),
+		errors.Is(err, repository.ErrInvalidEvent), errors.Is(err, repository.ErrInvalidStatus),
+		errors.Is(err, repository.ErrInvalidPhase), errors.Is(err, repository.ErrInvalidEntry):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, context.Canceled):
+		return status.Error(codes.Canceled, err.Error())
+	case errors.Is(err, context.DeadlineExceeded):
+		return status.Error(codes.DeadlineExceeded, err.Error())
+	case errors.As(err, &pqErr):
+		switch {
+		case pqErr.Code == "23505":
+			return status.Errorf(codes.AlreadyExists, "%s already exists", what)
+		case pqErr.Code.Class() == "22":
+			// Data exceptions: a malformed uuid, date or number.
+			return status.Errorf(codes.InvalidArgument, "invalid %s: %s", what, pqErr.Message)
+		case pqErr.Code.Class() == "23":
+			return status.Error(codes.FailedPrecondition, pqErr.Message)
+		}
+	}
+	return status.Error(codes.Internal, err.Error())
+}
+
+// withDetails builds a status carrying detail, falling back to the bare
+// status if the detail cannot be encoded.
+func withDetails(code codes.Code, message string, detail protoadapt.MessageV1) error {
+	st, err := status.New(code, message).WithDetails(detail)
+	if err != nil {
+		return status.Error(code, message)
+	}
+	return st.Err()
+}
@@ -0,0 +1,53 @@
+package service
+
+import (
+	"context"
+
+	pb "github.com/Bekzodbekk/paris2024_livestream_protos/genproto/eventpb"
+)
+
+// CreatePhase appends a phase, with its units, to an event.
+func (s *EventService) CreatePhase(ctx context.Context, req *pb.CreatePhaseRequest) (*pb.Phase, error) {
+	resp, err := s.Repo.CreatePhase(ctx, req)
+	return resp, statusError(err, "event "+req.EventId)
+}
+
+func (s *EventService) GetPhase(ctx context.Context, req *pb.GetPhaseRequest) (*pb.Phase, error) {
+	resp, err := s.Repo.GetPhase(ctx, req)
+	return resp, statusError(err, "phase "+req.Id)
+}
+
+// ListPhases returns the whole competition structure of an event: its phases
+// in order with their units, start lists and results.
+func (s *EventService) ListPhases(ctx context.Context, req *pb.ListPhasesRequest) (*pb.ListPhasesResponse, error) {
+	resp, err := s.Repo.ListPhases(ctx, req)
+	return resp, statusError(err, "phases")
+}
+
+func (s *EventService) DeletePhase(ctx context.Context, req *pb.DeletePhaseRequest) (*pb.DeletePhaseResponse, error) {
+	resp, err := s.Repo.DeletePhase(ctx, req)
+	return resp, statusError(err, "phase "+req.Id)
+}
+
+func (s *EventService) SetStartList(ctx context.Context, req *pb.SetStartListRequest) (*pb.Unit, error) {
+	resp, err := s.Repo.SetStartList(ctx, req)
+	return resp, statusError(err, "unit "+req.UnitId)
+}
+
+func (s *EventService) RecordResults(ctx context.Context, req *pb.RecordResultsRequest) (*pb.Unit, error) {
+	resp, err := s.Repo.RecordResults(ctx, req)
+	return resp, statusError(err, "unit "+req.UnitId)
+}
+
+// AdvancePhase fills the start lists of the next phase from the results of
+// a completed one.
+func (s *EventService) AdvancePhase(ctx context.Context, req *pb.AdvancePhaseRequest) (*pb.AdvancePhaseResponse, error) {
+	resp, err := s.Repo.AdvancePhase(ctx, req)
+	return resp, statusError(err, "phase "+req.PhaseId)
+}
+
+// GenerateBracket appends the rounds of a knockout bracket to an event.
+func (s *EventService) GenerateBracket(ctx context.Context, req *pb.GenerateBracketRequest) (*pb.GenerateBracketResponse, error) {
+	resp, err := s.Repo.GenerateBracket(ctx, req)
+	return resp, statusError(err, "event "+req.EventId)
+}
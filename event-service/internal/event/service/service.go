@@ -2,8 +2,12 @@ package service
 
 import (
 	"context"
+	"strings"
+	"time"
 	pb "github.com/Bekzodbekk/paris2024_livestream_protos/genproto/eventpb"
 	"event-service/internal/event/repository"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 type EventService struct {
@@ -18,21 +22,54 @@ func NewEventService(repo repository.EventRepository) *EventService {
 }
 
 func(s *EventService) CreateEvent(ctx context.Context,req *pb.CreateEventRequest) (*pb.Event, error) {
-	return s.Repo.CreateEvent(req)
+	resp, err := s.Repo.CreateEvent(ctx, req)
+	return resp, statusError(err, "event "+req.Name)
 } 
 
 func(s *EventService) GetEvent(ctx context.Context,req *pb.GetEventRequest) (*pb.Event, error) {
-	return s.Repo.GetEvent(req)
+	resp, err := s.Repo.GetEvent(ctx, req)
+	return resp, statusError(err, "event "+req.Id)
 }
 
 func(s *EventService) ListOfEvent(ctx context.Context,req *pb.ListOfEventRequest) (*pb.ListOfEventResponse, error) {
-	return s.Repo.ListOfEvent(req)
+	resp, err := s.Repo.ListOfEvent(ctx, req)
+	return resp, statusError(err, "events")
 }
 
 func(s *EventService) UpdateEvent(ctx context.Context, req *pb.UpdateEventRequest) (*pb.Event, error) {
-	return s.Repo.UpdateEvent(req)
+	resp, err := s.Repo.UpdateEvent(ctx, req)
+	return resp, statusError(err, "event "+req.Id)
 }
 
 func(s *EventService) DeleteEvent(ctx context.Context,req *pb.DeleteEventRequest) (*pb.DeleteEventResponse, error) {
-	return s.Repo.DeleteEvent(req)
-}
\ No newline at end of file
+	resp, err := s.Repo.DeleteEvent(ctx, req)
+	return resp, statusError(err, "event "+req.Id)
+}
+
+// TransitionEvent moves an event along its status lifecycle.
+func(s *EventService) TransitionEvent(ctx context.Context, req *pb.TransitionEventRequest) (*pb.Event, error) {
+	req.Status = strings.ToLower(strings.TrimSpace(req.Status))
+	if req.Status == "" {
+		return nil, status.Error(codes.InvalidArgument, "status is required")
+	}
+	resp, err := s.Repo.TransitionEvent(ctx, req)
+	return resp, statusError(err, "event "+req.Id)
+}
+
+// GetSchedule returns the day view of one date.
+func(s *EventService) GetSchedule(ctx context.Context, req *pb.GetScheduleRequest) (*pb.GetScheduleResponse, error) {
+	if _, err := time.Parse("2006-01-02", req.Date); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "date %q is not a YYYY-MM-DD date", req.Date)
+	}
+	resp, err := s.Repo.GetSchedule(ctx, req)
+	return resp, statusError(err, "schedule")
+}
+
+func(s *EventService) ImportEvents(ctx context.Context, req *pb.ImportEventsRequest) (*pb.ImportResponse, error) {
+	resp, err := s.Repo.ImportEvents(ctx, req)
+	return resp, statusError(err, "events")
+}
+
+func(s *EventService) ExportEvents(req *pb.ExportEventsRequest, stream pb.EventService_ExportEventsServer) error {
+	return statusError(s.Repo.ExportEvents(stream.Context(), req, stream.Send), "events")
+}
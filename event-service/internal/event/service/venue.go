@@ -0,0 +1,55 @@
+package service
+
+import (
+	"context"
+	"strings"
+
+	pb "github.com/Bekzodbekk/paris2024_livestream_protos/genproto/eventpb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// validateVenue trims the venue's name and city and checks what the table
+// would otherwise reject with a less helpful error.
+func validateVenue(name, city *string, capacity int32) error {
+	*name = strings.TrimSpace(*name)
+	*city = strings.TrimSpace(*city)
+	if *name == "" {
+		return status.Error(codes.InvalidArgument, "name is required")
+	}
+	if capacity < 0 {
+		return status.Error(codes.InvalidArgument, "capacity cannot be negative")
+	}
+	return nil
+}
+
+func (s *EventService) CreateVenue(ctx context.Context, req *pb.CreateVenueRequest) (*pb.Venue, error) {
+	if err := validateVenue(&req.Name, &req.City, req.Capacity); err != nil {
+		return nil, err
+	}
+	resp, err := s.Repo.CreateVenue(ctx, req)
+	return resp, statusError(err, "venue "+req.Name)
+}
+
+func (s *EventService) GetVenue(ctx context.Context, req *pb.GetVenueRequest) (*pb.Venue, error) {
+	resp, err := s.Repo.GetVenue(ctx, req)
+	return resp, statusError(err, "venue "+req.Id)
+}
+
+func (s *EventService) ListVenues(ctx context.Context, req *pb.ListVenuesRequest) (*pb.ListVenuesResponse, error) {
+	resp, err := s.Repo.ListVenues(ctx, req)
+	return resp, statusError(err, "venues")
+}
+
+func (s *EventService) UpdateVenue(ctx context.Context, req *pb.UpdateVenueRequest) (*pb.Venue, error) {
+	if err := validateVenue(&req.Name, &req.City, req.Capacity); err != nil {
+		return nil, err
+	}
+	resp, err := s.Repo.UpdateVenue(ctx, req)
+	return resp, statusError(err, "venue "+req.Id)
+}
+
+func (s *EventService) DeleteVenue(ctx context.Context, req *pb.DeleteVenueRequest) (*pb.DeleteVenueResponse, error) {
+	resp, err := s.Repo.DeleteVenue(ctx, req)
+	return resp, statusError(err, "venue "+req.Id)
+}
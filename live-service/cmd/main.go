@@ -2,20 +2,21 @@ package main
 
 import (
 	"context"
+	"live-service/internal/live/notifier"
+	"live-service/internal/live/pkg/healthcheck"
 	config "live-service/internal/live/pkg/load"
+	"live-service/internal/live/pkg/metrics"
+	"live-service/internal/live/pkg/tracing"
 	mongosh "live-service/internal/live/pkg/mongosh"
 	rpc "live-service/internal/live/pkg/register-service"
 	liveRepo "live-service/internal/live/repository"
 	liveService "live-service/internal/live/service"
 	"live-service/logger"
-	"log"
-	"os"
 	"os/signal"
-	"sync"
 	"syscall"
 	"time"
 
-	"google.golang.org/grpc"
+	"go.mongodb.org/mongo-driver/mongo/readpref"
 )
 
 func main() {
@@ -28,40 +29,66 @@ func main() {
 	}
 	logger.Info("Configuration loaded successfully")
 
+	shutdownTracing, err := tracing.Init(context.Background(), "live-service", *cfg)
+	if err != nil {
+		logger.Fatal("Failed to set up tracing: ", err)
+	}
+
 	db, err := mongosh.NewConnection(cfg)
 	if err != nil {
 		logger.Fatal("Failed to connect to database: ", err)
 	}
 	logger.Info("Connected to the database successfully")
 
+	if err := mongosh.EnsureIndexes(db); err != nil {
+		logger.Fatal("Failed to create indexes: ", err)
+	}
+
 	repo := liveRepo.NewMongoshLiveRepository(*db)
-	service := liveService.NewEventService(repo)
-
-	var wg sync.WaitGroup
-	wg.Add(1)
-	r := rpc.NewGrpcService(service)
-
-	gServer := grpc.NewServer()
-	go func() {
-		defer wg.Done()
-		log.Printf("Live Service running on :%d port", cfg.ServerPort)
-		if err = r.RUN(*cfg); err != nil {
-			logger.Fatal("Failed to run gRPC service: ", err)
-		}
-	}()
+
+	var n notifier.Notifier
+	switch cfg.Notifier {
+	case notifier.DriverMongo:
+		n = notifier.NewChangeStreamNotifier(&db.Collection)
+	default:
+		n = notifier.NewBroadcaster()
+	}
+	logger.Info("Using live stream notifier: ", cfg.Notifier)
+
+	service := liveService.NewEventService(repo, n)
+
+	r := rpc.NewGrpcService(service, healthcheck.Checks{
+		"mongo": func(ctx context.Context) error {
+			return db.Client.Ping(ctx, readpref.Primary())
+		},
+	})
+
+	app, err := r.RUN(*cfg)
+	if err != nil {
+		logger.Fatal("Failed to run gRPC service: ", err)
+	}
+	app.Own("log file", func(context.Context) error { return logger.Close() })
+	app.Own("tracing", shutdownTracing)
+	app.Own("mongo", db.Client.Disconnect)
 	logger.Info("Live service started successfully")
 
-	sigChan := make(chan os.Signal, 1)
-	signal.Notify(sigChan, syscall.SIGINT, syscall.SIGTERM)
+	app.ServeHTTP("metrics listener", metrics.Server(*cfg))
 
-	sig := <-sigChan
-	logger.Info("Received signal:", sig)
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
 
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-	defer cancel()
+	if err := app.Wait(ctx); err != nil {
+		logger.Error("gRPC server stopped: ", err)
+	} else {
+		logger.Info("Received shutdown signal")
+	}
 
-	gServer.GracefulStop()
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
 
-	<-ctx.Done()
+	if err := app.Shutdown(shutdownCtx); err != nil {
+		logger.Error("Shutdown incomplete: ", err)
+		return
+	}
 	logger.Info("Graceful shutdown complete.")
 }
@@ -0,0 +1,76 @@
+package notifier
+
+import (
+	"context"
+	"live-service/logger"
+
+	pb "github.com/Bekzodbekk/paris2024_livestream_protos/genproto/livepb"
+	"github.com/sirupsen/logrus"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// ChangeStreamNotifier watches the collection with a Mongo change stream, so
+// it sees inserts made by any replica. Change streams need a replica set.
+type ChangeStreamNotifier struct {
+	collection *mongo.Collection
+}
+
+func NewChangeStreamNotifier(collection *mongo.Collection) *ChangeStreamNotifier {
+	return &ChangeStreamNotifier{
+		collection: collection,
+	}
+}
+
+// Publish is a no-op: the change stream reports the insert itself.
+func (n *ChangeStreamNotifier) Publish(msg *pb.LiveStream) {}
+
+func (n *ChangeStreamNotifier) Subscribe(ctx context.Context, eventId string) (<-chan *pb.LiveStream, error) {
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.D{
+			{Key: "operationType", Value: "insert"},
+			{Key: "fullDocument.event_id", Value: eventId},
+		}}},
+	}
+	cs, err := n.collection.Watch(ctx, pipeline, options.ChangeStream().SetFullDocument(options.Default))
+	if err != nil {
+		return nil, err
+	}
+
+	ch := make(chan *pb.LiveStream, subscriberBuffer)
+	go func() {
+		defer close(ch)
+		defer cs.Close(context.Background())
+
+		for cs.Next(ctx) {
+			var event struct {
+				FullDocument *pb.LiveStream `bson:"fullDocument"`
+				DocumentKey  struct {
+					ID primitive.ObjectID `bson:"_id"`
+				} `bson:"documentKey"`
+			}
+			if err := cs.Decode(&event); err != nil {
+				logger.Error("Failed to decode change event: ", logrus.Fields{
+					"event_id": eventId,
+					"error":    err,
+				})
+				continue
+			}
+			event.FullDocument.Id = event.DocumentKey.ID.Hex()
+			select {
+			case ch <- event.FullDocument:
+			case <-ctx.Done():
+				return
+			}
+		}
+		if err := cs.Err(); err != nil && ctx.Err() == nil {
+			logger.Error("Change stream closed: ", logrus.Fields{
+				"event_id": eventId,
+				"error":    err,
+			})
+		}
+	}()
+	return ch, nil
+}
@@ -0,0 +1,99 @@
+package notifier
+
+import (
+	"context"
+	"live-service/logger"
+	"sync"
+
+	pb "github.com/Bekzodbekk/paris2024_livestream_protos/genproto/livepb"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	DriverMemory = "memory"
+	DriverMongo  = "mongo"
+
+	// subscriberBuffer is how far a subscriber may fall behind before the
+	// broadcaster drops it.
+	subscriberBuffer = 64
+)
+
+// Notifier tells subscribers about live stream entries as they are inserted.
+type Notifier interface {
+	// Publish announces an entry that has just been stored.
+	Publish(msg *pb.LiveStream)
+	// Subscribe returns new entries of the given event until ctx is done.
+	// The channel is closed when the subscription ends.
+	Subscribe(ctx context.Context, eventId string) (<-chan *pb.LiveStream, error)
+}
+
+// Broadcaster is an in-process Notifier. It only sees entries written
+// through this instance, which is enough for a single replica or tests.
+type Broadcaster struct {
+	mu          sync.RWMutex
+	subscribers map[string]map[chan *pb.LiveStream]struct{}
+}
+
+func NewBroadcaster() *Broadcaster {
+	return &Broadcaster{
+		subscribers: make(map[string]map[chan *pb.LiveStream]struct{}),
+	}
+}
+
+func (b *Broadcaster) Publish(msg *pb.LiveStream) {
+	var slow []chan *pb.LiveStream
+
+	b.mu.RLock()
+	for ch := range b.subscribers[msg.EventId] {
+		select {
+		case ch <- msg:
+		default:
+			slow = append(slow, ch)
+		}
+	}
+	b.mu.RUnlock()
+
+	for _, ch := range slow {
+		logger.Warn("Dropping slow live stream subscriber: ", logrus.Fields{
+			"event_id": msg.EventId,
+		})
+		b.remove(msg.EventId, ch)
+	}
+}
+
+func (b *Broadcaster) Subscribe(ctx context.Context, eventId string) (<-chan *pb.LiveStream, error) {
+	ch := make(chan *pb.LiveStream, subscriberBuffer)
+
+	b.mu.Lock()
+	subs, ok := b.subscribers[eventId]
+	if !ok {
+		subs = make(map[chan *pb.LiveStream]struct{})
+		b.subscribers[eventId] = subs
+	}
+	subs[ch] = struct{}{}
+	b.mu.Unlock()
+
+	go func() {
+		<-ctx.Done()
+		b.remove(eventId, ch)
+	}()
+	return ch, nil
+}
+
+func (b *Broadcaster) remove(eventId string, ch chan *pb.LiveStream) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	subs, ok := b.subscribers[eventId]
+	if !ok {
+		return
+	}
+	if _, ok := subs[ch]; !ok {
+		return
+	}
+	delete(subs, ch)
+	if len(subs) == 0 {
+		delete(b.subscribers, eventId)
+	}
+	close(ch)
+}
@@ -0,0 +1,90 @@
+// Package metrics exposes the service's Prometheus metrics on their own HTTP
+// listener, next to the gRPC port.
+package metrics
+
+import (
+	"fmt"
+	"net/http"
+
+	config "live-service/internal/live/pkg/load"
+
+	grpcprom "github.com/grpc-ecosystem/go-grpc-middleware/providers/prometheus"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"go.mongodb.org/mongo-driver/event"
+)
+
+// GRPC counts and times every RPC handled by the server. Its interceptors
+// are installed when the gRPC server is created.
+var GRPC = grpcprom.NewServerMetrics(grpcprom.WithServerHandlingTimeHistogram())
+
+// MessagesIngested counts the live messages stored by CreateLiveStream.
+var MessagesIngested = prometheus.NewCounter(prometheus.CounterOpts{
+	Name: "live_messages_ingested_total",
+	Help: "Live messages stored.",
+})
+
+// Subscribers is the number of open SubscribeLiveStream streams.
+var Subscribers = prometheus.NewGauge(prometheus.GaugeOpts{
+	Name: "live_subscribers",
+	Help: "Open live stream subscriptions.",
+})
+
+var (
+	mongoConnections = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "mongo_pool_connections",
+		Help: "Connections open in the Mongo pool.",
+	})
+	mongoConnectionsInUse = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "mongo_pool_connections_in_use",
+		Help: "Connections checked out of the Mongo pool.",
+	})
+	mongoCheckoutFailures = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "mongo_pool_checkout_failures_total",
+		Help: "Failed attempts to check a connection out of the Mongo pool.",
+	})
+)
+
+func init() {
+	prometheus.MustRegister(
+		GRPC,
+		MessagesIngested,
+		Subscribers,
+		mongoConnections,
+		mongoConnectionsInUse,
+		mongoCheckoutFailures,
+	)
+}
+
+// PoolMonitor keeps the Mongo pool metrics up to date. Install it with
+// options.ClientOptions.SetPoolMonitor.
+func PoolMonitor() *event.PoolMonitor {
+	return &event.PoolMonitor{
+		Event: func(e *event.PoolEvent) {
+			switch e.Type {
+			case event.ConnectionCreated:
+				mongoConnections.Inc()
+			case event.ConnectionClosed:
+				mongoConnections.Dec()
+			case event.GetSucceeded:
+				mongoConnectionsInUse.Inc()
+			case event.ConnectionReturned:
+				mongoConnectionsInUse.Dec()
+			case event.GetFailed:
+				mongoCheckoutFailures.Inc()
+			}
+		},
+	}
+}
+
+// Server returns the listener that answers scrapes on the configured port,
+// or nil when metrics are disabled.
+func Server(cfg config.Config) *http.Server {
+	if !cfg.Metrics.Enabled {
+		return nil
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle(cfg.Metrics.Path, promhttp.Handler())
+	return &http.Server{Addr: fmt.Sprintf(":%d", cfg.Metrics.Port), Handler: mux}
+}
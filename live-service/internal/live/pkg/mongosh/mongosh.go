@@ -4,10 +4,15 @@ import (
 	"context"
 	"fmt"
 	config "live-service/internal/live/pkg/load"
+	"live-service/internal/live/pkg/metrics"
+	"reflect"
 
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/bsoncodec"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
 	"go.mongodb.org/mongo-driver/mongo/readpref"
+	"go.opentelemetry.io/contrib/instrumentation/go.mongodb.org/mongo-driver/mongo/otelmongo"
 )
 
 type Mongo struct {
@@ -19,8 +24,13 @@ var ctx = context.Background()
 
 func NewConnection(cfg *config.Config) (*Mongo, error) {
 
+	registry, err := newRegistry()
+	if err != nil {
+		return nil, err
+	}
+
 	uri := fmt.Sprintf("mongodb://%s:%d", cfg.MongoConfig.Host, cfg.MongoConfig.Port)
-	client, err := mongo.Connect(ctx, options.Client().ApplyURI(uri))
+	client, err := mongo.Connect(ctx, options.Client().ApplyURI(uri).SetRegistry(registry).SetMonitor(otelmongo.NewMonitor()).SetPoolMonitor(metrics.PoolMonitor()))
 	if err != nil {
 		return nil, err
 	}
@@ -33,3 +43,30 @@ func NewConnection(cfg *config.Config) (*Mongo, error) {
 		Collection: *mycoll,
 	}, nil
 }
+
+// newRegistry stores protobuf messages under their json names (event_id,
+// left_side, ...) instead of the lowercased Go field names, so queries and
+// change stream filters can use the same keys as the API.
+func newRegistry() (*bsoncodec.Registry, error) {
+	structCodec, err := bsoncodec.NewStructCodec(bsoncodec.JSONFallbackStructTagParser)
+	if err != nil {
+		return nil, err
+	}
+	registry := bson.NewRegistry()
+	registry.RegisterKindEncoder(reflect.Struct, structCodec)
+	registry.RegisterKindDecoder(reflect.Struct, structCodec)
+	return registry, nil
+}
+
+// EnsureIndexes creates the indexes the timeline queries rely on.
+func EnsureIndexes(m *Mongo) error {
+	_, err := m.Collection.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{
+			{Key: "event_id", Value: 1},
+			{Key: "timestamp", Value: 1},
+			{Key: "_id", Value: 1},
+		},
+		Options: options.Index().SetName("event_id_timestamp"),
+	})
+	return err
+}
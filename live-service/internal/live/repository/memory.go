@@ -0,0 +1,111 @@
+package repository
+
+import (
+	"context"
+	"sort"
+	"strconv"
+	"sync"
+
+	pb "github.com/Bekzodbekk/paris2024_livestream_protos/genproto/livepb"
+)
+
+// InMemoryLiveRepository keeps live stream entries in process. It is meant
+// for local runs and tests where no MongoDB is available.
+type InMemoryLiveRepository struct {
+	mu      sync.RWMutex
+	entries []*pb.LiveStream
+	lastID  int
+}
+
+func NewInMemoryLiveRepository() LiveRepository {
+	return &InMemoryLiveRepository{}
+}
+
+func (db *InMemoryLiveRepository) CreateLiveStream(ctx context.Context, req *pb.LiveStream) (*pb.ResponseMessage, error) {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	db.lastID++
+	req.Id = strconv.Itoa(db.lastID)
+	db.entries = append(db.entries, req)
+	return &pb.ResponseMessage{
+		Status:  "success",
+		Message: "Live stream created successfully",
+	}, nil
+}
+
+func (db *InMemoryLiveRepository) GetLiveStream(ctx context.Context, req *pb.GetStreamRequest) (*pb.LiveStream, error) {
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	for _, entry := range db.entries {
+		if entry.EventId == req.Id {
+			return entry, nil
+		}
+	}
+	return nil, ErrNotFound
+}
+
+func (db *InMemoryLiveRepository) ListLiveStreamByEvent(ctx context.Context, eventId string) ([]*pb.LiveStream, error) {
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	var result []*pb.LiveStream
+	for _, entry := range db.entries {
+		if entry.EventId == eventId {
+			result = append(result, entry)
+		}
+	}
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].Timestamp < result[j].Timestamp
+	})
+	return result, nil
+}
+
+// ListLiveStream pages like the Mongo repository, with the same page size
+// default and maximum, but its page tokens are plain offsets.
+func (db *InMemoryLiveRepository) ListLiveStream(ctx context.Context, req *pb.ListLiveStreamRequest) (*pb.ListLiveStreamResponse, error) {
+	all, _ := db.ListLiveStreamByEvent(ctx, req.EventId)
+
+	var items []*pb.LiveStream
+	for _, entry := range all {
+		if req.Since != "" && entry.Timestamp < req.Since {
+			continue
+		}
+		if req.Until != "" && entry.Timestamp >= req.Until {
+			continue
+		}
+		items = append(items, entry)
+	}
+
+	if req.Latest > 0 {
+		if n := int(min(req.Latest, maxTimelinePageSize)); n < len(items) {
+			items = items[len(items)-n:]
+		}
+		return &pb.ListLiveStreamResponse{LiveStreams: items}, nil
+	}
+
+	offset := 0
+	if req.PageToken != "" {
+		n, err := strconv.Atoi(req.PageToken)
+		if err != nil || n < 0 || n > len(items) {
+			return nil, ErrInvalidPageToken
+		}
+		offset = n
+	}
+	pageSize := int(req.PageSize)
+	if pageSize <= 0 {
+		pageSize = defaultTimelinePageSize
+	}
+	if pageSize > maxTimelinePageSize {
+		pageSize = maxTimelinePageSize
+	}
+
+	resp := pb.ListLiveStreamResponse{}
+	end := min(offset+pageSize, len(items))
+	resp.LiveStreams = items[offset:end]
+	if end < len(items) {
+		resp.NextPageToken = strconv.Itoa(end)
+	}
+	return &resp, nil
+}
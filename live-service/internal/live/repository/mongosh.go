@@ -2,14 +2,31 @@ package repository
 
 import (
 	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"live-service/internal/live/pkg/mongosh"
 	"live-service/logger"
 
 	pb "github.com/Bekzodbekk/paris2024_livestream_protos/genproto/livepb"
 	"github.com/sirupsen/logrus"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
-	"gopkg.in/mgo.v2/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const (
+	defaultTimelinePageSize = 50
+	maxTimelinePageSize     = 500
+)
+
+var (
+	// ErrInvalidPageToken is returned when a timeline page token cannot be decoded.
+	ErrInvalidPageToken = errors.New("invalid page token")
+	// ErrNotFound is returned when no live stream entry matches the request.
+	ErrNotFound = errors.New("live stream not found")
 )
 
 type MongoshLiveRepository struct {
@@ -22,16 +39,22 @@ func NewMongoshLiveRepository(client mongosh.Mongo) LiveRepository {
 	}
 }
 
-func (db *MongoshLiveRepository) CreateLiveStream(req *pb.LiveStream) (*pb.ResponseMessage, error) {
-	_, err := db.Client.Collection.InsertOne(context.Background(), req)
+// CreateLiveStream stores req and sets its id to the hex of the ObjectID
+// Mongo assigned. The id is not stored in the document itself.
+func (db *MongoshLiveRepository) CreateLiveStream(ctx context.Context, req *pb.LiveStream) (*pb.ResponseMessage, error) {
+	req.Id = ""
+	result, err := db.Client.Collection.InsertOne(ctx, req)
 	if err != nil {
-		logger.Error("Failed to create live stream: ", logrus.Fields{
+		logger.ErrorContext(ctx, "Failed to create live stream: ", logrus.Fields{
 			"error":err,
 		})
-		return nil, fmt.Errorf("failed to create live stream: %v", err)
+		return nil, fmt.Errorf("failed to create live stream: %w", err)
+	}
+	if id, ok := result.InsertedID.(primitive.ObjectID); ok {
+		req.Id = id.Hex()
 	}
 
-	logger.Info("Live stream created successfully: ", logrus.Fields{
+	logger.InfoContext(ctx, "Live stream created successfully: ", logrus.Fields{
 		"left_side":req.LeftSide,
 		"right_side":req.RightSide,
 	})
@@ -41,24 +64,191 @@ func (db *MongoshLiveRepository) CreateLiveStream(req *pb.LiveStream) (*pb.Respo
 	}, nil
 }
 
-func (db *MongoshLiveRepository) GetLiveStream(req *pb.GetStreamRequest) (*pb.LiveStream, error) {
+func (db *MongoshLiveRepository) GetLiveStream(ctx context.Context, req *pb.GetStreamRequest) (*pb.LiveStream, error) {
 	var result pb.LiveStream
-	err := db.Client.Collection.FindOne(context.Background(), bson.M{"event_id": req.Id}).Decode(&result)
+	found := db.Client.Collection.FindOne(ctx, bson.M{"event_id": req.Id})
+	err := found.Decode(&result)
+	if err == nil {
+		var raw bson.Raw
+		raw, err = found.Raw()
+		result.Id = entryID(raw)
+	}
 	if err != nil {
 		if err == mongo.ErrNoDocuments {
-			logger.Warn("Live stream not found: ", logrus.Fields{
+			logger.WarnContext(ctx, "Live stream not found: ", logrus.Fields{
 				"event_id":req.Id,
 			})
-			return nil, fmt.Errorf("live stream not found")
+			return nil, ErrNotFound
 		}
-		logger.Error("Failed to get live stream: ", logrus.Fields{
+		logger.ErrorContext(ctx, "Failed to get live stream: ", logrus.Fields{
 				"event_id":req.Id,
 		})
-		return nil, fmt.Errorf("failed to get live stream: %v", err)
+		return nil, fmt.Errorf("failed to get live stream: %w", err)
 	}
-	logger.Info("Get live stream is successfully complete: ", logrus.Fields{
+	logger.InfoContext(ctx, "Get live stream is successfully complete: ", logrus.Fields{
 		"left_side": result.LeftSide,
 		"right_side": result.RightSide,
 	})
 	return &result, nil
 }
+
+func (db *MongoshLiveRepository) ListLiveStreamByEvent(ctx context.Context, eventId string) ([]*pb.LiveStream, error) {
+	opts := options.Find().SetSort(bson.M{"timestamp": 1})
+	cursor, err := db.Client.Collection.Find(ctx, bson.M{"event_id": eventId}, opts)
+	if err != nil {
+		logger.ErrorContext(ctx, "Failed to list live stream: ", logrus.Fields{
+			"event_id": eventId,
+			"error":    err,
+		})
+		return nil, fmt.Errorf("failed to list live stream: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var result []*pb.LiveStream
+	for cursor.Next(ctx) {
+		item := pb.LiveStream{}
+		if err := cursor.Decode(&item); err != nil {
+			logger.ErrorContext(ctx, "Failed to decode live stream: ", logrus.Fields{
+				"event_id": eventId,
+				"error":    err,
+			})
+			return nil, fmt.Errorf("failed to decode live stream: %w", err)
+		}
+		item.Id = entryID(cursor.Current)
+		result = append(result, &item)
+	}
+	if err := cursor.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list live stream: %w", err)
+	}
+
+	logger.InfoContext(ctx, "Live stream listed successfully: ", logrus.Fields{
+		"event_id": eventId,
+		"count":    len(result),
+	})
+	return result, nil
+}
+
+// ListLiveStream returns the timeline of an event ordered by timestamp. It
+// pages forward with an opaque (timestamp, _id) cursor, or returns only the
+// most recent entries when req.Latest is set.
+func (db *MongoshLiveRepository) ListLiveStream(ctx context.Context, req *pb.ListLiveStreamRequest) (*pb.ListLiveStreamResponse, error) {
+
+	conditions := bson.A{bson.M{"event_id": req.EventId}}
+	if req.Since != "" {
+		conditions = append(conditions, bson.M{"timestamp": bson.M{"$gte": req.Since}})
+	}
+	if req.Until != "" {
+		conditions = append(conditions, bson.M{"timestamp": bson.M{"$lt": req.Until}})
+	}
+
+	if req.Latest > 0 {
+		opts := options.Find().
+			SetSort(bson.D{{Key: "timestamp", Value: -1}, {Key: "_id", Value: -1}}).
+			SetLimit(int64(min(req.Latest, maxTimelinePageSize)))
+		items, _, err := db.findTimeline(ctx, bson.M{"$and": conditions}, opts)
+		if err != nil {
+			return nil, err
+		}
+		for i, j := 0, len(items)-1; i < j; i, j = i+1, j-1 {
+			items[i], items[j] = items[j], items[i]
+		}
+		return &pb.ListLiveStreamResponse{LiveStreams: items}, nil
+	}
+
+	pageSize := int64(req.PageSize)
+	if pageSize <= 0 {
+		pageSize = defaultTimelinePageSize
+	}
+	if pageSize > maxTimelinePageSize {
+		pageSize = maxTimelinePageSize
+	}
+
+	if req.PageToken != "" {
+		cursor, err := decodeTimelineCursor(req.PageToken)
+		if err != nil {
+			return nil, err
+		}
+		conditions = append(conditions, bson.M{"$or": bson.A{
+			bson.M{"timestamp": bson.M{"$gt": cursor.Timestamp}},
+			bson.M{"timestamp": cursor.Timestamp, "_id": bson.M{"$gt": cursor.ID}},
+		}})
+	}
+
+	opts := options.Find().
+		SetSort(bson.D{{Key: "timestamp", Value: 1}, {Key: "_id", Value: 1}}).
+		SetLimit(pageSize + 1)
+	items, ids, err := db.findTimeline(ctx, bson.M{"$and": conditions}, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := pb.ListLiveStreamResponse{}
+	if int64(len(items)) > pageSize {
+		items, ids = items[:pageSize], ids[:pageSize]
+		last := items[len(items)-1]
+		resp.NextPageToken = encodeTimelineCursor(timelineCursor{Timestamp: last.Timestamp, ID: ids[len(ids)-1]})
+	}
+	resp.LiveStreams = items
+
+	logger.InfoContext(ctx, "Live stream timeline listed successfully: ", logrus.Fields{
+		"event_id": req.EventId,
+		"count":    len(items),
+	})
+	return &resp, nil
+}
+
+func (db *MongoshLiveRepository) findTimeline(ctx context.Context, filter bson.M, opts *options.FindOptions) ([]*pb.LiveStream, []primitive.ObjectID, error) {
+	cursor, err := db.Client.Collection.Find(ctx, filter, opts)
+	if err != nil {
+		logger.ErrorContext(ctx, "Failed to list live stream timeline: ", logrus.Fields{
+			"error": err,
+		})
+		return nil, nil, fmt.Errorf("failed to list live stream timeline: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var items []*pb.LiveStream
+	var ids []primitive.ObjectID
+	for cursor.Next(ctx) {
+		item := pb.LiveStream{}
+		if err := cursor.Decode(&item); err != nil {
+			return nil, nil, fmt.Errorf("failed to decode live stream: %w", err)
+		}
+		id, _ := cursor.Current.Lookup("_id").ObjectIDOK()
+		item.Id = id.Hex()
+		items = append(items, &item)
+		ids = append(ids, id)
+	}
+	if err := cursor.Err(); err != nil {
+		return nil, nil, fmt.Errorf("failed to list live stream timeline: %w", err)
+	}
+	return items, ids, nil
+}
+
+// entryID is the id of a stored entry: the hex of its ObjectID.
+func entryID(doc bson.Raw) string {
+	id, _ := doc.Lookup("_id").ObjectIDOK()
+	return id.Hex()
+}
+
+type timelineCursor struct {
+	Timestamp string             `json:"t"`
+	ID        primitive.ObjectID `json:"i"`
+}
+
+func encodeTimelineCursor(c timelineCursor) string {
+	b, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+func decodeTimelineCursor(token string) (timelineCursor, error) {
+	c := timelineCursor{}
+	b, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return c, ErrInvalidPageToken
+	}
+	if err := json.Unmarshal(b, &c); err != nil {
+		return c, ErrInvalidPageToken
+	}
+	return c, nil
+}
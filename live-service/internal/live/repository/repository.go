@@ -1,10 +1,14 @@
 package repository
 
 import (
+	"context"
+
 	pb "github.com/Bekzodbekk/paris2024_livestream_protos/genproto/livepb"
 )
 
 type LiveRepository interface {
-	CreateLiveStream(req *pb.LiveStream) (*pb.ResponseMessage, error)
-	GetLiveStream(req *pb.GetStreamRequest) (*pb.LiveStream, error)
+	CreateLiveStream(ctx context.Context, req *pb.LiveStream) (*pb.ResponseMessage, error)
+	GetLiveStream(ctx context.Context, req *pb.GetStreamRequest) (*pb.LiveStream, error)
+	ListLiveStreamByEvent(ctx context.Context, eventId string) ([]*pb.LiveStream, error)
+	ListLiveStream(ctx context.Context, req *pb.ListLiveStreamRequest) (*pb.ListLiveStreamResponse, error)
 }
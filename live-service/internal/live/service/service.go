@@ -2,26 +2,106 @@ package service
 
 import (
 	"context"
+	"live-service/internal/live/notifier"
+	"live-service/internal/live/pkg/metrics"
 	"live-service/internal/live/repository"
+	"live-service/logger"
 
 	pb "github.com/Bekzodbekk/paris2024_livestream_protos/genproto/livepb"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 type LiveService struct {
 	pb.UnimplementedLiveStreamServiceServer
-	Repo repository.LiveRepository
+	Repo     repository.LiveRepository
+	Notifier notifier.Notifier
 }
 
-func NewEventService(repo repository.LiveRepository) *LiveService {
+func NewEventService(repo repository.LiveRepository, n notifier.Notifier) *LiveService {
 	return &LiveService{
-		Repo: repo,
+		Repo:     repo,
+		Notifier: n,
 	}
 }
 
 func (s *LiveService) CreateLiveStream(ctx context.Context, req *pb.LiveStream) (*pb.ResponseMessage, error) {
-	return s.Repo.CreateLiveStream(req)
+	resp, err := s.Repo.CreateLiveStream(ctx, req)
+	if err != nil {
+		return nil, statusError(err)
+	}
+	metrics.MessagesIngested.Inc()
+	s.Notifier.Publish(req)
+	return resp, nil
 }
 
 func (s *LiveService) GetLiveStream(ctx context.Context, req *pb.GetStreamRequest) (*pb.LiveStream, error) {
-	return s.Repo.GetLiveStream(req)
+	resp, err := s.Repo.GetLiveStream(ctx, req)
+	return resp, statusError(err)
+}
+
+func (s *LiveService) ListLiveStream(ctx context.Context, req *pb.ListLiveStreamRequest) (*pb.ListLiveStreamResponse, error) {
+	if req.EventId == "" {
+		return nil, status.Error(codes.InvalidArgument, "event_id is required")
+	}
+	resp, err := s.Repo.ListLiveStream(ctx, req)
+	return resp, statusError(err)
+}
+
+// SubscribeLiveStream replays the stored entries of an event, unless
+// req.LiveOnly is set, and then keeps streaming new ones until the client
+// goes away.
+func (s *LiveService) SubscribeLiveStream(req *pb.GetStreamRequest, stream pb.LiveStreamService_SubscribeLiveStreamServer) error {
+	ctx := stream.Context()
+
+	// Subscribe before reading the history so nothing inserted in between is lost.
+	updates, err := s.Notifier.Subscribe(ctx, req.Id)
+	if err != nil {
+		logger.ErrorContext(ctx, "Failed to subscribe to live stream: ", logrus.Fields{
+			"event_id": req.Id,
+			"error":    err,
+		})
+		return status.Errorf(codes.Unavailable, "failed to subscribe to live stream: %v", err)
+	}
+	metrics.Subscribers.Inc()
+	defer metrics.Subscribers.Dec()
+
+	var history []*pb.LiveStream
+	if !req.LiveOnly {
+		history, err = s.Repo.ListLiveStreamByEvent(ctx, req.Id)
+		if err != nil {
+			return status.Errorf(codes.Internal, "failed to replay live stream: %v", err)
+		}
+	}
+
+	replayed := make(map[string]struct{}, len(history))
+	for _, msg := range history {
+		if err := stream.Send(msg); err != nil {
+			return err
+		}
+		replayed[msg.Id] = struct{}{}
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case msg, ok := <-updates:
+			if !ok {
+				if ctx.Err() != nil {
+					return nil
+				}
+				return status.Error(codes.Unavailable, "live stream feed closed")
+			}
+			// An entry inserted while the history was read arrives twice.
+			if _, ok := replayed[msg.Id]; ok {
+				delete(replayed, msg.Id)
+				continue
+			}
+			if err := stream.Send(msg); err != nil {
+				return err
+			}
+		}
+	}
 }
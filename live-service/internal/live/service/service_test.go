@@ -0,0 +1,142 @@
+package service
+
+import (
+	"context"
+	"live-service/internal/live/notifier"
+	"live-service/internal/live/repository"
+	"testing"
+	"time"
+
+	pb "github.com/Bekzodbekk/paris2024_livestream_protos/genproto/livepb"
+	"google.golang.org/grpc"
+)
+
+type fakeStream struct {
+	grpc.ServerStream
+	ctx  context.Context
+	sent chan *pb.LiveStream
+}
+
+func (f *fakeStream) Context() context.Context {
+	return f.ctx
+}
+
+func (f *fakeStream) Send(msg *pb.LiveStream) error {
+	f.sent <- msg
+	return nil
+}
+
+func receive(t *testing.T, stream *fakeStream) *pb.LiveStream {
+	t.Helper()
+	select {
+	case msg := <-stream.sent:
+		return msg
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for a live stream entry")
+		return nil
+	}
+}
+
+func TestSubscribeLiveStreamReplaysThenStreams(t *testing.T) {
+	svc := NewEventService(repository.NewInMemoryLiveRepository(), notifier.NewBroadcaster())
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	svc.CreateLiveStream(ctx, &pb.LiveStream{EventId: "1", LeftSide: "FRA", Timestamp: "2024-08-01T10:00:00Z"})
+	svc.CreateLiveStream(ctx, &pb.LiveStream{EventId: "2", LeftSide: "USA", Timestamp: "2024-08-01T10:00:01Z"})
+
+	stream := &fakeStream{ctx: ctx, sent: make(chan *pb.LiveStream, 8)}
+	done := make(chan error, 1)
+	go func() {
+		done <- svc.SubscribeLiveStream(&pb.GetStreamRequest{Id: "1"}, stream)
+	}()
+
+	if msg := receive(t, stream); msg.LeftSide != "FRA" || msg.Id == "" {
+		t.Fatalf("expected replayed entry with an id, got %v", msg)
+	}
+
+	// Give the subscription time to finish replaying before publishing.
+	time.Sleep(50 * time.Millisecond)
+	svc.CreateLiveStream(ctx, &pb.LiveStream{EventId: "1", LeftSide: "ESP", Timestamp: "2024-08-01T10:00:02Z"})
+
+	if msg := receive(t, stream); msg.LeftSide != "ESP" {
+		t.Fatalf("expected live entry, got %v", msg)
+	}
+
+	cancel()
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatalf("expected clean shutdown, got %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("subscription did not stop after cancellation")
+	}
+}
+
+func TestSubscribeLiveStreamSendsRepeatedEntries(t *testing.T) {
+	svc := NewEventService(repository.NewInMemoryLiveRepository(), notifier.NewBroadcaster())
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	entry := func() *pb.LiveStream {
+		return &pb.LiveStream{EventId: "1", LeftSide: "FRA", Action: map[string]string{"point": "left"}, Timestamp: "2024-08-01T10:00:00Z"}
+	}
+	svc.CreateLiveStream(ctx, entry())
+
+	stream := &fakeStream{ctx: ctx, sent: make(chan *pb.LiveStream, 8)}
+	go svc.SubscribeLiveStream(&pb.GetStreamRequest{Id: "1"}, stream)
+	first := receive(t, stream)
+
+	// A second point scored with the same details is a new entry, not the
+	// replayed one arriving again.
+	time.Sleep(50 * time.Millisecond)
+	svc.CreateLiveStream(ctx, entry())
+
+	if msg := receive(t, stream); msg.Id == first.Id {
+		t.Fatalf("expected a new entry, got the replayed one again: %v", msg)
+	}
+}
+
+func TestSubscribeLiveStreamLiveOnly(t *testing.T) {
+	svc := NewEventService(repository.NewInMemoryLiveRepository(), notifier.NewBroadcaster())
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	svc.CreateLiveStream(ctx, &pb.LiveStream{EventId: "1", LeftSide: "FRA", Timestamp: "2024-08-01T10:00:00Z"})
+
+	stream := &fakeStream{ctx: ctx, sent: make(chan *pb.LiveStream, 8)}
+	go svc.SubscribeLiveStream(&pb.GetStreamRequest{Id: "1", LiveOnly: true}, stream)
+
+	time.Sleep(50 * time.Millisecond)
+	svc.CreateLiveStream(ctx, &pb.LiveStream{EventId: "1", LeftSide: "ESP", Timestamp: "2024-08-01T10:00:01Z"})
+
+	if msg := receive(t, stream); msg.LeftSide != "ESP" {
+		t.Fatalf("expected only the live entry, got %v", msg)
+	}
+}
+
+func TestListLiveStreamCapsPageSize(t *testing.T) {
+	svc := NewEventService(repository.NewInMemoryLiveRepository(), notifier.NewBroadcaster())
+	ctx := context.Background()
+
+	for i := 0; i < 501; i++ {
+		svc.CreateLiveStream(ctx, &pb.LiveStream{EventId: "1", Timestamp: time.Unix(int64(i), 0).UTC().Format(time.RFC3339)})
+	}
+
+	resp, err := svc.ListLiveStream(ctx, &pb.ListLiveStreamRequest{EventId: "1", PageSize: 1000})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(resp.LiveStreams) != 500 || resp.NextPageToken == "" {
+		t.Fatalf("expected a capped page of 500 and a next page, got %d entries and token %q", len(resp.LiveStreams), resp.NextPageToken)
+	}
+
+	resp, err = svc.ListLiveStream(ctx, &pb.ListLiveStreamRequest{EventId: "1", Latest: 1000})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(resp.LiveStreams) != 500 {
+		t.Fatalf("expected latest to be capped at 500, got %d", len(resp.LiveStreams))
+	}
+}
@@ -0,0 +1,40 @@
+package logger
+
+import (
+	"context"
+
+	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// traceHook adds the ids of the span active in an entry's context, so log
+// lines can be looked up from a trace and the other way round.
+type traceHook struct{}
+
+func (traceHook) Levels() []logrus.Level {
+	return logrus.AllLevels
+}
+
+func (traceHook) Fire(entry *logrus.Entry) error {
+	if entry.Context == nil {
+		return nil
+	}
+	span := trace.SpanContextFromContext(entry.Context)
+	if span.IsValid() {
+		entry.Data["trace_id"] = span.TraceID().String()
+		entry.Data["span_id"] = span.SpanID().String()
+	}
+	return nil
+}
+
+func InfoContext(ctx context.Context, msg ...interface{}) {
+	logrus.WithContext(ctx).Infoln(msg...)
+}
+
+func WarnContext(ctx context.Context, msg ...interface{}) {
+	logrus.WithContext(ctx).Warnln(msg...)
+}
+
+func ErrorContext(ctx context.Context, msg ...interface{}) {
+	logrus.WithContext(ctx).Errorln(msg...)
+}
@@ -2,19 +2,21 @@ package main
 
 import (
 	"context"
-	"os"
 	"os/signal"
-	"sync"
 	"syscall"
 	"time"
+	athleteService "medal-service/internal/medal/pkg/athlete-service"
+	countryService "medal-service/internal/medal/pkg/country-service"
+	eventService "medal-service/internal/medal/pkg/event-service"
+	"medal-service/internal/medal/pkg/healthcheck"
 	config "medal-service/internal/medal/pkg/load"
+	"medal-service/internal/medal/pkg/metrics"
+	"medal-service/internal/medal/pkg/tracing"
 	pq "medal-service/internal/medal/pkg/postgres"
 	rpc "medal-service/internal/medal/pkg/register-service"
 	medalRepo "medal-service/internal/medal/repository"
 	medalService "medal-service/internal/medal/service"
 	"medal-service/logger"
-
-	"google.golang.org/grpc"
 )
 
 func main() {
@@ -27,40 +29,75 @@ func main() {
 	}
 	logger.Info("Configuration loaded successfully")
 
+	shutdownTracing, err := tracing.Init(context.Background(), "medal-service", *cfg)
+	if err != nil {
+		logger.Fatal("Failed to set up tracing: ", err)
+	}
+
 	db, err := pq.InitDB(*cfg)
 	if err != nil {
 		logger.Fatal("Failed to connect to database: ", err)
 	}
 	logger.Info("Connected to the database successfully")
+	metrics.RegisterDB(db, cfg.Postgres.Database)
 
-	repo := medalRepo.NewPostgresMedalRepo(db)
-	service := medalService.NewMedalService(repo)
+	countryClient, countryConn, err := countryService.DialWithCountryService(*cfg)
+	if err != nil {
+		logger.Fatal("Failed to dial country service: ", err)
+	}
+	eventClient, eventConn, err := eventService.DialWithEventService(*cfg)
+	if err != nil {
+		logger.Fatal("Failed to dial event service: ", err)
+	}
+	athleteClient, athleteConn, err := athleteService.DialWithAthleteService(*cfg)
+	if err != nil {
+		logger.Fatal("Failed to dial athlete service: ", err)
+	}
 
-	var wg sync.WaitGroup
-	wg.Add(1)
+	repo := medalRepo.NewPostgresMedalRepo(db)
+	service := medalService.NewMedalService(repo, medalService.Clients{
+		Country: countryClient,
+		Event:   eventClient,
+		Athlete: athleteClient,
+	}, medalService.AwardRules{
+		TeamSports:         cfg.Awards.TeamSports,
+		TeamKeywords:       cfg.Awards.TeamKeywords,
+		SharedBronzeSports: cfg.Awards.SharedBronzeSports,
+	})
 
-	r := rpc.NewGrpcService(service)
+	r := rpc.NewGrpcService(service, healthcheck.Checks{
+		"postgres": db.PingContext,
+	})
 
-	gServer := grpc.NewServer()
-	go func() {
-		defer wg.Done()
-		if err := r.RUN(*cfg); err != nil {
-			logger.Fatal("Failed to run gRPC service: ", err)
-		}
-	}()
+	app, err := r.RUN(*cfg)
+	if err != nil {
+		logger.Fatal("Failed to run gRPC service: ", err)
+	}
+	app.Own("log file", func(context.Context) error { return logger.Close() })
+	app.Own("tracing", shutdownTracing)
+	app.OwnCloser("postgres", db)
+	app.OwnCloser("country-service connection", countryConn)
+	app.OwnCloser("event-service connection", eventConn)
+	app.OwnCloser("athlete-service connection", athleteConn)
 	logger.Info("Medal service started successfully")
 
-	sigChan := make(chan os.Signal, 1)
-	signal.Notify(sigChan, syscall.SIGINT, syscall.SIGTERM)
+	app.ServeHTTP("metrics listener", metrics.Server(*cfg))
 
-	sig := <-sigChan
-	logger.Info("Received signal:", sig)
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
 
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-	defer cancel()
+	if err := app.Wait(ctx); err != nil {
+		logger.Error("gRPC server stopped: ", err)
+	} else {
+		logger.Info("Received shutdown signal")
+	}
 
-	gServer.GracefulStop()
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
 
-	<-ctx.Done()
+	if err := app.Shutdown(shutdownCtx); err != nil {
+		logger.Error("Shutdown incomplete: ", err)
+		return
+	}
 	logger.Info("Graceful shutdown complete.")
 }
@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS medal_members;
+DELETE FROM medals WHERE team_id IS NOT NULL;
+ALTER TABLE medals DROP CONSTRAINT IF EXISTS medals_athlete_or_team;
+ALTER TABLE medals ALTER COLUMN athlete_id SET NOT NULL;
+ALTER TABLE medals DROP COLUMN IF EXISTS team_id;
@@ -0,0 +1,14 @@
+-- A medal goes to an athlete or to a team. A team medal is one row, so the
+-- medal table counts it once; its roster at the time of the award is kept in
+-- medal_members, which credits every member.
+ALTER TABLE medals ADD COLUMN team_id UUID;
+ALTER TABLE medals ALTER COLUMN athlete_id DROP NOT NULL;
+ALTER TABLE medals ADD CONSTRAINT medals_athlete_or_team CHECK ((athlete_id IS NULL) <> (team_id IS NULL));
+
+CREATE TABLE IF NOT EXISTS medal_members (
+    medal_id UUID NOT NULL REFERENCES medals(id) ON DELETE CASCADE,
+    athlete_id UUID NOT NULL,
+    PRIMARY KEY (medal_id, athlete_id)
+);
+
+CREATE INDEX IF NOT EXISTS medal_members_athlete_id_idx ON medal_members (athlete_id);
@@ -0,0 +1,26 @@
+package athleteservice
+
+import (
+	"fmt"
+	config "medal-service/internal/medal/pkg/load"
+
+	pb "github.com/Bekzodbekk/paris2024_livestream_protos/genproto/athletepb"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// DialWithAthleteService connects to the athlete service. The caller owns the
+// returned connection and closes it on shutdown.
+func DialWithAthleteService(cfg config.Config) (pb.AthleteServiceClient, *grpc.ClientConn, error) {
+
+	target := fmt.Sprintf("%s:%d", cfg.AthleteService.Host, cfg.AthleteService.Port)
+	conn, err := grpc.NewClient(target,
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithStatsHandler(otelgrpc.NewClientHandler()),
+	)
+	if err != nil {
+		return nil, nil, err
+	}
+	return pb.NewAthleteServiceClient(conn), conn, nil
+}
@@ -0,0 +1,26 @@
+package countryservice
+
+import (
+	"fmt"
+	config "medal-service/internal/medal/pkg/load"
+
+	pb "github.com/Bekzodbekk/paris2024_livestream_protos/genproto/countrypb"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// DialWithCountryService connects to the country service. The caller owns the
+// returned connection and closes it on shutdown.
+func DialWithCountryService(cfg config.Config) (pb.CountryServiceClient, *grpc.ClientConn, error) {
+
+	target := fmt.Sprintf("%s:%d", cfg.CountryService.Host, cfg.CountryService.Port)
+	conn, err := grpc.NewClient(target,
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithStatsHandler(otelgrpc.NewClientHandler()),
+	)
+	if err != nil {
+		return nil, nil, err
+	}
+	return pb.NewCountryServiceClient(conn), conn, nil
+}
@@ -0,0 +1,26 @@
+package eventservice
+
+import (
+	"fmt"
+	config "medal-service/internal/medal/pkg/load"
+
+	pb "github.com/Bekzodbekk/paris2024_livestream_protos/genproto/eventpb"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// DialWithEventService connects to the event service. The caller owns the
+// returned connection and closes it on shutdown.
+func DialWithEventService(cfg config.Config) (pb.EventServiceClient, *grpc.ClientConn, error) {
+
+	target := fmt.Sprintf("%s:%d", cfg.EventService.Host, cfg.EventService.Port)
+	conn, err := grpc.NewClient(target,
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithStatsHandler(otelgrpc.NewClientHandler()),
+	)
+	if err != nil {
+		return nil, nil, err
+	}
+	return pb.NewEventServiceClient(conn), conn, nil
+}
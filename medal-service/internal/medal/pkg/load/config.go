@@ -10,11 +10,47 @@ type PostgresConfig struct {
 	Database string
 }
 
+type ServiceConfig struct {
+	Host string
+	Port int
+}
+
+type AwardConfig struct {
+	TeamSports         []string
+	TeamKeywords       []string
+	SharedBronzeSports []string
+}
+
+// TracingConfig selects where spans are exported: otlp (a collector listening
+// on Endpoint), stdout or none.
+type TracingConfig struct {
+	Exporter    string
+	Endpoint    string
+	SampleRatio float64
+}
+
+// MetricsConfig controls the Prometheus listener. It serves Path on its own
+// Port so scrapes never go through the gRPC server.
+type MetricsConfig struct {
+	Enabled bool
+	Port    int
+	Path    string
+}
+
 type Config struct {
 	Postgres PostgresConfig
 
 	MedalServiceHost string
 	MedalServicePort int
+
+	CountryService ServiceConfig
+	EventService   ServiceConfig
+	AthleteService ServiceConfig
+
+	Awards AwardConfig
+
+	Tracing TracingConfig
+	Metrics MetricsConfig
 }
 
 func Load(path string) (*Config, error) {
@@ -23,6 +59,10 @@ func Load(path string) (*Config, error) {
 	viper.SetConfigType("yaml")
 	viper.AutomaticEnv()
 
+	viper.SetDefault("tracing.exporter", "none")
+	viper.SetDefault("tracing.sample_ratio", 1.0)
+	viper.SetDefault("metrics.path", "/metrics")
+
 	if err := viper.ReadInConfig(); err != nil {
 		return nil, err
 	}
@@ -37,6 +77,37 @@ func Load(path string) (*Config, error) {
 		},
 		MedalServiceHost: viper.GetString("server.host"),
 		MedalServicePort: viper.GetInt("server.port"),
+
+		CountryService: ServiceConfig{
+			Host: viper.GetString("services.country_service.host"),
+			Port: viper.GetInt("services.country_service.port"),
+		},
+		EventService: ServiceConfig{
+			Host: viper.GetString("services.event_service.host"),
+			Port: viper.GetInt("services.event_service.port"),
+		},
+		AthleteService: ServiceConfig{
+			Host: viper.GetString("services.athlete_service.host"),
+			Port: viper.GetInt("services.athlete_service.port"),
+		},
+
+		Awards: AwardConfig{
+			TeamSports:         viper.GetStringSlice("awards.team_sports"),
+			TeamKeywords:       viper.GetStringSlice("awards.team_keywords"),
+			SharedBronzeSports: viper.GetStringSlice("awards.shared_bronze_sports"),
+		},
+
+		Tracing: TracingConfig{
+			Exporter:    viper.GetString("tracing.exporter"),
+			Endpoint:    viper.GetString("tracing.endpoint"),
+			SampleRatio: viper.GetFloat64("tracing.sample_ratio"),
+		},
+
+		Metrics: MetricsConfig{
+			Enabled: viper.GetBool("metrics.enabled"),
+			Port:    viper.GetInt("metrics.port"),
+			Path:    viper.GetString("metrics.path"),
+		},
 	}
 	return &cfg, nil
 }
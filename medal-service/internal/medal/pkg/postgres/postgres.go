@@ -5,7 +5,9 @@ import (
 	"fmt"
 	config "medal-service/internal/medal/pkg/load"
 
+	"github.com/XSAM/otelsql"
 	_ "github.com/lib/pq"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
 )
 
 func InitDB(cfg config.Config) (*sql.DB, error) {
@@ -25,7 +27,7 @@ func InitDB(cfg config.Config) (*sql.DB, error) {
 		cfg.Postgres.Database,
 	)
 
-	db, err := sql.Open("postgres", target)
+	db, err := otelsql.Open("postgres", target, otelsql.WithAttributes(semconv.DBSystemPostgreSQL))
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,36 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	pb "github.com/Bekzodbekk/paris2024_livestream_protos/genproto/medalspb"
+)
+
+// lockEventMedals takes a transaction-scoped advisory lock on the event, so
+// awards for one event are checked and written one at a time, and returns the
+// medals the event already holds. exclude leaves out a medal being updated.
+func lockEventMedals(ctx context.Context, tx *sql.Tx, eventID, exclude string) ([]*pb.Medal, error) {
+	if _, err := tx.ExecContext(ctx, `SELECT pg_advisory_xact_lock(hashtext($1))`, eventID); err != nil {
+		return nil, err
+	}
+
+	rows, err := tx.QueryContext(ctx, `
+		SELECT `+medalFields+`
+		FROM medals
+		WHERE event_id = $1 AND deleted_at = 0 AND id::text <> $2`, eventID, exclude)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var medals []*pb.Medal
+	for rows.Next() {
+		var medal pb.Medal
+		if err := rows.Scan(medalDest(&medal)...); err != nil {
+			return nil, err
+		}
+		medals = append(medals, &medal)
+	}
+	return medals, rows.Err()
+}
@@ -0,0 +1,90 @@
+package repository
+
+import (
+	"errors"
+	"fmt"
+	"shared/paging"
+	"time"
+
+	pb "github.com/Bekzodbekk/paris2024_livestream_protos/genproto/medalspb"
+	"github.com/lib/pq"
+)
+
+// ErrInvalidFilter is returned when a medal filter cannot be turned into a query.
+var ErrInvalidFilter = errors.New("invalid medal filter")
+
+// Medal types as stored in medals.type.
+const (
+	MedalGold   = 0
+	MedalSilver = 1
+	MedalBronze = 2
+)
+
+// medalFilter builds the WHERE clause of GetMedalByFilter. Every field of the
+// request is optional; an empty list means "any value", several values of one
+// field are OR-ed together and different fields are AND-ed.
+func medalFilter(req *pb.GetMedalByFilterRequest) (*paging.Conditions, error) {
+	filter := paging.NewConditions("deleted_at = 0")
+
+	if len(req.CountryIds) > 0 {
+		filter.Add("country_id = ANY($%d)", pq.Array(req.CountryIds))
+	}
+	if len(req.EventIds) > 0 {
+		filter.Add("event_id = ANY($%d)", pq.Array(req.EventIds))
+	}
+	if len(req.AthleteIds) > 0 {
+		filter.Add(creditsAthletes, pq.Array(req.AthleteIds))
+	}
+	if len(req.TeamIds) > 0 {
+		filter.Add("team_id = ANY($%d)", pq.Array(req.TeamIds))
+	}
+	if len(req.Types) > 0 {
+		types := make([]int64, len(req.Types))
+		for i, t := range req.Types {
+			if t < MedalGold || t > MedalBronze {
+				return nil, fmt.Errorf("%w: unknown medal type %d", ErrInvalidFilter, t)
+			}
+			types[i] = int64(t)
+		}
+		filter.Add("type = ANY($%d)", pq.Int64Array(types))
+	}
+
+	from, err := parseFilterTime("created_from", req.CreatedFrom)
+	if err != nil {
+		return nil, err
+	}
+	to, err := parseFilterTime("created_to", req.CreatedTo)
+	if err != nil {
+		return nil, err
+	}
+	if !from.IsZero() && !to.IsZero() && to.Before(from) {
+		return nil, fmt.Errorf("%w: created_to is before created_from", ErrInvalidFilter)
+	}
+	if !from.IsZero() {
+		filter.Add("created_at >= $%d", from)
+	}
+	if !to.IsZero() {
+		filter.Add("created_at < $%d", to)
+	}
+
+	return filter, nil
+}
+
+// parseFilterTime accepts an RFC 3339 timestamp or a plain date. A plain date
+// used as the upper bound covers the whole day.
+func parseFilterTime(name, value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	t, err := time.Parse("2006-01-02", value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%w: %s must be an RFC 3339 timestamp or a YYYY-MM-DD date", ErrInvalidFilter, name)
+	}
+	if name == "created_to" {
+		t = t.AddDate(0, 0, 1)
+	}
+	return t, nil
+}
@@ -0,0 +1,144 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"medal-service/logger"
+	"time"
+
+	pb "github.com/Bekzodbekk/paris2024_livestream_protos/genproto/medalspb"
+	"github.com/sirupsen/logrus"
+)
+
+// What FinalizeEvent does with each medal.
+const (
+	AwardCreate = "create"
+	AwardKeep   = "keep"
+	AwardRemove = "remove"
+)
+
+// FinalizeEvent reconciles the medals of an event with awards, the medals its
+// final results call for. Missing medals are created, medals the results no
+// longer back are deleted and the rest are kept, so finalizing twice changes
+// nothing. Each award is checked against the ones before it, as the medals
+// the event will hold, so the medals being replaced do not count against it.
+// With dryRun the changes are reported but not written.
+func (r *MedalRepo) FinalizeEvent(ctx context.Context, eventID string, awards []*pb.CreateMedalRequest, check AwardCheck, dryRun bool) (*pb.FinalizeEventResponse, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		logger.ErrorContext(ctx, "Failed to begin medal transaction", logrus.Fields{
+			"error":    err,
+			"event_id": eventID,
+		})
+		return nil, fmt.Errorf("failed to finalize event: %w", err)
+	}
+	defer tx.Rollback()
+
+	held, err := lockEventMedals(ctx, tx, eventID, "")
+	if err != nil {
+		logger.ErrorContext(ctx, "Failed to lock event medals", logrus.Fields{
+			"error":    err,
+			"event_id": eventID,
+		})
+		return nil, fmt.Errorf("failed to finalize event: %w", err)
+	}
+
+	resp := &pb.FinalizeEventResponse{EventId: eventID}
+	matched := make(map[string]bool)
+	var finalized []*pb.Medal
+	for _, award := range awards {
+		if err := check(award, finalized); err != nil {
+			logger.WarnContext(ctx, "Medal award rejected", logrus.Fields{
+				"error":    err,
+				"event_id": eventID,
+			})
+			return nil, err
+		}
+
+		item := &pb.MedalAward{
+			Type:      award.Type,
+			CountryId: award.CountryId,
+			AthleteId: award.AthleteId,
+			TeamId:    award.TeamId,
+			Action:    AwardCreate,
+		}
+		medal := &pb.Medal{
+			CountryId: award.CountryId,
+			Type:      award.Type,
+			EventId:   award.EventId,
+			AthleteId: award.AthleteId,
+			TeamId:    award.TeamId,
+			MemberIds: award.MemberIds,
+		}
+		for _, other := range held {
+			if !matched[other.Id] && other.AthleteId == award.AthleteId && other.TeamId == award.TeamId && other.CountryId == award.CountryId && other.Type == award.Type {
+				matched[other.Id] = true
+				item.MedalId, item.Action = other.Id, AwardKeep
+				medal = other
+				break
+			}
+		}
+		if item.Action == AwardKeep {
+			resp.Kept++
+		} else {
+			if !dryRun {
+				if err := tx.QueryRowContext(ctx, insertMedal, medalArgs(award)...).Scan(medalDest(medal)...); err != nil {
+					logger.ErrorContext(ctx, "Failed to create medal", logrus.Fields{
+						"error":    err,
+						"event_id": eventID,
+					})
+					return nil, fmt.Errorf("failed to finalize event: %w", err)
+				}
+				item.MedalId = medal.Id
+			}
+			resp.Created++
+		}
+		finalized = append(finalized, medal)
+		resp.Awards = append(resp.Awards, item)
+	}
+
+	now := time.Now().Unix()
+	for _, medal := range held {
+		if matched[medal.Id] {
+			continue
+		}
+		if !dryRun {
+			if _, err := tx.ExecContext(ctx, `UPDATE medals SET deleted_at = $1 WHERE id = $2`, now, medal.Id); err != nil {
+				logger.ErrorContext(ctx, "Failed to delete medal", logrus.Fields{
+					"error": err,
+					"id":    medal.Id,
+				})
+				return nil, fmt.Errorf("failed to finalize event: %w", err)
+			}
+		}
+		resp.Removed++
+		resp.Awards = append(resp.Awards, &pb.MedalAward{
+			MedalId:   medal.Id,
+			Type:      medal.Type,
+			CountryId: medal.CountryId,
+			AthleteId: medal.AthleteId,
+			TeamId:    medal.TeamId,
+			Action:    AwardRemove,
+		})
+	}
+
+	if !dryRun {
+		if err := tx.Commit(); err != nil {
+			logger.ErrorContext(ctx, "Failed to commit medals", logrus.Fields{
+				"error":    err,
+				"event_id": eventID,
+			})
+			return nil, fmt.Errorf("failed to finalize event: %w", err)
+		}
+		resp.Committed = true
+	}
+
+	logger.InfoContext(ctx, "Event finalized", logrus.Fields{
+		"event_id": eventID,
+		"created":  resp.Created,
+		"kept":     resp.Kept,
+		"removed":  resp.Removed,
+		"dry_run":  dryRun,
+	})
+	return resp, nil
+}
@@ -1,15 +1,16 @@
 package repository
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
 	"medal-service/logger"
+	"shared/paging"
 	"time"
 
 	pb "github.com/Bekzodbekk/paris2024_livestream_protos/genproto/medalspb"
+	"github.com/lib/pq"
 	"github.com/sirupsen/logrus"
-
-	_ "github.com/lib/pq"
 )
 
 type MedalRepo struct {
@@ -20,22 +21,50 @@ func NewPostgresMedalRepo(db *sql.DB) MedalRepository {
 	return &MedalRepo{db: db}
 }
 
-func (r *MedalRepo) CreateMedal(req *pb.CreateMedalRequest) (*pb.CreateMedalResponse, error) {
-	query := `
-		INSERT INTO medals (country_id, type, event_id, athlete_id)
-		VALUES ($1, $2, $3, $4)
-		RETURNING id, country_id, type, event_id, athlete_id, created_at, updated_at, deleted_at`
+func (r *MedalRepo) CreateMedal(ctx context.Context, req *pb.CreateMedalRequest, check AwardCheck) (*pb.CreateMedalResponse, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		logger.ErrorContext(ctx, "Failed to begin medal transaction", logrus.Fields{
+			"error": err,
+		})
+		return nil, fmt.Errorf("failed to create medal: %w", err)
+	}
+	defer tx.Rollback()
+
+	awarded, err := lockEventMedals(ctx, tx, req.EventId, "")
+	if err != nil {
+		logger.ErrorContext(ctx, "Failed to lock event medals", logrus.Fields{
+			"error":    err,
+			"event_id": req.EventId,
+		})
+		return nil, fmt.Errorf("failed to create medal: %w", err)
+	}
+	if err := check(req, awarded); err != nil {
+		logger.WarnContext(ctx, "Medal award rejected", logrus.Fields{
+			"error":    err,
+			"event_id": req.EventId,
+		})
+		return nil, err
+	}
+
 	var medal pb.Medal
-	err := r.db.QueryRow(query, req.CountryId, req.Type, req.EventId, req.AthleteId).Scan(
-		&medal.Id, &medal.CountryId, &medal.Type, &medal.EventId, &medal.AthleteId, &medal.CreatedAt, &medal.UpdatedAt, &medal.DeletedAt)
+	err = tx.QueryRowContext(ctx, insertMedal, medalArgs(req)...).Scan(medalDest(&medal)...)
 	if err != nil {
-		logger.Error("Failed to create medal", logrus.Fields{
+		logger.ErrorContext(ctx, "Failed to create medal", logrus.Fields{
+			"error": err,
+		})
+		return nil, fmt.Errorf("failed to create medal: %w", err)
+	}
+	if err := tx.Commit(); err != nil {
+		logger.ErrorContext(ctx, "Failed to commit medal", logrus.Fields{
 			"error": err,
 		})
-		return nil, fmt.Errorf("failed to create medal: %v", err)
+		return nil, fmt.Errorf("failed to create medal: %w", err)
 	}
 
-	logger.Info("Medal created successfully", logrus.Fields{
+	medal.MemberIds = req.MemberIds
+
+	logger.InfoContext(ctx, "Medal created successfully", logrus.Fields{
 		"id": medal.Id,
 	})
 
@@ -45,30 +74,86 @@ func (r *MedalRepo) CreateMedal(req *pb.CreateMedalRequest) (*pb.CreateMedalResp
 		Type:      medal.Type,
 		EventId:   medal.EventId,
 		AthleteId: medal.AthleteId,
+		TeamId:    medal.TeamId,
+		MemberIds: medal.MemberIds,
 		CreatedAt: medal.CreatedAt,
 		UpdatedAt: medal.UpdatedAt,
 		DeletedAt: medal.DeletedAt,
 	}, nil
 }
 
-func (r *MedalRepo) UpdateMedal(req *pb.UpdateMedalRequest) (*pb.UpdateMedalResponse, error) {
+func (r *MedalRepo) UpdateMedal(ctx context.Context, req *pb.UpdateMedalRequest, check AwardCheck) (*pb.UpdateMedalResponse, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		logger.ErrorContext(ctx, "Failed to begin medal transaction", logrus.Fields{
+			"error": err,
+			"id":    req.Id,
+		})
+		return nil, fmt.Errorf("failed to update medal: %w", err)
+	}
+	defer tx.Rollback()
+
+	awarded, err := lockEventMedals(ctx, tx, req.EventId, req.Id)
+	if err != nil {
+		logger.ErrorContext(ctx, "Failed to lock event medals", logrus.Fields{
+			"error":    err,
+			"event_id": req.EventId,
+		})
+		return nil, fmt.Errorf("failed to update medal: %w", err)
+	}
+	award := &pb.CreateMedalRequest{
+		CountryId: req.CountryId,
+		Type:      req.Type,
+		EventId:   req.EventId,
+		AthleteId: req.AthleteId,
+		TeamId:    req.TeamId,
+		MemberIds: req.MemberIds,
+	}
+	if err := check(award, awarded); err != nil {
+		logger.WarnContext(ctx, "Medal award rejected", logrus.Fields{
+			"error": err,
+			"id":    req.Id,
+		})
+		return nil, err
+	}
+
+	// Members who stay on the roster are left in place, as one statement
+	// cannot delete and re-insert the same row.
 	query := `
-		UPDATE medals
-		SET country_id = $1, type = $2, event_id = $3, athlete_id = $4, updated_at = $5
-		WHERE id = $6 AND deleted_at=0
-		RETURNING id, country_id, type, event_id, athlete_id, created_at, updated_at, deleted_at`
+		WITH medals AS (
+			UPDATE medals
+			SET country_id = $1, type = $2, event_id = $3, athlete_id = NULLIF($4, '')::uuid, team_id = NULLIF($5, '')::uuid, updated_at = $6
+			WHERE id = $7 AND deleted_at=0
+			RETURNING *
+		), dropped AS (
+			DELETE FROM medal_members
+			WHERE medal_id IN (SELECT id FROM medals) AND athlete_id <> ALL($8::uuid[])
+		), added AS (
+			INSERT INTO medal_members (medal_id, athlete_id)
+			SELECT medals.id, unnest($8::uuid[]) FROM medals
+			ON CONFLICT DO NOTHING
+		)
+		SELECT ` + medalFields + ` FROM medals`
 	var medal pb.Medal
-	err := r.db.QueryRow(query, req.CountryId, req.Type, req.EventId, req.AthleteId, time.Now().Format(time.RFC3339), req.Id).Scan(
-		&medal.Id, &medal.CountryId, &medal.Type, &medal.EventId, &medal.AthleteId, &medal.CreatedAt, &medal.UpdatedAt, &medal.DeletedAt)
+	err = tx.QueryRowContext(ctx, query, req.CountryId, req.Type, req.EventId, req.AthleteId, req.TeamId, time.Now().Format(time.RFC3339), req.Id, pq.Array(req.MemberIds)).Scan(medalDest(&medal)...)
 	if err != nil {
-		logger.Error("Failed to update medal", logrus.Fields{
+		logger.ErrorContext(ctx, "Failed to update medal", logrus.Fields{
 			"error": err,
 			"id":    req.Id,
 		})
-		return nil, fmt.Errorf("failed to update medal: %v", err)
+		return nil, fmt.Errorf("failed to update medal: %w", err)
+	}
+	if err := tx.Commit(); err != nil {
+		logger.ErrorContext(ctx, "Failed to commit medal", logrus.Fields{
+			"error": err,
+			"id":    req.Id,
+		})
+		return nil, fmt.Errorf("failed to update medal: %w", err)
 	}
 
-	logger.Info("Medal updated successfully", logrus.Fields{
+	medal.MemberIds = req.MemberIds
+
+	logger.InfoContext(ctx, "Medal updated successfully", logrus.Fields{
 		"id": medal.Id,
 	})
 
@@ -78,43 +163,49 @@ func (r *MedalRepo) UpdateMedal(req *pb.UpdateMedalRequest) (*pb.UpdateMedalResp
 		Type:      medal.Type,
 		EventId:   medal.EventId,
 		AthleteId: medal.AthleteId,
+		TeamId:    medal.TeamId,
+		MemberIds: medal.MemberIds,
 		CreatedAt: medal.CreatedAt,
 		UpdatedAt: medal.UpdatedAt,
 		DeletedAt: medal.DeletedAt,
 	}, nil
 }
 
-func (r *MedalRepo) DeleteMedal(req *pb.DeleteMedalRequest) (*pb.DeleteMedalResponse, error) {
-	query := `UPDATE medals SET deleted_at = $1 WHERE id = $2`
-	_, err := r.db.Exec(query, time.Now().Unix(), req.Id)
+func (r *MedalRepo) DeleteMedal(ctx context.Context, req *pb.DeleteMedalRequest) (*pb.DeleteMedalResponse, error) {
+	query := `UPDATE medals SET deleted_at = $1 WHERE id = $2 AND deleted_at = 0`
+	result, err := r.db.ExecContext(ctx, query, time.Now().Unix(), req.Id)
 	if err != nil {
-		logger.Error("Failed to delete medal", logrus.Fields{
+		logger.ErrorContext(ctx, "Failed to delete medal", logrus.Fields{
 			"error": err,
 			"id":    req.Id,
 		})
-		return nil, fmt.Errorf("failed to delete medal: %v", err)
+		return nil, fmt.Errorf("failed to delete medal: %w", err)
+	}
+	if num, err := result.RowsAffected(); err != nil {
+		return nil, fmt.Errorf("failed to delete medal: %w", err)
+	} else if num == 0 {
+		return nil, sql.ErrNoRows
 	}
 
-	logger.Info("Medal deleted successfully", logrus.Fields{
+	logger.InfoContext(ctx, "Medal deleted successfully", logrus.Fields{
 		"id": req.Id,
 	})
 	return &pb.DeleteMedalResponse{Success: true}, nil
 }
 
-func (r *MedalRepo) GetMedalById(req *pb.GetMedalByIdRequest) (*pb.GetMedalByIdResponse, error) {
-	query := `SELECT id, country_id, type, event_id, athlete_id, created_at, updated_at, deleted_at FROM medals WHERE id = $1`
+func (r *MedalRepo) GetMedalById(ctx context.Context, req *pb.GetMedalByIdRequest) (*pb.GetMedalByIdResponse, error) {
+	query := `SELECT ` + medalFields + ` FROM medals WHERE id = $1`
 	var medal pb.Medal
-	err := r.db.QueryRow(query, req.Id).Scan(
-		&medal.Id, &medal.CountryId, &medal.Type, &medal.EventId, &medal.AthleteId, &medal.CreatedAt, &medal.UpdatedAt, &medal.DeletedAt)
+	err := r.db.QueryRowContext(ctx, query, req.Id).Scan(medalDest(&medal)...)
 	if err != nil {
-		logger.Error("Failed to get medal by id", logrus.Fields{
+		logger.ErrorContext(ctx, "Failed to get medal by id", logrus.Fields{
 			"error": err,
 			"id":    req.Id,
 		})
-		return nil, fmt.Errorf("failed to get medal by id: %v", err)
+		return nil, fmt.Errorf("failed to get medal by id: %w", err)
 	}
 
-	logger.Info("Medal retrieved successfully", logrus.Fields{
+	logger.InfoContext(ctx, "Medal retrieved successfully", logrus.Fields{
 		"id": medal.Id,
 	})
 	return &pb.GetMedalByIdResponse{
@@ -123,70 +214,304 @@ func (r *MedalRepo) GetMedalById(req *pb.GetMedalByIdRequest) (*pb.GetMedalByIdR
 		Type:      medal.Type,
 		EventId:   medal.EventId,
 		AthleteId: medal.AthleteId,
+		TeamId:    medal.TeamId,
+		MemberIds: medal.MemberIds,
 		CreatedAt: medal.CreatedAt,
 		UpdatedAt: medal.UpdatedAt,
 		DeletedAt: medal.DeletedAt,
 	}, nil
 }
 
-func (r *MedalRepo) GetMedals(req *pb.VoidMedal) (*pb.GetMedalsResponse, error) {
-	query := `SELECT id, country_id, type, event_id, athlete_id, created_at, updated_at, deleted_at FROM medals`
-	rows, err := r.db.Query(query)
+// medalOrderColumns maps the order_by fields accepted by GetMedals to columns.
+var medalOrderColumns = map[string]string{
+	"created_at": "created_at",
+	"type":       "type",
+	"country_id": "country_id",
+}
+
+func (r *MedalRepo) GetMedals(ctx context.Context, req *pb.GetMedalsRequest) (*pb.GetMedalsResponse, error) {
+	p, err := paging.New(req.PageSize, req.PageToken, req.OrderBy, medalOrderColumns, "created_at")
+	if err != nil {
+		return nil, err
+	}
+
+	filter := paging.NewConditions("deleted_at = 0")
+	if req.CountryId != "" {
+		filter.Add("country_id = $%d", req.CountryId)
+	}
+	if req.EventId != "" {
+		filter.Add("event_id = $%d", req.EventId)
+	}
+	if req.AthleteId != "" {
+		filter.Add(creditsAthletes, pq.Array([]string{req.AthleteId}))
+	}
+	if req.TeamId != "" {
+		filter.Add("team_id = $%d", req.TeamId)
+	}
+
+	var total int64
+	if err := r.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM medals WHERE `+filter.String(), filter.Args()...).Scan(&total); err != nil {
+		logger.ErrorContext(ctx, "Failed to count medals", logrus.Fields{
+			"error": err,
+		})
+		return nil, fmt.Errorf("failed to count medals: %w", err)
+	}
+
+	query := `SELECT ` + medalFields + ` FROM medals` + p.Clause(filter)
+	rows, err := r.db.QueryContext(ctx, query, filter.Args()...)
 	if err != nil {
-		logger.Error("Failed to get medals", logrus.Fields{
+		logger.ErrorContext(ctx, "Failed to get medals", logrus.Fields{
 			"error": err,
 		})
-		return nil, fmt.Errorf("failed to get medals: %v", err)
+		return nil, fmt.Errorf("failed to get medals: %w", err)
 	}
 	defer rows.Close()
 
 	var medals []*pb.Medal
 	for rows.Next() {
 		var medal pb.Medal
-		err := rows.Scan(&medal.Id, &medal.CountryId, &medal.Type, &medal.EventId, &medal.AthleteId, &medal.CreatedAt, &medal.UpdatedAt, &medal.DeletedAt)
+		err := rows.Scan(medalDest(&medal)...)
 		if err != nil {
-			logger.Error("Failed to scan medal", logrus.Fields{
+			logger.ErrorContext(ctx, "Failed to scan medal", logrus.Fields{
 				"error": err,
 			})
-			return nil, fmt.Errorf("failed to scan medal: %v", err)
+			return nil, fmt.Errorf("failed to scan medal: %w", err)
 		}
 		medals = append(medals, &medal)
 	}
+	if err := rows.Err(); err != nil {
+		logger.ErrorContext(ctx, "Failed to get medals", logrus.Fields{
+			"error": err,
+		})
+		return nil, fmt.Errorf("failed to get medals: %w", err)
+	}
+
+	resp := &pb.GetMedalsResponse{TotalCount: total}
+	if len(medals) > p.Size {
+		medals = medals[:p.Size]
+		last := medals[p.Size-1]
+		resp.NextPageToken = p.NextToken(medalSortValue(last, p.Column), last.Id)
+	}
+	resp.Medals = medals
 
-	logger.Info("Medals retrieved successfully", logrus.Fields{
+	logger.InfoContext(ctx, "Medals retrieved successfully", logrus.Fields{
 		"count": len(medals),
+		"total": total,
 	})
 
-	return &pb.GetMedalsResponse{Medals: medals}, nil
+	return resp, nil
+}
+
+func medalSortValue(m *pb.Medal, column string) string {
+	switch column {
+	case "type":
+		return fmt.Sprintf("%d", m.Type)
+	case "country_id":
+		return m.CountryId
+	default:
+		return m.CreatedAt
+	}
 }
 
-func (r *MedalRepo) GetMedalByFilter(req *pb.GetMedalByFilterRequest) (*pb.GetMedalByFilterResponse, error) {
-	query := `SELECT id, country_id, type, event_id, athlete_id, created_at, updated_at, deleted_at FROM medals WHERE country_id = $1 AND type = $2 AND event_id = $3 AND athlete_id = $4`
-	rows, err := r.db.Query(query, req.CountryId, req.Type, req.EventId, req.AthleteId)
+func (r *MedalRepo) GetMedalByFilter(ctx context.Context, req *pb.GetMedalByFilterRequest) (*pb.GetMedalByFilterResponse, error) {
+	filter, err := medalFilter(req)
 	if err != nil {
-		logger.Error("Failed to get medals by filter", logrus.Fields{
+		return nil, err
+	}
+
+	query := `SELECT ` + medalFields + ` FROM medals WHERE ` + filter.String() + ` ORDER BY created_at, id`
+	rows, err := r.db.QueryContext(ctx, query, filter.Args()...)
+	if err != nil {
+		logger.ErrorContext(ctx, "Failed to get medals by filter", logrus.Fields{
 			"error": err,
 		})
-		return nil, fmt.Errorf("failed to get medals by filter: %v", err)
+		return nil, fmt.Errorf("failed to get medals by filter: %w", err)
 	}
 	defer rows.Close()
 
 	var medals []*pb.Medal
 	for rows.Next() {
 		var medal pb.Medal
-		err := rows.Scan(&medal.Id, &medal.CountryId, &medal.Type, &medal.EventId, &medal.AthleteId, &medal.CreatedAt, &medal.UpdatedAt, &medal.DeletedAt)
+		err := rows.Scan(medalDest(&medal)...)
 		if err != nil {
-			logger.Error("Failed to scan medal", logrus.Fields{
+			logger.ErrorContext(ctx, "Failed to scan medal", logrus.Fields{
 				"error": err,
 			})
-			return nil, fmt.Errorf("failed to scan medal: %v", err)
+			return nil, fmt.Errorf("failed to scan medal: %w", err)
 		}
 		medals = append(medals, &medal)
 	}
-	logger.Info("Medals retrieved by filter successfully", logrus.Fields{
+	if err := rows.Err(); err != nil {
+		logger.ErrorContext(ctx, "Failed to get medals by filter", logrus.Fields{
+			"error": err,
+		})
+		return nil, fmt.Errorf("failed to get medals by filter: %w", err)
+	}
+	logger.InfoContext(ctx, "Medals retrieved by filter successfully", logrus.Fields{
 		"count": len(medals),
 	})
 
 	return &pb.GetMedalByFilterResponse{Medals: medals}, nil
 }
 
+// GetMedalTable counts the gold, silver and bronze medals of every country.
+// Every individual medal counts, shared and double bronzes included, while
+// the rows of one team's award in an event count once however many there
+// are. Ranking is left to the service layer.
+func (r *MedalRepo) GetMedalTable(ctx context.Context, req *pb.GetMedalTableRequest) ([]*pb.MedalTableRow, error) {
+	query := `
+		SELECT country_id, type,
+			CASE WHEN team_id IS NULL THEN id::text ELSE team_id::text || ':' || event_id::text END AS place
+		FROM medals
+		WHERE deleted_at = 0
+		ORDER BY country_id`
+	rows, err := r.db.QueryContext(ctx, query)
+	if err != nil {
+		logger.ErrorContext(ctx, "Failed to get medal table", logrus.Fields{
+			"error": err,
+		})
+		return nil, fmt.Errorf("failed to get medal table: %w", err)
+	}
+	defer rows.Close()
+
+	var table []*pb.MedalTableRow
+	countries := make(map[string]*pb.MedalTableRow)
+	places := make(map[[2]string]bool)
+	for rows.Next() {
+		var countryID, place string
+		var medalType int32
+		if err := rows.Scan(&countryID, &medalType, &place); err != nil {
+			logger.ErrorContext(ctx, "Failed to scan medal table row", logrus.Fields{
+				"error": err,
+			})
+			return nil, fmt.Errorf("failed to scan medal table row: %w", err)
+		}
+		if places[[2]string{countryID, place}] {
+			continue
+		}
+		places[[2]string{countryID, place}] = true
+
+		row, ok := countries[countryID]
+		if !ok {
+			row = &pb.MedalTableRow{CountryId: countryID}
+			countries[countryID] = row
+			table = append(table, row)
+		}
+		switch medalType {
+		case MedalGold:
+			row.Gold++
+		case MedalSilver:
+			row.Silver++
+		case MedalBronze:
+			row.Bronze++
+		}
+		row.Total++
+	}
+	if err := rows.Err(); err != nil {
+		logger.ErrorContext(ctx, "Failed to get medal table", logrus.Fields{
+			"error": err,
+		})
+		return nil, fmt.Errorf("failed to get medal table: %w", err)
+	}
+
+	logger.InfoContext(ctx, "Medal table retrieved successfully", logrus.Fields{
+		"countries": len(table),
+	})
+	return table, nil
+}
+
+// ImportMedals inserts a batch of medals in one transaction. The referenced
+// country, event and athlete or team are checked by the caller.
+func (r *MedalRepo) ImportMedals(ctx context.Context, req *pb.ImportMedalsRequest, check AwardCheck) (*pb.ImportResponse, error) {
+	accepted, failed, committed, err := importRows(ctx, r.db, len(req.Medals), req.DryRun, func(tx *sql.Tx, i int) error {
+		medal := req.Medals[i]
+		switch {
+		case medal.Type < MedalGold || medal.Type > MedalBronze:
+			return fmt.Errorf("unknown medal type %d", medal.Type)
+		case medal.CountryId == "":
+			return fmt.Errorf("country_id is required")
+		case medal.EventId == "":
+			return fmt.Errorf("event_id is required")
+		case medal.AthleteId == "" && medal.TeamId == "":
+			return fmt.Errorf("athlete_id or team_id is required")
+		}
+
+		awarded, err := lockEventMedals(ctx, tx, medal.EventId, "")
+		if err != nil {
+			return err
+		}
+		if err := check(medal, awarded); err != nil {
+			return err
+		}
+
+		_, err = tx.ExecContext(ctx, insertMedal, medalArgs(medal)...)
+		return err
+	})
+	if err != nil {
+		logger.ErrorContext(ctx, "Failed to import medals", logrus.Fields{
+			"error": err,
+		})
+		return nil, fmt.Errorf("failed to import medals: %w", err)
+	}
+
+	resp := &pb.ImportResponse{
+		Accepted:  int32(accepted),
+		Committed: committed,
+	}
+	for _, row := range failed {
+		resp.Errors = append(resp.Errors, &pb.ImportRowError{
+			Index:   int32(row.index),
+			Message: row.err.Error(),
+		})
+	}
+
+	logger.InfoContext(ctx, "Medals imported", logrus.Fields{
+		"rows":      len(req.Medals),
+		"accepted":  accepted,
+		"failed":    len(failed),
+		"committed": committed,
+		"dry_run":   req.DryRun,
+	})
+	return resp, nil
+}
+
+// ExportMedals hands every medal to send as it is read, so the caller can
+// stream the table without holding it in memory.
+func (r *MedalRepo) ExportMedals(ctx context.Context, req *pb.ExportMedalsRequest, send func(*pb.Medal) error) error {
+	query := `SELECT ` + medalFields + ` FROM medals WHERE deleted_at = 0 ORDER BY created_at, id`
+	rows, err := r.db.QueryContext(ctx, query)
+	if err != nil {
+		logger.ErrorContext(ctx, "Failed to export medals", logrus.Fields{
+			"error": err,
+		})
+		return fmt.Errorf("failed to export medals: %w", err)
+	}
+	defer rows.Close()
+
+	count := 0
+	for rows.Next() {
+		var medal pb.Medal
+		err := rows.Scan(medalDest(&medal)...)
+		if err != nil {
+			logger.ErrorContext(ctx, "Failed to scan medal", logrus.Fields{
+				"error": err,
+			})
+			return fmt.Errorf("failed to scan medal: %w", err)
+		}
+		if err := send(&medal); err != nil {
+			return err
+		}
+		count++
+	}
+	if err := rows.Err(); err != nil {
+		logger.ErrorContext(ctx, "Failed to export medals", logrus.Fields{
+			"error": err,
+		})
+		return fmt.Errorf("failed to export medals: %w", err)
+	}
+
+	logger.InfoContext(ctx, "Medals exported successfully", logrus.Fields{
+		"count": count,
+	})
+	return nil
+}
@@ -1,14 +1,27 @@
 package repository
 
 import (
+	"context"
+	"errors"
+	"shared/paging"
 	"testing"
 	"time"
 
 	pb "github.com/Bekzodbekk/paris2024_livestream_protos/genproto/medalspb"
 	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/lib/pq"
 	"github.com/stretchr/testify/assert"
 )
 
+var medalColumns = []string{"id", "country_id", "type", "event_id", "athlete_id", "team_id", "created_at", "updated_at", "deleted_at", "member_ids"}
+
+func allowAward(*pb.CreateMedalRequest, []*pb.Medal) error { return nil }
+
+func expectEventLock(mock sqlmock.Sqlmock, eventID, exclude string, awarded *sqlmock.Rows) {
+	mock.ExpectExec("SELECT pg_advisory_xact_lock").WithArgs(eventID).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery("SELECT (.+) FROM medals WHERE event_id = \\$1 AND deleted_at = 0").WithArgs(eventID, exclude).WillReturnRows(awarded)
+}
+
 func TestCreateMedal(t *testing.T) {
 	db, mock, err := sqlmock.New()
 	if err != nil {
@@ -18,25 +31,88 @@ func TestCreateMedal(t *testing.T) {
 
 	repo := NewPostgresMedalRepo(db)
 
-	mock.ExpectQuery("SELECT 1 FROM countries").WithArgs("1").WillReturnRows(sqlmock.NewRows([]string{"1"}).AddRow(1))
-	mock.ExpectQuery("SELECT 1 FROM events").WithArgs("1").WillReturnRows(sqlmock.NewRows([]string{"1"}).AddRow(1))
-	mock.ExpectQuery("SELECT 1 FROM athletes").WithArgs("1").WillReturnRows(sqlmock.NewRows([]string{"1"}).AddRow(1))
-
-	mock.ExpectQuery("INSERT INTO medals").WithArgs("1", "GOLD", "1", "1").WillReturnRows(sqlmock.NewRows([]string{"id", "country_id", "type", "event_id", "athlete_id", "created_at", "updated_at", "deleted_at"}).AddRow(1, "1", "GOLD", "1", "1", time.Now(), time.Now(), nil))
+	mock.ExpectBegin()
+	expectEventLock(mock, "1", "", sqlmock.NewRows(medalColumns).AddRow("2", "2", MedalGold, "1", "2", "", time.Now(), time.Now(), 0, nil))
+	mock.ExpectQuery("INSERT INTO medals").WithArgs("1", MedalSilver, "1", "1", "", sqlmock.AnyArg()).WillReturnRows(sqlmock.NewRows(medalColumns).AddRow("1", "1", MedalSilver, "1", "1", "", time.Now(), time.Now(), 0, nil))
+	mock.ExpectCommit()
 
 	req := &pb.CreateMedalRequest{
 		CountryId: "1",
-		Type:      1,
+		Type:      MedalSilver,
 		EventId:   "1",
 		AthleteId: "1",
 	}
 
-	resp, err := repo.CreateMedal(req)
+	var checked []*pb.Medal
+	resp, err := repo.CreateMedal(context.Background(), req, func(medal *pb.CreateMedalRequest, awarded []*pb.Medal) error {
+		checked = awarded
+		return nil
+	})
 
 	assert.NoError(t, err)
 	assert.NotNil(t, resp)
 	assert.Equal(t, "1", resp.CountryId)
-	assert.Equal(t, "GOLD", resp.Type)
+	assert.EqualValues(t, MedalSilver, resp.Type)
+	assert.Len(t, checked, 1)
+	assert.Equal(t, "2", checked[0].AthleteId)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCreateTeamMedal(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	repo := NewPostgresMedalRepo(db)
+
+	mock.ExpectBegin()
+	expectEventLock(mock, "1", "", sqlmock.NewRows(medalColumns))
+	mock.ExpectQuery("INSERT INTO medals (.+) INSERT INTO medal_members").
+		WithArgs("usa", MedalGold, "1", "", "t1", sqlmock.AnyArg()).
+		WillReturnRows(sqlmock.NewRows(medalColumns).AddRow("1", "usa", MedalGold, "1", "", "t1", time.Now(), time.Now(), 0, nil))
+	mock.ExpectCommit()
+
+	req := &pb.CreateMedalRequest{
+		CountryId: "usa",
+		Type:      MedalGold,
+		EventId:   "1",
+		TeamId:    "t1",
+		MemberIds: []string{"a1", "a2", "a3", "a4"},
+	}
+
+	resp, err := repo.CreateMedal(context.Background(), req, func(medal *pb.CreateMedalRequest, awarded []*pb.Medal) error {
+		return nil
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "t1", resp.TeamId)
+	assert.Empty(t, resp.AthleteId)
+	assert.Equal(t, req.MemberIds, resp.MemberIds)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCreateMedalRejected(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	repo := NewPostgresMedalRepo(db)
+
+	mock.ExpectBegin()
+	expectEventLock(mock, "1", "", sqlmock.NewRows(medalColumns))
+	mock.ExpectRollback()
+
+	rejected := errors.New("rejected")
+	resp, err := repo.CreateMedal(context.Background(), &pb.CreateMedalRequest{CountryId: "1", Type: MedalGold, EventId: "1", AthleteId: "1"},
+		func(*pb.CreateMedalRequest, []*pb.Medal) error { return rejected })
+
+	assert.Nil(t, resp)
+	assert.Equal(t, rejected, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
 }
 
 func TestUpdateMedal(t *testing.T) {
@@ -48,22 +124,26 @@ func TestUpdateMedal(t *testing.T) {
 
 	repo := NewPostgresMedalRepo(db)
 
-	mock.ExpectQuery("UPDATE medals").WithArgs("1", "SILVER", "1", "1", sqlmock.AnyArg(), "1").WillReturnRows(sqlmock.NewRows([]string{"id", "country_id", "type", "event_id", "athlete_id", "created_at", "updated_at", "deleted_at"}).AddRow(1, "1", "SILVER", "1", "1", time.Now(), time.Now(), nil))
+	mock.ExpectBegin()
+	expectEventLock(mock, "1", "1", sqlmock.NewRows(medalColumns))
+	mock.ExpectQuery("UPDATE medals").WithArgs("1", MedalBronze, "1", "1", "", sqlmock.AnyArg(), "1", sqlmock.AnyArg()).WillReturnRows(sqlmock.NewRows(medalColumns).AddRow("1", "1", MedalBronze, "1", "1", "", time.Now(), time.Now(), 0, nil))
+	mock.ExpectCommit()
 
 	req := &pb.UpdateMedalRequest{
 		Id:        "1",
 		CountryId: "1",
-		Type:      2,
+		Type:      MedalBronze,
 		EventId:   "1",
 		AthleteId: "1",
 	}
 
-	resp, err := repo.UpdateMedal(req)
+	resp, err := repo.UpdateMedal(context.Background(), req, allowAward)
 
 	assert.NoError(t, err)
 	assert.NotNil(t, resp)
 	assert.Equal(t, "1", resp.CountryId)
-	assert.Equal(t, "SILVER", resp.Type)
+	assert.EqualValues(t, MedalBronze, resp.Type)
+	assert.NoError(t, mock.ExpectationsWereMet())
 }
 
 func TestDeleteMedal(t *testing.T) {
@@ -81,7 +161,7 @@ func TestDeleteMedal(t *testing.T) {
 		Id: "1",
 	}
 
-	resp, err := repo.DeleteMedal(req)
+	resp, err := repo.DeleteMedal(context.Background(), req)
 
 	assert.NoError(t, err)
 	assert.NotNil(t, resp)
@@ -97,18 +177,18 @@ func TestGetMedalById(t *testing.T) {
 
 	repo := NewPostgresMedalRepo(db)
 
-	mock.ExpectQuery("SELECT (.+) FROM medals WHERE id").WithArgs("1").WillReturnRows(sqlmock.NewRows([]string{"id", "country_id", "type", "event_id", "athlete_id", "created_at", "updated_at", "deleted_at"}).AddRow("1", "1", "GOLD", "1", "1", time.Now(), time.Now(), nil))
+	mock.ExpectQuery("SELECT (.+) FROM medals WHERE id").WithArgs("1").WillReturnRows(sqlmock.NewRows(medalColumns).AddRow("1", "1", MedalGold, "1", "1", "", time.Now(), time.Now(), 0, nil))
 
 	req := &pb.GetMedalByIdRequest{
 		Id: "1",
 	}
 
-	resp, err := repo.GetMedalById(req)
+	resp, err := repo.GetMedalById(context.Background(), req)
 
 	assert.NoError(t, err)
 	assert.NotNil(t, resp)
 	assert.Equal(t, "1", resp.Id)
-	assert.Equal(t, "GOLD", resp.Type)
+	assert.EqualValues(t, MedalGold, resp.Type)
 }
 
 func TestGetMedals(t *testing.T) {
@@ -120,17 +200,81 @@ func TestGetMedals(t *testing.T) {
 
 	repo := NewPostgresMedalRepo(db)
 
-	rows := sqlmock.NewRows([]string{"id", "country_id", "type", "event_id", "athlete_id", "created_at", "updated_at", "deleted_at"}).
-		AddRow("1", "1", "GOLD", "1", "1", time.Now(), time.Now(), nil).
-		AddRow("2", "2", "SILVER", "2", "2", time.Now(), time.Now(), nil)
+	rows := sqlmock.NewRows(medalColumns).
+		AddRow("1", "1", 0, "1", "1", "", time.Now(), time.Now(), 0, nil).
+		AddRow("2", "2", 1, "2", "2", "", time.Now(), time.Now(), 0, nil)
 
-	mock.ExpectQuery("SELECT (.+) FROM medals").WillReturnRows(rows)
+	mock.ExpectQuery("SELECT COUNT(.+) FROM medals WHERE deleted_at = 0").
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(2))
+	mock.ExpectQuery("SELECT (.+) FROM medals WHERE deleted_at = 0 ORDER BY created_at ASC, id ASC LIMIT").
+		WithArgs(paging.DefaultPageSize + 1).
+		WillReturnRows(rows)
 
-	resp, err := repo.GetMedals(&pb.VoidMedal{})
+	resp, err := repo.GetMedals(context.Background(), &pb.GetMedalsRequest{})
 
 	assert.NoError(t, err)
 	assert.NotNil(t, resp)
 	assert.Len(t, resp.Medals, 2)
+	assert.Equal(t, int64(2), resp.TotalCount)
+	assert.Empty(t, resp.NextPageToken)
+}
+
+func TestGetMedalsByCountryPaged(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	repo := NewPostgresMedalRepo(db)
+
+	rows := sqlmock.NewRows(medalColumns).
+		AddRow("1", "1", 0, "1", "1", "", time.Now(), time.Now(), 0, nil).
+		AddRow("2", "1", 2, "2", "2", "", time.Now(), time.Now(), 0, nil)
+
+	mock.ExpectQuery("SELECT COUNT(.+) FROM medals WHERE deleted_at = 0 AND country_id = \\$1").
+		WithArgs("1").
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(3))
+	mock.ExpectQuery("SELECT (.+) FROM medals WHERE deleted_at = 0 AND country_id = \\$1 ORDER BY type ASC, id ASC LIMIT \\$2").
+		WithArgs("1", 2).
+		WillReturnRows(rows)
+
+	resp, err := repo.GetMedals(context.Background(), &pb.GetMedalsRequest{PageSize: 1, OrderBy: "type", CountryId: "1"})
+
+	assert.NoError(t, err)
+	assert.Len(t, resp.Medals, 1)
+	assert.Equal(t, int64(3), resp.TotalCount)
+	assert.NotEmpty(t, resp.NextPageToken)
+}
+
+func TestGetMedalsByAthlete(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	repo := NewPostgresMedalRepo(db)
+
+	// The relay gold is the team's, and credited to the athlete as a member.
+	rows := sqlmock.NewRows(medalColumns).
+		AddRow("1", "usa", 0, "100fr", "a1", "", time.Now(), time.Now(), 0, nil).
+		AddRow("2", "usa", 0, "4x100", "", "t1", time.Now(), time.Now(), 0, "{a1,a2,a3,a4}")
+
+	mock.ExpectQuery("SELECT COUNT(.+) FROM medals WHERE deleted_at = 0 AND \\(medals.athlete_id = ANY\\(\\$1\\) OR medals.id IN \\(SELECT medal_id FROM medal_members").
+		WithArgs(sqlmock.AnyArg()).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(2))
+	mock.ExpectQuery("SELECT (.+) FROM medals WHERE deleted_at = 0 AND (.+) LIMIT \\$2").
+		WithArgs(sqlmock.AnyArg(), paging.DefaultPageSize+1).
+		WillReturnRows(rows)
+
+	resp, err := repo.GetMedals(context.Background(), &pb.GetMedalsRequest{AthleteId: "a1"})
+
+	assert.NoError(t, err)
+	assert.Len(t, resp.Medals, 2)
+	assert.Equal(t, "t1", resp.Medals[1].TeamId)
+	assert.Equal(t, []string{"a1", "a2", "a3", "a4"}, resp.Medals[1].MemberIds)
+	assert.NoError(t, mock.ExpectationsWereMet())
 }
 
 func TestGetMedalByFilter(t *testing.T) {
@@ -142,22 +286,290 @@ func TestGetMedalByFilter(t *testing.T) {
 
 	repo := NewPostgresMedalRepo(db)
 
-	rows := sqlmock.NewRows([]string{"id", "country_id", "type", "event_id", "athlete_id", "created_at", "updated_at", "deleted_at"}).
-		AddRow("1", "1", "GOLD", "1", "1", time.Now(), time.Now(), nil)
+	rows := sqlmock.NewRows(medalColumns).
+		AddRow("1", "1", 0, "1", "1", "", time.Now(), time.Now(), 0, nil)
 
-	mock.ExpectQuery("SELECT (.+) FROM medals WHERE country_id").WithArgs("1", "GOLD", "1", "1").WillReturnRows(rows)
+	mock.ExpectQuery("SELECT (.+) FROM medals WHERE deleted_at = 0 AND country_id = ANY\\(\\$1\\) AND type = ANY\\(\\$2\\)").
+		WithArgs(pq.Array([]string{"1", "2"}), pq.Int64Array{0}).
+		WillReturnRows(rows)
 
 	req := &pb.GetMedalByFilterRequest{
-		CountryId: "1",
-		Type:      1,
-		EventId:   "1",
-		AthleteId: "1",
+		CountryIds: []string{"1", "2"},
+		Types:      []int32{MedalGold},
 	}
 
-	resp, err := repo.GetMedalByFilter(req)
+	resp, err := repo.GetMedalByFilter(context.Background(), req)
 
 	assert.NoError(t, err)
 	assert.NotNil(t, resp)
 	assert.Len(t, resp.Medals, 1)
-	assert.Equal(t, "GOLD", resp.Medals[0].Type)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetMedalByFilterCountryOnly(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	repo := NewPostgresMedalRepo(db)
+
+	rows := sqlmock.NewRows(medalColumns).
+		AddRow("1", "1", 0, "1", "1", "", time.Now(), time.Now(), 0, nil).
+		AddRow("2", "1", 2, "2", "2", "", time.Now(), time.Now(), 0, nil)
+
+	from := time.Date(2024, 7, 26, 0, 0, 0, 0, time.UTC)
+	mock.ExpectQuery("SELECT (.+) FROM medals WHERE deleted_at = 0 AND country_id = ANY\\(\\$1\\) AND created_at >= \\$2 AND created_at < \\$3 ORDER BY created_at, id").
+		WithArgs(pq.Array([]string{"1"}), from, from.AddDate(0, 0, 1)).
+		WillReturnRows(rows)
+
+	resp, err := repo.GetMedalByFilter(context.Background(), &pb.GetMedalByFilterRequest{
+		CountryIds:  []string{"1"},
+		CreatedFrom: "2024-07-26",
+		CreatedTo:   "2024-07-26",
+	})
+
+	assert.NoError(t, err)
+	assert.Len(t, resp.Medals, 2)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetMedalByFilterInvalid(t *testing.T) {
+	db, _, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	repo := NewPostgresMedalRepo(db)
+
+	_, err = repo.GetMedalByFilter(context.Background(), &pb.GetMedalByFilterRequest{Types: []int32{7}})
+	assert.ErrorIs(t, err, ErrInvalidFilter)
+
+	_, err = repo.GetMedalByFilter(context.Background(), &pb.GetMedalByFilterRequest{CreatedFrom: "yesterday"})
+	assert.ErrorIs(t, err, ErrInvalidFilter)
+}
+
+func TestGetMedalTable(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	repo := NewPostgresMedalRepo(db)
+
+	// Medals without a team are places of their own: a tie for gold within
+	// one country and a double bronze both count twice.
+	rows := sqlmock.NewRows([]string{"country_id", "type", "place"}).
+		AddRow("1", MedalGold, "m1").
+		AddRow("1", MedalGold, "m2").
+		AddRow("1", MedalSilver, "m3").
+		AddRow("2", MedalBronze, "m4").
+		AddRow("2", MedalBronze, "m5").
+		AddRow("2", MedalBronze, "relay:e1")
+
+	mock.ExpectQuery("SELECT country_id, type, (.+) FROM medals WHERE deleted_at = 0").WillReturnRows(rows)
+
+	table, err := repo.GetMedalTable(context.Background(), &pb.GetMedalTableRequest{})
+
+	assert.NoError(t, err)
+	assert.Len(t, table, 2)
+	assert.Equal(t, "1", table[0].CountryId)
+	assert.EqualValues(t, 2, table[0].Gold)
+	assert.EqualValues(t, 1, table[0].Silver)
+	assert.EqualValues(t, 3, table[0].Total)
+	assert.Equal(t, "2", table[1].CountryId)
+	assert.EqualValues(t, 3, table[1].Bronze)
+	assert.EqualValues(t, 3, table[1].Total)
+}
+
+func TestGetMedalTableTeamMembers(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	repo := NewPostgresMedalRepo(db)
+
+	// A team's basketball gold recorded once per member is still one medal.
+	rows := sqlmock.NewRows([]string{"country_id", "type", "place"})
+	for i := 0; i < 12; i++ {
+		rows.AddRow("usa", MedalGold, "usa-basketball:basketball")
+	}
+	rows.AddRow("usa", MedalSilver, "usa-relay:4x100")
+
+	mock.ExpectQuery("SELECT country_id, type, (.+) FROM medals WHERE deleted_at = 0").WillReturnRows(rows)
+
+	table, err := repo.GetMedalTable(context.Background(), &pb.GetMedalTableRequest{})
+
+	assert.NoError(t, err)
+	assert.Len(t, table, 1)
+	assert.EqualValues(t, 1, table[0].Gold)
+	assert.EqualValues(t, 1, table[0].Silver)
+	assert.EqualValues(t, 2, table[0].Total)
+}
+
+func TestImportMedals(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	repo := NewPostgresMedalRepo(db)
+
+	req := &pb.ImportMedalsRequest{
+		Medals: []*pb.CreateMedalRequest{
+			{CountryId: "1", Type: MedalGold, EventId: "1", AthleteId: "1"},
+			{CountryId: "2", Type: MedalSilver, EventId: "1", AthleteId: "2"},
+		},
+	}
+
+	mock.ExpectBegin()
+	for _, medal := range req.Medals {
+		mock.ExpectExec("SAVEPOINT import_row").WillReturnResult(sqlmock.NewResult(0, 0))
+		expectEventLock(mock, medal.EventId, "", sqlmock.NewRows(medalColumns))
+		mock.ExpectExec("INSERT INTO medals").
+			WithArgs(medal.CountryId, medal.Type, medal.EventId, medal.AthleteId, medal.TeamId, sqlmock.AnyArg()).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectExec("RELEASE SAVEPOINT import_row").WillReturnResult(sqlmock.NewResult(0, 0))
+	}
+	mock.ExpectCommit()
+
+	resp, err := repo.ImportMedals(context.Background(), req, allowAward)
+
+	assert.NoError(t, err)
+	assert.Equal(t, int32(2), resp.Accepted)
+	assert.True(t, resp.Committed)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestExportMedals(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	repo := NewPostgresMedalRepo(db)
+
+	rows := sqlmock.NewRows(medalColumns).
+		AddRow("1", "1", 0, "1", "1", "", time.Now(), time.Now(), 0, nil).
+		AddRow("2", "2", 1, "1", "2", "", time.Now(), time.Now(), 0, nil)
+
+	mock.ExpectQuery("SELECT (.+) FROM medals WHERE deleted_at = 0 ORDER BY created_at, id").WillReturnRows(rows)
+
+	var ids []string
+	err = repo.ExportMedals(context.Background(), &pb.ExportMedalsRequest{}, func(medal *pb.Medal) error {
+		ids = append(ids, medal.Id)
+		return nil
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"1", "2"}, ids)
+}
+
+func TestFinalizeEvent(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	repo := NewPostgresMedalRepo(db)
+
+	awards := []*pb.CreateMedalRequest{
+		{CountryId: "fra", Type: MedalGold, EventId: "1", AthleteId: "riner"},
+		{CountryId: "jpn", Type: MedalSilver, EventId: "1", AthleteId: "saito"},
+	}
+
+	mock.ExpectBegin()
+	expectEventLock(mock, "1", "", sqlmock.NewRows(medalColumns).
+		AddRow("10", "fra", MedalGold, "1", "riner", "", time.Now(), time.Now(), 0, nil).
+		AddRow("11", "geo", MedalSilver, "1", "tushishvili", "", time.Now(), time.Now(), 0, nil))
+	mock.ExpectQuery("INSERT INTO medals").WithArgs("jpn", MedalSilver, "1", "saito", "", sqlmock.AnyArg()).
+		WillReturnRows(sqlmock.NewRows(medalColumns).AddRow("12", "jpn", MedalSilver, "1", "saito", "", time.Now(), time.Now(), 0, nil))
+	mock.ExpectExec("UPDATE medals SET deleted_at").WithArgs(sqlmock.AnyArg(), "11").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	resp, err := repo.FinalizeEvent(context.Background(), "1", awards, allowAward, false)
+
+	assert.NoError(t, err)
+	assert.True(t, resp.Committed)
+	assert.Equal(t, int32(1), resp.Created)
+	assert.Equal(t, int32(1), resp.Kept)
+	assert.Equal(t, int32(1), resp.Removed)
+	assert.Len(t, resp.Awards, 3)
+	assert.Equal(t, AwardKeep, resp.Awards[0].Action)
+	assert.Equal(t, "10", resp.Awards[0].MedalId)
+	assert.Equal(t, AwardCreate, resp.Awards[1].Action)
+	assert.Equal(t, "12", resp.Awards[1].MedalId)
+	assert.Equal(t, AwardRemove, resp.Awards[2].Action)
+	assert.Equal(t, "11", resp.Awards[2].MedalId)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestFinalizeEventDryRun(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	repo := NewPostgresMedalRepo(db)
+
+	mock.ExpectBegin()
+	expectEventLock(mock, "1", "", sqlmock.NewRows(medalColumns).
+		AddRow("11", "geo", MedalSilver, "1", "tushishvili", "", time.Now(), time.Now(), 0, nil))
+	mock.ExpectRollback()
+
+	resp, err := repo.FinalizeEvent(context.Background(), "1", []*pb.CreateMedalRequest{
+		{CountryId: "fra", Type: MedalGold, EventId: "1", AthleteId: "riner"},
+	}, allowAward, true)
+
+	assert.NoError(t, err)
+	assert.False(t, resp.Committed)
+	assert.Equal(t, int32(1), resp.Created)
+	assert.Equal(t, int32(1), resp.Removed)
+	assert.Empty(t, resp.Awards[0].MedalId)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestFinalizeEventChecksAwards(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	repo := NewPostgresMedalRepo(db)
+
+	mock.ExpectBegin()
+	expectEventLock(mock, "1", "", sqlmock.NewRows(medalColumns).
+		AddRow("11", "geo", MedalGold, "1", "tushishvili", "", time.Now(), time.Now(), 0, nil))
+	mock.ExpectRollback()
+
+	// A dry run is refused like the real thing. The gold about to be
+	// removed does not count; the one awarded before does.
+	var seen [][]*pb.Medal
+	rejected := errors.New("event 1 already has 1 gold medal(s)")
+	_, err = repo.FinalizeEvent(context.Background(), "1", []*pb.CreateMedalRequest{
+		{CountryId: "fra", Type: MedalGold, EventId: "1", AthleteId: "riner"},
+		{CountryId: "jpn", Type: MedalGold, EventId: "1", AthleteId: "saito"},
+	}, func(medal *pb.CreateMedalRequest, awarded []*pb.Medal) error {
+		seen = append(seen, awarded)
+		if len(awarded) > 0 {
+			return rejected
+		}
+		return nil
+	}, true)
+
+	assert.ErrorIs(t, err, rejected)
+	assert.Len(t, seen, 2)
+	assert.Empty(t, seen[0])
+	assert.Equal(t, "riner", seen[1][0].AthleteId)
+	assert.NoError(t, mock.ExpectationsWereMet())
 }
@@ -1,12 +1,25 @@
 package repository
 
-import pb "github.com/Bekzodbekk/paris2024_livestream_protos/genproto/medalspb"
+import (
+	"context"
+
+	pb "github.com/Bekzodbekk/paris2024_livestream_protos/genproto/medalspb"
+)
+
+// AwardCheck decides whether medal may be awarded, given the medals its event
+// already holds. It runs inside the award transaction while the event is
+// locked, so two concurrent awards cannot both pass it.
+type AwardCheck func(medal *pb.CreateMedalRequest, awarded []*pb.Medal) error
 
 type MedalRepository interface {
-	CreateMedal(req *pb.CreateMedalRequest) (*pb.CreateMedalResponse, error)
-	UpdateMedal(req *pb.UpdateMedalRequest) (*pb.UpdateMedalResponse, error)
-	DeleteMedal(req *pb.DeleteMedalRequest) (*pb.DeleteMedalResponse, error)
-	GetMedalById(req *pb.GetMedalByIdRequest) (*pb.GetMedalByIdResponse, error)
-	GetMedals(req *pb.VoidMedal) (*pb.GetMedalsResponse, error)
-	GetMedalByFilter(req *pb.GetMedalByFilterRequest) (*pb.GetMedalByFilterResponse, error)
+	CreateMedal(ctx context.Context, req *pb.CreateMedalRequest, check AwardCheck) (*pb.CreateMedalResponse, error)
+	UpdateMedal(ctx context.Context, req *pb.UpdateMedalRequest, check AwardCheck) (*pb.UpdateMedalResponse, error)
+	DeleteMedal(ctx context.Context, req *pb.DeleteMedalRequest) (*pb.DeleteMedalResponse, error)
+	GetMedalById(ctx context.Context, req *pb.GetMedalByIdRequest) (*pb.GetMedalByIdResponse, error)
+	GetMedals(ctx context.Context, req *pb.GetMedalsRequest) (*pb.GetMedalsResponse, error)
+	GetMedalByFilter(ctx context.Context, req *pb.GetMedalByFilterRequest) (*pb.GetMedalByFilterResponse, error)
+	GetMedalTable(ctx context.Context, req *pb.GetMedalTableRequest) ([]*pb.MedalTableRow, error)
+	ImportMedals(ctx context.Context, req *pb.ImportMedalsRequest, check AwardCheck) (*pb.ImportResponse, error)
+	ExportMedals(ctx context.Context, req *pb.ExportMedalsRequest, send func(*pb.Medal) error) error
+	FinalizeEvent(ctx context.Context, eventID string, awards []*pb.CreateMedalRequest, check AwardCheck, dryRun bool) (*pb.FinalizeEventResponse, error)
 }
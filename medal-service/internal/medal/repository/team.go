@@ -0,0 +1,43 @@
+package repository
+
+import (
+	pb "github.com/Bekzodbekk/paris2024_livestream_protos/genproto/medalspb"
+	"github.com/lib/pq"
+)
+
+// medalFields are the columns every medal query reads, in the order
+// medalDest scans them. A medal has either an athlete or a team; a team
+// medal also lists the members it was awarded to.
+const medalFields = `medals.id, medals.country_id, medals.type, medals.event_id,
+	COALESCE(medals.athlete_id::text, ''), COALESCE(medals.team_id::text, ''),
+	medals.created_at, medals.updated_at, medals.deleted_at,
+	ARRAY(SELECT medal_members.athlete_id::text FROM medal_members WHERE medal_members.medal_id = medals.id ORDER BY medal_members.athlete_id)`
+
+// insertMedal writes a medal and, for a team medal, its members in one
+// statement. Its arguments are medalArgs. The members are written by the
+// same statement, so its result does not list them yet.
+const insertMedal = `
+	WITH medals AS (
+		INSERT INTO medals (country_id, type, event_id, athlete_id, team_id)
+		VALUES ($1, $2, $3, NULLIF($4, '')::uuid, NULLIF($5, '')::uuid)
+		RETURNING *
+	), members AS (
+		INSERT INTO medal_members (medal_id, athlete_id)
+		SELECT medals.id, unnest($6::uuid[]) FROM medals
+	)
+	SELECT ` + medalFields + ` FROM medals`
+
+func medalDest(medal *pb.Medal) []interface{} {
+	return []interface{}{
+		&medal.Id, &medal.CountryId, &medal.Type, &medal.EventId, &medal.AthleteId, &medal.TeamId,
+		&medal.CreatedAt, &medal.UpdatedAt, &medal.DeletedAt, pq.Array(&medal.MemberIds),
+	}
+}
+
+func medalArgs(req *pb.CreateMedalRequest) []interface{} {
+	return []interface{}{req.CountryId, req.Type, req.EventId, req.AthleteId, req.TeamId, pq.Array(req.MemberIds)}
+}
+
+// creditsAthletes is the condition for the medals any of an array of
+// athletes won, alone or as a member of a team.
+const creditsAthletes = "(medals.athlete_id = ANY($%[1]d) OR medals.id IN (SELECT medal_id FROM medal_members WHERE medal_members.athlete_id = ANY($%[1]d)))"
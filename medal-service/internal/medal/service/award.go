@@ -0,0 +1,251 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	pbAthlete "github.com/Bekzodbekk/paris2024_livestream_protos/genproto/athletepb"
+	pbCountry "github.com/Bekzodbekk/paris2024_livestream_protos/genproto/countrypb"
+	pbEvent "github.com/Bekzodbekk/paris2024_livestream_protos/genproto/eventpb"
+	pb "github.com/Bekzodbekk/paris2024_livestream_protos/genproto/medalspb"
+	"medal-service/internal/medal/repository"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Clients reach the services that own the records a medal refers to.
+type Clients struct {
+	Country pbCountry.CountryServiceClient
+	Event   pbEvent.EventServiceClient
+	Athlete pbAthlete.AthleteServiceClient
+}
+
+// AwardRules tells team events apart from individual ones. An event is a team
+// event when its sport is listed in TeamSports or its name contains one of
+// TeamKeywords (e.g. "relay"); both are matched case-insensitively. Events of
+// the SharedBronzeSports, which have no bronze final (judo, boxing,
+// wrestling, ...), award two bronzes; every other event awards one.
+type AwardRules struct {
+	TeamSports         []string
+	TeamKeywords       []string
+	SharedBronzeSports []string
+}
+
+// EventRules are the award rules that apply to one event.
+type EventRules struct {
+	// Team is set when every member of the winning team receives a medal
+	// of the same type.
+	Team bool
+	// Bronzes is how many bronze medals the event hands out.
+	Bronzes int
+	// Tied counts, by medal type, the places a tie in the final results
+	// adds. Only FinalizeEvent sets it, from the ranks event-service
+	// recorded; a medal entered by hand cannot claim a tie.
+	Tied map[int32]int
+}
+
+// ForEvent works out the rules for event.
+func (r AwardRules) ForEvent(event *pbEvent.Event) EventRules {
+	rules := EventRules{Team: r.IsTeamEvent(event), Bronzes: 1}
+	for _, sport := range r.SharedBronzeSports {
+		if strings.EqualFold(event.SportType, sport) {
+			rules.Bronzes = 2
+		}
+	}
+	return rules
+}
+
+// IsTeamEvent reports whether every member of the winning team receives a
+// medal of the same type.
+func (r AwardRules) IsTeamEvent(event *pbEvent.Event) bool {
+	for _, sport := range r.TeamSports {
+		if strings.EqualFold(event.SportType, sport) {
+			return true
+		}
+	}
+	name := strings.ToLower(event.Name)
+	for _, keyword := range r.TeamKeywords {
+		if keyword != "" && strings.Contains(name, strings.ToLower(keyword)) {
+			return true
+		}
+	}
+	return false
+}
+
+// limit is how many places of a type the event hands out.
+func (r EventRules) limit(medalType int32) int {
+	limit := 1
+	if medalType == repository.MedalBronze && r.Bronzes > 0 {
+		limit = r.Bronzes
+	}
+	return limit + r.Tied[medalType]
+}
+
+func medalTypeName(medalType int32) string {
+	switch medalType {
+	case repository.MedalGold:
+		return "gold"
+	case repository.MedalSilver:
+		return "silver"
+	case repository.MedalBronze:
+		return "bronze"
+	}
+	return fmt.Sprint(medalType)
+}
+
+// credited lists the athletes a medal is credited to: its athlete, or the
+// members of its team.
+func credited(athleteID string, memberIDs []string) []string {
+	if athleteID != "" {
+		return []string{athleteID}
+	}
+	return memberIDs
+}
+
+// CheckAwardLimits enforces the per-event award rules against the medals the
+// event already holds. No athlete is credited with two medals of one event,
+// alone or through a team. In an individual event every medal is a place of
+// its own, as is every team medal; in a team event awarded member by member
+// the members of one country's team share a place, so a gold can go to each
+// of them but not to a second country.
+func CheckAwardLimits(medal *pb.CreateMedalRequest, awarded []*pb.Medal, rules EventRules) error {
+	team := rules.Team
+	holders := make(map[string]bool)
+	places := make(map[string]bool)
+	for i, other := range awarded {
+		if medal.TeamId != "" && other.TeamId == medal.TeamId {
+			return status.Errorf(codes.FailedPrecondition, "team %s already holds a medal in event %s", medal.TeamId, medal.EventId)
+		}
+		for _, id := range credited(other.AthleteId, other.MemberIds) {
+			holders[id] = true
+		}
+		if int32(other.Type) != int32(medal.Type) {
+			continue
+		}
+		if team && other.TeamId == "" {
+			places["country "+other.CountryId] = true
+		} else {
+			places[fmt.Sprint("medal ", i)] = true
+		}
+	}
+	for _, id := range credited(medal.AthleteId, medal.MemberIds) {
+		if holders[id] {
+			return status.Errorf(codes.FailedPrecondition, "athlete %s already holds a medal in event %s", id, medal.EventId)
+		}
+	}
+
+	if team && medal.TeamId == "" && places["country "+medal.CountryId] {
+		return nil
+	}
+	if limit := rules.limit(int32(medal.Type)); len(places) >= limit {
+		return status.Errorf(codes.FailedPrecondition, "event %s already has %d %s medal(s)", medal.EventId, limit, medalTypeName(int32(medal.Type)))
+	}
+	return nil
+}
+
+type cached[T any] struct {
+	value T
+	err   error
+}
+
+func lookup[T any](cache map[string]cached[T], id string, get func(id string) (T, error)) (T, error) {
+	if c, ok := cache[id]; ok {
+		return c.value, c.err
+	}
+	value, err := get(id)
+	cache[id] = cached[T]{value: value, err: err}
+	return value, err
+}
+
+// awardReferences resolves the records medals point at through the owning
+// services. Answers are remembered, so a bulk import asks once per id.
+type awardReferences struct {
+	ctx       context.Context
+	clients   Clients
+	rules     AwardRules
+	countries map[string]cached[*pbCountry.Country]
+	events    map[string]cached[*pbEvent.Event]
+	athletes  map[string]cached[*pbAthlete.GetAthleteResponse]
+	teams     map[string]cached[*pbAthlete.Team]
+}
+
+func newAwardReferences(ctx context.Context, clients Clients, rules AwardRules) *awardReferences {
+	return &awardReferences{
+		ctx:       ctx,
+		clients:   clients,
+		rules:     rules,
+		countries: make(map[string]cached[*pbCountry.Country]),
+		events:    make(map[string]cached[*pbEvent.Event]),
+		athletes:  make(map[string]cached[*pbAthlete.GetAthleteResponse]),
+		teams:     make(map[string]cached[*pbAthlete.Team]),
+	}
+}
+
+// check validates medal and its references and returns the rules of its
+// event. A team medal gets the team's current roster as its members.
+func (r *awardReferences) check(medal *pb.CreateMedalRequest) (EventRules, error) {
+	switch {
+	case medal.Type < repository.MedalGold || medal.Type > repository.MedalBronze:
+		return EventRules{}, status.Errorf(codes.InvalidArgument, "unknown medal type %d", medal.Type)
+	case medal.CountryId == "":
+		return EventRules{}, status.Error(codes.InvalidArgument, "country_id is required")
+	case medal.EventId == "":
+		return EventRules{}, status.Error(codes.InvalidArgument, "event_id is required")
+	case medal.AthleteId == "" && medal.TeamId == "":
+		return EventRules{}, status.Error(codes.InvalidArgument, "athlete_id or team_id is required")
+	case medal.AthleteId != "" && medal.TeamId != "":
+		return EventRules{}, status.Error(codes.InvalidArgument, "a medal goes to an athlete or a team, not both")
+	}
+
+	_, err := lookup(r.countries, medal.CountryId, func(id string) (*pbCountry.Country, error) {
+		return r.clients.Country.GetCountry(r.ctx, &pbCountry.GetCountryRequest{Id: id})
+	})
+	if err != nil {
+		return EventRules{}, referenceError("country", medal.CountryId, err)
+	}
+
+	event, err := lookup(r.events, medal.EventId, func(id string) (*pbEvent.Event, error) {
+		return r.clients.Event.GetEvent(r.ctx, &pbEvent.GetEventRequest{Id: id})
+	})
+	if err != nil {
+		return EventRules{}, referenceError("event", medal.EventId, err)
+	}
+
+	if medal.TeamId != "" {
+		team, err := lookup(r.teams, medal.TeamId, func(id string) (*pbAthlete.Team, error) {
+			return r.clients.Athlete.GetTeam(r.ctx, &pbAthlete.GetTeamRequest{Id: id})
+		})
+		if err != nil {
+			return EventRules{}, referenceError("team", medal.TeamId, err)
+		}
+		if team.CountryId != medal.CountryId {
+			return EventRules{}, status.Errorf(codes.FailedPrecondition, "team %s represents country %s, not %s", medal.TeamId, team.CountryId, medal.CountryId)
+		}
+		medal.MemberIds = team.AthleteIds
+		return r.rules.ForEvent(event), nil
+	}
+
+	athlete, err := lookup(r.athletes, medal.AthleteId, func(id string) (*pbAthlete.GetAthleteResponse, error) {
+		return r.clients.Athlete.GetAthlete(r.ctx, &pbAthlete.GetAthleteRequest{Id: id})
+	})
+	if err != nil {
+		return EventRules{}, referenceError("athlete", medal.AthleteId, err)
+	}
+	if athlete.CountryId != medal.CountryId {
+		return EventRules{}, status.Errorf(codes.FailedPrecondition, "athlete %s represents country %s, not %s", medal.AthleteId, athlete.CountryId, medal.CountryId)
+	}
+	medal.MemberIds = nil
+
+	return r.rules.ForEvent(event), nil
+}
+
+// referenceError tells a missing record, which the caller can fix, from a
+// service that could not be reached.
+func referenceError(kind, id string, err error) error {
+	if status.Code(err) == codes.NotFound {
+		return status.Errorf(codes.FailedPrecondition, "%s %s does not exist", kind, id)
+	}
+	return status.Errorf(codes.Unavailable, "failed to look up %s %s: %s", kind, id, status.Convert(err).Message())
+}
@@ -0,0 +1,191 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	pbAthlete "github.com/Bekzodbekk/paris2024_livestream_protos/genproto/athletepb"
+	pbCountry "github.com/Bekzodbekk/paris2024_livestream_protos/genproto/countrypb"
+	pbEvent "github.com/Bekzodbekk/paris2024_livestream_protos/genproto/eventpb"
+	pb "github.com/Bekzodbekk/paris2024_livestream_protos/genproto/medalspb"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"medal-service/internal/medal/repository"
+)
+
+type fakeCountryClient struct {
+	pbCountry.CountryServiceClient
+	countries map[string]*pbCountry.Country
+}
+
+func (f fakeCountryClient) GetCountry(ctx context.Context, req *pbCountry.GetCountryRequest, opts ...grpc.CallOption) (*pbCountry.Country, error) {
+	if country, ok := f.countries[req.Id]; ok {
+		return country, nil
+	}
+	return nil, status.Error(codes.NotFound, "not found")
+}
+
+type fakeEventClient struct {
+	pbEvent.EventServiceClient
+	events map[string]*pbEvent.Event
+}
+
+func (f fakeEventClient) GetEvent(ctx context.Context, req *pbEvent.GetEventRequest, opts ...grpc.CallOption) (*pbEvent.Event, error) {
+	if event, ok := f.events[req.Id]; ok {
+		return event, nil
+	}
+	return nil, status.Error(codes.NotFound, "not found")
+}
+
+type fakeAthleteClient struct {
+	pbAthlete.AthleteServiceClient
+	athletes map[string]*pbAthlete.GetAthleteResponse
+	teams    map[string]*pbAthlete.Team
+	calls    int
+}
+
+func (f *fakeAthleteClient) GetAthlete(ctx context.Context, req *pbAthlete.GetAthleteRequest, opts ...grpc.CallOption) (*pbAthlete.GetAthleteResponse, error) {
+	f.calls++
+	if athlete, ok := f.athletes[req.Id]; ok {
+		return athlete, nil
+	}
+	return nil, status.Error(codes.Unavailable, "connection refused")
+}
+
+func (f *fakeAthleteClient) GetTeam(ctx context.Context, req *pbAthlete.GetTeamRequest, opts ...grpc.CallOption) (*pbAthlete.Team, error) {
+	if team, ok := f.teams[req.Id]; ok {
+		return team, nil
+	}
+	return nil, status.Error(codes.NotFound, "not found")
+}
+
+func testReferences() (*awardReferences, *fakeAthleteClient) {
+	athletes := &fakeAthleteClient{athletes: map[string]*pbAthlete.GetAthleteResponse{
+		"marchand": {Id: "marchand", CountryId: "fra"},
+		"ledecky":  {Id: "ledecky", CountryId: "usa"},
+	}, teams: map[string]*pbAthlete.Team{
+		"usa-relay": {Id: "usa-relay", CountryId: "usa", AthleteIds: []string{"ledecky", "smith"}},
+	}}
+	clients := Clients{
+		Country: fakeCountryClient{countries: map[string]*pbCountry.Country{
+			"fra": {Id: "fra"},
+			"usa": {Id: "usa"},
+		}},
+		Event: fakeEventClient{events: map[string]*pbEvent.Event{
+			"200im": {Id: "200im", Name: "Men's 200m Individual Medley", SportType: "Swimming"},
+			"relay": {Id: "relay", Name: "Women's 4x200m Freestyle Relay", SportType: "Swimming"},
+		}},
+		Athlete: athletes,
+	}
+	rules := AwardRules{TeamSports: []string{"football"}, TeamKeywords: []string{"relay"}}
+	return newAwardReferences(context.Background(), clients, rules), athletes
+}
+
+func TestAwardReferences(t *testing.T) {
+	refs, athletes := testReferences()
+
+	rules, err := refs.check(&pb.CreateMedalRequest{CountryId: "fra", Type: repository.MedalGold, EventId: "200im", AthleteId: "marchand"})
+	assert.NoError(t, err)
+	assert.Equal(t, EventRules{Bronzes: 1}, rules)
+
+	rules, err = refs.check(&pb.CreateMedalRequest{CountryId: "usa", Type: repository.MedalGold, EventId: "relay", AthleteId: "ledecky"})
+	assert.NoError(t, err)
+	assert.True(t, rules.Team)
+
+	medal := &pb.CreateMedalRequest{CountryId: "usa", Type: repository.MedalGold, EventId: "relay", TeamId: "usa-relay", MemberIds: []string{"forged"}}
+	rules, err = refs.check(medal)
+	assert.NoError(t, err)
+	assert.True(t, rules.Team)
+	assert.Equal(t, []string{"ledecky", "smith"}, medal.MemberIds)
+
+	tests := []struct {
+		name  string
+		medal *pb.CreateMedalRequest
+		code  codes.Code
+	}{
+		{"unknown type", &pb.CreateMedalRequest{CountryId: "fra", Type: 3, EventId: "200im", AthleteId: "marchand"}, codes.InvalidArgument},
+		{"missing athlete", &pb.CreateMedalRequest{CountryId: "fra", Type: repository.MedalGold, EventId: "200im"}, codes.InvalidArgument},
+		{"athlete and team", &pb.CreateMedalRequest{CountryId: "usa", Type: repository.MedalGold, EventId: "relay", AthleteId: "ledecky", TeamId: "usa-relay"}, codes.InvalidArgument},
+		{"unknown team", &pb.CreateMedalRequest{CountryId: "usa", Type: repository.MedalGold, EventId: "relay", TeamId: "xyz"}, codes.FailedPrecondition},
+		{"wrong team country", &pb.CreateMedalRequest{CountryId: "fra", Type: repository.MedalGold, EventId: "relay", TeamId: "usa-relay"}, codes.FailedPrecondition},
+		{"unknown country", &pb.CreateMedalRequest{CountryId: "xyz", Type: repository.MedalGold, EventId: "200im", AthleteId: "marchand"}, codes.FailedPrecondition},
+		{"unknown event", &pb.CreateMedalRequest{CountryId: "fra", Type: repository.MedalGold, EventId: "xyz", AthleteId: "marchand"}, codes.FailedPrecondition},
+		{"wrong country", &pb.CreateMedalRequest{CountryId: "usa", Type: repository.MedalGold, EventId: "200im", AthleteId: "marchand"}, codes.FailedPrecondition},
+		{"athlete service down", &pb.CreateMedalRequest{CountryId: "fra", Type: repository.MedalGold, EventId: "200im", AthleteId: "xyz"}, codes.Unavailable},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := refs.check(tt.medal)
+			assert.Equal(t, tt.code, status.Code(err))
+		})
+	}
+
+	// marchand, ledecky and xyz were each looked up once.
+	assert.Equal(t, 3, athletes.calls)
+}
+
+func TestCheckAwardLimits(t *testing.T) {
+	individual := []*pb.Medal{
+		{Id: "1", CountryId: "fra", Type: repository.MedalGold, EventId: "judo", AthleteId: "riner"},
+		{Id: "2", CountryId: "jpn", Type: repository.MedalBronze, EventId: "judo", AthleteId: "saito"},
+	}
+	team := []*pb.Medal{
+		{Id: "1", CountryId: "usa", Type: repository.MedalGold, EventId: "relay", AthleteId: "a1"},
+		{Id: "2", CountryId: "usa", Type: repository.MedalGold, EventId: "relay", AthleteId: "a2"},
+		{Id: "3", CountryId: "aus", Type: repository.MedalBronze, EventId: "relay", AthleteId: "b1"},
+		{Id: "4", CountryId: "chn", Type: repository.MedalBronze, EventId: "relay", AthleteId: "c1"},
+	}
+	teams := []*pb.Medal{
+		{Id: "1", CountryId: "usa", Type: repository.MedalGold, EventId: "relay", TeamId: "usa", MemberIds: []string{"a1", "a2"}},
+		{Id: "2", CountryId: "aus", Type: repository.MedalBronze, EventId: "relay", TeamId: "aus", MemberIds: []string{"b1", "b2"}},
+	}
+
+	// The tests share a bronze, as judo does, so two teams can place third.
+	judo := EventRules{Bronzes: 2}
+	relay := EventRules{Team: true, Bronzes: 2}
+
+	tests := []struct {
+		name    string
+		medal   *pb.CreateMedalRequest
+		awarded []*pb.Medal
+		rules   EventRules
+		ok      bool
+	}{
+		{"first silver", &pb.CreateMedalRequest{CountryId: "kor", Type: repository.MedalSilver, EventId: "judo", AthleteId: "kim"}, individual, judo, true},
+		{"second gold", &pb.CreateMedalRequest{CountryId: "kor", Type: repository.MedalGold, EventId: "judo", AthleteId: "kim"}, individual, judo, false},
+		{"second bronze", &pb.CreateMedalRequest{CountryId: "kor", Type: repository.MedalBronze, EventId: "judo", AthleteId: "kim"}, individual, judo, true},
+		{"bronze final", &pb.CreateMedalRequest{CountryId: "kor", Type: repository.MedalBronze, EventId: "judo", AthleteId: "kim"}, individual, EventRules{Bronzes: 1}, false},
+		{"athlete already placed", &pb.CreateMedalRequest{CountryId: "fra", Type: repository.MedalSilver, EventId: "judo", AthleteId: "riner"}, individual, judo, false},
+		{"team member gold", &pb.CreateMedalRequest{CountryId: "usa", Type: repository.MedalGold, EventId: "relay", AthleteId: "a3"}, team, relay, true},
+		{"second team gold", &pb.CreateMedalRequest{CountryId: "gbr", Type: repository.MedalGold, EventId: "relay", AthleteId: "d1"}, team, relay, false},
+		{"team member bronze", &pb.CreateMedalRequest{CountryId: "chn", Type: repository.MedalBronze, EventId: "relay", AthleteId: "c2"}, team, relay, true},
+		{"third team bronze", &pb.CreateMedalRequest{CountryId: "gbr", Type: repository.MedalBronze, EventId: "relay", AthleteId: "d1"}, team, relay, false},
+		{"team silver", &pb.CreateMedalRequest{CountryId: "gbr", Type: repository.MedalSilver, EventId: "relay", TeamId: "gbr", MemberIds: []string{"d1", "d2"}}, teams, relay, true},
+		{"second team bronze", &pb.CreateMedalRequest{CountryId: "chn", Type: repository.MedalBronze, EventId: "relay", TeamId: "chn", MemberIds: []string{"c1", "c2"}}, teams, relay, true},
+		{"second gold team", &pb.CreateMedalRequest{CountryId: "gbr", Type: repository.MedalGold, EventId: "relay", TeamId: "gbr", MemberIds: []string{"d1", "d2"}}, teams, relay, false},
+		{"team placed twice", &pb.CreateMedalRequest{CountryId: "usa", Type: repository.MedalBronze, EventId: "relay", TeamId: "usa", MemberIds: []string{"a1", "a2"}}, teams, relay, false},
+		{"member of a placed team", &pb.CreateMedalRequest{CountryId: "usa", Type: repository.MedalSilver, EventId: "relay", AthleteId: "a1"}, teams, relay, false},
+		{"member already placed", &pb.CreateMedalRequest{CountryId: "gbr", Type: repository.MedalSilver, EventId: "relay", TeamId: "gbr", MemberIds: []string{"d1", "b2"}}, teams, relay, false},
+		{"tied gold", &pb.CreateMedalRequest{CountryId: "kor", Type: repository.MedalGold, EventId: "judo", AthleteId: "kim"}, individual, EventRules{Bronzes: 1, Tied: map[int32]int{repository.MedalGold: 1}}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := CheckAwardLimits(tt.medal, tt.awarded, tt.rules)
+			if tt.ok {
+				assert.NoError(t, err)
+			} else {
+				assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+			}
+		})
+	}
+}
+
+func TestAwardRulesForEvent(t *testing.T) {
+	rules := AwardRules{TeamSports: []string{"football"}, SharedBronzeSports: []string{"judo"}}
+
+	assert.Equal(t, EventRules{Bronzes: 2}, rules.ForEvent(&pbEvent.Event{Name: "Men -100 kg", SportType: "Judo"}))
+	assert.Equal(t, EventRules{Bronzes: 1}, rules.ForEvent(&pbEvent.Event{Name: "Men's Singles", SportType: "Table Tennis"}))
+	assert.Equal(t, EventRules{Team: true, Bronzes: 1}, rules.ForEvent(&pbEvent.Event{Name: "Women's Football", SportType: "Football"}))
+}
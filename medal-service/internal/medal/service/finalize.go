@@ -0,0 +1,237 @@
+package service
+
+import (
+	"context"
+
+	pbAthlete "github.com/Bekzodbekk/paris2024_livestream_protos/genproto/athletepb"
+	pbEvent "github.com/Bekzodbekk/paris2024_livestream_protos/genproto/eventpb"
+	pb "github.com/Bekzodbekk/paris2024_livestream_protos/genproto/medalspb"
+	"medal-service/internal/medal/pkg/metrics"
+	"medal-service/internal/medal/repository"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Phase formats and unit statuses as event-service reports them.
+const (
+	formatKnockout = "knockout"
+	unitCompleted  = "completed"
+)
+
+// place is a medal won by one competitor in the final results. A place
+// without an athlete belongs to a country's team.
+type place struct {
+	medalType int32
+	countryID string
+	athleteID string
+}
+
+// FinalizeEvent awards the medals of an event from its final results and
+// reconciles them with the medals it already holds. A winning team gets one
+// medal credited to its members; with DryRun nothing is written. The awards
+// go through the same reference and award limit checks as CreateMedal, so
+// results that call for more medals than the event's rules allow are refused
+// rather than stored.
+func (s *MedalService) FinalizeEvent(ctx context.Context, req *pb.FinalizeEventRequest) (*pb.FinalizeEventResponse, error) {
+	if req.EventId == "" {
+		return nil, status.Error(codes.InvalidArgument, "event_id is required")
+	}
+
+	refs := newAwardReferences(ctx, s.clients, s.rules)
+	event, err := lookup(refs.events, req.EventId, func(id string) (*pbEvent.Event, error) {
+		return s.clients.Event.GetEvent(ctx, &pbEvent.GetEventRequest{Id: id})
+	})
+	if err != nil {
+		return nil, referenceError("event", req.EventId, err)
+	}
+	phases, err := s.clients.Event.ListPhases(ctx, &pbEvent.ListPhasesRequest{EventId: req.EventId})
+	if err != nil {
+		return nil, referenceError("event", req.EventId, err)
+	}
+
+	rules := s.rules.ForEvent(event)
+	places, tied, err := finalPlaces(req.EventId, phases.Phases, rules)
+	if err != nil {
+		return nil, err
+	}
+	rules.Tied = tied
+	awards, err := s.expandTeams(ctx, event, places)
+	if err != nil {
+		return nil, err
+	}
+	for _, award := range awards {
+		if _, err := refs.check(award); err != nil {
+			return nil, err
+		}
+	}
+
+	resp, err := s.medalRepo.FinalizeEvent(ctx, req.EventId, awards, func(medal *pb.CreateMedalRequest, awarded []*pb.Medal) error {
+		return CheckAwardLimits(medal, awarded, rules)
+	}, req.DryRun)
+	if err != nil {
+		return nil, statusError(err, "event "+req.EventId)
+	}
+	if resp.Committed {
+		for _, award := range resp.Awards {
+			if award.Action == repository.AwardCreate {
+				metrics.MedalsAwarded.WithLabelValues(medalTypeName(int32(award.Type))).Inc()
+			}
+		}
+	}
+	return resp, nil
+}
+
+// finalPlaces reads the medals off the last phase of an event, which must
+// be complete. In a final ranked by time, score or distance the first three
+// places win, and tied competitors share a medal: two golds are followed by a
+// bronze. The returned counts are the places the ties add to each medal
+// type. In a knockout final the gold medal match gives gold and silver. An
+// event with one bronze gives it to the winner of the bronze medal match; one
+// that shares bronze gives it to the winners of its bronze medal matches or,
+// as in boxing, to both losing semifinalists when it has none.
+func finalPlaces(eventID string, phases []*pbEvent.Phase, rules EventRules) ([]place, map[int32]int, error) {
+	if len(phases) == 0 {
+		return nil, nil, status.Errorf(codes.FailedPrecondition, "event %s has no phases", eventID)
+	}
+	final := phases[len(phases)-1]
+	if len(final.Units) == 0 {
+		return nil, nil, status.Errorf(codes.FailedPrecondition, "%s of event %s has no units", final.Name, eventID)
+	}
+	for _, unit := range final.Units {
+		if unit.Status != unitCompleted {
+			return nil, nil, status.Errorf(codes.FailedPrecondition, "final results are not in: %s has no results yet", unit.Name)
+		}
+	}
+
+	var places []place
+	add := func(medalType int32, e *pbEvent.UnitEntry) {
+		places = append(places, place{medalType: medalType, countryID: e.CountryId, athleteID: e.AthleteId})
+	}
+
+	if final.Format != formatKnockout {
+		if len(final.Units) > 1 {
+			return nil, nil, status.Errorf(codes.FailedPrecondition, "%s of event %s has %d units; a final is a single unit", final.Name, eventID, len(final.Units))
+		}
+		// Members of one team may be ranked one by one; they hold a single
+		// place between them.
+		competitors := make(map[int32]map[string]bool)
+		for _, e := range final.Units[0].Entries {
+			if e.Rank < 1 || e.Rank > 3 {
+				continue
+			}
+			add(e.Rank-1, e)
+			key := e.AthleteId
+			if rules.Team || key == "" {
+				key = "country " + e.CountryId
+			}
+			if competitors[e.Rank-1] == nil {
+				competitors[e.Rank-1] = make(map[string]bool)
+			}
+			competitors[e.Rank-1][key] = true
+		}
+		tied := make(map[int32]int)
+		for medalType, keys := range competitors {
+			if len(keys) > 1 {
+				tied[medalType] = len(keys) - 1
+			}
+		}
+		return places, tied, nil
+	}
+
+	for _, e := range final.Units[0].Entries {
+		switch e.Rank {
+		case 1:
+			add(repository.MedalGold, e)
+		case 2:
+			add(repository.MedalSilver, e)
+		}
+	}
+	switch {
+	case len(final.Units) > 1:
+		for _, unit := range final.Units[1:] {
+			for _, e := range unit.Entries {
+				if e.Rank == 1 {
+					add(repository.MedalBronze, e)
+				}
+			}
+		}
+	case rules.Bronzes > 1 && len(phases) > 1:
+		for _, unit := range phases[len(phases)-2].Units {
+			for _, e := range unit.Entries {
+				if e.Rank == 2 {
+					add(repository.MedalBronze, e)
+				}
+			}
+		}
+	default:
+		return nil, nil, status.Errorf(codes.FailedPrecondition, "%s of event %s has no bronze medal match", final.Name, eventID)
+	}
+	return places, nil, nil
+}
+
+// expandTeams turns places into medals. A team's place, or any place in a
+// team event, goes to the country's team in the event's sport. Without a
+// registered team only the athletes the results name are awarded; a place
+// naming nobody is refused rather than guessed from the country's roster. An
+// athlete or team placed twice keeps the better medal.
+func (s *MedalService) expandTeams(ctx context.Context, event *pbEvent.Event, places []place) ([]*pb.CreateMedalRequest, error) {
+	team := s.rules.IsTeamEvent(event)
+	teams := make(map[string]*pbAthlete.Team)
+
+	var awards []*pb.CreateMedalRequest
+	awarded := make(map[string]*pb.CreateMedalRequest)
+	give := func(key string, award *pb.CreateMedalRequest) {
+		if held, ok := awarded[key]; ok {
+			if award.Type < held.Type {
+				held.Type = award.Type
+			}
+			return
+		}
+		award.EventId = event.Id
+		awarded[key] = award
+		awards = append(awards, award)
+	}
+
+	for _, p := range places {
+		if p.athleteID != "" && !team {
+			give(p.athleteID, &pb.CreateMedalRequest{CountryId: p.countryID, Type: p.medalType, AthleteId: p.athleteID})
+			continue
+		}
+
+		t, ok := teams[p.countryID]
+		if !ok {
+			var err error
+			if t, err = s.countryTeam(ctx, p.countryID, event.SportType); err != nil {
+				return nil, err
+			}
+			teams[p.countryID] = t
+		}
+		if t != nil {
+			give("team "+t.Id, &pb.CreateMedalRequest{CountryId: p.countryID, Type: p.medalType, TeamId: t.Id, MemberIds: t.AthleteIds})
+			continue
+		}
+
+		if p.athleteID == "" {
+			return nil, status.Errorf(codes.FailedPrecondition, "country %s has no %s team registered to award", p.countryID, event.SportType)
+		}
+		give(p.athleteID, &pb.CreateMedalRequest{CountryId: p.countryID, Type: p.medalType, AthleteId: p.athleteID})
+	}
+	return awards, nil
+}
+
+// countryTeam finds the team a country enters in a sport, or nil if it has
+// none. A country with several cannot be told apart by its results alone.
+func (s *MedalService) countryTeam(ctx context.Context, countryID, sportType string) (*pbAthlete.Team, error) {
+	resp, err := s.clients.Athlete.ListTeams(ctx, &pbAthlete.ListTeamsRequest{CountryId: countryID, SportType: sportType, PageSize: 2})
+	if err != nil {
+		return nil, status.Errorf(codes.Unavailable, "failed to list the teams of country %s: %s", countryID, status.Convert(err).Message())
+	}
+	switch len(resp.Teams) {
+	case 0:
+		return nil, nil
+	case 1:
+		return resp.Teams[0], nil
+	}
+	return nil, status.Errorf(codes.FailedPrecondition, "country %s has several %s teams; award the medal to the team directly", countryID, sportType)
+}
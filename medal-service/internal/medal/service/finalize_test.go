@@ -0,0 +1,184 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	pbAthlete "github.com/Bekzodbekk/paris2024_livestream_protos/genproto/athletepb"
+	pbEvent "github.com/Bekzodbekk/paris2024_livestream_protos/genproto/eventpb"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"medal-service/internal/medal/repository"
+)
+
+type fakeRosterClient struct {
+	pbAthlete.AthleteServiceClient
+	athletes []*pbAthlete.GetAthleteResponse
+	teams    []*pbAthlete.Team
+}
+
+func (f fakeRosterClient) ListOfAthlete(ctx context.Context, req *pbAthlete.ListOfAthleteRequest, opts ...grpc.CallOption) (*pbAthlete.ListOfAthleteResponse, error) {
+	resp := &pbAthlete.ListOfAthleteResponse{}
+	for _, athlete := range f.athletes {
+		if athlete.CountryId == req.CountryId && athlete.SportType == req.SportType {
+			resp.Athletes = append(resp.Athletes, athlete)
+		}
+	}
+	return resp, nil
+}
+
+func (f fakeRosterClient) ListTeams(ctx context.Context, req *pbAthlete.ListTeamsRequest, opts ...grpc.CallOption) (*pbAthlete.ListTeamsResponse, error) {
+	resp := &pbAthlete.ListTeamsResponse{}
+	for _, team := range f.teams {
+		if team.CountryId == req.CountryId && team.SportType == req.SportType {
+			resp.Teams = append(resp.Teams, team)
+		}
+	}
+	return resp, nil
+}
+
+func entry(athleteID, countryID string, rank int32) *pbEvent.UnitEntry {
+	return &pbEvent.UnitEntry{AthleteId: athleteID, CountryId: countryID, Rank: rank}
+}
+
+func completed(name string, entries ...*pbEvent.UnitEntry) *pbEvent.Unit {
+	return &pbEvent.Unit{Name: name, Status: unitCompleted, Entries: entries}
+}
+
+func TestFinalPlacesTies(t *testing.T) {
+	phases := []*pbEvent.Phase{{
+		Name:   "Final",
+		Format: "heats",
+		Units: []*pbEvent.Unit{completed("Final",
+			entry("a", "usa", 1), entry("b", "can", 1), entry("c", "aus", 3), entry("d", "fra", 4), entry("e", "gbr", 0)),
+		},
+	}}
+
+	places, tied, err := finalPlaces("100fr", phases, EventRules{Bronzes: 1})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []place{
+		{medalType: repository.MedalGold, countryID: "usa", athleteID: "a"},
+		{medalType: repository.MedalGold, countryID: "can", athleteID: "b"},
+		{medalType: repository.MedalBronze, countryID: "aus", athleteID: "c"},
+	}, places)
+	assert.Equal(t, map[int32]int{repository.MedalGold: 1}, tied)
+
+	// The swimmers of one relay ranked together share a place, not a tie.
+	phases[0].Units[0].Entries = []*pbEvent.UnitEntry{entry("a", "usa", 1), entry("b", "usa", 1), entry("c", "aus", 2)}
+	_, tied, err = finalPlaces("4x100", phases, EventRules{Team: true, Bronzes: 1})
+	assert.NoError(t, err)
+	assert.Empty(t, tied)
+}
+
+func TestFinalPlacesKnockout(t *testing.T) {
+	semis := &pbEvent.Phase{Name: "Semifinals", Format: formatKnockout, Units: []*pbEvent.Unit{
+		completed("Match 1", entry("a", "fra", 1), entry("c", "jpn", 2)),
+		completed("Match 2", entry("b", "geo", 1), entry("d", "kor", 2)),
+	}}
+	final := &pbEvent.Phase{Name: "Final", Format: formatKnockout, Units: []*pbEvent.Unit{
+		completed("Gold Medal Match", entry("b", "geo", 1), entry("a", "fra", 2)),
+	}}
+
+	boxing := EventRules{Bronzes: 2}
+	judo := EventRules{Bronzes: 2}
+	tennis := EventRules{Bronzes: 1}
+
+	// Without a bronze medal match a sport that shares bronze gives it to
+	// both losing semifinalists.
+	places, tied, err := finalPlaces("boxing", []*pbEvent.Phase{semis, final}, boxing)
+	assert.NoError(t, err)
+	assert.Empty(t, tied)
+	assert.Equal(t, []place{
+		{medalType: repository.MedalGold, countryID: "geo", athleteID: "b"},
+		{medalType: repository.MedalSilver, countryID: "fra", athleteID: "a"},
+		{medalType: repository.MedalBronze, countryID: "jpn", athleteID: "c"},
+		{medalType: repository.MedalBronze, countryID: "kor", athleteID: "d"},
+	}, places)
+
+	// A sport with one bronze needs its bronze medal match.
+	_, _, err = finalPlaces("tennis", []*pbEvent.Phase{semis, final}, tennis)
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+
+	// With one, only its winner does.
+	final.Units = append(final.Units, completed("Bronze Medal Match", entry("c", "jpn", 2), entry("d", "kor", 1)))
+	places, _, err = finalPlaces("tennis", []*pbEvent.Phase{semis, final}, tennis)
+	assert.NoError(t, err)
+	assert.Len(t, places, 3)
+	assert.Equal(t, place{medalType: repository.MedalBronze, countryID: "kor", athleteID: "d"}, places[2])
+
+	// Judo holds two bronze medal matches.
+	final.Units = append(final.Units, completed("Bronze Medal Match 2", entry("e", "bra", 1), entry("f", "ita", 2)))
+	places, _, err = finalPlaces("judo", []*pbEvent.Phase{semis, final}, judo)
+	assert.NoError(t, err)
+	assert.Len(t, places, 4)
+	assert.Equal(t, place{medalType: repository.MedalBronze, countryID: "bra", athleteID: "e"}, places[3])
+
+	final.Units[1].Status = "scheduled"
+	_, _, err = finalPlaces("judo", []*pbEvent.Phase{semis, final}, judo)
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+
+	_, _, err = finalPlaces("judo", nil, judo)
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+}
+
+func TestExpandTeams(t *testing.T) {
+	s := &MedalService{
+		clients: Clients{Athlete: fakeRosterClient{athletes: []*pbAthlete.GetAthleteResponse{
+			{Id: "u1", CountryId: "usa", SportType: "Basketball"},
+			{Id: "u2", CountryId: "usa", SportType: "Basketball"},
+			{Id: "u3", CountryId: "usa", SportType: "Basketball"},
+			{Id: "f1", CountryId: "fra", SportType: "Basketball"},
+		}}},
+		rules: AwardRules{TeamSports: []string{"basketball"}},
+	}
+	event := &pbEvent.Event{Id: "mbk", Name: "Men's Basketball", SportType: "Basketball"}
+
+	// Without registered teams only the athletes on the results are awarded,
+	// not the rest of their country's roster.
+	awards, err := s.expandTeams(context.Background(), event, []place{
+		{medalType: repository.MedalGold, countryID: "usa", athleteID: "u1"},
+		{medalType: repository.MedalGold, countryID: "usa", athleteID: "u2"},
+		{medalType: repository.MedalSilver, countryID: "fra", athleteID: "f1"},
+	})
+	assert.NoError(t, err)
+	assert.Len(t, awards, 3)
+	for _, award := range awards {
+		assert.Equal(t, "mbk", award.EventId)
+		assert.NotEqual(t, "u3", award.AthleteId)
+		if award.CountryId == "usa" {
+			assert.EqualValues(t, repository.MedalGold, award.Type)
+		} else {
+			assert.EqualValues(t, repository.MedalSilver, award.Type)
+		}
+	}
+
+	_, err = s.expandTeams(context.Background(), event, []place{{medalType: repository.MedalGold, countryID: "usa"}})
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+}
+
+func TestExpandTeamsToTeam(t *testing.T) {
+	roster := fakeRosterClient{
+		athletes: []*pbAthlete.GetAthleteResponse{{Id: "u1", CountryId: "usa", SportType: "Swimming"}},
+		teams: []*pbAthlete.Team{
+			{Id: "t1", CountryId: "usa", SportType: "Swimming", AthleteIds: []string{"u1", "u2", "u3", "u4"}},
+		},
+	}
+	s := &MedalService{clients: Clients{Athlete: roster}}
+	event := &pbEvent.Event{Id: "4x100", Name: "Men's 4x100m Freestyle Relay", SportType: "Swimming"}
+
+	// The relay goes to the team as one medal, not to each swimmer.
+	awards, err := s.expandTeams(context.Background(), event, []place{{medalType: repository.MedalSilver, countryID: "usa"}})
+	assert.NoError(t, err)
+	assert.Len(t, awards, 1)
+	assert.Equal(t, "t1", awards[0].TeamId)
+	assert.Empty(t, awards[0].AthleteId)
+	assert.Equal(t, []string{"u1", "u2", "u3", "u4"}, awards[0].MemberIds)
+
+	roster.teams = append(roster.teams, &pbAthlete.Team{Id: "t2", CountryId: "usa", SportType: "Swimming"})
+	s.clients.Athlete = roster
+	_, err = s.expandTeams(context.Background(), event, []place{{medalType: repository.MedalSilver, countryID: "usa"}})
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+}
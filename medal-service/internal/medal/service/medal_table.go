@@ -0,0 +1,65 @@
+package service
+
+import (
+	"sort"
+
+	pb "github.com/Bekzodbekk/paris2024_livestream_protos/genproto/medalspb"
+)
+
+const (
+	// RankByGold is the official IOC ordering: gold, then silver, then bronze.
+	RankByGold = "gold"
+	// RankByTotal orders countries by their total number of medals.
+	RankByTotal = "total"
+)
+
+// RankMedalTable sorts the table and assigns ranks in place. Countries that
+// tie on the ranking key share a rank, and the next rank skips accordingly
+// (1, 2, 2, 4).
+func RankMedalTable(table []*pb.MedalTableRow, rankBy string) {
+	key := goldKey
+	if rankBy == RankByTotal {
+		key = totalKey
+	}
+
+	sort.SliceStable(table, func(i, j int) bool {
+		if c := compareDesc(key(table[i]), key(table[j])); c != 0 {
+			return c < 0
+		}
+		// Tied countries are still listed in a stable, medal-weighted order.
+		if c := compareDesc(goldKey(table[i]), goldKey(table[j])); c != 0 {
+			return c < 0
+		}
+		return table[i].CountryId < table[j].CountryId
+	})
+
+	for i, row := range table {
+		if i > 0 && key(row) == key(table[i-1]) {
+			row.Rank = table[i-1].Rank
+			continue
+		}
+		row.Rank = int32(i + 1)
+	}
+}
+
+func goldKey(row *pb.MedalTableRow) [3]int32 {
+	return [3]int32{row.Gold, row.Silver, row.Bronze}
+}
+
+func totalKey(row *pb.MedalTableRow) [3]int32 {
+	return [3]int32{row.Total}
+}
+
+// compareDesc returns -1 when a ranks ahead of b, 1 when b ranks ahead of a
+// and 0 when they tie.
+func compareDesc(a, b [3]int32) int {
+	for n := range a {
+		switch {
+		case a[n] > b[n]:
+			return -1
+		case a[n] < b[n]:
+			return 1
+		}
+	}
+	return 0
+}
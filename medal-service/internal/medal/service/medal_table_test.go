@@ -0,0 +1,63 @@
+package service
+
+import (
+	"testing"
+
+	pb "github.com/Bekzodbekk/paris2024_livestream_protos/genproto/medalspb"
+	"github.com/stretchr/testify/assert"
+)
+
+func medalTable() []*pb.MedalTableRow {
+	return []*pb.MedalTableRow{
+		{CountryId: "usa", Gold: 40, Silver: 44, Bronze: 42, Total: 126},
+		{CountryId: "chn", Gold: 40, Silver: 27, Bronze: 24, Total: 91},
+		{CountryId: "jpn", Gold: 20, Silver: 12, Bronze: 13, Total: 45},
+		{CountryId: "aus", Gold: 18, Silver: 19, Bronze: 16, Total: 53},
+		{CountryId: "nzl", Gold: 10, Silver: 7, Bronze: 3, Total: 20},
+		{CountryId: "can", Gold: 9, Silver: 7, Bronze: 11, Total: 27},
+		{CountryId: "uzb", Gold: 8, Silver: 2, Bronze: 3, Total: 13},
+		{CountryId: "hun", Gold: 6, Silver: 7, Bronze: 6, Total: 19},
+		{CountryId: "isr", Gold: 1, Silver: 5, Bronze: 1, Total: 7},
+		{CountryId: "sui", Gold: 1, Silver: 2, Bronze: 5, Total: 8},
+		{CountryId: "xyz", Gold: 1, Silver: 5, Bronze: 1, Total: 7},
+	}
+}
+
+func ranks(table []*pb.MedalTableRow) map[string]int32 {
+	result := make(map[string]int32, len(table))
+	for _, row := range table {
+		result[row.CountryId] = row.Rank
+	}
+	return result
+}
+
+func TestRankMedalTableByGold(t *testing.T) {
+	table := medalTable()
+	RankMedalTable(table, RankByGold)
+
+	assert.Equal(t, "usa", table[0].CountryId)
+	assert.Equal(t, "chn", table[1].CountryId)
+
+	r := ranks(table)
+	assert.EqualValues(t, 1, r["usa"])
+	assert.EqualValues(t, 2, r["chn"])
+	assert.EqualValues(t, 3, r["jpn"])
+	assert.EqualValues(t, 4, r["aus"])
+	// Identical gold, silver and bronze counts share a rank.
+	assert.EqualValues(t, 9, r["isr"])
+	assert.EqualValues(t, 9, r["xyz"])
+	assert.EqualValues(t, 11, r["sui"])
+}
+
+func TestRankMedalTableByTotal(t *testing.T) {
+	table := medalTable()
+	RankMedalTable(table, RankByTotal)
+
+	r := ranks(table)
+	assert.EqualValues(t, 1, r["usa"])
+	assert.EqualValues(t, 2, r["chn"])
+	assert.EqualValues(t, 3, r["aus"])
+	assert.EqualValues(t, 4, r["jpn"])
+	assert.EqualValues(t, 10, r["isr"])
+	assert.EqualValues(t, 10, r["xyz"])
+}
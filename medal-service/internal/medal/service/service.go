@@ -2,42 +2,143 @@ package service
 
 import (
 	"context"
+	"errors"
 	pb "github.com/Bekzodbekk/paris2024_livestream_protos/genproto/medalspb"
 	
+	"medal-service/internal/medal/pkg/metrics"
 	"medal-service/internal/medal/repository"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 type MedalService struct {
 	pb.UnimplementedMedalServiceServer
 	medalRepo repository.MedalRepository
+	clients   Clients
+	rules     AwardRules
 }
 
-func NewMedalService(medal repository.MedalRepository) *MedalService {
+func NewMedalService(medal repository.MedalRepository, clients Clients, rules AwardRules) *MedalService {
 	return &MedalService{
 		medalRepo: medal,
+		clients:   clients,
+		rules:     rules,
 	}
 }
 
+// CreateMedal checks the medal's references before taking the event lock, so
+// no gRPC call is made while other awards for the event wait.
 func (s *MedalService) CreateMedal(ctx context.Context, req *pb.CreateMedalRequest) (*pb.CreateMedalResponse, error) {
-	return s.medalRepo.CreateMedal(req)
+	rules, err := newAwardReferences(ctx, s.clients, s.rules).check(req)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := s.medalRepo.CreateMedal(ctx, req, func(medal *pb.CreateMedalRequest, awarded []*pb.Medal) error {
+		return CheckAwardLimits(medal, awarded, rules)
+	})
+	if err != nil {
+		return nil, statusError(err, "medal")
+	}
+	metrics.MedalsAwarded.WithLabelValues(medalTypeName(int32(req.Type))).Inc()
+	return resp, nil
 }
 
 func (s *MedalService) UpdateMedal(ctx context.Context, req *pb.UpdateMedalRequest) (*pb.UpdateMedalResponse, error) {
-	return s.medalRepo.UpdateMedal(req)
+	award := &pb.CreateMedalRequest{
+		CountryId: req.CountryId,
+		Type:      req.Type,
+		EventId:   req.EventId,
+		AthleteId: req.AthleteId,
+		TeamId:    req.TeamId,
+	}
+	rules, err := newAwardReferences(ctx, s.clients, s.rules).check(award)
+	if err != nil {
+		return nil, err
+	}
+	req.MemberIds = award.MemberIds
+	resp, err := s.medalRepo.UpdateMedal(ctx, req, func(medal *pb.CreateMedalRequest, awarded []*pb.Medal) error {
+		return CheckAwardLimits(medal, awarded, rules)
+	})
+	return resp, statusError(err, "medal "+req.Id)
 }
 
 func (s *MedalService) DeleteMedal(ctx context.Context, req *pb.DeleteMedalRequest) (*pb.DeleteMedalResponse, error) {
-	return s.medalRepo.DeleteMedal(req)
+	resp, err := s.medalRepo.DeleteMedal(ctx, req)
+	return resp, statusError(err, "medal "+req.Id)
 }
 
 func (s *MedalService) GetMedalById(ctx context.Context, req *pb.GetMedalByIdRequest) (*pb.GetMedalByIdResponse, error) {
-	return s.medalRepo.GetMedalById(req)
+	resp, err := s.medalRepo.GetMedalById(ctx, req)
+	return resp, statusError(err, "medal "+req.Id)
 }
 
-func (s *MedalService) GetMedals(ctx context.Context, req *pb.VoidMedal) (*pb.GetMedalsResponse, error) {
-	return s.medalRepo.GetMedals(req)
+func (s *MedalService) GetMedals(ctx context.Context, req *pb.GetMedalsRequest) (*pb.GetMedalsResponse, error) {
+	resp, err := s.medalRepo.GetMedals(ctx, req)
+	return resp, statusError(err, "medals")
 }
 
 func (s *MedalService) GetMedalByFilter(ctx context.Context, req *pb.GetMedalByFilterRequest) (*pb.GetMedalByFilterResponse, error) {
-	return s.medalRepo.GetMedalByFilter(req)
+	resp, err := s.medalRepo.GetMedalByFilter(ctx, req)
+	return resp, statusError(err, "medals")
+}
+
+func (s *MedalService) GetMedalTable(ctx context.Context, req *pb.GetMedalTableRequest) (*pb.GetMedalTableResponse, error) {
+	switch req.RankBy {
+	case "":
+		req.RankBy = RankByGold
+	case RankByGold, RankByTotal:
+	default:
+		return nil, status.Errorf(codes.InvalidArgument, "rank_by must be %q or %q", RankByGold, RankByTotal)
+	}
+
+	table, err := s.medalRepo.GetMedalTable(ctx, req)
+	if err != nil {
+		return nil, statusError(err, "medal table")
+	}
+	RankMedalTable(table, req.RankBy)
+	return &pb.GetMedalTableResponse{Rows: table}, nil
+}
+
+// ImportMedals applies the same checks as CreateMedal to every row. As in
+// CreateMedal the references are resolved before the transaction is opened,
+// so no gRPC call is made while it holds the event locks. Rejected rows are
+// reported by message, as the other import errors are.
+func (s *MedalService) ImportMedals(ctx context.Context, req *pb.ImportMedalsRequest) (*pb.ImportResponse, error) {
+	type checked struct {
+		rules EventRules
+		err   error
+	}
+	refs := newAwardReferences(ctx, s.clients, s.rules)
+	results := make(map[*pb.CreateMedalRequest]checked, len(req.Medals))
+	for _, medal := range req.Medals {
+		rules, err := refs.check(medal)
+		results[medal] = checked{rules: rules, err: err}
+	}
+
+	resp, err := s.medalRepo.ImportMedals(ctx, req, func(medal *pb.CreateMedalRequest, awarded []*pb.Medal) error {
+		result := results[medal]
+		err := result.err
+		if err == nil {
+			err = CheckAwardLimits(medal, awarded, result.rules)
+		}
+		if err != nil {
+			return errors.New(status.Convert(err).Message())
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, statusError(err, "medals")
+	}
+	// Imports commit all rows or none.
+	if resp.Committed {
+		for _, medal := range req.Medals {
+			metrics.MedalsAwarded.WithLabelValues(medalTypeName(int32(medal.Type))).Inc()
+		}
+	}
+	return resp, nil
+}
+
+func (s *MedalService) ExportMedals(req *pb.ExportMedalsRequest, stream pb.MedalService_ExportMedalsServer) error {
+	return statusError(s.medalRepo.ExportMedals(stream.Context(), req, stream.Send), "medals")
 }
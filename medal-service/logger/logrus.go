@@ -16,6 +16,17 @@ func InitLog() {
 		logrus.Fatal(err) 
 	}
 	logrus.SetOutput(io.MultiWriter(os.Stdout, logFile)) 
+	logrus.AddHook(traceHook{})
+}
+
+// Close stops writing to the log file and closes it. Later entries still
+// go to stdout.
+func Close() error {
+	logrus.SetOutput(os.Stdout)
+	if logFile == nil {
+		return nil
+	}
+	return logFile.Close()
 }
 
 func SetOutput(output io.Writer) {
@@ -0,0 +1,543 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: athlete.proto
+
+package athletepb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	AthleteService_CreateAthlete_FullMethodName  = "/athlete.AthleteService/CreateAthlete"
+	AthleteService_GetAthlete_FullMethodName     = "/athlete.AthleteService/GetAthlete"
+	AthleteService_ListOfAthlete_FullMethodName  = "/athlete.AthleteService/ListOfAthlete"
+	AthleteService_UpdateAthlete_FullMethodName  = "/athlete.AthleteService/UpdateAthlete"
+	AthleteService_DeleteAthlete_FullMethodName  = "/athlete.AthleteService/DeleteAthlete"
+	AthleteService_ImportAthletes_FullMethodName = "/athlete.AthleteService/ImportAthletes"
+	AthleteService_ExportAthletes_FullMethodName = "/athlete.AthleteService/ExportAthletes"
+	AthleteService_CreateTeam_FullMethodName     = "/athlete.AthleteService/CreateTeam"
+	AthleteService_GetTeam_FullMethodName        = "/athlete.AthleteService/GetTeam"
+	AthleteService_ListTeams_FullMethodName      = "/athlete.AthleteService/ListTeams"
+	AthleteService_UpdateTeam_FullMethodName     = "/athlete.AthleteService/UpdateTeam"
+	AthleteService_DeleteTeam_FullMethodName     = "/athlete.AthleteService/DeleteTeam"
+)
+
+// AthleteServiceClient is the client API for AthleteService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type AthleteServiceClient interface {
+	CreateAthlete(ctx context.Context, in *CreateAthleteRequest, opts ...grpc.CallOption) (*Athlete, error)
+	GetAthlete(ctx context.Context, in *GetAthleteRequest, opts ...grpc.CallOption) (*GetAthleteResponse, error)
+	ListOfAthlete(ctx context.Context, in *ListOfAthleteRequest, opts ...grpc.CallOption) (*ListOfAthleteResponse, error)
+	UpdateAthlete(ctx context.Context, in *UpdateAthleteRequest, opts ...grpc.CallOption) (*Athlete, error)
+	DeleteAthlete(ctx context.Context, in *DeleteAthleteRequest, opts ...grpc.CallOption) (*DeleteAthleteResponse, error)
+	ImportAthletes(ctx context.Context, in *ImportAthletesRequest, opts ...grpc.CallOption) (*ImportResponse, error)
+	ExportAthletes(ctx context.Context, in *ExportAthletesRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Athlete], error)
+	CreateTeam(ctx context.Context, in *CreateTeamRequest, opts ...grpc.CallOption) (*Team, error)
+	GetTeam(ctx context.Context, in *GetTeamRequest, opts ...grpc.CallOption) (*Team, error)
+	ListTeams(ctx context.Context, in *ListTeamsRequest, opts ...grpc.CallOption) (*ListTeamsResponse, error)
+	UpdateTeam(ctx context.Context, in *UpdateTeamRequest, opts ...grpc.CallOption) (*Team, error)
+	DeleteTeam(ctx context.Context, in *DeleteTeamRequest, opts ...grpc.CallOption) (*DeleteTeamResponse, error)
+}
+
+type athleteServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewAthleteServiceClient(cc grpc.ClientConnInterface) AthleteServiceClient {
+	return &athleteServiceClient{cc}
+}
+
+func (c *athleteServiceClient) CreateAthlete(ctx context.Context, in *CreateAthleteRequest, opts ...grpc.CallOption) (*Athlete, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Athlete)
+	err := c.cc.Invoke(ctx, AthleteService_CreateAthlete_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *athleteServiceClient) GetAthlete(ctx context.Context, in *GetAthleteRequest, opts ...grpc.CallOption) (*GetAthleteResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetAthleteResponse)
+	err := c.cc.Invoke(ctx, AthleteService_GetAthlete_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *athleteServiceClient) ListOfAthlete(ctx context.Context, in *ListOfAthleteRequest, opts ...grpc.CallOption) (*ListOfAthleteResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListOfAthleteResponse)
+	err := c.cc.Invoke(ctx, AthleteService_ListOfAthlete_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *athleteServiceClient) UpdateAthlete(ctx context.Context, in *UpdateAthleteRequest, opts ...grpc.CallOption) (*Athlete, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Athlete)
+	err := c.cc.Invoke(ctx, AthleteService_UpdateAthlete_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *athleteServiceClient) DeleteAthlete(ctx context.Context, in *DeleteAthleteRequest, opts ...grpc.CallOption) (*DeleteAthleteResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteAthleteResponse)
+	err := c.cc.Invoke(ctx, AthleteService_DeleteAthlete_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *athleteServiceClient) ImportAthletes(ctx context.Context, in *ImportAthletesRequest, opts ...grpc.CallOption) (*ImportResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ImportResponse)
+	err := c.cc.Invoke(ctx, AthleteService_ImportAthletes_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *athleteServiceClient) ExportAthletes(ctx context.Context, in *ExportAthletesRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Athlete], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &AthleteService_ServiceDesc.Streams[0], AthleteService_ExportAthletes_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ExportAthletesRequest, Athlete]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type AthleteService_ExportAthletesClient = grpc.ServerStreamingClient[Athlete]
+
+func (c *athleteServiceClient) CreateTeam(ctx context.Context, in *CreateTeamRequest, opts ...grpc.CallOption) (*Team, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Team)
+	err := c.cc.Invoke(ctx, AthleteService_CreateTeam_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *athleteServiceClient) GetTeam(ctx context.Context, in *GetTeamRequest, opts ...grpc.CallOption) (*Team, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Team)
+	err := c.cc.Invoke(ctx, AthleteService_GetTeam_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *athleteServiceClient) ListTeams(ctx context.Context, in *ListTeamsRequest, opts ...grpc.CallOption) (*ListTeamsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListTeamsResponse)
+	err := c.cc.Invoke(ctx, AthleteService_ListTeams_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *athleteServiceClient) UpdateTeam(ctx context.Context, in *UpdateTeamRequest, opts ...grpc.CallOption) (*Team, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Team)
+	err := c.cc.Invoke(ctx, AthleteService_UpdateTeam_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *athleteServiceClient) DeleteTeam(ctx context.Context, in *DeleteTeamRequest, opts ...grpc.CallOption) (*DeleteTeamResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteTeamResponse)
+	err := c.cc.Invoke(ctx, AthleteService_DeleteTeam_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// AthleteServiceServer is the server API for AthleteService service.
+// All implementations must embed UnimplementedAthleteServiceServer
+// for forward compatibility.
+type AthleteServiceServer interface {
+	CreateAthlete(context.Context, *CreateAthleteRequest) (*Athlete, error)
+	GetAthlete(context.Context, *GetAthleteRequest) (*GetAthleteResponse, error)
+	ListOfAthlete(context.Context, *ListOfAthleteRequest) (*ListOfAthleteResponse, error)
+	UpdateAthlete(context.Context, *UpdateAthleteRequest) (*Athlete, error)
+	DeleteAthlete(context.Context, *DeleteAthleteRequest) (*DeleteAthleteResponse, error)
+	ImportAthletes(context.Context, *ImportAthletesRequest) (*ImportResponse, error)
+	ExportAthletes(*ExportAthletesRequest, grpc.ServerStreamingServer[Athlete]) error
+	CreateTeam(context.Context, *CreateTeamRequest) (*Team, error)
+	GetTeam(context.Context, *GetTeamRequest) (*Team, error)
+	ListTeams(context.Context, *ListTeamsRequest) (*ListTeamsResponse, error)
+	UpdateTeam(context.Context, *UpdateTeamRequest) (*Team, error)
+	DeleteTeam(context.Context, *DeleteTeamRequest) (*DeleteTeamResponse, error)
+	mustEmbedUnimplementedAthleteServiceServer()
+}
+
+// UnimplementedAthleteServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedAthleteServiceServer struct{}
+
+func (UnimplementedAthleteServiceServer) CreateAthlete(context.Context, *CreateAthleteRequest) (*Athlete, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateAthlete not implemented")
+}
+func (UnimplementedAthleteServiceServer) GetAthlete(context.Context, *GetAthleteRequest) (*GetAthleteResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAthlete not implemented")
+}
+func (UnimplementedAthleteServiceServer) ListOfAthlete(context.Context, *ListOfAthleteRequest) (*ListOfAthleteResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListOfAthlete not implemented")
+}
+func (UnimplementedAthleteServiceServer) UpdateAthlete(context.Context, *UpdateAthleteRequest) (*Athlete, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateAthlete not implemented")
+}
+func (UnimplementedAthleteServiceServer) DeleteAthlete(context.Context, *DeleteAthleteRequest) (*DeleteAthleteResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteAthlete not implemented")
+}
+func (UnimplementedAthleteServiceServer) ImportAthletes(context.Context, *ImportAthletesRequest) (*ImportResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ImportAthletes not implemented")
+}
+func (UnimplementedAthleteServiceServer) ExportAthletes(*ExportAthletesRequest, grpc.ServerStreamingServer[Athlete]) error {
+	return status.Errorf(codes.Unimplemented, "method ExportAthletes not implemented")
+}
+func (UnimplementedAthleteServiceServer) CreateTeam(context.Context, *CreateTeamRequest) (*Team, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateTeam not implemented")
+}
+func (UnimplementedAthleteServiceServer) GetTeam(context.Context, *GetTeamRequest) (*Team, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTeam not implemented")
+}
+func (UnimplementedAthleteServiceServer) ListTeams(context.Context, *ListTeamsRequest) (*ListTeamsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListTeams not implemented")
+}
+func (UnimplementedAthleteServiceServer) UpdateTeam(context.Context, *UpdateTeamRequest) (*Team, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateTeam not implemented")
+}
+func (UnimplementedAthleteServiceServer) DeleteTeam(context.Context, *DeleteTeamRequest) (*DeleteTeamResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteTeam not implemented")
+}
+func (UnimplementedAthleteServiceServer) mustEmbedUnimplementedAthleteServiceServer() {}
+func (UnimplementedAthleteServiceServer) testEmbeddedByValue()                        {}
+
+// UnsafeAthleteServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to AthleteServiceServer will
+// result in compilation errors.
+type UnsafeAthleteServiceServer interface {
+	mustEmbedUnimplementedAthleteServiceServer()
+}
+
+func RegisterAthleteServiceServer(s grpc.ServiceRegistrar, srv AthleteServiceServer) {
+	// If the following call pancis, it indicates UnimplementedAthleteServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&AthleteService_ServiceDesc, srv)
+}
+
+func _AthleteService_CreateAthlete_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateAthleteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AthleteServiceServer).CreateAthlete(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AthleteService_CreateAthlete_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AthleteServiceServer).CreateAthlete(ctx, req.(*CreateAthleteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AthleteService_GetAthlete_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetAthleteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AthleteServiceServer).GetAthlete(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AthleteService_GetAthlete_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AthleteServiceServer).GetAthlete(ctx, req.(*GetAthleteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AthleteService_ListOfAthlete_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListOfAthleteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AthleteServiceServer).ListOfAthlete(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AthleteService_ListOfAthlete_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AthleteServiceServer).ListOfAthlete(ctx, req.(*ListOfAthleteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AthleteService_UpdateAthlete_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateAthleteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AthleteServiceServer).UpdateAthlete(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AthleteService_UpdateAthlete_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AthleteServiceServer).UpdateAthlete(ctx, req.(*UpdateAthleteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AthleteService_DeleteAthlete_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteAthleteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AthleteServiceServer).DeleteAthlete(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AthleteService_DeleteAthlete_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AthleteServiceServer).DeleteAthlete(ctx, req.(*DeleteAthleteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AthleteService_ImportAthletes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ImportAthletesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AthleteServiceServer).ImportAthletes(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AthleteService_ImportAthletes_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AthleteServiceServer).ImportAthletes(ctx, req.(*ImportAthletesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AthleteService_ExportAthletes_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ExportAthletesRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(AthleteServiceServer).ExportAthletes(m, &grpc.GenericServerStream[ExportAthletesRequest, Athlete]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type AthleteService_ExportAthletesServer = grpc.ServerStreamingServer[Athlete]
+
+func _AthleteService_CreateTeam_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateTeamRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AthleteServiceServer).CreateTeam(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AthleteService_CreateTeam_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AthleteServiceServer).CreateTeam(ctx, req.(*CreateTeamRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AthleteService_GetTeam_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTeamRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AthleteServiceServer).GetTeam(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AthleteService_GetTeam_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AthleteServiceServer).GetTeam(ctx, req.(*GetTeamRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AthleteService_ListTeams_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListTeamsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AthleteServiceServer).ListTeams(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AthleteService_ListTeams_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AthleteServiceServer).ListTeams(ctx, req.(*ListTeamsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AthleteService_UpdateTeam_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateTeamRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AthleteServiceServer).UpdateTeam(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AthleteService_UpdateTeam_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AthleteServiceServer).UpdateTeam(ctx, req.(*UpdateTeamRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AthleteService_DeleteTeam_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteTeamRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AthleteServiceServer).DeleteTeam(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AthleteService_DeleteTeam_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AthleteServiceServer).DeleteTeam(ctx, req.(*DeleteTeamRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// AthleteService_ServiceDesc is the grpc.ServiceDesc for AthleteService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var AthleteService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "athlete.AthleteService",
+	HandlerType: (*AthleteServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateAthlete",
+			Handler:    _AthleteService_CreateAthlete_Handler,
+		},
+		{
+			MethodName: "GetAthlete",
+			Handler:    _AthleteService_GetAthlete_Handler,
+		},
+		{
+			MethodName: "ListOfAthlete",
+			Handler:    _AthleteService_ListOfAthlete_Handler,
+		},
+		{
+			MethodName: "UpdateAthlete",
+			Handler:    _AthleteService_UpdateAthlete_Handler,
+		},
+		{
+			MethodName: "DeleteAthlete",
+			Handler:    _AthleteService_DeleteAthlete_Handler,
+		},
+		{
+			MethodName: "ImportAthletes",
+			Handler:    _AthleteService_ImportAthletes_Handler,
+		},
+		{
+			MethodName: "CreateTeam",
+			Handler:    _AthleteService_CreateTeam_Handler,
+		},
+		{
+			MethodName: "GetTeam",
+			Handler:    _AthleteService_GetTeam_Handler,
+		},
+		{
+			MethodName: "ListTeams",
+			Handler:    _AthleteService_ListTeams_Handler,
+		},
+		{
+			MethodName: "UpdateTeam",
+			Handler:    _AthleteService_UpdateTeam_Handler,
+		},
+		{
+			MethodName: "DeleteTeam",
+			Handler:    _AthleteService_DeleteTeam_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "ExportAthletes",
+			Handler:       _AthleteService_ExportAthletes_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "athlete.proto",
+}
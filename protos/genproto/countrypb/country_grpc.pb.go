@@ -0,0 +1,353 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: country.proto
+
+package countrypb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	CountryService_CreateCountry_FullMethodName   = "/country.CountryService/CreateCountry"
+	CountryService_GetCountry_FullMethodName      = "/country.CountryService/GetCountry"
+	CountryService_ListOfCountry_FullMethodName   = "/country.CountryService/ListOfCountry"
+	CountryService_UpdateCountry_FullMethodName   = "/country.CountryService/UpdateCountry"
+	CountryService_DeleteCountry_FullMethodName   = "/country.CountryService/DeleteCountry"
+	CountryService_ImportCountries_FullMethodName = "/country.CountryService/ImportCountries"
+	CountryService_ExportCountries_FullMethodName = "/country.CountryService/ExportCountries"
+)
+
+// CountryServiceClient is the client API for CountryService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type CountryServiceClient interface {
+	CreateCountry(ctx context.Context, in *CreateCountryRequest, opts ...grpc.CallOption) (*Country, error)
+	GetCountry(ctx context.Context, in *GetCountryRequest, opts ...grpc.CallOption) (*Country, error)
+	ListOfCountry(ctx context.Context, in *ListOfCountryRequest, opts ...grpc.CallOption) (*ListOfCountryResponse, error)
+	UpdateCountry(ctx context.Context, in *UpdateCountryRequest, opts ...grpc.CallOption) (*Country, error)
+	DeleteCountry(ctx context.Context, in *DeleteCountryRequest, opts ...grpc.CallOption) (*DeleteCountryResponse, error)
+	ImportCountries(ctx context.Context, in *ImportCountriesRequest, opts ...grpc.CallOption) (*ImportResponse, error)
+	ExportCountries(ctx context.Context, in *ExportCountriesRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Country], error)
+}
+
+type countryServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewCountryServiceClient(cc grpc.ClientConnInterface) CountryServiceClient {
+	return &countryServiceClient{cc}
+}
+
+func (c *countryServiceClient) CreateCountry(ctx context.Context, in *CreateCountryRequest, opts ...grpc.CallOption) (*Country, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Country)
+	err := c.cc.Invoke(ctx, CountryService_CreateCountry_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *countryServiceClient) GetCountry(ctx context.Context, in *GetCountryRequest, opts ...grpc.CallOption) (*Country, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Country)
+	err := c.cc.Invoke(ctx, CountryService_GetCountry_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *countryServiceClient) ListOfCountry(ctx context.Context, in *ListOfCountryRequest, opts ...grpc.CallOption) (*ListOfCountryResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListOfCountryResponse)
+	err := c.cc.Invoke(ctx, CountryService_ListOfCountry_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *countryServiceClient) UpdateCountry(ctx context.Context, in *UpdateCountryRequest, opts ...grpc.CallOption) (*Country, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Country)
+	err := c.cc.Invoke(ctx, CountryService_UpdateCountry_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *countryServiceClient) DeleteCountry(ctx context.Context, in *DeleteCountryRequest, opts ...grpc.CallOption) (*DeleteCountryResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteCountryResponse)
+	err := c.cc.Invoke(ctx, CountryService_DeleteCountry_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *countryServiceClient) ImportCountries(ctx context.Context, in *ImportCountriesRequest, opts ...grpc.CallOption) (*ImportResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ImportResponse)
+	err := c.cc.Invoke(ctx, CountryService_ImportCountries_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *countryServiceClient) ExportCountries(ctx context.Context, in *ExportCountriesRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Country], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &CountryService_ServiceDesc.Streams[0], CountryService_ExportCountries_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ExportCountriesRequest, Country]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type CountryService_ExportCountriesClient = grpc.ServerStreamingClient[Country]
+
+// CountryServiceServer is the server API for CountryService service.
+// All implementations must embed UnimplementedCountryServiceServer
+// for forward compatibility.
+type CountryServiceServer interface {
+	CreateCountry(context.Context, *CreateCountryRequest) (*Country, error)
+	GetCountry(context.Context, *GetCountryRequest) (*Country, error)
+	ListOfCountry(context.Context, *ListOfCountryRequest) (*ListOfCountryResponse, error)
+	UpdateCountry(context.Context, *UpdateCountryRequest) (*Country, error)
+	DeleteCountry(context.Context, *DeleteCountryRequest) (*DeleteCountryResponse, error)
+	ImportCountries(context.Context, *ImportCountriesRequest) (*ImportResponse, error)
+	ExportCountries(*ExportCountriesRequest, grpc.ServerStreamingServer[Country]) error
+	mustEmbedUnimplementedCountryServiceServer()
+}
+
+// UnimplementedCountryServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedCountryServiceServer struct{}
+
+func (UnimplementedCountryServiceServer) CreateCountry(context.Context, *CreateCountryRequest) (*Country, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateCountry not implemented")
+}
+func (UnimplementedCountryServiceServer) GetCountry(context.Context, *GetCountryRequest) (*Country, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCountry not implemented")
+}
+func (UnimplementedCountryServiceServer) ListOfCountry(context.Context, *ListOfCountryRequest) (*ListOfCountryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListOfCountry not implemented")
+}
+func (UnimplementedCountryServiceServer) UpdateCountry(context.Context, *UpdateCountryRequest) (*Country, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateCountry not implemented")
+}
+func (UnimplementedCountryServiceServer) DeleteCountry(context.Context, *DeleteCountryRequest) (*DeleteCountryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteCountry not implemented")
+}
+func (UnimplementedCountryServiceServer) ImportCountries(context.Context, *ImportCountriesRequest) (*ImportResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ImportCountries not implemented")
+}
+func (UnimplementedCountryServiceServer) ExportCountries(*ExportCountriesRequest, grpc.ServerStreamingServer[Country]) error {
+	return status.Errorf(codes.Unimplemented, "method ExportCountries not implemented")
+}
+func (UnimplementedCountryServiceServer) mustEmbedUnimplementedCountryServiceServer() {}
+func (UnimplementedCountryServiceServer) testEmbeddedByValue()                        {}
+
+// UnsafeCountryServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to CountryServiceServer will
+// result in compilation errors.
+type UnsafeCountryServiceServer interface {
+	mustEmbedUnimplementedCountryServiceServer()
+}
+
+func RegisterCountryServiceServer(s grpc.ServiceRegistrar, srv CountryServiceServer) {
+	// If the following call pancis, it indicates UnimplementedCountryServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&CountryService_ServiceDesc, srv)
+}
+
+func _CountryService_CreateCountry_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateCountryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CountryServiceServer).CreateCountry(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CountryService_CreateCountry_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CountryServiceServer).CreateCountry(ctx, req.(*CreateCountryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CountryService_GetCountry_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetCountryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CountryServiceServer).GetCountry(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CountryService_GetCountry_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CountryServiceServer).GetCountry(ctx, req.(*GetCountryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CountryService_ListOfCountry_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListOfCountryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CountryServiceServer).ListOfCountry(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CountryService_ListOfCountry_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CountryServiceServer).ListOfCountry(ctx, req.(*ListOfCountryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CountryService_UpdateCountry_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateCountryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CountryServiceServer).UpdateCountry(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CountryService_UpdateCountry_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CountryServiceServer).UpdateCountry(ctx, req.(*UpdateCountryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CountryService_DeleteCountry_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteCountryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CountryServiceServer).DeleteCountry(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CountryService_DeleteCountry_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CountryServiceServer).DeleteCountry(ctx, req.(*DeleteCountryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CountryService_ImportCountries_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ImportCountriesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CountryServiceServer).ImportCountries(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CountryService_ImportCountries_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CountryServiceServer).ImportCountries(ctx, req.(*ImportCountriesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CountryService_ExportCountries_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ExportCountriesRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(CountryServiceServer).ExportCountries(m, &grpc.GenericServerStream[ExportCountriesRequest, Country]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type CountryService_ExportCountriesServer = grpc.ServerStreamingServer[Country]
+
+// CountryService_ServiceDesc is the grpc.ServiceDesc for CountryService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var CountryService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "country.CountryService",
+	HandlerType: (*CountryServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateCountry",
+			Handler:    _CountryService_CreateCountry_Handler,
+		},
+		{
+			MethodName: "GetCountry",
+			Handler:    _CountryService_GetCountry_Handler,
+		},
+		{
+			MethodName: "ListOfCountry",
+			Handler:    _CountryService_ListOfCountry_Handler,
+		},
+		{
+			MethodName: "UpdateCountry",
+			Handler:    _CountryService_UpdateCountry_Handler,
+		},
+		{
+			MethodName: "DeleteCountry",
+			Handler:    _CountryService_DeleteCountry_Handler,
+		},
+		{
+			MethodName: "ImportCountries",
+			Handler:    _CountryService_ImportCountries_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "ExportCountries",
+			Handler:       _CountryService_ExportCountries_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "country.proto",
+}
@@ -0,0 +1,239 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: live.proto
+
+package livepb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	LiveStreamService_CreateLiveStream_FullMethodName    = "/live.LiveStreamService/CreateLiveStream"
+	LiveStreamService_GetLiveStream_FullMethodName       = "/live.LiveStreamService/GetLiveStream"
+	LiveStreamService_SubscribeLiveStream_FullMethodName = "/live.LiveStreamService/SubscribeLiveStream"
+	LiveStreamService_ListLiveStream_FullMethodName      = "/live.LiveStreamService/ListLiveStream"
+)
+
+// LiveStreamServiceClient is the client API for LiveStreamService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type LiveStreamServiceClient interface {
+	CreateLiveStream(ctx context.Context, in *LiveStream, opts ...grpc.CallOption) (*ResponseMessage, error)
+	GetLiveStream(ctx context.Context, in *GetStreamRequest, opts ...grpc.CallOption) (*LiveStream, error)
+	SubscribeLiveStream(ctx context.Context, in *GetStreamRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[LiveStream], error)
+	ListLiveStream(ctx context.Context, in *ListLiveStreamRequest, opts ...grpc.CallOption) (*ListLiveStreamResponse, error)
+}
+
+type liveStreamServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewLiveStreamServiceClient(cc grpc.ClientConnInterface) LiveStreamServiceClient {
+	return &liveStreamServiceClient{cc}
+}
+
+func (c *liveStreamServiceClient) CreateLiveStream(ctx context.Context, in *LiveStream, opts ...grpc.CallOption) (*ResponseMessage, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ResponseMessage)
+	err := c.cc.Invoke(ctx, LiveStreamService_CreateLiveStream_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *liveStreamServiceClient) GetLiveStream(ctx context.Context, in *GetStreamRequest, opts ...grpc.CallOption) (*LiveStream, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(LiveStream)
+	err := c.cc.Invoke(ctx, LiveStreamService_GetLiveStream_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *liveStreamServiceClient) SubscribeLiveStream(ctx context.Context, in *GetStreamRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[LiveStream], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &LiveStreamService_ServiceDesc.Streams[0], LiveStreamService_SubscribeLiveStream_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[GetStreamRequest, LiveStream]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type LiveStreamService_SubscribeLiveStreamClient = grpc.ServerStreamingClient[LiveStream]
+
+func (c *liveStreamServiceClient) ListLiveStream(ctx context.Context, in *ListLiveStreamRequest, opts ...grpc.CallOption) (*ListLiveStreamResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListLiveStreamResponse)
+	err := c.cc.Invoke(ctx, LiveStreamService_ListLiveStream_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// LiveStreamServiceServer is the server API for LiveStreamService service.
+// All implementations must embed UnimplementedLiveStreamServiceServer
+// for forward compatibility.
+type LiveStreamServiceServer interface {
+	CreateLiveStream(context.Context, *LiveStream) (*ResponseMessage, error)
+	GetLiveStream(context.Context, *GetStreamRequest) (*LiveStream, error)
+	SubscribeLiveStream(*GetStreamRequest, grpc.ServerStreamingServer[LiveStream]) error
+	ListLiveStream(context.Context, *ListLiveStreamRequest) (*ListLiveStreamResponse, error)
+	mustEmbedUnimplementedLiveStreamServiceServer()
+}
+
+// UnimplementedLiveStreamServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedLiveStreamServiceServer struct{}
+
+func (UnimplementedLiveStreamServiceServer) CreateLiveStream(context.Context, *LiveStream) (*ResponseMessage, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateLiveStream not implemented")
+}
+func (UnimplementedLiveStreamServiceServer) GetLiveStream(context.Context, *GetStreamRequest) (*LiveStream, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetLiveStream not implemented")
+}
+func (UnimplementedLiveStreamServiceServer) SubscribeLiveStream(*GetStreamRequest, grpc.ServerStreamingServer[LiveStream]) error {
+	return status.Errorf(codes.Unimplemented, "method SubscribeLiveStream not implemented")
+}
+func (UnimplementedLiveStreamServiceServer) ListLiveStream(context.Context, *ListLiveStreamRequest) (*ListLiveStreamResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListLiveStream not implemented")
+}
+func (UnimplementedLiveStreamServiceServer) mustEmbedUnimplementedLiveStreamServiceServer() {}
+func (UnimplementedLiveStreamServiceServer) testEmbeddedByValue()                           {}
+
+// UnsafeLiveStreamServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to LiveStreamServiceServer will
+// result in compilation errors.
+type UnsafeLiveStreamServiceServer interface {
+	mustEmbedUnimplementedLiveStreamServiceServer()
+}
+
+func RegisterLiveStreamServiceServer(s grpc.ServiceRegistrar, srv LiveStreamServiceServer) {
+	// If the following call pancis, it indicates UnimplementedLiveStreamServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&LiveStreamService_ServiceDesc, srv)
+}
+
+func _LiveStreamService_CreateLiveStream_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LiveStream)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LiveStreamServiceServer).CreateLiveStream(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LiveStreamService_CreateLiveStream_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LiveStreamServiceServer).CreateLiveStream(ctx, req.(*LiveStream))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LiveStreamService_GetLiveStream_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetStreamRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LiveStreamServiceServer).GetLiveStream(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LiveStreamService_GetLiveStream_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LiveStreamServiceServer).GetLiveStream(ctx, req.(*GetStreamRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LiveStreamService_SubscribeLiveStream_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(GetStreamRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(LiveStreamServiceServer).SubscribeLiveStream(m, &grpc.GenericServerStream[GetStreamRequest, LiveStream]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type LiveStreamService_SubscribeLiveStreamServer = grpc.ServerStreamingServer[LiveStream]
+
+func _LiveStreamService_ListLiveStream_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListLiveStreamRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LiveStreamServiceServer).ListLiveStream(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LiveStreamService_ListLiveStream_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LiveStreamServiceServer).ListLiveStream(ctx, req.(*ListLiveStreamRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// LiveStreamService_ServiceDesc is the grpc.ServiceDesc for LiveStreamService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var LiveStreamService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "live.LiveStreamService",
+	HandlerType: (*LiveStreamServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateLiveStream",
+			Handler:    _LiveStreamService_CreateLiveStream_Handler,
+		},
+		{
+			MethodName: "GetLiveStream",
+			Handler:    _LiveStreamService_GetLiveStream_Handler,
+		},
+		{
+			MethodName: "ListLiveStream",
+			Handler:    _LiveStreamService_ListLiveStream_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "SubscribeLiveStream",
+			Handler:       _LiveStreamService_SubscribeLiveStream_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "live.proto",
+}
@@ -0,0 +1,467 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: medals.proto
+
+package medalspb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	MedalService_CreateMedal_FullMethodName      = "/medals.MedalService/CreateMedal"
+	MedalService_UpdateMedal_FullMethodName      = "/medals.MedalService/UpdateMedal"
+	MedalService_DeleteMedal_FullMethodName      = "/medals.MedalService/DeleteMedal"
+	MedalService_GetMedalById_FullMethodName     = "/medals.MedalService/GetMedalById"
+	MedalService_GetMedals_FullMethodName        = "/medals.MedalService/GetMedals"
+	MedalService_GetMedalByFilter_FullMethodName = "/medals.MedalService/GetMedalByFilter"
+	MedalService_GetMedalTable_FullMethodName    = "/medals.MedalService/GetMedalTable"
+	MedalService_FinalizeEvent_FullMethodName    = "/medals.MedalService/FinalizeEvent"
+	MedalService_ImportMedals_FullMethodName     = "/medals.MedalService/ImportMedals"
+	MedalService_ExportMedals_FullMethodName     = "/medals.MedalService/ExportMedals"
+)
+
+// MedalServiceClient is the client API for MedalService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type MedalServiceClient interface {
+	CreateMedal(ctx context.Context, in *CreateMedalRequest, opts ...grpc.CallOption) (*CreateMedalResponse, error)
+	UpdateMedal(ctx context.Context, in *UpdateMedalRequest, opts ...grpc.CallOption) (*UpdateMedalResponse, error)
+	DeleteMedal(ctx context.Context, in *DeleteMedalRequest, opts ...grpc.CallOption) (*DeleteMedalResponse, error)
+	GetMedalById(ctx context.Context, in *GetMedalByIdRequest, opts ...grpc.CallOption) (*GetMedalByIdResponse, error)
+	GetMedals(ctx context.Context, in *GetMedalsRequest, opts ...grpc.CallOption) (*GetMedalsResponse, error)
+	GetMedalByFilter(ctx context.Context, in *GetMedalByFilterRequest, opts ...grpc.CallOption) (*GetMedalByFilterResponse, error)
+	GetMedalTable(ctx context.Context, in *GetMedalTableRequest, opts ...grpc.CallOption) (*GetMedalTableResponse, error)
+	FinalizeEvent(ctx context.Context, in *FinalizeEventRequest, opts ...grpc.CallOption) (*FinalizeEventResponse, error)
+	ImportMedals(ctx context.Context, in *ImportMedalsRequest, opts ...grpc.CallOption) (*ImportResponse, error)
+	ExportMedals(ctx context.Context, in *ExportMedalsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Medal], error)
+}
+
+type medalServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewMedalServiceClient(cc grpc.ClientConnInterface) MedalServiceClient {
+	return &medalServiceClient{cc}
+}
+
+func (c *medalServiceClient) CreateMedal(ctx context.Context, in *CreateMedalRequest, opts ...grpc.CallOption) (*CreateMedalResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateMedalResponse)
+	err := c.cc.Invoke(ctx, MedalService_CreateMedal_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *medalServiceClient) UpdateMedal(ctx context.Context, in *UpdateMedalRequest, opts ...grpc.CallOption) (*UpdateMedalResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UpdateMedalResponse)
+	err := c.cc.Invoke(ctx, MedalService_UpdateMedal_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *medalServiceClient) DeleteMedal(ctx context.Context, in *DeleteMedalRequest, opts ...grpc.CallOption) (*DeleteMedalResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteMedalResponse)
+	err := c.cc.Invoke(ctx, MedalService_DeleteMedal_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *medalServiceClient) GetMedalById(ctx context.Context, in *GetMedalByIdRequest, opts ...grpc.CallOption) (*GetMedalByIdResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetMedalByIdResponse)
+	err := c.cc.Invoke(ctx, MedalService_GetMedalById_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *medalServiceClient) GetMedals(ctx context.Context, in *GetMedalsRequest, opts ...grpc.CallOption) (*GetMedalsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetMedalsResponse)
+	err := c.cc.Invoke(ctx, MedalService_GetMedals_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *medalServiceClient) GetMedalByFilter(ctx context.Context, in *GetMedalByFilterRequest, opts ...grpc.CallOption) (*GetMedalByFilterResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetMedalByFilterResponse)
+	err := c.cc.Invoke(ctx, MedalService_GetMedalByFilter_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *medalServiceClient) GetMedalTable(ctx context.Context, in *GetMedalTableRequest, opts ...grpc.CallOption) (*GetMedalTableResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetMedalTableResponse)
+	err := c.cc.Invoke(ctx, MedalService_GetMedalTable_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *medalServiceClient) FinalizeEvent(ctx context.Context, in *FinalizeEventRequest, opts ...grpc.CallOption) (*FinalizeEventResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(FinalizeEventResponse)
+	err := c.cc.Invoke(ctx, MedalService_FinalizeEvent_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *medalServiceClient) ImportMedals(ctx context.Context, in *ImportMedalsRequest, opts ...grpc.CallOption) (*ImportResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ImportResponse)
+	err := c.cc.Invoke(ctx, MedalService_ImportMedals_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *medalServiceClient) ExportMedals(ctx context.Context, in *ExportMedalsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Medal], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &MedalService_ServiceDesc.Streams[0], MedalService_ExportMedals_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ExportMedalsRequest, Medal]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type MedalService_ExportMedalsClient = grpc.ServerStreamingClient[Medal]
+
+// MedalServiceServer is the server API for MedalService service.
+// All implementations must embed UnimplementedMedalServiceServer
+// for forward compatibility.
+type MedalServiceServer interface {
+	CreateMedal(context.Context, *CreateMedalRequest) (*CreateMedalResponse, error)
+	UpdateMedal(context.Context, *UpdateMedalRequest) (*UpdateMedalResponse, error)
+	DeleteMedal(context.Context, *DeleteMedalRequest) (*DeleteMedalResponse, error)
+	GetMedalById(context.Context, *GetMedalByIdRequest) (*GetMedalByIdResponse, error)
+	GetMedals(context.Context, *GetMedalsRequest) (*GetMedalsResponse, error)
+	GetMedalByFilter(context.Context, *GetMedalByFilterRequest) (*GetMedalByFilterResponse, error)
+	GetMedalTable(context.Context, *GetMedalTableRequest) (*GetMedalTableResponse, error)
+	FinalizeEvent(context.Context, *FinalizeEventRequest) (*FinalizeEventResponse, error)
+	ImportMedals(context.Context, *ImportMedalsRequest) (*ImportResponse, error)
+	ExportMedals(*ExportMedalsRequest, grpc.ServerStreamingServer[Medal]) error
+	mustEmbedUnimplementedMedalServiceServer()
+}
+
+// UnimplementedMedalServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedMedalServiceServer struct{}
+
+func (UnimplementedMedalServiceServer) CreateMedal(context.Context, *CreateMedalRequest) (*CreateMedalResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateMedal not implemented")
+}
+func (UnimplementedMedalServiceServer) UpdateMedal(context.Context, *UpdateMedalRequest) (*UpdateMedalResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateMedal not implemented")
+}
+func (UnimplementedMedalServiceServer) DeleteMedal(context.Context, *DeleteMedalRequest) (*DeleteMedalResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteMedal not implemented")
+}
+func (UnimplementedMedalServiceServer) GetMedalById(context.Context, *GetMedalByIdRequest) (*GetMedalByIdResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetMedalById not implemented")
+}
+func (UnimplementedMedalServiceServer) GetMedals(context.Context, *GetMedalsRequest) (*GetMedalsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetMedals not implemented")
+}
+func (UnimplementedMedalServiceServer) GetMedalByFilter(context.Context, *GetMedalByFilterRequest) (*GetMedalByFilterResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetMedalByFilter not implemented")
+}
+func (UnimplementedMedalServiceServer) GetMedalTable(context.Context, *GetMedalTableRequest) (*GetMedalTableResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetMedalTable not implemented")
+}
+func (UnimplementedMedalServiceServer) FinalizeEvent(context.Context, *FinalizeEventRequest) (*FinalizeEventResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FinalizeEvent not implemented")
+}
+func (UnimplementedMedalServiceServer) ImportMedals(context.Context, *ImportMedalsRequest) (*ImportResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ImportMedals not implemented")
+}
+func (UnimplementedMedalServiceServer) ExportMedals(*ExportMedalsRequest, grpc.ServerStreamingServer[Medal]) error {
+	return status.Errorf(codes.Unimplemented, "method ExportMedals not implemented")
+}
+func (UnimplementedMedalServiceServer) mustEmbedUnimplementedMedalServiceServer() {}
+func (UnimplementedMedalServiceServer) testEmbeddedByValue()                      {}
+
+// UnsafeMedalServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to MedalServiceServer will
+// result in compilation errors.
+type UnsafeMedalServiceServer interface {
+	mustEmbedUnimplementedMedalServiceServer()
+}
+
+func RegisterMedalServiceServer(s grpc.ServiceRegistrar, srv MedalServiceServer) {
+	// If the following call pancis, it indicates UnimplementedMedalServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&MedalService_ServiceDesc, srv)
+}
+
+func _MedalService_CreateMedal_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateMedalRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MedalServiceServer).CreateMedal(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MedalService_CreateMedal_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MedalServiceServer).CreateMedal(ctx, req.(*CreateMedalRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MedalService_UpdateMedal_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateMedalRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MedalServiceServer).UpdateMedal(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MedalService_UpdateMedal_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MedalServiceServer).UpdateMedal(ctx, req.(*UpdateMedalRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MedalService_DeleteMedal_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteMedalRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MedalServiceServer).DeleteMedal(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MedalService_DeleteMedal_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MedalServiceServer).DeleteMedal(ctx, req.(*DeleteMedalRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MedalService_GetMedalById_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetMedalByIdRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MedalServiceServer).GetMedalById(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MedalService_GetMedalById_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MedalServiceServer).GetMedalById(ctx, req.(*GetMedalByIdRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MedalService_GetMedals_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetMedalsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MedalServiceServer).GetMedals(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MedalService_GetMedals_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MedalServiceServer).GetMedals(ctx, req.(*GetMedalsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MedalService_GetMedalByFilter_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetMedalByFilterRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MedalServiceServer).GetMedalByFilter(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MedalService_GetMedalByFilter_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MedalServiceServer).GetMedalByFilter(ctx, req.(*GetMedalByFilterRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MedalService_GetMedalTable_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetMedalTableRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MedalServiceServer).GetMedalTable(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MedalService_GetMedalTable_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MedalServiceServer).GetMedalTable(ctx, req.(*GetMedalTableRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MedalService_FinalizeEvent_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FinalizeEventRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MedalServiceServer).FinalizeEvent(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MedalService_FinalizeEvent_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MedalServiceServer).FinalizeEvent(ctx, req.(*FinalizeEventRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MedalService_ImportMedals_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ImportMedalsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MedalServiceServer).ImportMedals(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MedalService_ImportMedals_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MedalServiceServer).ImportMedals(ctx, req.(*ImportMedalsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MedalService_ExportMedals_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ExportMedalsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(MedalServiceServer).ExportMedals(m, &grpc.GenericServerStream[ExportMedalsRequest, Medal]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type MedalService_ExportMedalsServer = grpc.ServerStreamingServer[Medal]
+
+// MedalService_ServiceDesc is the grpc.ServiceDesc for MedalService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var MedalService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "medals.MedalService",
+	HandlerType: (*MedalServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateMedal",
+			Handler:    _MedalService_CreateMedal_Handler,
+		},
+		{
+			MethodName: "UpdateMedal",
+			Handler:    _MedalService_UpdateMedal_Handler,
+		},
+		{
+			MethodName: "DeleteMedal",
+			Handler:    _MedalService_DeleteMedal_Handler,
+		},
+		{
+			MethodName: "GetMedalById",
+			Handler:    _MedalService_GetMedalById_Handler,
+		},
+		{
+			MethodName: "GetMedals",
+			Handler:    _MedalService_GetMedals_Handler,
+		},
+		{
+			MethodName: "GetMedalByFilter",
+			Handler:    _MedalService_GetMedalByFilter_Handler,
+		},
+		{
+			MethodName: "GetMedalTable",
+			Handler:    _MedalService_GetMedalTable_Handler,
+		},
+		{
+			MethodName: "FinalizeEvent",
+			Handler:    _MedalService_FinalizeEvent_Handler,
+		},
+		{
+			MethodName: "ImportMedals",
+			Handler:    _MedalService_ImportMedals_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "ExportMedals",
+			Handler:       _MedalService_ExportMedals_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "medals.proto",
+}
@@ -0,0 +1,171 @@
+// Package lifecycle runs the gRPC server of a service and owns the resources
+// it depends on, so that shutdown happens in one place and in a fixed order:
+// stop accepting connections, drain in-flight RPCs, then close the resources.
+// It logs through the standard logrus logger, which every service configures
+// at startup.
+package lifecycle
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+)
+
+// closeTimeout bounds closing the owned resources. They get their own time,
+// as the shutdown deadline may already have been spent draining RPCs.
+const closeTimeout = 5 * time.Second
+
+type resource struct {
+	name  string
+	close func(context.Context) error
+}
+
+// App serves one gRPC server on one listener.
+type App struct {
+	server   *grpc.Server
+	listener net.Listener
+	health   *health.Server
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	served chan error
+
+	mu        sync.Mutex
+	resources []resource
+}
+
+// New returns an App for server. health may be nil; when set it is switched
+// to NOT_SERVING as soon as shutdown begins, so clients watching it stop
+// sending new calls while the old ones drain.
+func New(server *grpc.Server, listener net.Listener, health *health.Server) *App {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &App{
+		server:   server,
+		listener: listener,
+		health:   health,
+		ctx:      ctx,
+		cancel:   cancel,
+		served:   make(chan error, 1),
+	}
+}
+
+// Server returns the gRPC server run by the app.
+func (a *App) Server() *grpc.Server {
+	return a.server
+}
+
+// Context is cancelled when shutdown begins. Background work tied to the
+// server, such as health monitoring, should stop with it.
+func (a *App) Context() context.Context {
+	return a.ctx
+}
+
+// Own hands a resource to the app. Resources are closed after the server
+// has stopped, in the reverse order they were handed over, so a resource
+// acquired early is still open while the ones built on it are closed.
+func (a *App) Own(name string, close func(context.Context) error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.resources = append(a.resources, resource{name: name, close: close})
+}
+
+// OwnCloser is Own for resources that close without a context.
+func (a *App) OwnCloser(name string, c io.Closer) {
+	a.Own(name, func(context.Context) error { return c.Close() })
+}
+
+// ServeHTTP runs an auxiliary HTTP listener, such as the metrics endpoint,
+// in the background and owns it: Shutdown stops it with the other resources.
+// A nil server is ignored, so a disabled listener needs no special case.
+func (a *App) ServeHTTP(name string, server *http.Server) {
+	if server == nil {
+		return
+	}
+	go func() {
+		if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			logrus.WithFields(logrus.Fields{
+				"listener": name,
+				"error":    err,
+			}).Error("HTTP listener stopped")
+		}
+	}()
+	a.Own(name, server.Shutdown)
+}
+
+// Start serves in the background.
+func (a *App) Start() {
+	go func() {
+		a.served <- a.server.Serve(a.listener)
+	}()
+}
+
+// Wait blocks until ctx is done, typically on SIGINT or SIGTERM, or until
+// the server stops by itself. In the latter case the serve error is
+// returned.
+func (a *App) Wait(ctx context.Context) error {
+	select {
+	case <-ctx.Done():
+		return nil
+	case err := <-a.served:
+		if err == nil {
+			err = errors.New("grpc server stopped unexpectedly")
+		}
+		return err
+	}
+}
+
+// Shutdown stops accepting connections and waits for in-flight RPCs to
+// finish. If ctx expires first the remaining RPCs are cancelled. The owned
+// resources are closed either way, within closeTimeout of the drain ending;
+// every failure is returned.
+func (a *App) Shutdown(ctx context.Context) error {
+	if a.health != nil {
+		a.health.Shutdown()
+	}
+	a.cancel()
+
+	var errs []error
+
+	drained := make(chan struct{})
+	go func() {
+		a.server.GracefulStop()
+		close(drained)
+	}()
+	select {
+	case <-drained:
+		logrus.Info("gRPC server drained")
+	case <-ctx.Done():
+		logrus.Warn("Drain deadline exceeded, cancelling in-flight RPCs")
+		a.server.Stop()
+		<-drained
+		errs = append(errs, fmt.Errorf("drain grpc server: %w", ctx.Err()))
+	}
+
+	a.mu.Lock()
+	resources := a.resources
+	a.resources = nil
+	a.mu.Unlock()
+
+	closeCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), closeTimeout)
+	defer cancel()
+	for i := len(resources) - 1; i >= 0; i-- {
+		r := resources[i]
+		if err := r.close(closeCtx); err != nil {
+			logrus.WithFields(logrus.Fields{
+				"resource": r.name,
+				"error":    err,
+			}).Error("Failed to close resource")
+			errs = append(errs, fmt.Errorf("close %s: %w", r.name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
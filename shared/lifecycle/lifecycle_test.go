@@ -0,0 +1,207 @@
+package lifecycle
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+const slowMethod = "/lifecycle.test.Slow/Call"
+
+// slowServer blocks every call until release is closed.
+type slowServer struct {
+	started chan struct{}
+	release chan struct{}
+}
+
+func (s *slowServer) desc() *grpc.ServiceDesc {
+	return &grpc.ServiceDesc{
+		ServiceName: "lifecycle.test.Slow",
+		HandlerType: (*any)(nil),
+		Methods: []grpc.MethodDesc{{
+			MethodName: "Call",
+			Handler: func(_ any, ctx context.Context, dec func(any) error, _ grpc.UnaryServerInterceptor) (any, error) {
+				if err := dec(&healthpb.HealthCheckRequest{}); err != nil {
+					return nil, err
+				}
+				s.started <- struct{}{}
+				select {
+				case <-s.release:
+					return &healthpb.HealthCheckResponse{}, nil
+				case <-ctx.Done():
+					return nil, status.FromContextError(ctx.Err()).Err()
+				}
+			},
+		}},
+	}
+}
+
+func newTestApp(t *testing.T) (*App, *slowServer, *health.Server, *grpc.ClientConn) {
+	t.Helper()
+
+	listener := bufconn.Listen(1 << 20)
+	server := grpc.NewServer()
+	slow := &slowServer{started: make(chan struct{}, 1), release: make(chan struct{})}
+	server.RegisterService(slow.desc(), slow)
+	healthServer := health.NewServer()
+	healthpb.RegisterHealthServer(server, healthServer)
+
+	app := New(server, listener, healthServer)
+	app.Start()
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return app, slow, healthServer, conn
+}
+
+func callSlow(conn *grpc.ClientConn) <-chan error {
+	done := make(chan error, 1)
+	go func() {
+		done <- conn.Invoke(context.Background(), slowMethod, &healthpb.HealthCheckRequest{}, &healthpb.HealthCheckResponse{})
+	}()
+	return done
+}
+
+func TestShutdownDrainsInFlightRPCs(t *testing.T) {
+	app, slow, healthServer, conn := newTestApp(t)
+
+	var closed []string
+	app.Own("first", func(context.Context) error { closed = append(closed, "first"); return nil })
+	app.Own("second", func(context.Context) error { closed = append(closed, "second"); return nil })
+
+	call := callSlow(conn)
+	<-slow.started
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	shutdown := make(chan error, 1)
+	go func() { shutdown <- app.Shutdown(ctx) }()
+
+	// The server stops reporting healthy before it starts draining.
+	deadline := time.Now().Add(time.Second)
+	for {
+		resp, err := healthServer.Check(context.Background(), &healthpb.HealthCheckRequest{})
+		if err == nil && resp.Status == healthpb.HealthCheckResponse_NOT_SERVING {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("health still reports %v, %v", resp, err)
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	if app.Context().Err() == nil {
+		t.Fatal("app context not cancelled when shutdown began")
+	}
+
+	select {
+	case err := <-shutdown:
+		t.Fatalf("shutdown returned while an RPC was in flight: %v", err)
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	close(slow.release)
+	if err := <-call; err != nil {
+		t.Fatalf("in-flight RPC failed: %v", err)
+	}
+	if err := <-shutdown; err != nil {
+		t.Fatalf("shutdown failed: %v", err)
+	}
+	if want := []string{"second", "first"}; !reflect.DeepEqual(closed, want) {
+		t.Fatalf("resources closed in order %v, want %v", closed, want)
+	}
+}
+
+func TestShutdownCancelsRPCsAfterDeadline(t *testing.T) {
+	app, slow, _, conn := newTestApp(t)
+
+	closedDB := false
+	app.Own("postgres", func(ctx context.Context) error {
+		closedDB = true
+		// Closing gets time of its own after the drain deadline.
+		return ctx.Err()
+	})
+
+	call := callSlow(conn)
+	<-slow.started
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	err := app.Shutdown(ctx)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected the drain deadline to be reported, got %v", err)
+	}
+	if err := <-call; status.Code(err) == codes.OK {
+		t.Fatal("expected the in-flight RPC to be cancelled")
+	}
+	if !closedDB {
+		t.Fatal("resources must be closed even when the drain times out")
+	}
+}
+
+func TestServeHTTPStopsOnShutdown(t *testing.T) {
+	app, _, _, _ := newTestApp(t)
+
+	server := httptest.NewUnstartedServer(http.NotFoundHandler())
+	server.Config.Addr = "127.0.0.1:0"
+	app.ServeHTTP("metrics", server.Config)
+	app.ServeHTTP("disabled", nil)
+
+	if err := app.Shutdown(context.Background()); err != nil {
+		t.Fatalf("shutdown failed: %v", err)
+	}
+	if err := server.Config.ListenAndServe(); !errors.Is(err, http.ErrServerClosed) {
+		t.Fatalf("expected the listener to be shut down, got %v", err)
+	}
+}
+
+func TestShutdownReportsCloseErrors(t *testing.T) {
+	app, _, _, _ := newTestApp(t)
+
+	closedLog := false
+	app.Own("log file", func(context.Context) error { closedLog = true; return nil })
+	app.Own("redis", func(context.Context) error { return errors.New("connection reset") })
+
+	err := app.Shutdown(context.Background())
+	if err == nil || err.Error() != "close redis: connection reset" {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !closedLog {
+		t.Fatal("a failing resource must not keep the others open")
+	}
+}
+
+func TestWaitReturnsServeError(t *testing.T) {
+	listener := bufconn.Listen(1 << 20)
+	app := New(grpc.NewServer(), listener, nil)
+	app.Start()
+
+	listener.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := app.Wait(ctx); err == nil {
+		t.Fatal("expected Wait to report the serve error")
+	}
+}
@@ -0,0 +1,150 @@
+// Package paging implements the list contract every service shares: keyset
+// pagination over an ordered column, order_by validation and the WHERE
+// clause of the field filters.
+package paging
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+const (
+	// DefaultPageSize is the page size of a request that sets none.
+	DefaultPageSize = 50
+	// MaxPageSize caps the page size a request may ask for.
+	MaxPageSize = 1000
+)
+
+var (
+	// ErrInvalidPageToken is returned when a page token cannot be decoded.
+	ErrInvalidPageToken = errors.New("invalid page token")
+	// ErrInvalidOrderBy is returned when order_by names an unsupported field.
+	ErrInvalidOrderBy = errors.New("invalid order_by")
+)
+
+// Conditions collects the WHERE clauses of a list query together with their
+// positional arguments.
+type Conditions struct {
+	clauses []string
+	args    []interface{}
+}
+
+// NewConditions starts the conditions with clauses that take no arguments.
+func NewConditions(clauses ...string) *Conditions {
+	return &Conditions{clauses: clauses}
+}
+
+// Add appends a clause whose "%d" verbs are replaced by the positions of the
+// given values, e.g. Add("date >= $%d", from).
+func (c *Conditions) Add(clause string, values ...interface{}) {
+	positions := make([]interface{}, len(values))
+	for i, value := range values {
+		c.args = append(c.args, value)
+		positions[i] = len(c.args)
+	}
+	c.clauses = append(c.clauses, fmt.Sprintf(clause, positions...))
+}
+
+// Args returns the positional arguments of the clauses.
+func (c *Conditions) Args() []interface{} {
+	return c.args
+}
+
+func (c *Conditions) String() string {
+	if len(c.clauses) == 0 {
+		return "TRUE"
+	}
+	return strings.Join(c.clauses, " AND ")
+}
+
+// Page describes one keyset-paginated slice of a list ordered by Column, id.
+type Page struct {
+	// Column is the column the list is ordered by.
+	Column string
+	// Size is the number of rows on the page.
+	Size int
+
+	desc   bool
+	cursor *cursor
+}
+
+type cursor struct {
+	Value string `json:"v"`
+	ID    string `json:"id"`
+}
+
+// New validates the list parameters against the sortable columns, which map
+// order_by fields to columns. orderBy takes the form "field" or
+// "field desc".
+func New(pageSize int32, pageToken, orderBy string, columns map[string]string, defaultOrder string) (*Page, error) {
+	p := Page{Size: int(pageSize)}
+	if p.Size <= 0 {
+		p.Size = DefaultPageSize
+	}
+	if p.Size > MaxPageSize {
+		p.Size = MaxPageSize
+	}
+
+	if orderBy == "" {
+		orderBy = defaultOrder
+	}
+	fields := strings.Fields(orderBy)
+	if len(fields) == 0 || len(fields) > 2 {
+		return nil, ErrInvalidOrderBy
+	}
+	column, ok := columns[fields[0]]
+	if !ok {
+		return nil, ErrInvalidOrderBy
+	}
+	p.Column = column
+	if len(fields) == 2 {
+		switch strings.ToLower(fields[1]) {
+		case "asc":
+		case "desc":
+			p.desc = true
+		default:
+			return nil, ErrInvalidOrderBy
+		}
+	}
+
+	if pageToken != "" {
+		b, err := base64.RawURLEncoding.DecodeString(pageToken)
+		if err != nil {
+			return nil, ErrInvalidPageToken
+		}
+		c := cursor{}
+		if err := json.Unmarshal(b, &c); err != nil || c.ID == "" {
+			return nil, ErrInvalidPageToken
+		}
+		p.cursor = &c
+	}
+	return &p, nil
+}
+
+// Clause appends the keyset condition, ordering and limit to the filter. One
+// row more than the page size is requested so the caller can tell whether
+// another page follows.
+func (p *Page) Clause(filter *Conditions) string {
+	direction, comparison := "ASC", ">"
+	if p.desc {
+		direction, comparison = "DESC", "<"
+	}
+
+	if p.cursor != nil {
+		filter.Add(fmt.Sprintf("(%s, id) %s ($%%d, $%%d)", p.Column, comparison), p.cursor.Value, p.cursor.ID)
+	}
+	filter.args = append(filter.args, p.Size+1)
+	return fmt.Sprintf(" WHERE %s ORDER BY %s %s, id %s LIMIT $%d",
+		filter.String(), p.Column, direction, direction, len(filter.args))
+}
+
+// NextToken returns the token of the page after a row with the given sort
+// value and id. The value must read back as the sort column's type, so
+// dates and times are best selected as text in their SQL form.
+func (p *Page) NextToken(value, id string) string {
+	b, _ := json.Marshal(cursor{Value: value, ID: id})
+	return base64.RawURLEncoding.EncodeToString(b)
+}
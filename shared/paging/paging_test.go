@@ -0,0 +1,80 @@
+package paging
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+var columns = map[string]string{"name": "name", "date": "start_date"}
+
+func TestConditions(t *testing.T) {
+	filter := NewConditions("deleted_at=0")
+	filter.Add("region=$%d", "Europe")
+	filter.Add("date BETWEEN $%d AND $%d", "2024-07-26", "2024-08-11")
+
+	if want := "deleted_at=0 AND region=$1 AND date BETWEEN $2 AND $3"; filter.String() != want {
+		t.Fatalf("got %q, want %q", filter.String(), want)
+	}
+	if want := []interface{}{"Europe", "2024-07-26", "2024-08-11"}; !reflect.DeepEqual(filter.Args(), want) {
+		t.Fatalf("got args %v, want %v", filter.Args(), want)
+	}
+	if NewConditions().String() != "TRUE" {
+		t.Fatal("no conditions must match every row")
+	}
+}
+
+func TestNew(t *testing.T) {
+	p, err := New(0, "", "", columns, "name")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p.Size != DefaultPageSize || p.Column != "name" {
+		t.Fatalf("got size %d, column %q", p.Size, p.Column)
+	}
+
+	p, err = New(MaxPageSize+1, "", "date DESC", columns, "name")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p.Size != MaxPageSize || p.Column != "start_date" || !p.desc {
+		t.Fatalf("got size %d, column %q, desc %v", p.Size, p.Column, p.desc)
+	}
+
+	for _, orderBy := range []string{"region", "name up", "name asc id"} {
+		if _, err := New(10, "", orderBy, columns, "name"); !errors.Is(err, ErrInvalidOrderBy) {
+			t.Errorf("order_by %q: got %v", orderBy, err)
+		}
+	}
+	for _, token := range []string{"%%%", "bm90IGpzb24", "e30"} {
+		if _, err := New(10, token, "", columns, "name"); !errors.Is(err, ErrInvalidPageToken) {
+			t.Errorf("page_token %q: got %v", token, err)
+		}
+	}
+}
+
+func TestClause(t *testing.T) {
+	p, err := New(2, "", "", columns, "name")
+	if err != nil {
+		t.Fatal(err)
+	}
+	filter := NewConditions("deleted_at=0")
+	if want := " WHERE deleted_at=0 ORDER BY name ASC, id ASC LIMIT $1"; p.Clause(filter) != want {
+		t.Fatalf("got %q", p.Clause(NewConditions("deleted_at=0")))
+	}
+
+	// The next page picks up after the last row, in the same direction.
+	p, err = New(2, p.NextToken("2024-07-27", "42"), "date desc", columns, "name")
+	if err != nil {
+		t.Fatal(err)
+	}
+	filter = NewConditions("deleted_at=0")
+	filter.Add("region=$%d", "Europe")
+	clause := p.Clause(filter)
+	if want := " WHERE deleted_at=0 AND region=$1 AND (start_date, id) < ($2, $3) ORDER BY start_date DESC, id DESC LIMIT $4"; clause != want {
+		t.Fatalf("got %q, want %q", clause, want)
+	}
+	if want := []interface{}{"Europe", "2024-07-27", "42", 3}; !reflect.DeepEqual(filter.Args(), want) {
+		t.Fatalf("got args %v, want %v", filter.Args(), want)
+	}
+}
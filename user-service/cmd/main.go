@@ -2,20 +2,22 @@ package main
 
 import (
 	"context"
-	"os"
 	"os/signal"
-	"sync"
 	"syscall"
 	"time"
+	"user-service/internal/user/pkg/healthcheck"
+	"user-service/internal/user/pkg/jwks"
 	config "user-service/internal/user/pkg/load"
+	"user-service/internal/user/pkg/metrics"
+	"user-service/internal/user/pkg/password"
+	"user-service/internal/user/pkg/tracing"
 	pq "user-service/internal/user/pkg/postgres"
 	rpc "user-service/internal/user/pkg/register-service"
 	userRepo "user-service/internal/user/repository"
 	userService "user-service/internal/user/service"
 	"user-service/logger"
 	"user-service/redis"
-
-	"google.golang.org/grpc"
+	"user-service/token"
 )
 
 func main() {
@@ -28,45 +30,70 @@ func main() {
 	}
 	logger.Info("Configuration loaded successfully")
 
+	shutdownTracing, err := tracing.Init(context.Background(), "user-service", *cfg)
+	if err != nil {
+		logger.Fatal("Failed to set up tracing: ", err)
+	}
+
 	db, err := pq.InitDB(*cfg)
 	if err != nil {
 		logger.Fatal("Failed to connect to database: ", err)
 	}
 	logger.Info("Connected to the database successfully")
+	metrics.RegisterDB(db, cfg.Postgres.Database)
 
 	rds, err := redis.ConnectRedis(*cfg)
 	if err != nil {
 		logger.Fatal("Failed to connect to redis: ", err)
 	}
+	metrics.RegisterRedis(rds)
 
-	repo := userRepo.NewPostgresUserRepo(db, rds)
-	service := userService.NewService(repo, rds)
+	issuer, err := token.NewIssuer(cfg.JWT)
+	if err != nil {
+		logger.Fatal("Failed to load signing keys: ", err)
+	}
+	if generated := issuer.GeneratedKeys(); len(generated) > 0 {
+		logger.Warn("Signing with generated keys; tokens will not survive a restart: ", generated)
+	}
 
-	var wg sync.WaitGroup
-	wg.Add(1)
+	repo := userRepo.NewPostgresUserRepo(db, rds, issuer, cfg.Lockout)
+	service := userService.NewService(repo, rds, password.NewPolicy(cfg.PasswordPolicy))
 
-	r := rpc.NewGrpcService(service)
+	r := rpc.NewGrpcService(service, healthcheck.Checks{
+		"postgres": db.PingContext,
+		"redis": func(ctx context.Context) error {
+			return rds.Ping(ctx).Err()
+		},
+	})
 
-	gServer := grpc.NewServer()
-	go func() {
-		defer wg.Done()
-		if err := r.RUN(*cfg); err != nil {
-			logger.Fatal("Failed to run gRPC service: ", err)
-		}
-	}()
+	app, err := r.RUN(*cfg)
+	if err != nil {
+		logger.Fatal("Failed to run gRPC service: ", err)
+	}
+	app.Own("log file", func(context.Context) error { return logger.Close() })
+	app.Own("tracing", shutdownTracing)
+	app.OwnCloser("postgres", db)
+	app.OwnCloser("redis", rds)
 	logger.Info("User service started successfully")
 
-	sigChan := make(chan os.Signal, 1)
-	signal.Notify(sigChan, syscall.SIGINT, syscall.SIGTERM)
+	app.ServeHTTP("metrics listener", metrics.Server(*cfg))
+	app.ServeHTTP("jwks listener", jwks.Server(*cfg, issuer))
 
-	sig := <-sigChan
-	logger.Info("Received signal:", sig)
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
 
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-	defer cancel()
+	if err := app.Wait(ctx); err != nil {
+		logger.Error("gRPC server stopped: ", err)
+	} else {
+		logger.Info("Received shutdown signal")
+	}
 
-	gServer.GracefulStop()
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
 
-	<-ctx.Done()
+	if err := app.Shutdown(shutdownCtx); err != nil {
+		logger.Error("Shutdown incomplete: ", err)
+		return
+	}
 	logger.Info("Graceful shutdown complete.")
 }
@@ -0,0 +1,28 @@
+-- Live accounts whose usernames differ only in case would stop the index
+-- below from being built. Renaming them here would lock their owners out
+-- without telling them, so the migration fails and lists them instead;
+-- rename or delete all but one account of each name and run it again.
+DO $$
+DECLARE
+    duplicates text;
+BEGIN
+    SELECT string_agg(format('%s (ids %s)', name, ids), '; ' ORDER BY name)
+    INTO duplicates
+    FROM (
+        SELECT lower(username) AS name, string_agg(id::text, ', ' ORDER BY created_at, id) AS ids
+        FROM users
+        WHERE deleted_at = 0
+        GROUP BY lower(username)
+        HAVING count(*) > 1
+    ) AS clashes;
+
+    IF duplicates IS NOT NULL THEN
+        RAISE EXCEPTION 'usernames that differ only in case: %', duplicates
+            USING HINT = 'Rename or delete all but one account of each name, then rerun the migration.';
+    END IF;
+END $$;
+
+-- Deleted accounts keep their names without blocking them for new ones.
+CREATE UNIQUE INDEX IF NOT EXISTS users_username_lower_key
+    ON users (lower(username))
+    WHERE deleted_at = 0;
@@ -0,0 +1,21 @@
+package jwks
+
+import (
+	"fmt"
+	"net/http"
+	config "user-service/internal/user/pkg/load"
+	"user-service/token"
+)
+
+// Server returns the listener that publishes the public signing keys on their
+// own port, so the gateway can verify tokens without sharing a secret with
+// this service. It is nil when publishing is disabled.
+func Server(cfg config.Config, issuer *token.Issuer) *http.Server {
+	if !cfg.JWT.JWKS.Enabled {
+		return nil
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle(cfg.JWT.JWKS.Path, issuer.JWKSHandler())
+	return &http.Server{Addr: fmt.Sprintf(":%d", cfg.JWT.JWKS.Port), Handler: mux}
+}
@@ -1,6 +1,8 @@
 package load
 
 import (
+	"time"
+
 	"github.com/spf13/viper"
 )
 
@@ -17,11 +19,82 @@ type RedisConfig struct {
 	Port int
 }
 
+// TracingConfig selects where spans are exported: otlp (a collector listening
+// on Endpoint), stdout or none.
+type TracingConfig struct {
+	Exporter    string
+	Endpoint    string
+	SampleRatio float64
+}
+
+// MetricsConfig controls the Prometheus listener. It serves Path on its own
+// Port so scrapes never go through the gRPC server.
+type MetricsConfig struct {
+	Enabled bool
+	Port    int
+	Path    string
+}
+
+// SigningKey is one key tokens can be signed with. Algorithm is HS256, RS256
+// or EdDSA. HS256 keys use Secret; the others read a PEM private key from
+// PrivateKey or PrivateKeyFile, or generate a throwaway one when both are
+// empty.
+type SigningKey struct {
+	ID             string `mapstructure:"id"`
+	Algorithm      string `mapstructure:"algorithm"`
+	Secret         string `mapstructure:"secret"`
+	PrivateKey     string `mapstructure:"private_key"`
+	PrivateKeyFile string `mapstructure:"private_key_file"`
+}
+
+// JWKSConfig controls the listener that publishes the public signing keys.
+type JWKSConfig struct {
+	Enabled bool
+	Port    int
+	Path    string
+}
+
+// JWTConfig lists the signing keys. ActiveKey signs new tokens; the others
+// only verify, so a key can be rotated out once its tokens have expired.
+type JWTConfig struct {
+	Issuer     string        `mapstructure:"issuer"`
+	ActiveKey  string        `mapstructure:"active_key"`
+	Keys       []SigningKey  `mapstructure:"keys"`
+	AccessTTL  time.Duration `mapstructure:"access_ttl"`
+	RefreshTTL time.Duration `mapstructure:"refresh_ttl"`
+	JWKS       JWKSConfig    `mapstructure:"jwks"`
+}
+
+// PasswordPolicyConfig is what a new password must satisfy. MaxLength is
+// capped at the 72 bytes bcrypt hashes.
+type PasswordPolicyConfig struct {
+	MinLength        int  `mapstructure:"min_length"`
+	MaxLength        int  `mapstructure:"max_length"`
+	RequireUpper     bool `mapstructure:"require_upper"`
+	RequireLower     bool `mapstructure:"require_lower"`
+	RequireDigit     bool `mapstructure:"require_digit"`
+	RequireSymbol    bool `mapstructure:"require_symbol"`
+	DisallowUsername bool `mapstructure:"disallow_username"`
+}
+
+// LockoutConfig locks a username for Duration once MaxFailures logins have
+// failed within Window. A MaxFailures of 0 disables the lockout.
+type LockoutConfig struct {
+	MaxFailures int           `mapstructure:"max_failures"`
+	Window      time.Duration `mapstructure:"window"`
+	Duration    time.Duration `mapstructure:"duration"`
+}
+
 type Config struct {
 	Postgres        PostgresConfig
 	Redis           RedisConfig
 	UserServiceHost string
 	UserServicePort int
+	Tracing         TracingConfig
+	Metrics         MetricsConfig
+	JWT             JWTConfig
+	PasswordPolicy  PasswordPolicyConfig
+	Lockout         LockoutConfig
 }
 
 func Load(path string) (*Config, error) {
@@ -30,6 +103,17 @@ func Load(path string) (*Config, error) {
 	viper.SetConfigType("yaml")
 	viper.AutomaticEnv()
 
+	viper.SetDefault("tracing.exporter", "none")
+	viper.SetDefault("tracing.sample_ratio", 1.0)
+	viper.SetDefault("metrics.path", "/metrics")
+	viper.SetDefault("jwt.issuer", "user-service")
+	viper.SetDefault("jwt.access_ttl", "1h")
+	viper.SetDefault("jwt.refresh_ttl", "24h")
+	viper.SetDefault("jwt.jwks.path", "/.well-known/jwks.json")
+	viper.SetDefault("password_policy.min_length", 8)
+	viper.SetDefault("login_lockout.window", "15m")
+	viper.SetDefault("login_lockout.duration", "15m")
+
 	if err := viper.ReadInConfig(); err != nil {
 		return nil, err
 	}
@@ -48,6 +132,27 @@ func Load(path string) (*Config, error) {
 		},
 		UserServiceHost: viper.GetString("server.host"),
 		UserServicePort: viper.GetInt("server.port"),
+
+		Tracing: TracingConfig{
+			Exporter:    viper.GetString("tracing.exporter"),
+			Endpoint:    viper.GetString("tracing.endpoint"),
+			SampleRatio: viper.GetFloat64("tracing.sample_ratio"),
+		},
+
+		Metrics: MetricsConfig{
+			Enabled: viper.GetBool("metrics.enabled"),
+			Port:    viper.GetInt("metrics.port"),
+			Path:    viper.GetString("metrics.path"),
+		},
+	}
+	if err := viper.UnmarshalKey("jwt", &cfg.JWT); err != nil {
+		return nil, err
+	}
+	if err := viper.UnmarshalKey("password_policy", &cfg.PasswordPolicy); err != nil {
+		return nil, err
+	}
+	if err := viper.UnmarshalKey("login_lockout", &cfg.Lockout); err != nil {
+		return nil, err
 	}
 	return &cfg, nil
 }
@@ -0,0 +1,63 @@
+// Package metrics exposes the service's Prometheus metrics on their own HTTP
+// listener, next to the gRPC port.
+package metrics
+
+import (
+	"database/sql"
+	"fmt"
+	"net/http"
+
+	config "user-service/internal/user/pkg/load"
+
+	grpcprom "github.com/grpc-ecosystem/go-grpc-middleware/providers/prometheus"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/redis/go-redis/v9"
+)
+
+// GRPC counts and times every RPC handled by the server. Its interceptors
+// are installed when the gRPC server is created.
+var GRPC = grpcprom.NewServerMetrics(grpcprom.WithServerHandlingTimeHistogram())
+
+func init() {
+	prometheus.MustRegister(GRPC)
+}
+
+// RegisterDB exports the connection pool stats of db under the given name.
+func RegisterDB(db *sql.DB, name string) {
+	prometheus.MustRegister(collectors.NewDBStatsCollector(db, name))
+}
+
+// RegisterRedis exports the connection pool stats of rdb. The values are
+// read from the client on every scrape.
+func RegisterRedis(rdb *redis.Client) {
+	stat := func(name, help string, value func(*redis.PoolStats) uint32, counter bool) prometheus.Collector {
+		opts := prometheus.CounterOpts{Name: "redis_pool_" + name, Help: help}
+		read := func() float64 { return float64(value(rdb.PoolStats())) }
+		if counter {
+			return prometheus.NewCounterFunc(opts, read)
+		}
+		return prometheus.NewGaugeFunc(prometheus.GaugeOpts(opts), read)
+	}
+	prometheus.MustRegister(
+		stat("hits_total", "Times a free connection was found in the pool.", func(s *redis.PoolStats) uint32 { return s.Hits }, true),
+		stat("misses_total", "Times a free connection was not found in the pool.", func(s *redis.PoolStats) uint32 { return s.Misses }, true),
+		stat("timeouts_total", "Times a wait for a connection timed out.", func(s *redis.PoolStats) uint32 { return s.Timeouts }, true),
+		stat("connections", "Connections in the pool.", func(s *redis.PoolStats) uint32 { return s.TotalConns }, false),
+		stat("idle_connections", "Idle connections in the pool.", func(s *redis.PoolStats) uint32 { return s.IdleConns }, false),
+		stat("stale_connections_total", "Stale connections removed from the pool.", func(s *redis.PoolStats) uint32 { return s.StaleConns }, true),
+	)
+}
+
+// Server returns the listener that answers scrapes on the configured port,
+// or nil when metrics are disabled.
+func Server(cfg config.Config) *http.Server {
+	if !cfg.Metrics.Enabled {
+		return nil
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle(cfg.Metrics.Path, promhttp.Handler())
+	return &http.Server{Addr: fmt.Sprintf(":%d", cfg.Metrics.Port), Handler: mux}
+}
@@ -0,0 +1,90 @@
+// Package password checks new passwords against the configured strength
+// policy before they are hashed.
+package password
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+	config "user-service/internal/user/pkg/load"
+)
+
+// bcryptMaxLength is the number of bytes bcrypt actually hashes; anything
+// past it would be silently ignored.
+const bcryptMaxLength = 72
+
+// ErrWeak is wrapped by every policy violation.
+var ErrWeak = errors.New("password does not meet the policy")
+
+type Policy struct {
+	minLength        int
+	maxLength        int
+	requireUpper     bool
+	requireLower     bool
+	requireDigit     bool
+	requireSymbol    bool
+	disallowUsername bool
+}
+
+func NewPolicy(cfg config.PasswordPolicyConfig) *Policy {
+	p := &Policy{
+		minLength:        cfg.MinLength,
+		maxLength:        cfg.MaxLength,
+		requireUpper:     cfg.RequireUpper,
+		requireLower:     cfg.RequireLower,
+		requireDigit:     cfg.RequireDigit,
+		requireSymbol:    cfg.RequireSymbol,
+		disallowUsername: cfg.DisallowUsername,
+	}
+	if p.maxLength <= 0 || p.maxLength > bcryptMaxLength {
+		p.maxLength = bcryptMaxLength
+	}
+	return p
+}
+
+// Validate returns an error wrapping ErrWeak that lists every rule the
+// password breaks, or nil.
+func (p *Policy) Validate(password, username string) error {
+	var problems []string
+	if len([]rune(password)) < p.minLength {
+		problems = append(problems, fmt.Sprintf("at least %d characters", p.minLength))
+	}
+	if len(password) > p.maxLength {
+		problems = append(problems, fmt.Sprintf("at most %d bytes", p.maxLength))
+	}
+
+	var upper, lower, digit, symbol bool
+	for _, r := range password {
+		switch {
+		case unicode.IsUpper(r):
+			upper = true
+		case unicode.IsLower(r):
+			lower = true
+		case unicode.IsDigit(r):
+			digit = true
+		case unicode.IsPunct(r) || unicode.IsSymbol(r) || unicode.IsSpace(r):
+			symbol = true
+		}
+	}
+	if p.requireUpper && !upper {
+		problems = append(problems, "an upper-case letter")
+	}
+	if p.requireLower && !lower {
+		problems = append(problems, "a lower-case letter")
+	}
+	if p.requireDigit && !digit {
+		problems = append(problems, "a digit")
+	}
+	if p.requireSymbol && !symbol {
+		problems = append(problems, "a symbol")
+	}
+	if p.disallowUsername && username != "" && strings.Contains(strings.ToLower(password), strings.ToLower(username)) {
+		problems = append(problems, "no copy of the username")
+	}
+
+	if len(problems) == 0 {
+		return nil
+	}
+	return fmt.Errorf("%w: needs %s", ErrWeak, strings.Join(problems, ", "))
+}
@@ -0,0 +1,43 @@
+package password
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	config "user-service/internal/user/pkg/load"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidate(t *testing.T) {
+	policy := NewPolicy(config.PasswordPolicyConfig{
+		MinLength:        8,
+		RequireUpper:     true,
+		RequireLower:     true,
+		RequireDigit:     true,
+		DisallowUsername: true,
+	})
+
+	assert.NoError(t, policy.Validate("Correct1Horse", "mongosh"))
+
+	for password, want := range map[string]string{
+		"Sh0rt":                   "at least 8 characters",
+		"alllowercase1":           "an upper-case letter",
+		"ALLUPPERCASE1":           "a lower-case letter",
+		"NoDigitsHere":            "a digit",
+		"Mongosh123":              "no copy of the username",
+		strings.Repeat("Aa1", 25): "at most 72 bytes",
+	} {
+		err := policy.Validate(password, "mongosh")
+		assert.True(t, errors.Is(err, ErrWeak), password)
+		assert.ErrorContains(t, err, want, password)
+	}
+}
+
+func TestValidateSymbol(t *testing.T) {
+	policy := NewPolicy(config.PasswordPolicyConfig{RequireSymbol: true})
+
+	assert.Error(t, policy.Validate("password", ""))
+	assert.NoError(t, policy.Validate("pass word", ""))
+	assert.NoError(t, policy.Validate("pass-word", ""))
+}
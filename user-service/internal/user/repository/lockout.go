@@ -0,0 +1,76 @@
+package repository
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// Failed logins are counted per username, lower-cased like the unique
+// index, whether or not the account exists, so a lockout reveals nothing
+// about which usernames are taken.
+//
+//	auth:login:failures:<username>   failed attempts within the window
+//	auth:login:locked:<username>     present while the username is locked
+const (
+	failuresPrefix = "auth:login:failures:"
+	lockedPrefix   = "auth:login:locked:"
+)
+
+func loginKey(username string) string {
+	return strings.ToLower(strings.TrimSpace(username))
+}
+
+// lockedFor returns how much longer the username stays locked, or 0.
+func (u *UserRepo) lockedFor(ctx context.Context, username string) (time.Duration, error) {
+	if u.lockout.MaxFailures <= 0 {
+		return 0, nil
+	}
+	ttl, err := u.rds.TTL(ctx, lockedPrefix+loginKey(username)).Result()
+	if err != nil {
+		return 0, err
+	}
+	// TTL is negative for a missing key.
+	if ttl < 0 {
+		return 0, nil
+	}
+	return ttl, nil
+}
+
+// recordFailedLogin counts a failed attempt and locks the username once the
+// limit is reached. It reports whether this attempt locked it.
+func (u *UserRepo) recordFailedLogin(ctx context.Context, username string) (bool, error) {
+	if u.lockout.MaxFailures <= 0 {
+		return false, nil
+	}
+	key := failuresPrefix + loginKey(username)
+	failures, err := u.rds.Incr(ctx, key).Result()
+	if err != nil {
+		return false, err
+	}
+	if failures == 1 {
+		if err := u.rds.Expire(ctx, key, u.lockout.Window).Err(); err != nil {
+			return false, err
+		}
+	}
+	if failures < int64(u.lockout.MaxFailures) {
+		return false, nil
+	}
+
+	_, err = u.rds.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Set(ctx, lockedPrefix+loginKey(username), "1", u.lockout.Duration)
+		pipe.Del(ctx, key)
+		return nil
+	})
+	return err == nil, err
+}
+
+// clearFailedLogins forgets the failures once the user gets in.
+func (u *UserRepo) clearFailedLogins(ctx context.Context, username string) error {
+	if u.lockout.MaxFailures <= 0 {
+		return nil
+	}
+	return u.rds.Del(ctx, failuresPrefix+loginKey(username)).Err()
+}
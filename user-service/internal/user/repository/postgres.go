@@ -3,8 +3,12 @@ package repository
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
+	"shared/paging"
+	"strings"
 	"time"
+	config "user-service/internal/user/pkg/load"
 	"user-service/logger"
 	"user-service/token"
 
@@ -15,17 +19,25 @@ import (
 )
 
 type UserRepo struct {
-	db  *sql.DB
-	rds *redis.Client
+	db      *sql.DB
+	rds     *redis.Client
+	issuer  *token.Issuer
+	lockout config.LockoutConfig
 }
 
-func NewPostgresUserRepo(db *sql.DB, rds *redis.Client) UserRepository {
+func NewPostgresUserRepo(db *sql.DB, rds *redis.Client, issuer *token.Issuer, lockout config.LockoutConfig) UserRepository {
 	return &UserRepo{
-		db:  db,
-		rds: rds,
+		db:      db,
+		rds:     rds,
+		issuer:  issuer,
+		lockout: lockout,
 	}
 }
 
+// dummyHash is compared against when the username is unknown, so that a
+// failed login takes as long whether or not the account exists.
+var dummyHash, _ = bcrypt.GenerateFromPassword([]byte("dummy password"), bcrypt.DefaultCost)
+
 func (u *UserRepo) Register(ctx context.Context, req *pb.CreateUserRequest) (*pb.CreateUserResponse, error) {
 	user := &pb.User{}
 	now := time.Now().Format(time.RFC3339)
@@ -33,7 +45,7 @@ func (u *UserRepo) Register(ctx context.Context, req *pb.CreateUserRequest) (*pb
 	// Parolni hashlash
 	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(req.Password), bcrypt.DefaultCost)
 	if err != nil {
-		logger.Error("Failed to hash password", logrus.Fields{
+		logger.ErrorContext(ctx, "Failed to hash password", logrus.Fields{
 			"username": req.Username,
 			"error":    err,
 		})
@@ -44,7 +56,7 @@ func (u *UserRepo) Register(ctx context.Context, req *pb.CreateUserRequest) (*pb
 	`INSERT INTO users (username, password, role, created_at, updated_at) 
 	VALUES ($1, $2, $3, $4, $5) 
 	RETURNING id, username, role, created_at, updated_at`
-	err = u.db.QueryRow(query, 
+	err = u.db.QueryRowContext(ctx, query, 
 		req.Username, 
 		string(hashedPassword), 
 		req.Role, 
@@ -52,14 +64,14 @@ func (u *UserRepo) Register(ctx context.Context, req *pb.CreateUserRequest) (*pb
 	).Scan(&user.Id, &user.Username, &user.Role, &user.CreatedAt, &user.UpdatedAt)
 
 	if err != nil {
-		logger.Error("Failed to create user", logrus.Fields{
+		logger.ErrorContext(ctx, "Failed to create user", logrus.Fields{
 			"username": req.Username,
 			"error":    err,
 		})
 		return &pb.CreateUserResponse{Success: false, Message: "Failed to create user"}, err
 	}
 
-	logger.Info("User created successfully", logrus.Fields{
+	logger.InfoContext(ctx, "User created successfully", logrus.Fields{
 		"username": req.Username,
 		"user_id": user.Id,
 	})
@@ -71,53 +83,75 @@ func (u *UserRepo) Register(ctx context.Context, req *pb.CreateUserRequest) (*pb
 	}, nil
 }
 
+// Login matches the username exactly, ignoring case, and counts failures
+// towards a temporary lockout of that username.
 func (s *UserRepo) Login(ctx context.Context, req *pb.LoginRequest) (*pb.LoginResponse, error) {
-	userResp, err := s.GetUserByFilter(ctx, &pb.UserFilter{
-		Username: req.Username,
-	})
-
-	if err != nil || len(userResp.Users) == 0 {
-		logger.Warn("Invalid login attempt", logrus.Fields{
+	locked, err := s.lockedFor(ctx, req.Username)
+	if err != nil {
+		logger.ErrorContext(ctx, "Failed to check login lockout", logrus.Fields{
+			"username": req.Username,
+			"error":    err,
+		})
+		return nil, err
+	}
+	if locked > 0 {
+		logger.WarnContext(ctx, "Login attempt on a locked username", logrus.Fields{
 			"username": req.Username,
 		})
-		return &pb.LoginResponse{
-			Success: false,
-			Message: "Invalid credentials",
-		}, nil
+		return nil, &LockedError{RetryAfter: locked}
 	}
 
-	user := userResp.Users[0]
+	user, hash, err := s.userByUsername(ctx, req.Username)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return nil, err
+	}
+	if errors.Is(err, sql.ErrNoRows) {
+		bcrypt.CompareHashAndPassword(dummyHash, []byte(req.Password))
+		logger.WarnContext(ctx, "Invalid login attempt", logrus.Fields{
+			"username": req.Username,
+		})
+		return nil, s.loginFailed(ctx, req.Username)
+	}
 
-	err = bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(req.Password))
+	err = bcrypt.CompareHashAndPassword([]byte(hash), []byte(req.Password))
 	if err != nil {
-		logger.Warn("Invalid password attempt", logrus.Fields{
+		logger.WarnContext(ctx, "Invalid password attempt", logrus.Fields{
 			"username": req.Username,
 		})
-		return &pb.LoginResponse{
-			Success: false,
-			Message: "Invalid credentials",
-		}, nil
+		return nil, s.loginFailed(ctx, req.Username)
 	}
 
-	accessToken, refreshToken, err := token.CreateTokens(user)
+	if err := s.clearFailedLogins(ctx, req.Username); err != nil {
+		logger.ErrorContext(ctx, "Failed to clear failed logins", logrus.Fields{
+			"username": req.Username,
+			"error":    err,
+		})
+		return nil, err
+	}
+
+	sid, err := token.NewID()
 	if err != nil {
-		logger.Error("Failed to create tokens", logrus.Fields{
+		return nil, err
+	}
+	tokens, err := s.issuer.CreateTokens(user, sid)
+	if err != nil {
+		logger.ErrorContext(ctx, "Failed to create tokens", logrus.Fields{
 			"username": req.Username,
 			"error":    err,
 		})
 		return nil, err
 	}
 
-	err = s.rds.Set(ctx, refreshToken, user.Id, time.Hour*24).Err()
+	err = s.startSession(ctx, user.Id, sid, tokens)
 	if err != nil {
-		logger.Error("Failed to set refresh token in Redis", logrus.Fields{
-			"refresh_token": refreshToken,
-			"error":        err,
+		logger.ErrorContext(ctx, "Failed to start session in Redis", logrus.Fields{
+			"user_id": user.Id,
+			"error":   err,
 		})
 		return nil, err
 	}
 
-	logger.Info("Login successful", logrus.Fields{
+	logger.InfoContext(ctx, "Login successful", logrus.Fields{
 		"username": req.Username,
 	})
 
@@ -125,64 +159,205 @@ func (s *UserRepo) Login(ctx context.Context, req *pb.LoginRequest) (*pb.LoginRe
 		Success:      true,
 		Message:      "Login successful",
 		User:         user,
-		AccessToken:  accessToken,
-		RefreshToken: refreshToken,
+		AccessToken:  tokens.Access,
+		RefreshToken: tokens.Refresh,
 	}, nil
 }
 
+// loginFailed records a failed attempt against the username, which may lock
+// it, and returns the error to report: always ErrInvalidCredentials, so the
+// caller cannot tell a wrong password from an unknown user.
+func (s *UserRepo) loginFailed(ctx context.Context, username string) error {
+	locked, err := s.recordFailedLogin(ctx, username)
+	if err != nil {
+		logger.ErrorContext(ctx, "Failed to record failed login", logrus.Fields{
+			"username": username,
+			"error":    err,
+		})
+		return err
+	}
+	if locked {
+		logger.WarnContext(ctx, "Username locked after repeated failed logins", logrus.Fields{
+			"username": username,
+		})
+	}
+	return ErrInvalidCredentials
+}
+
+// userByUsername looks a live user up by exact username, ignoring case, and
+// returns the password hash separately: it never leaves this package.
+func (s *UserRepo) userByUsername(ctx context.Context, username string) (*pb.User, string, error) {
+	user := &pb.User{}
+	var hash string
+	err := s.db.QueryRowContext(ctx,
+		"SELECT id, username, password, role, created_at, updated_at FROM users WHERE lower(username) = lower($1) AND deleted_at = 0",
+		strings.TrimSpace(username),
+	).Scan(&user.Id, &user.Username, &hash, &user.Role, &user.CreatedAt, &user.UpdatedAt)
+	if err != nil {
+		if !errors.Is(err, sql.ErrNoRows) {
+			logger.ErrorContext(ctx, "Failed to retrieve user by username", logrus.Fields{
+				"username": username,
+				"error":    err,
+			})
+		}
+		return nil, "", err
+	}
+	return user, hash, nil
+}
+
+// RefreshToken rotates the session the refresh token belongs to: the token
+// is spent and a new pair is returned. A token that was already spent means
+// it leaked, so the whole session is revoked.
 func (s *UserRepo) RefreshToken(ctx context.Context, req *pb.RefreshTokenRequest) (*pb.RefreshTokenResponse, error) {
-	userId, err := s.rds.Get(ctx, req.RefreshToken).Result()
+	claims, err := s.issuer.Parse(req.RefreshToken, token.TypeRefresh)
 	if err != nil {
-		logger.Warn("Invalid refresh token", logrus.Fields{
-			"refresh_token": req.RefreshToken,
-			"error":        err,
+		logger.WarnContext(ctx, "Invalid refresh token", logrus.Fields{
+			"error": err,
 		})
-		return &pb.RefreshTokenResponse{
-			Success: false,
-			Message: "Invalid refresh token",
-		}, nil
+		return nil, ErrInvalidRefreshToken
 	}
 
-	userResp, err := s.GetUserById(ctx, &pb.GetUserRequest{Id: userId})
+	userResp, err := s.GetUserById(ctx, &pb.GetUserRequest{Id: claims.UserID})
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrInvalidRefreshToken
+	}
 	if err != nil {
-		logger.Error("Failed to get user by ID", logrus.Fields{
-			"user_id": userId,
+		logger.ErrorContext(ctx, "Failed to get user by ID", logrus.Fields{
+			"user_id": claims.UserID,
 			"error":   err,
 		})
 		return nil, err
 	}
 	user := userResp.User
 
-	accessToken, refreshToken, err := token.CreateTokens(user)
+	tokens, err := s.issuer.CreateTokens(user, claims.Session)
 	if err != nil {
-		logger.Error("Failed to create tokens", logrus.Fields{
+		logger.ErrorContext(ctx, "Failed to create tokens", logrus.Fields{
 			"user_id": user.Id,
 			"error":   err,
 		})
 		return nil, err
 	}
 
-	err = s.rds.Set(ctx, refreshToken, user.Id, time.Hour*24).Err()
+	result, err := s.rotateSession(ctx, claims, tokens)
 	if err != nil {
-		logger.Error("Failed to set new refresh token in Redis", logrus.Fields{
-			"refresh_token": refreshToken,
-			"error":        err,
+		logger.ErrorContext(ctx, "Failed to rotate refresh token in Redis", logrus.Fields{
+			"session": claims.Session,
+			"error":   err,
 		})
 		return nil, err
 	}
+	switch result {
+	case rotationUnknown:
+		logger.WarnContext(ctx, "Refresh token of an ended session", logrus.Fields{
+			"user_id": user.Id,
+			"session": claims.Session,
+		})
+		return nil, ErrInvalidRefreshToken
+	case rotationReused:
+		logger.WarnContext(ctx, "Refresh token reused, revoking session", logrus.Fields{
+			"user_id": user.Id,
+			"session": claims.Session,
+		})
+		if _, err := s.revokeSession(ctx, user.Id, claims.Session); err != nil {
+			logger.ErrorContext(ctx, "Failed to revoke session", logrus.Fields{
+				"session": claims.Session,
+				"error":   err,
+			})
+			return nil, err
+		}
+		return nil, ErrRefreshTokenReused
+	}
 
-	logger.Info("Token refreshed successfully", logrus.Fields{
+	logger.InfoContext(ctx, "Token refreshed successfully", logrus.Fields{
 		"username": user.Username,
 	})
 
 	return &pb.RefreshTokenResponse{
 		Success:      true,
 		Message:      "Token refreshed successfully",
-		AccessToken:  accessToken,
-		RefreshToken: refreshToken,
+		AccessToken:  tokens.Access,
+		RefreshToken: tokens.Refresh,
 	}, nil
 }
 
+// Logout ends the session of the given access token and denylists it.
+func (s *UserRepo) Logout(ctx context.Context, req *pb.LogoutRequest) (*pb.LogoutResponse, error) {
+	claims, err := s.issuer.Parse(req.AccessToken, token.TypeAccess)
+	if err != nil {
+		logger.WarnContext(ctx, "Invalid access token on logout", logrus.Fields{
+			"error": err,
+		})
+		return nil, ErrInvalidAccessToken
+	}
+
+	if _, err := s.revokeSession(ctx, claims.UserID, claims.Session); err != nil {
+		logger.ErrorContext(ctx, "Failed to revoke session", logrus.Fields{
+			"session": claims.Session,
+			"error":   err,
+		})
+		return nil, err
+	}
+	// The session normally lists the token already; this covers one that
+	// outlived it.
+	if err := s.denyAccessToken(ctx, claims); err != nil {
+		logger.ErrorContext(ctx, "Failed to denylist access token", logrus.Fields{
+			"session": claims.Session,
+			"error":   err,
+		})
+		return nil, err
+	}
+
+	logger.InfoContext(ctx, "Logout successful", logrus.Fields{
+		"user_id": claims.UserID,
+		"session": claims.Session,
+	})
+
+	return &pb.LogoutResponse{
+		Success: true,
+		Message: "Logout successful",
+	}, nil
+}
+
+// LogoutAll ends every session of the user the access token belongs to.
+func (s *UserRepo) LogoutAll(ctx context.Context, req *pb.LogoutAllRequest) (*pb.LogoutAllResponse, error) {
+	claims, err := s.issuer.Parse(req.AccessToken, token.TypeAccess)
+	if err != nil {
+		logger.WarnContext(ctx, "Invalid access token on logout", logrus.Fields{
+			"error": err,
+		})
+		return nil, ErrInvalidAccessToken
+	}
+
+	revoked, err := s.revokeAllSessions(ctx, claims.UserID)
+	if err != nil {
+		logger.ErrorContext(ctx, "Failed to revoke sessions", logrus.Fields{
+			"user_id": claims.UserID,
+			"error":   err,
+		})
+		return nil, err
+	}
+	if err := s.denyAccessToken(ctx, claims); err != nil {
+		logger.ErrorContext(ctx, "Failed to denylist access token", logrus.Fields{
+			"session": claims.Session,
+			"error":   err,
+		})
+		return nil, err
+	}
+
+	logger.InfoContext(ctx, "Logged out of all sessions", logrus.Fields{
+		"user_id":  claims.UserID,
+		"sessions": revoked,
+	})
+
+	return &pb.LogoutAllResponse{
+		Success:  true,
+		Message:  "Logged out of all sessions",
+		Sessions: int32(revoked),
+	}, nil
+}
+
+// UpdateUser keeps the stored role when req.User.Role is empty.
 func (u *UserRepo) UpdateUser(ctx context.Context, req *pb.UpdateUserRequest) (*pb.UpdateUserResponse, error) {
 	now := time.Now().Format(time.RFC3339)
 
@@ -191,32 +366,35 @@ func (u *UserRepo) UpdateUser(ctx context.Context, req *pb.UpdateUserRequest) (*
 		// Agar parol yangilanayotgan bo'lsa, uni hashlash
 		hashedPassword, err := bcrypt.GenerateFromPassword([]byte(req.User.Password), bcrypt.DefaultCost)
 		if err != nil {
-			logger.Error("Failed to hash password for update", logrus.Fields{
+			logger.ErrorContext(ctx, "Failed to hash password for update", logrus.Fields{
 				"user_id": req.User.Id,
 				"error":   err,
 			})
 			return &pb.UpdateUserResponse{Success: false, Message: "Failed to hash password"}, err
 		}
-		_, err = u.db.Exec(
-			"UPDATE users SET username = $1, role = $2, password = $3, updated_at = $4 WHERE id = $5",
+		err = u.db.QueryRowContext(ctx,
+			"UPDATE users SET username = $1, role = COALESCE(NULLIF($2, ''), role), password = $3, updated_at = $4 WHERE id = $5 AND deleted_at = 0 RETURNING role",
 			req.User.Username, req.User.Role, string(hashedPassword), now, req.User.Id,
-		)
-		if err != nil {
-			logger.Error("Failed to update user", logrus.Fields{
+		).Scan(&req.User.Role)
+		if err != nil && !errors.Is(err, sql.ErrNoRows) {
+			logger.ErrorContext(ctx, "Failed to update user", logrus.Fields{
 				"user_id": req.User.Id,
 				"error":   err,
 			})
 			return nil, err
 		}
 	} else {
-		_, err = u.db.Exec(
-			"UPDATE users SET username = $1, role = $2, updated_at = $3 WHERE id = $4",
+		err = u.db.QueryRowContext(ctx,
+			"UPDATE users SET username = $1, role = COALESCE(NULLIF($2, ''), role), updated_at = $3 WHERE id = $4 AND deleted_at = 0 RETURNING role",
 			req.User.Username, req.User.Role, now, req.User.Id,
-		)
+		).Scan(&req.User.Role)
 	}
 
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, err
+	}
 	if err != nil {
-		logger.Error("Failed to update user", logrus.Fields{
+		logger.ErrorContext(ctx, "Failed to update user", logrus.Fields{
 			"user_id": req.User.Id,
 			"error":   err,
 		})
@@ -224,8 +402,9 @@ func (u *UserRepo) UpdateUser(ctx context.Context, req *pb.UpdateUserRequest) (*
 	}
 
 	req.User.UpdatedAt = now
+	req.User.Password = ""
 
-	logger.Info("User updated successfully", logrus.Fields{
+	logger.InfoContext(ctx, "User updated successfully", logrus.Fields{
 		"user_id": req.User.Id,
 		"username": req.User.Username,
 	})
@@ -238,20 +417,25 @@ func (u *UserRepo) UpdateUser(ctx context.Context, req *pb.UpdateUserRequest) (*
 }
 
 func (u *UserRepo) DeleteUser(ctx context.Context, req *pb.DeleteUserRequest) (*pb.DeleteUserResponse, error) {
-	_, err := u.db.Exec(
-		"UPDATE users SET deleted_at = $1 WHERE id = $2",
+	result, err := u.db.ExecContext(ctx,
+		"UPDATE users SET deleted_at = $1 WHERE id = $2 AND deleted_at = 0",
 		time.Now().Unix(), req.Id,
 	)
 
 	if err != nil {
-		logger.Error("Failed to delete user", logrus.Fields{
+		logger.ErrorContext(ctx, "Failed to delete user", logrus.Fields{
 			"user_id": req.Id,
 			"error":   err,
 		})
 		return &pb.DeleteUserResponse{Success: false, Message: "Failed to delete user"}, err
 	}
+	if num, err := result.RowsAffected(); err != nil {
+		return &pb.DeleteUserResponse{Success: false, Message: "Failed to delete user"}, err
+	} else if num == 0 {
+		return nil, sql.ErrNoRows
+	}
 
-	logger.Info("User deleted successfully", logrus.Fields{
+	logger.InfoContext(ctx, "User deleted successfully", logrus.Fields{
 		"user_id": req.Id,
 	})
 
@@ -264,26 +448,26 @@ func (u *UserRepo) DeleteUser(ctx context.Context, req *pb.DeleteUserRequest) (*
 
 func (u *UserRepo) GetUserById(ctx context.Context, req *pb.GetUserRequest) (*pb.GetUserResponse, error) {
 	user := &pb.User{}
-	err := u.db.QueryRow(
+	err := u.db.QueryRowContext(ctx,
 		"SELECT id, username, role, created_at, updated_at FROM users WHERE id = $1 AND deleted_at = 0",
 		req.Id,
 	).Scan(&user.Id, &user.Username, &user.Role, &user.CreatedAt, &user.UpdatedAt)
 
 	if err != nil {
 		if err == sql.ErrNoRows {
-			logger.Warn("User not found", logrus.Fields{
+			logger.WarnContext(ctx, "User not found", logrus.Fields{
 				"user_id": req.Id,
 			})
-			return &pb.GetUserResponse{Success: false, Message: "User not found"}, nil
+			return nil, sql.ErrNoRows
 		}
-		logger.Error("Failed to retrieve user", logrus.Fields{
+		logger.ErrorContext(ctx, "Failed to retrieve user", logrus.Fields{
 			"user_id": req.Id,
 			"error":   err,
 		})
 		return &pb.GetUserResponse{Success: false, Message: "Failed to get user"}, err
 	}
 
-	logger.Info("User retrieved successfully", logrus.Fields{
+	logger.InfoContext(ctx, "User retrieved successfully", logrus.Fields{
 		"user_id": req.Id,
 		"username": user.Username,
 	})
@@ -295,10 +479,36 @@ func (u *UserRepo) GetUserById(ctx context.Context, req *pb.GetUserRequest) (*pb
 	}, nil
 }
 
-func (u *UserRepo) GetUsers(ctx context.Context, req *pb.Void) (*pb.GetUsersResponse, error) {
-	rows, err := u.db.Query("SELECT id, username, role, created_at, updated_at FROM users WHERE deleted_at = 0")
+// userOrderColumns maps the order_by fields accepted by GetUsers to columns.
+var userOrderColumns = map[string]string{
+	"username":   "username",
+	"role":       "role",
+	"created_at": "created_at",
+}
+
+func (u *UserRepo) GetUsers(ctx context.Context, req *pb.GetUsersRequest) (*pb.GetUsersResponse, error) {
+	p, err := paging.New(req.PageSize, req.PageToken, req.OrderBy, userOrderColumns, "username")
+	if err != nil {
+		return &pb.GetUsersResponse{Success: false, Message: err.Error()}, err
+	}
+
+	filter := paging.NewConditions("deleted_at = 0")
+	if req.Role != "" {
+		filter.Add("role = $%d", req.Role)
+	}
+
+	var total int64
+	if err := u.db.QueryRowContext(ctx, "SELECT COUNT(*) FROM users WHERE "+filter.String(), filter.Args()...).Scan(&total); err != nil {
+		logger.ErrorContext(ctx, "Failed to count users", logrus.Fields{
+			"error": err,
+		})
+		return &pb.GetUsersResponse{Success: false, Message: "Failed to get users"}, err
+	}
+
+	query := "SELECT id, username, role, created_at, updated_at FROM users" + p.Clause(filter)
+	rows, err := u.db.QueryContext(ctx, query, filter.Args()...)
 	if err != nil {
-		logger.Error("Failed to retrieve users", logrus.Fields{
+		logger.ErrorContext(ctx, "Failed to retrieve users", logrus.Fields{
 			"error": err,
 		})
 		return &pb.GetUsersResponse{Success: false, Message: "Failed to get users"}, err
@@ -310,27 +520,54 @@ func (u *UserRepo) GetUsers(ctx context.Context, req *pb.Void) (*pb.GetUsersResp
 		user := &pb.User{}
 		err := rows.Scan(&user.Id, &user.Username, &user.Role, &user.CreatedAt, &user.UpdatedAt)
 		if err != nil {
-			logger.Error("Failed to scan user row", logrus.Fields{
+			logger.ErrorContext(ctx, "Failed to scan user row", logrus.Fields{
 				"error": err,
 			})
 			return &pb.GetUsersResponse{Success: false, Message: "Failed to scan user"}, err
 		}
 		users = append(users, user)
 	}
+	if err := rows.Err(); err != nil {
+		logger.ErrorContext(ctx, "Failed to retrieve users", logrus.Fields{
+			"error": err,
+		})
+		return &pb.GetUsersResponse{Success: false, Message: "Failed to get users"}, err
+	}
+
+	var nextPageToken string
+	if len(users) > p.Size {
+		users = users[:p.Size]
+		last := users[p.Size-1]
+		nextPageToken = p.NextToken(userSortValue(last, p.Column), last.Id)
+	}
 
-	logger.Info("Users retrieved successfully", logrus.Fields{
+	logger.InfoContext(ctx, "Users retrieved successfully", logrus.Fields{
 		"count": len(users),
+		"total": total,
 	})
 
 	return &pb.GetUsersResponse{
-		Success: true,
-		Message: "Users retrieved successfully",
-		Users:   users,
+		Success:       true,
+		Message:       "Users retrieved successfully",
+		Users:         users,
+		NextPageToken: nextPageToken,
+		TotalCount:    total,
 	}, nil
 }
 
+func userSortValue(user *pb.User, column string) string {
+	switch column {
+	case "role":
+		return user.Role
+	case "created_at":
+		return user.CreatedAt
+	default:
+		return user.Username
+	}
+}
+
 func (u *UserRepo) GetUserByFilter(ctx context.Context, req *pb.UserFilter) (*pb.GetUsersResponse, error) {
-	query := "SELECT id, username, password, role, created_at, updated_at FROM users WHERE deleted_at = 0"
+	query := "SELECT id, username, role, created_at, updated_at FROM users WHERE deleted_at = 0"
 	args := []interface{}{}
 
 	if req.Username != "" {
@@ -343,9 +580,9 @@ func (u *UserRepo) GetUserByFilter(ctx context.Context, req *pb.UserFilter) (*pb
 		args = append(args, req.Role)
 	}
 
-	rows, err := u.db.Query(query, args...)
+	rows, err := u.db.QueryContext(ctx, query, args...)
 	if err != nil {
-		logger.Error("Failed to retrieve users by filter", logrus.Fields{
+		logger.ErrorContext(ctx, "Failed to retrieve users by filter", logrus.Fields{
 			"error": err,
 		})
 		return &pb.GetUsersResponse{Success: false, Message: "Failed to get users"}, err
@@ -355,9 +592,9 @@ func (u *UserRepo) GetUserByFilter(ctx context.Context, req *pb.UserFilter) (*pb
 	var users []*pb.User
 	for rows.Next() {
 		user := &pb.User{}
-		err := rows.Scan(&user.Id, &user.Username, &user.Password, &user.Role, &user.CreatedAt, &user.UpdatedAt)
+		err := rows.Scan(&user.Id, &user.Username, &user.Role, &user.CreatedAt, &user.UpdatedAt)
 		if err != nil {
-			logger.Error("Failed to scan user row", logrus.Fields{
+			logger.ErrorContext(ctx, "Failed to scan user row", logrus.Fields{
 				"error": err,
 			})
 			return &pb.GetUsersResponse{Success: false, Message: "Failed to scan user"}, err
@@ -365,7 +602,7 @@ func (u *UserRepo) GetUserByFilter(ctx context.Context, req *pb.UserFilter) (*pb
 		users = append(users, user)
 	}
 
-	logger.Info("Users retrieved successfully by filter", logrus.Fields{
+	logger.InfoContext(ctx, "Users retrieved successfully by filter", logrus.Fields{
 		"username": req.Username,
 		"role":     req.Role,
 		"count":    len(users),
@@ -2,8 +2,11 @@ package repository
 
 import (
 	"context"
+	"shared/paging"
 	"testing"
 	"time"
+	config "user-service/internal/user/pkg/load"
+	"user-service/token"
 
 	pb "github.com/Bekzodbekk/paris2024_livestream_protos/genproto/userpb"
 	"github.com/DATA-DOG/go-sqlmock"
@@ -28,7 +31,18 @@ func setupTest(t *testing.T) (*UserRepo, sqlmock.Sqlmock, *redis.Client, func())
 		Addr: mr.Addr(),
 	})
 
-	repo := NewPostgresUserRepo(db, rdb).(*UserRepo)
+	issuer, err := token.NewIssuer(config.JWTConfig{
+		Issuer:     "user-service",
+		Keys:       []config.SigningKey{{ID: "test", Algorithm: "EdDSA"}},
+		AccessTTL:  time.Hour,
+		RefreshTTL: time.Hour * 24,
+	})
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when creating the token issuer", err)
+	}
+
+	lockout := config.LockoutConfig{MaxFailures: 3, Window: time.Minute, Duration: time.Minute}
+	repo := NewPostgresUserRepo(db, rdb, issuer, lockout).(*UserRepo)
 
 	return repo, mock, rdb, func() {
 		db.Close()
@@ -73,8 +87,8 @@ func TestLogin(t *testing.T) {
 
 	hashedPassword, _ := bcrypt.GenerateFromPassword([]byte(req.Password), bcrypt.DefaultCost)
 
-	mock.ExpectQuery("SELECT (.+) FROM users").
-		WithArgs("%" + req.Username + "%").
+	mock.ExpectQuery("SELECT (.+) FROM users WHERE lower\\(username\\) = lower\\(\\$1\\)").
+		WithArgs(req.Username).
 		WillReturnRows(sqlmock.NewRows([]string{"id", "username", "password", "role", "created_at", "updated_at"}).
 			AddRow("1", req.Username, string(hashedPassword), "user", time.Now().Format(time.RFC3339), time.Now().Format(time.RFC3339)))
 
@@ -86,30 +100,96 @@ func TestLogin(t *testing.T) {
 	assert.NotEmpty(t, resp.AccessToken)
 	assert.NotEmpty(t, resp.RefreshToken)
 
-	// Check if refresh token is set in Redis
-	val, err := rdb.Get(ctx, resp.RefreshToken).Result()
+	// The login starts a session whose current refresh token is the one returned
+	claims, err := repo.issuer.Parse(resp.RefreshToken, token.TypeRefresh)
+	assert.NoError(t, err)
+	val, err := rdb.HGet(ctx, sessionKey(claims.Session), "refresh").Result()
 	assert.NoError(t, err)
-	assert.Equal(t, "1", val)
+	assert.Equal(t, claims.ID, val)
+	assert.True(t, rdb.SIsMember(ctx, userSessionsKey("1"), claims.Session).Val())
+
+	// The hash stays in the repository
+	assert.Empty(t, resp.User.Password)
 }
 
-func TestRefreshToken(t *testing.T) {
+func expectLogin(mock sqlmock.Sqlmock, username, hashedPassword string) {
+	rows := sqlmock.NewRows([]string{"id", "username", "password", "role", "created_at", "updated_at"})
+	if hashedPassword != "" {
+		rows.AddRow("1", username, hashedPassword, "user", time.Now().Format(time.RFC3339), time.Now().Format(time.RFC3339))
+	}
+	mock.ExpectQuery("SELECT (.+) FROM users WHERE lower\\(username\\) = lower\\(\\$1\\)").
+		WithArgs(username).
+		WillReturnRows(rows)
+}
+
+func TestLoginUnknownUser(t *testing.T) {
+	repo, mock, _, teardown := setupTest(t)
+	defer teardown()
+
+	expectLogin(mock, "nobody", "")
+
+	_, err := repo.Login(context.Background(), &pb.LoginRequest{Username: "nobody", Password: "1001"})
+	assert.ErrorIs(t, err, ErrInvalidCredentials)
+}
+
+func TestLoginLockout(t *testing.T) {
 	repo, mock, rdb, teardown := setupTest(t)
 	defer teardown()
 
 	ctx := context.Background()
-	refreshToken := "testRefreshToken"
-	userId := "1"
+	hashedPassword, _ := bcrypt.GenerateFromPassword([]byte("1001"), bcrypt.MinCost)
+
+	// A success clears earlier failures.
+	expectLogin(mock, "mongosh", string(hashedPassword))
+	_, err := repo.Login(ctx, &pb.LoginRequest{Username: "mongosh", Password: "wrong"})
+	assert.ErrorIs(t, err, ErrInvalidCredentials)
+	expectLogin(mock, "mongosh", string(hashedPassword))
+	_, err = repo.Login(ctx, &pb.LoginRequest{Username: "mongosh", Password: "1001"})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), rdb.Exists(ctx, failuresPrefix+"mongosh").Val())
 
-	// Set refresh token in Redis
-	err := rdb.Set(ctx, refreshToken, userId, time.Hour).Err()
+	// Failures count against the username whatever its case.
+	for _, username := range []string{"mongosh", "MongoSH", "MONGOSH"} {
+		expectLogin(mock, username, string(hashedPassword))
+		_, err := repo.Login(ctx, &pb.LoginRequest{Username: username, Password: "wrong"})
+		assert.ErrorIs(t, err, ErrInvalidCredentials)
+	}
+
+	// Locked: even the right password is refused, without a lookup.
+	_, err = repo.Login(ctx, &pb.LoginRequest{Username: "mongosh", Password: "1001"})
+	var locked *LockedError
+	assert.ErrorAs(t, err, &locked)
+	assert.Greater(t, locked.RetryAfter, time.Duration(0))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+// login starts a session for user 1 the way Login does.
+func login(t *testing.T, repo *UserRepo) *token.Tokens {
+	sid, err := token.NewID()
+	assert.NoError(t, err)
+	tokens, err := repo.issuer.CreateTokens(&pb.User{Id: "1", Username: "testuser", Role: "user"}, sid)
 	assert.NoError(t, err)
+	assert.NoError(t, repo.startSession(context.Background(), "1", sid, tokens))
+	return tokens
+}
 
+func expectUserById(mock sqlmock.Sqlmock, userId string) {
 	mock.ExpectQuery("SELECT (.+) FROM users").
 		WithArgs(userId).
 		WillReturnRows(sqlmock.NewRows([]string{"id", "username", "role", "created_at", "updated_at"}).
 			AddRow(userId, "testuser", "user", time.Now().Format(time.RFC3339), time.Now().Format(time.RFC3339)))
+}
 
-	resp, err := repo.RefreshToken(ctx, &pb.RefreshTokenRequest{RefreshToken: refreshToken})
+func TestRefreshToken(t *testing.T) {
+	repo, mock, rdb, teardown := setupTest(t)
+	defer teardown()
+
+	ctx := context.Background()
+	tokens := login(t, repo)
+
+	expectUserById(mock, "1")
+
+	resp, err := repo.RefreshToken(ctx, &pb.RefreshTokenRequest{RefreshToken: tokens.Refresh})
 
 	assert.NoError(t, err)
 	assert.True(t, resp.Success)
@@ -117,10 +197,104 @@ func TestRefreshToken(t *testing.T) {
 	assert.NotEmpty(t, resp.AccessToken)
 	assert.NotEmpty(t, resp.RefreshToken)
 
-	// Check if new refresh token is set in Redis
-	val, err := rdb.Get(ctx, resp.RefreshToken).Result()
+	// The new refresh token belongs to the same session and replaces the old one
+	claims, err := repo.issuer.Parse(resp.RefreshToken, token.TypeRefresh)
+	assert.NoError(t, err)
+	val, err := rdb.HGet(ctx, sessionKey(claims.Session), "refresh").Result()
+	assert.NoError(t, err)
+	assert.Equal(t, claims.ID, val)
+	assert.NotEqual(t, tokens.RefreshID, val)
+}
+
+func TestRefreshTokenReuseRevokesSession(t *testing.T) {
+	repo, mock, rdb, teardown := setupTest(t)
+	defer teardown()
+
+	ctx := context.Background()
+	tokens := login(t, repo)
+
+	expectUserById(mock, "1")
+	resp, err := repo.RefreshToken(ctx, &pb.RefreshTokenRequest{RefreshToken: tokens.Refresh})
+	assert.NoError(t, err)
+
+	// Presenting the spent token again ends the session...
+	expectUserById(mock, "1")
+	_, err = repo.RefreshToken(ctx, &pb.RefreshTokenRequest{RefreshToken: tokens.Refresh})
+	assert.ErrorIs(t, err, ErrRefreshTokenReused)
+
+	// ...so the token issued by the rotation is no longer valid either,
+	expectUserById(mock, "1")
+	_, err = repo.RefreshToken(ctx, &pb.RefreshTokenRequest{RefreshToken: resp.RefreshToken})
+	assert.ErrorIs(t, err, ErrInvalidRefreshToken)
+
+	// and every access token of the session is denylisted.
+	rotated, err := repo.issuer.Parse(resp.AccessToken, token.TypeAccess)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), rdb.Exists(ctx, DenylistPrefix+tokens.AccessID, DenylistPrefix+rotated.ID).Val())
+}
+
+func TestRefreshTokenInvalid(t *testing.T) {
+	repo, _, _, teardown := setupTest(t)
+	defer teardown()
+
+	_, err := repo.RefreshToken(context.Background(), &pb.RefreshTokenRequest{RefreshToken: "testRefreshToken"})
+	assert.ErrorIs(t, err, ErrInvalidRefreshToken)
+}
+
+func TestRefreshTokenRejectsAccessToken(t *testing.T) {
+	repo, _, _, teardown := setupTest(t)
+	defer teardown()
+
+	tokens := login(t, repo)
+
+	_, err := repo.RefreshToken(context.Background(), &pb.RefreshTokenRequest{RefreshToken: tokens.Access})
+	assert.ErrorIs(t, err, ErrInvalidRefreshToken)
+}
+
+func TestLogout(t *testing.T) {
+	repo, mock, rdb, teardown := setupTest(t)
+	defer teardown()
+
+	ctx := context.Background()
+	tokens := login(t, repo)
+	other := login(t, repo)
+
+	resp, err := repo.Logout(ctx, &pb.LogoutRequest{AccessToken: tokens.Access})
+
+	assert.NoError(t, err)
+	assert.True(t, resp.Success)
+	assert.Equal(t, int64(1), rdb.Exists(ctx, DenylistPrefix+tokens.AccessID).Val())
+	assert.Equal(t, int64(0), rdb.Exists(ctx, DenylistPrefix+other.AccessID).Val())
+
+	// The logged out session can no longer be refreshed; the other one can.
+	expectUserById(mock, "1")
+	_, err = repo.RefreshToken(ctx, &pb.RefreshTokenRequest{RefreshToken: tokens.Refresh})
+	assert.ErrorIs(t, err, ErrInvalidRefreshToken)
+
+	expectUserById(mock, "1")
+	_, err = repo.RefreshToken(ctx, &pb.RefreshTokenRequest{RefreshToken: other.Refresh})
 	assert.NoError(t, err)
-	assert.Equal(t, userId, val)
+}
+
+func TestLogoutAll(t *testing.T) {
+	repo, mock, rdb, teardown := setupTest(t)
+	defer teardown()
+
+	ctx := context.Background()
+	tokens := login(t, repo)
+	other := login(t, repo)
+
+	resp, err := repo.LogoutAll(ctx, &pb.LogoutAllRequest{AccessToken: tokens.Access})
+
+	assert.NoError(t, err)
+	assert.True(t, resp.Success)
+	assert.Equal(t, int32(2), resp.Sessions)
+	assert.Equal(t, int64(2), rdb.Exists(ctx, DenylistPrefix+tokens.AccessID, DenylistPrefix+other.AccessID).Val())
+	assert.Equal(t, int64(0), rdb.Exists(ctx, userSessionsKey("1")).Val())
+
+	expectUserById(mock, "1")
+	_, err = repo.RefreshToken(ctx, &pb.RefreshTokenRequest{RefreshToken: other.Refresh})
+	assert.ErrorIs(t, err, ErrInvalidRefreshToken)
 }
 
 func TestUpdateUser(t *testing.T) {
@@ -137,9 +311,9 @@ func TestUpdateUser(t *testing.T) {
 		},
 	}
 
-	mock.ExpectExec("UPDATE users SET").
+	mock.ExpectQuery("UPDATE users SET").
 		WithArgs(req.User.Username, req.User.Role, sqlmock.AnyArg(), sqlmock.AnyArg(), req.User.Id).
-		WillReturnResult(sqlmock.NewResult(1, 1))
+		WillReturnRows(sqlmock.NewRows([]string{"role"}).AddRow("admin"))
 
 	resp, err := repo.UpdateUser(ctx, req)
 
@@ -147,7 +321,27 @@ func TestUpdateUser(t *testing.T) {
 	assert.True(t, resp.Success)
 	assert.Equal(t, "User updated successfully", resp.Message)
 	assert.Equal(t, req.User.Username, resp.User.Username)
-	assert.Equal(t, req.User.Role, resp.User.Role)
+	assert.Equal(t, "admin", resp.User.Role)
+	assert.Empty(t, resp.User.Password)
+}
+
+func TestUpdateUserKeepsRole(t *testing.T) {
+	repo, mock, _, teardown := setupTest(t)
+	defer teardown()
+
+	req := &pb.UpdateUserRequest{
+		User: &pb.User{Id: "1", Username: "mongosh"},
+	}
+
+	mock.ExpectQuery(`UPDATE users SET username = \$1, role = COALESCE\(NULLIF\(\$2, ''\), role\)`).
+		WithArgs(req.User.Username, "", sqlmock.AnyArg(), req.User.Id).
+		WillReturnRows(sqlmock.NewRows([]string{"role"}).AddRow("user"))
+
+	resp, err := repo.UpdateUser(context.Background(), req)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "user", resp.User.Role)
+	assert.NoError(t, mock.ExpectationsWereMet())
 }
 
 func TestDeleteUser(t *testing.T) {
@@ -199,17 +393,45 @@ func TestGetUsers(t *testing.T) {
 
 	ctx := context.Background()
 
-	mock.ExpectQuery("SELECT (.+) FROM users").
+	mock.ExpectQuery("SELECT COUNT(.+) FROM users WHERE deleted_at = 0").
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(2))
+	mock.ExpectQuery("SELECT (.+) FROM users WHERE deleted_at = 0 ORDER BY username ASC, id ASC LIMIT").
+		WithArgs(paging.DefaultPageSize + 1).
 		WillReturnRows(sqlmock.NewRows([]string{"id", "username", "role", "created_at", "updated_at"}).
 			AddRow("1", "user1", "user", time.Now().Format(time.RFC3339), time.Now().Format(time.RFC3339)).
 			AddRow("2", "user2", "admin", time.Now().Format(time.RFC3339), time.Now().Format(time.RFC3339)))
 
-	resp, err := repo.GetUsers(ctx, &pb.Void{})
+	resp, err := repo.GetUsers(ctx, &pb.GetUsersRequest{})
 
 	assert.NoError(t, err)
 	assert.True(t, resp.Success)
 	assert.Equal(t, "Users retrieved successfully", resp.Message)
 	assert.Len(t, resp.Users, 2)
+	assert.Equal(t, int64(2), resp.TotalCount)
+}
+
+func TestGetUsersByRole(t *testing.T) {
+	repo, mock, _, teardown := setupTest(t)
+	defer teardown()
+
+	ctx := context.Background()
+
+	mock.ExpectQuery("SELECT COUNT(.+) FROM users WHERE deleted_at = 0 AND role = \\$1").
+		WithArgs("admin").
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(3))
+	mock.ExpectQuery("SELECT (.+) FROM users WHERE deleted_at = 0 AND role = \\$1 ORDER BY created_at DESC, id DESC LIMIT \\$2").
+		WithArgs("admin", 3).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "username", "role", "created_at", "updated_at"}).
+			AddRow("3", "user3", "admin", time.Now().Format(time.RFC3339), time.Now().Format(time.RFC3339)).
+			AddRow("2", "user2", "admin", time.Now().Format(time.RFC3339), time.Now().Format(time.RFC3339)).
+			AddRow("1", "user1", "admin", time.Now().Format(time.RFC3339), time.Now().Format(time.RFC3339)))
+
+	resp, err := repo.GetUsers(ctx, &pb.GetUsersRequest{PageSize: 2, OrderBy: "created_at desc", Role: "admin"})
+
+	assert.NoError(t, err)
+	assert.Len(t, resp.Users, 2)
+	assert.Equal(t, int64(3), resp.TotalCount)
+	assert.NotEmpty(t, resp.NextPageToken)
 }
 
 func TestGetUserByFilter(t *testing.T) {
@@ -222,10 +444,10 @@ func TestGetUserByFilter(t *testing.T) {
 		Role:     "admin",
 	}
 
-	mock.ExpectQuery("SELECT (.+) FROM users").
+	mock.ExpectQuery("SELECT id, username, role, created_at, updated_at FROM users").
 		WithArgs("%"+req.Username+"%", req.Role).
-		WillReturnRows(sqlmock.NewRows([]string{"id", "username", "password", "role", "created_at", "updated_at"}).
-			AddRow("1", "testuser", "hashedpassword", "user", time.Now().Format(time.RFC3339), time.Now().Format(time.RFC3339)))
+		WillReturnRows(sqlmock.NewRows([]string{"id", "username", "role", "created_at", "updated_at"}).
+			AddRow("1", "testuser", "user", time.Now().Format(time.RFC3339), time.Now().Format(time.RFC3339)))
 
 	resp, err := repo.GetUserByFilter(ctx, req)
 
@@ -235,4 +457,5 @@ func TestGetUserByFilter(t *testing.T) {
 	assert.Len(t, resp.Users, 1)
 	assert.Equal(t, "testuser", resp.Users[0].Username)
 	assert.Equal(t, "user", resp.Users[0].Role)
+	assert.Empty(t, resp.Users[0].Password)
 }
@@ -2,17 +2,47 @@ package repository
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"time"
 
 	pb "github.com/Bekzodbekk/paris2024_livestream_protos/genproto/userpb"
 )
 
+var (
+	// ErrInvalidCredentials is returned by Login for an unknown username or a
+	// wrong password; the two are not told apart.
+	ErrInvalidCredentials = errors.New("invalid credentials")
+	// ErrInvalidRefreshToken is returned by RefreshToken for a token that is
+	// unknown, expired or belongs to a deleted user.
+	ErrInvalidRefreshToken = errors.New("invalid refresh token")
+	// ErrRefreshTokenReused is returned by RefreshToken for a token that was
+	// already exchanged; its session is revoked.
+	ErrRefreshTokenReused = errors.New("refresh token reused, session revoked")
+	// ErrInvalidAccessToken is returned by Logout and LogoutAll for a token
+	// that is malformed, expired or was not issued by this service.
+	ErrInvalidAccessToken = errors.New("invalid access token")
+)
+
+// LockedError is returned by Login while the username is locked out after
+// too many failed attempts.
+type LockedError struct {
+	RetryAfter time.Duration
+}
+
+func (e *LockedError) Error() string {
+	return fmt.Sprintf("too many failed logins, try again in %s", e.RetryAfter.Round(time.Second))
+}
+
 type UserRepository interface {
 	Register(ctx context.Context, req *pb.CreateUserRequest) (*pb.CreateUserResponse, error)
 	Login(ctx context.Context, req *pb.LoginRequest) (*pb.LoginResponse, error)
 	RefreshToken(ctx context.Context, req *pb.RefreshTokenRequest) (*pb.RefreshTokenResponse, error)
+	Logout(ctx context.Context, req *pb.LogoutRequest) (*pb.LogoutResponse, error)
+	LogoutAll(ctx context.Context, req *pb.LogoutAllRequest) (*pb.LogoutAllResponse, error)
 	UpdateUser(ctx context.Context, req *pb.UpdateUserRequest) (*pb.UpdateUserResponse, error)
 	DeleteUser(ctx context.Context, req *pb.DeleteUserRequest) (*pb.DeleteUserResponse, error)
 	GetUserById(ctx context.Context, req *pb.GetUserRequest) (*pb.GetUserResponse, error)
-	GetUsers(ctx context.Context, req *pb.Void) (*pb.GetUsersResponse, error)
+	GetUsers(ctx context.Context, req *pb.GetUsersRequest) (*pb.GetUsersResponse, error)
 	GetUserByFilter(ctx context.Context, req *pb.UserFilter) (*pb.GetUsersResponse, error)
 }
@@ -0,0 +1,144 @@
+package repository
+
+import (
+	"context"
+	"time"
+	"user-service/token"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// Sessions are refresh token families kept in Redis. Every login starts a
+// session; each refresh replaces its refresh token, and presenting one that
+// has already been replaced revokes the whole session.
+//
+//	auth:session:<sid>           hash: user, refresh (the current refresh jti)
+//	auth:session:<sid>:access    zset: access jti scored by its expiry
+//	auth:user:<id>:sessions      set of the user's session ids
+//	auth:denylist:<jti>          revoked access tokens, until they expire
+//
+// The api-gateway reads the denylist, so its prefix is shared with it.
+const (
+	sessionPrefix  = "auth:session:"
+	userPrefix     = "auth:user:"
+	DenylistPrefix = "auth:denylist:"
+)
+
+func sessionKey(sid string) string     { return sessionPrefix + sid }
+func accessKey(sid string) string      { return sessionPrefix + sid + ":access" }
+func userSessionsKey(id string) string { return userPrefix + id + ":sessions" }
+
+// rotateScript swaps the current refresh token of a session for a new one,
+// but only when the presented token is the current one. It returns 1 on
+// success, 0 for an unknown session and -1 when the token was already used.
+var rotateScript = redis.NewScript(`
+local current = redis.call('HGET', KEYS[1], 'refresh')
+if not current then
+  return 0
+end
+if current ~= ARGV[1] then
+  return -1
+end
+redis.call('HSET', KEYS[1], 'refresh', ARGV[2])
+redis.call('EXPIRE', KEYS[1], ARGV[5])
+redis.call('ZREMRANGEBYSCORE', KEYS[2], '-inf', ARGV[6])
+redis.call('ZADD', KEYS[2], ARGV[4], ARGV[3])
+redis.call('EXPIRE', KEYS[2], ARGV[5])
+redis.call('EXPIRE', KEYS[3], ARGV[5])
+return 1
+`)
+
+// revokeScript deletes a session and denylists every access token issued
+// for it that has not expired yet. It returns the number of keys deleted, so
+// 0 means the session was already gone.
+var revokeScript = redis.NewScript(`
+local now = tonumber(ARGV[1])
+local tokens = redis.call('ZRANGEBYSCORE', KEYS[2], '(' .. now, '+inf', 'WITHSCORES')
+for i = 1, #tokens, 2 do
+  redis.call('SET', ARGV[2] .. tokens[i], '1', 'EX', tonumber(tokens[i + 1]) - now)
+end
+local deleted = redis.call('DEL', KEYS[1], KEYS[2])
+redis.call('SREM', KEYS[3], ARGV[3])
+return deleted
+`)
+
+type rotation int
+
+const (
+	rotationUnknown rotation = 0
+	rotationReused  rotation = -1
+	rotationDone    rotation = 1
+)
+
+// startSession records a new session holding the tokens just issued.
+func (u *UserRepo) startSession(ctx context.Context, userID, sid string, tokens *token.Tokens) error {
+	ttl := u.issuer.RefreshTTL()
+	_, err := u.rds.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.HSet(ctx, sessionKey(sid), "user", userID, "refresh", tokens.RefreshID)
+		pipe.Expire(ctx, sessionKey(sid), ttl)
+		pipe.ZAdd(ctx, accessKey(sid), redis.Z{Score: float64(tokens.AccessExpiresAt.Unix()), Member: tokens.AccessID})
+		pipe.Expire(ctx, accessKey(sid), ttl)
+		pipe.SAdd(ctx, userSessionsKey(userID), sid)
+		pipe.Expire(ctx, userSessionsKey(userID), ttl)
+		return nil
+	})
+	return err
+}
+
+// rotateSession makes tokens the current pair of the session that claims,
+// the presented refresh token, belongs to.
+func (u *UserRepo) rotateSession(ctx context.Context, claims *token.Claims, tokens *token.Tokens) (rotation, error) {
+	result, err := rotateScript.Run(ctx, u.rds,
+		[]string{sessionKey(claims.Session), accessKey(claims.Session), userSessionsKey(claims.UserID)},
+		claims.ID,
+		tokens.RefreshID,
+		tokens.AccessID,
+		tokens.AccessExpiresAt.Unix(),
+		int64(u.issuer.RefreshTTL()/time.Second),
+		time.Now().Unix(),
+	).Int()
+	if err != nil {
+		return rotationUnknown, err
+	}
+	return rotation(result), nil
+}
+
+// revokeSession ends a session. It reports whether the session still existed.
+func (u *UserRepo) revokeSession(ctx context.Context, userID, sid string) (bool, error) {
+	deleted, err := revokeScript.Run(ctx, u.rds,
+		[]string{sessionKey(sid), accessKey(sid), userSessionsKey(userID)},
+		time.Now().Unix(),
+		DenylistPrefix,
+		sid,
+	).Int()
+	return deleted > 0, err
+}
+
+// revokeAllSessions ends every session of a user and returns how many were
+// still active.
+func (u *UserRepo) revokeAllSessions(ctx context.Context, userID string) (int, error) {
+	sids, err := u.rds.SMembers(ctx, userSessionsKey(userID)).Result()
+	if err != nil {
+		return 0, err
+	}
+	revoked := 0
+	for _, sid := range sids {
+		ok, err := u.revokeSession(ctx, userID, sid)
+		if err != nil {
+			return revoked, err
+		}
+		if ok {
+			revoked++
+		}
+	}
+	return revoked, nil
+}
+
+// denyAccessToken denylists one access token until it expires.
+func (u *UserRepo) denyAccessToken(ctx context.Context, claims *token.Claims) error {
+	ttl := time.Until(claims.ExpiresAt)
+	if ttl <= 0 {
+		return nil
+	}
+	return u.rds.Set(ctx, DenylistPrefix+claims.ID, "1", ttl).Err()
+}
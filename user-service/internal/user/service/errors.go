@@ -0,0 +1,75 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"shared/paging"
+	"user-service/internal/user/pkg/password"
+	"user-service/internal/user/repository"
+
+	"github.com/lib/pq"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/protoadapt"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+// statusError turns a repository error into the gRPC status the gateway
+// translates for its clients. what names the record involved, e.g.
+// "user 42", for NotFound and AlreadyExists messages. Errors that already
+// carry a status are returned unchanged.
+func statusError(err error, what string) error {
+	if err == nil {
+		return nil
+	}
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+
+	var pqErr *pq.Error
+	var locked *repository.LockedError
+	switch {
+	case errors.As(err, &locked):
+		return withDetails(codes.ResourceExhausted, err.Error(), &errdetails.RetryInfo{
+			RetryDelay: durationpb.New(locked.RetryAfter),
+		})
+	case errors.Is(err, password.ErrWeak):
+		return withDetails(codes.InvalidArgument, err.Error(), &errdetails.BadRequest{
+			FieldViolations: []*errdetails.BadRequest_FieldViolation{{Field: "password", Description: err.Error()}},
+		})
+	case errors.Is(err, repository.ErrInvalidCredentials), errors.Is(err, repository.ErrInvalidRefreshToken),
+		errors.Is(err, repository.ErrRefreshTokenReused), errors.Is(err, repository.ErrInvalidAccessToken):
+		return status.Error(codes.Unauthenticated, err.Error())
+	case errors.Is(err, sql.ErrNoRows):
+		return status.Errorf(codes.NotFound, "%s not found", what)
+	case errors.Is(err, paging.ErrInvalidPageToken), errors.Is(err, paging.ErrInvalidOrderBy):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, context.Canceled):
+		return status.Error(codes.Canceled, err.Error())
+	case errors.Is(err, context.DeadlineExceeded):
+		return status.Error(codes.DeadlineExceeded, err.Error())
+	case errors.As(err, &pqErr):
+		switch {
+		case pqErr.Code == "23505":
+			return status.Errorf(codes.AlreadyExists, "%s already exists", what)
+		case pqErr.Code.Class() == "22":
+			// Data exceptions: a malformed uuid, date or number.
+			return status.Errorf(codes.InvalidArgument, "invalid %s: %s", what, pqErr.Message)
+		case pqErr.Code.Class() == "23":
+			return status.Error(codes.FailedPrecondition, pqErr.Message)
+		}
+	}
+	return status.Error(codes.Internal, err.Error())
+}
+
+// withDetails builds a status carrying detail, falling back to the bare
+// status if the detail cannot be encoded.
+func withDetails(code codes.Code, message string, detail protoadapt.MessageV1) error {
+	st, err := status.New(code, message).WithDetails(detail)
+	if err != nil {
+		return status.Error(code, message)
+	}
+	return st.Err()
+}
@@ -2,54 +2,105 @@ package service
 
 import (
 	"context"
+	"strings"
+	"user-service/internal/user/pkg/password"
 	"user-service/internal/user/repository"
 
 	pb "github.com/Bekzodbekk/paris2024_livestream_protos/genproto/userpb"
 
 	"github.com/redis/go-redis/v9"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
+// defaultRole is given to users registered without one. Granting any other
+// role is up to the gateway, which only lets admins do it.
+const defaultRole = "user"
+
 type UserService struct {
 	pb.UnimplementedUserServiceServer
 	userRepo repository.UserRepository
 	rdb      *redis.Client
+	policy   *password.Policy
 }
 
-func NewService(user repository.UserRepository, rdb *redis.Client) *UserService {
+func NewService(user repository.UserRepository, rdb *redis.Client, policy *password.Policy) *UserService {
 	return &UserService{
 		userRepo: user,
 		rdb:      rdb,
+		policy:   policy,
 	}
 }
 
+// Register stores usernames as given but trimmed; uniqueness ignores case.
 func (s *UserService) Register(ctx context.Context, req *pb.CreateUserRequest) (*pb.CreateUserResponse, error) {
-	return s.userRepo.Register(ctx, req)
+	req.Username = strings.TrimSpace(req.Username)
+	if req.Username == "" {
+		return nil, status.Error(codes.InvalidArgument, "username is required")
+	}
+	if err := s.policy.Validate(req.Password, req.Username); err != nil {
+		return nil, statusError(err, "user "+req.Username)
+	}
+	if req.Role == "" {
+		req.Role = defaultRole
+	}
+	resp, err := s.userRepo.Register(ctx, req)
+	return resp, statusError(err, "user "+req.Username)
 }
 
 func (s *UserService) Login(ctx context.Context, req *pb.LoginRequest) (*pb.LoginResponse, error) {
-	return s.userRepo.Login(ctx, req)
+	resp, err := s.userRepo.Login(ctx, req)
+	return resp, statusError(err, "user "+req.Username)
 }
 
 func (s *UserService) RefreshToken(ctx context.Context, req *pb.RefreshTokenRequest) (*pb.RefreshTokenResponse, error) {
-	return s.userRepo.RefreshToken(ctx, req)
+	resp, err := s.userRepo.RefreshToken(ctx, req)
+	return resp, statusError(err, "refresh token")
+}
+
+func (s *UserService) Logout(ctx context.Context, req *pb.LogoutRequest) (*pb.LogoutResponse, error) {
+	resp, err := s.userRepo.Logout(ctx, req)
+	return resp, statusError(err, "session")
+}
+
+func (s *UserService) LogoutAll(ctx context.Context, req *pb.LogoutAllRequest) (*pb.LogoutAllResponse, error) {
+	resp, err := s.userRepo.LogoutAll(ctx, req)
+	return resp, statusError(err, "sessions")
 }
 
 func (s *UserService) UpdateUser(ctx context.Context, req *pb.UpdateUserRequest) (*pb.UpdateUserResponse, error) {
-	return s.userRepo.UpdateUser(ctx, req)
+	if req.GetUser() == nil {
+		return nil, status.Error(codes.InvalidArgument, "user is required")
+	}
+	req.User.Username = strings.TrimSpace(req.User.Username)
+	if req.User.Username == "" {
+		return nil, status.Error(codes.InvalidArgument, "username is required")
+	}
+	if req.User.Password != "" {
+		if err := s.policy.Validate(req.User.Password, req.User.Username); err != nil {
+			return nil, statusError(err, "user "+req.User.Username)
+		}
+	}
+	resp, err := s.userRepo.UpdateUser(ctx, req)
+	return resp, statusError(err, "user "+req.GetUser().GetUsername())
 }
 
 func (s *UserService) DeleteUser(ctx context.Context, req *pb.DeleteUserRequest) (*pb.DeleteUserResponse, error) {
-	return s.userRepo.DeleteUser(ctx, req)
+	resp, err := s.userRepo.DeleteUser(ctx, req)
+	return resp, statusError(err, "user "+req.Id)
 }
 
 func (s *UserService) GetUserById(ctx context.Context, req *pb.GetUserRequest) (*pb.GetUserResponse, error) {
-	return s.userRepo.GetUserById(ctx, req)
+	resp, err := s.userRepo.GetUserById(ctx, req)
+	return resp, statusError(err, "user "+req.Id)
 }
 
-func (s *UserService) GetUsers(ctx context.Context, req *pb.Void) (*pb.GetUsersResponse, error) {
-	return s.userRepo.GetUsers(ctx, req)
+func (s *UserService) GetUsers(ctx context.Context, req *pb.GetUsersRequest) (*pb.GetUsersResponse, error) {
+	resp, err := s.userRepo.GetUsers(ctx, req)
+	return resp, statusError(err, "users")
 }
 
 func (s *UserService) GetUserByFilter(ctx context.Context, req *pb.UserFilter) (*pb.GetUsersResponse, error) {
-	return s.userRepo.GetUserByFilter(ctx, req)
+	resp, err := s.userRepo.GetUserByFilter(ctx, req)
+	return resp, statusError(err, "users")
 }
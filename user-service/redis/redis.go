@@ -5,6 +5,7 @@ import (
 	"fmt"
 	config "user-service/internal/user/pkg/load"
 
+	"github.com/redis/go-redis/extra/redisotel/v9"
 	"github.com/redis/go-redis/v9"
 )
 
@@ -13,6 +14,9 @@ func ConnectRedis(cfg config.Config) (*redis.Client, error) {
 	rdb := redis.NewClient(&redis.Options{
 		Addr: target,
 	})
+	if err := redisotel.InstrumentTracing(rdb); err != nil {
+		return nil, err
+	}
 
 	if err := rdb.Ping(context.Background()); err != nil {
 		return rdb, nil
@@ -0,0 +1,146 @@
+package token
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"os"
+	config "user-service/internal/user/pkg/load"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// thumbprintLength is how many characters of the public key hash a generated
+// key's kid carries.
+const thumbprintLength = 11
+
+// signingKey is one configured key: the private half signs, the public half
+// verifies and is published in the JWKS. id is the kid tokens name it by;
+// name is the id it is configured under.
+type signingKey struct {
+	id        string
+	name      string
+	method    jwt.SigningMethod
+	private   interface{}
+	public    interface{}
+	generated bool
+}
+
+// loadKey resolves a configured key. HS256 keys are symmetric and never
+// published.
+func loadKey(cfg config.SigningKey) (*signingKey, error) {
+	if cfg.ID == "" {
+		return nil, errors.New("signing key has no id")
+	}
+
+	key := &signingKey{id: cfg.ID, name: cfg.ID}
+	switch cfg.Algorithm {
+	case "HS256":
+		if cfg.Secret == "" {
+			return nil, fmt.Errorf("key %s: HS256 needs a secret", cfg.ID)
+		}
+		key.method = jwt.SigningMethodHS256
+		key.private = []byte(cfg.Secret)
+		key.public = key.private
+		return key, nil
+	case "RS256":
+		key.method = jwt.SigningMethodRS256
+	case "EdDSA":
+		key.method = jwt.SigningMethodEdDSA
+	default:
+		return nil, fmt.Errorf("key %s: unsupported algorithm %q", cfg.ID, cfg.Algorithm)
+	}
+
+	pem := []byte(cfg.PrivateKey)
+	if len(pem) == 0 && cfg.PrivateKeyFile != "" {
+		b, err := os.ReadFile(cfg.PrivateKeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("key %s: %w", cfg.ID, err)
+		}
+		pem = b
+	}
+
+	var err error
+	if len(pem) == 0 {
+		key.generated = true
+		err = key.generate()
+	} else {
+		err = key.parse(pem)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("key %s: %w", cfg.ID, err)
+	}
+	if key.generated {
+		// Every replica and every restart generates a different key. A kid
+		// of its own keeps a verifier from checking a token against another
+		// key it cached under the configured id.
+		thumbprint, err := key.thumbprint()
+		if err != nil {
+			return nil, fmt.Errorf("key %s: %w", cfg.ID, err)
+		}
+		key.id = cfg.ID + "-" + thumbprint
+	}
+	return key, nil
+}
+
+// thumbprint is a short hash of the public key.
+func (k *signingKey) thumbprint() (string, error) {
+	der, err := x509.MarshalPKIXPublicKey(k.public)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(der)
+	return base64.RawURLEncoding.EncodeToString(sum[:])[:thumbprintLength], nil
+}
+
+func (k *signingKey) generate() error {
+	switch k.method {
+	case jwt.SigningMethodRS256:
+		private, err := rsa.GenerateKey(rand.Reader, 2048)
+		if err != nil {
+			return err
+		}
+		k.private, k.public = private, &private.PublicKey
+	case jwt.SigningMethodEdDSA:
+		public, private, err := ed25519.GenerateKey(rand.Reader)
+		if err != nil {
+			return err
+		}
+		k.private, k.public = private, public
+	}
+	return nil
+}
+
+func (k *signingKey) parse(pem []byte) error {
+	switch k.method {
+	case jwt.SigningMethodRS256:
+		private, err := jwt.ParseRSAPrivateKeyFromPEM(pem)
+		if err != nil {
+			return err
+		}
+		k.private, k.public = private, &private.PublicKey
+	case jwt.SigningMethodEdDSA:
+		parsed, err := jwt.ParseEdPrivateKeyFromPEM(pem)
+		if err != nil {
+			return err
+		}
+		private, ok := parsed.(ed25519.PrivateKey)
+		if !ok {
+			return errors.New("not an Ed25519 private key")
+		}
+		k.private, k.public = private, private.Public()
+	}
+	return nil
+}
+
+// symmetric reports whether the key is a shared secret, which must not be
+// published.
+func (k *signingKey) symmetric() bool {
+	_, ok := k.public.([]byte)
+	return ok
+}
@@ -1,43 +1,219 @@
 package token
 
 import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
 	"time"
+	config "user-service/internal/user/pkg/load"
+
 	pb "github.com/Bekzodbekk/paris2024_livestream_protos/genproto/userpb"
 
-	"github.com/dgrijalva/jwt-go"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// Token types, carried in the "typ" claim so a refresh token is never
+// accepted where an access token is expected, or the other way round.
+const (
+	TypeAccess  = "access"
+	TypeRefresh = "refresh"
 )
 
-var secretKey = "HelloWorld"
+// Tokens is one access/refresh pair. Both tokens carry the session (the
+// refresh token family) they belong to in "sid" and their own id in "jti".
+type Tokens struct {
+	Access           string
+	AccessID         string
+	AccessExpiresAt  time.Time
+	Refresh          string
+	RefreshID        string
+	RefreshExpiresAt time.Time
+}
+
+// Claims are the fields read back from a token issued by CreateTokens.
+type Claims struct {
+	ID        string
+	UserID    string
+	Session   string
+	ExpiresAt time.Time
+}
+
+// claims is the JWT body. id duplicates sub for the clients that read it.
+type claims struct {
+	UserID   string `json:"id"`
+	Username string `json:"username"`
+	Role     string `json:"role"`
+	Session  string `json:"sid"`
+	Type     string `json:"typ"`
+	jwt.RegisteredClaims
+}
+
+// Issuer signs tokens with the active key and verifies them with any
+// configured key, chosen by the kid header.
+type Issuer struct {
+	issuer     string
+	active     *signingKey
+	keys       map[string]*signingKey
+	accessTTL  time.Duration
+	refreshTTL time.Duration
+}
+
+func NewIssuer(cfg config.JWTConfig) (*Issuer, error) {
+	if len(cfg.Keys) == 0 {
+		return nil, errors.New("no signing keys configured")
+	}
+	if cfg.AccessTTL <= 0 || cfg.RefreshTTL <= 0 {
+		return nil, errors.New("token lifetimes must be positive")
+	}
 
-// CreateTokens function creates both access and refresh tokens.
-func CreateTokens(user *pb.User) (string, string, error) {
-	// Create access token
-	accessTokenClaims := jwt.MapClaims{
-		"id":       user.Id,
-		"username": user.Username,
-		"role":     user.Role,
-		"exp":      time.Now().Add(time.Hour * 1).Unix(), // Access token expires in 1 hour
+	i := &Issuer{
+		issuer:     cfg.Issuer,
+		keys:       make(map[string]*signingKey, len(cfg.Keys)),
+		accessTTL:  cfg.AccessTTL,
+		refreshTTL: cfg.RefreshTTL,
+	}
+	configured := make(map[string]*signingKey, len(cfg.Keys))
+	for _, keyCfg := range cfg.Keys {
+		key, err := loadKey(keyCfg)
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := configured[key.name]; ok {
+			return nil, fmt.Errorf("duplicate signing key %s", key.name)
+		}
+		configured[key.name] = key
+		i.keys[key.id] = key
 	}
 
-	accessToken := jwt.NewWithClaims(jwt.SigningMethodHS256, accessTokenClaims)
-	accessTokenString, err := accessToken.SignedString([]byte(secretKey))
-	if err != nil {
-		return "", "", err
+	active := cfg.ActiveKey
+	if active == "" && len(cfg.Keys) == 1 {
+		active = cfg.Keys[0].ID
+	}
+	if i.active = configured[active]; i.active == nil {
+		return nil, fmt.Errorf("active key %q is not configured", cfg.ActiveKey)
 	}
+	return i, nil
+}
 
-	// Create refresh token
-	refreshTokenClaims := jwt.MapClaims{
-		"id":       user.Id,
-		"username": user.Username,
-		"role":     user.Role,
-		"exp":      time.Now().Add(time.Hour * 24).Unix(), // Refresh token expires in 24 hours
+// GeneratedKeys lists the kids of the keys made up at startup for lack of a
+// configured private key.
+func (i *Issuer) GeneratedKeys() []string {
+	var ids []string
+	for id, key := range i.keys {
+		if key.generated {
+			ids = append(ids, id)
+		}
 	}
+	return ids
+}
+
+func (i *Issuer) AccessTTL() time.Duration  { return i.accessTTL }
+func (i *Issuer) RefreshTTL() time.Duration { return i.refreshTTL }
+
+// NewID returns a random identifier for a token or a session.
+func NewID() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
 
-	refreshToken := jwt.NewWithClaims(jwt.SigningMethodHS256, refreshTokenClaims)
-	refreshTokenString, err := refreshToken.SignedString([]byte(secretKey))
+// CreateTokens creates both access and refresh tokens for the given session.
+func (i *Issuer) CreateTokens(user *pb.User, session string) (*Tokens, error) {
+	now := time.Now()
+	tokens := &Tokens{
+		AccessExpiresAt:  now.Add(i.accessTTL),
+		RefreshExpiresAt: now.Add(i.refreshTTL),
+	}
+
+	var err error
+	if tokens.AccessID, err = NewID(); err != nil {
+		return nil, err
+	}
+	if tokens.RefreshID, err = NewID(); err != nil {
+		return nil, err
+	}
+
+	tokens.Access, err = i.sign(user, session, TypeAccess, tokens.AccessID, now, tokens.AccessExpiresAt)
 	if err != nil {
-		return "", "", err
+		return nil, err
 	}
+	tokens.Refresh, err = i.sign(user, session, TypeRefresh, tokens.RefreshID, now, tokens.RefreshExpiresAt)
+	if err != nil {
+		return nil, err
+	}
+	return tokens, nil
+}
 
-	return accessTokenString, refreshTokenString, nil
+func (i *Issuer) sign(user *pb.User, session, typ, id string, issuedAt, expiresAt time.Time) (string, error) {
+	token := jwt.NewWithClaims(i.active.method, claims{
+		UserID:   user.Id,
+		Username: user.Username,
+		Role:     user.Role,
+		Session:  session,
+		Type:     typ,
+		RegisteredClaims: jwt.RegisteredClaims{
+			Issuer:    i.issuer,
+			Subject:   user.Id,
+			ID:        id,
+			IssuedAt:  jwt.NewNumericDate(issuedAt),
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
+		},
+	})
+	token.Header["kid"] = i.active.id
+	return token.SignedString(i.active.private)
+}
+
+// Parse verifies the signature, issuer and expiry of a token issued by
+// CreateTokens, checks that it is of type typ and returns its claims.
+func (i *Issuer) Parse(tokenString, typ string) (*Claims, error) {
+	parsed := &claims{}
+	_, err := jwt.ParseWithClaims(tokenString, parsed, i.keyFunc,
+		jwt.WithValidMethods(i.methods()),
+		jwt.WithIssuer(i.issuer),
+		jwt.WithExpirationRequired(),
+	)
+	if err != nil {
+		return nil, err
+	}
+	if parsed.Type != typ {
+		return nil, fmt.Errorf("expected a %s token, got %q", typ, parsed.Type)
+	}
+	if parsed.ID == "" || parsed.UserID == "" || parsed.Session == "" {
+		return nil, errors.New("token has no id, subject or session")
+	}
+	return &Claims{
+		ID:        parsed.ID,
+		UserID:    parsed.UserID,
+		Session:   parsed.Session,
+		ExpiresAt: parsed.ExpiresAt.Time,
+	}, nil
+}
+
+// keyFunc picks the verification key named by the kid header, and only if
+// the token was signed with that key's algorithm.
+func (i *Issuer) keyFunc(t *jwt.Token) (interface{}, error) {
+	kid, _ := t.Header["kid"].(string)
+	key, ok := i.keys[kid]
+	if !ok {
+		return nil, fmt.Errorf("unknown signing key %q", kid)
+	}
+	if t.Method.Alg() != key.method.Alg() {
+		return nil, fmt.Errorf("key %s does not sign %s", kid, t.Method.Alg())
+	}
+	return key.public, nil
+}
+
+func (i *Issuer) methods() []string {
+	seen := map[string]bool{}
+	var methods []string
+	for _, key := range i.keys {
+		if alg := key.method.Alg(); !seen[alg] {
+			seen[alg] = true
+			methods = append(methods, alg)
+		}
+	}
+	return methods
 }
@@ -0,0 +1,150 @@
+package token
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"strings"
+	"testing"
+	"time"
+	config "user-service/internal/user/pkg/load"
+
+	pb "github.com/Bekzodbekk/paris2024_livestream_protos/genproto/userpb"
+	"github.com/stretchr/testify/assert"
+)
+
+var testUser = &pb.User{Id: "1", Username: "mongosh", Role: "admin"}
+
+func newIssuer(t *testing.T, active string, keys ...config.SigningKey) *Issuer {
+	issuer, err := NewIssuer(config.JWTConfig{
+		Issuer:     "user-service",
+		ActiveKey:  active,
+		Keys:       keys,
+		AccessTTL:  time.Hour,
+		RefreshTTL: time.Hour * 24,
+	})
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when creating the issuer", err)
+	}
+	return issuer
+}
+
+func rsaPEM(t *testing.T) string {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)}))
+}
+
+func TestCreateAndParse(t *testing.T) {
+	for _, key := range []config.SigningKey{
+		{ID: "hs", Algorithm: "HS256", Secret: "secret"},
+		{ID: "rs", Algorithm: "RS256", PrivateKey: rsaPEM(t)},
+		{ID: "ed", Algorithm: "EdDSA"},
+	} {
+		t.Run(key.Algorithm, func(t *testing.T) {
+			issuer := newIssuer(t, key.ID, key)
+
+			tokens, err := issuer.CreateTokens(testUser, "session")
+			assert.NoError(t, err)
+
+			claims, err := issuer.Parse(tokens.Access, TypeAccess)
+			assert.NoError(t, err)
+			assert.Equal(t, tokens.AccessID, claims.ID)
+			assert.Equal(t, "1", claims.UserID)
+			assert.Equal(t, "session", claims.Session)
+
+			claims, err = issuer.Parse(tokens.Refresh, TypeRefresh)
+			assert.NoError(t, err)
+			assert.Equal(t, tokens.RefreshID, claims.ID)
+		})
+	}
+}
+
+func TestParseRejectsWrongType(t *testing.T) {
+	issuer := newIssuer(t, "ed", config.SigningKey{ID: "ed", Algorithm: "EdDSA"})
+
+	tokens, err := issuer.CreateTokens(testUser, "session")
+	assert.NoError(t, err)
+
+	_, err = issuer.Parse(tokens.Refresh, TypeAccess)
+	assert.Error(t, err)
+	_, err = issuer.Parse(tokens.Access, TypeRefresh)
+	assert.Error(t, err)
+}
+
+func TestKeyRotation(t *testing.T) {
+	old := config.SigningKey{ID: "old", Algorithm: "RS256", PrivateKey: rsaPEM(t)}
+	next := config.SigningKey{ID: "new", Algorithm: "EdDSA"}
+
+	before := newIssuer(t, "old", old)
+	tokens, err := before.CreateTokens(testUser, "session")
+	assert.NoError(t, err)
+
+	// Once the new key is active, tokens signed with the old one still verify.
+	after := newIssuer(t, "new", old, next)
+	_, err = after.Parse(tokens.Access, TypeAccess)
+	assert.NoError(t, err)
+
+	// After the old key is dropped they no longer do.
+	retired := newIssuer(t, "new", next)
+	_, err = retired.Parse(tokens.Access, TypeAccess)
+	assert.Error(t, err)
+}
+
+func TestJWKS(t *testing.T) {
+	issuer := newIssuer(t, "ed",
+		config.SigningKey{ID: "ed", Algorithm: "EdDSA"},
+		config.SigningKey{ID: "hs", Algorithm: "HS256", Secret: "secret"},
+		config.SigningKey{ID: "rs", Algorithm: "RS256", PrivateKey: rsaPEM(t)},
+	)
+
+	set := issuer.JWKS()
+
+	// The shared secret is never published.
+	assert.Len(t, set.Keys, 2)
+	assert.True(t, strings.HasPrefix(set.Keys[0].Kid, "ed-"))
+	assert.Equal(t, "OKP", set.Keys[0].Kty)
+	assert.Equal(t, "Ed25519", set.Keys[0].Crv)
+	assert.NotEmpty(t, set.Keys[0].X)
+	assert.Equal(t, "rs", set.Keys[1].Kid)
+	assert.Equal(t, "RSA", set.Keys[1].Kty)
+	assert.Equal(t, "AQAB", set.Keys[1].E)
+	assert.NotEmpty(t, set.Keys[1].N)
+}
+
+func TestGeneratedKeyID(t *testing.T) {
+	cfg := config.SigningKey{ID: "dev", Algorithm: "EdDSA"}
+	a := newIssuer(t, "dev", cfg)
+	b := newIssuer(t, "dev", cfg)
+
+	// Two replicas generating keys under one configured id still publish
+	// distinct kids, so neither token is checked against the other's key.
+	kidA, kidB := a.JWKS().Keys[0].Kid, b.JWKS().Keys[0].Kid
+	assert.True(t, strings.HasPrefix(kidA, "dev-"))
+	assert.NotEqual(t, kidA, kidB)
+	assert.Equal(t, []string{kidA}, a.GeneratedKeys())
+
+	tokens, err := a.CreateTokens(testUser, "session")
+	assert.NoError(t, err)
+	_, err = a.Parse(tokens.Access, TypeAccess)
+	assert.NoError(t, err)
+	_, err = b.Parse(tokens.Access, TypeAccess)
+	assert.Error(t, err)
+}
+
+func TestNewIssuerErrors(t *testing.T) {
+	for name, cfg := range map[string]config.JWTConfig{
+		"no keys":        {AccessTTL: time.Hour, RefreshTTL: time.Hour},
+		"unknown active": {ActiveKey: "x", Keys: []config.SigningKey{{ID: "a", Algorithm: "EdDSA"}}, AccessTTL: time.Hour, RefreshTTL: time.Hour},
+		"bad algorithm":  {Keys: []config.SigningKey{{ID: "a", Algorithm: "none"}}, AccessTTL: time.Hour, RefreshTTL: time.Hour},
+		"no secret":      {Keys: []config.SigningKey{{ID: "a", Algorithm: "HS256"}}, AccessTTL: time.Hour, RefreshTTL: time.Hour},
+		"no ttl":         {Keys: []config.SigningKey{{ID: "a", Algorithm: "EdDSA"}}},
+		"duplicate":      {Keys: []config.SigningKey{{ID: "a", Algorithm: "EdDSA"}, {ID: "a", Algorithm: "EdDSA"}}, AccessTTL: time.Hour, RefreshTTL: time.Hour},
+	} {
+		_, err := NewIssuer(cfg)
+		assert.Error(t, err, name)
+	}
+}